@@ -0,0 +1,155 @@
+package main
+
+import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/application/services"
+	"HITS_ToDoList_Tests/internal/config"
+	"HITS_ToDoList_Tests/internal/delivery/handlers"
+	"HITS_ToDoList_Tests/internal/delivery/middleware"
+	"HITS_ToDoList_Tests/internal/delivery/routes"
+	"HITS_ToDoList_Tests/internal/infrastructure/events"
+	"HITS_ToDoList_Tests/internal/infrastructure/notifiers"
+	"HITS_ToDoList_Tests/internal/infrastructure/schedulers"
+	"HITS_ToDoList_Tests/internal/infrastructure/storage"
+	"HITS_ToDoList_Tests/internal/infrastructure/webhooks"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/swaggo/files"
+	"github.com/swaggo/gin-swagger"
+	"log"
+	"net"
+	"net/http"
+)
+
+// run поднимает хранилище, планировщик дедлайнов и напоминаний, очистку корзины, отправку вебхуков
+// и HTTP-сервер и держит их до отмены ctx.
+// Остановка идёт в обратном порядке: сервер перестаёт принимать соединения, закрывает потоки событий
+// и в пределах server.shutdownTimeout дожидается текущих запросов, затем останавливаются фоновые задачи
+// и закрывается соединение с БД.
+func run(ctx context.Context, cfg *config.Config) error {
+	store, err := storage.Open(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("open %s storage: %w", cfg.Database.Driver, err)
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.Printf("Failed to close storage: %v", err)
+		}
+	}()
+
+	authService := services.NewAuthService(store.Users, []byte(cfg.Auth.JWTSecret), cfg.Auth.TokenTTL)
+	usersService := services.NewUsersService(store.Users)
+	deadlineQueue := schedulers.NewDeadlineQueue(cfg.Scheduler.Interval)
+	reminderQueue := schedulers.NewDeadlineQueue(cfg.Scheduler.Interval)
+	taskChangeBus := events.NewTaskChangeBus(cfg.Events.HistorySize)
+	webhooksService := services.NewWebhooksService(store.Webhooks, store.WebhookDeliveries,
+		webhooks.NewHTTPWebhookSender(cfg.Webhooks.Timeout, cfg.Webhooks.AllowPrivateNetworks),
+		cfg.Webhooks.MaxAttempts, cfg.Webhooks.RetryBackoff, cfg.Webhooks.AllowPrivateNetworks)
+	remindersService := services.NewRemindersService(store.Tasks, store.Users, store.TaskReminders,
+		newNotifier(cfg.Reminders, webhooksService), reminderQueue)
+	tasksService := services.NewTasksService(store.Tasks, store.ChecklistItems, store.Tags, store.Projects,
+		store.TaskEvents, store.UnitOfWork, deadlineQueue,
+		events.TaskChangePublishers{taskChangeBus, webhooksService, remindersService})
+	checklistService := services.NewChecklistService(store.Tasks, store.ChecklistItems, store.UnitOfWork)
+	tagsService := services.NewTagsService(store.Tags)
+	projectsService := services.NewProjectsService(store.Projects, store.Tasks, tasksService)
+
+	stopScheduler := schedulers.StartTasksDeadlineScheduling(ctx, tasksService, deadlineQueue, remindersService,
+		reminderQueue, cfg.Scheduler.Interval)
+	defer stopScheduler()
+	stopPurger := schedulers.StartTrashPurging(ctx, tasksService, cfg.Trash.Retention, cfg.Trash.PurgeInterval)
+	defer stopPurger()
+	stopWebhooks := schedulers.StartWebhookDelivery(ctx, webhooksService, cfg.Webhooks.DeliveryInterval)
+	defer stopWebhooks()
+
+	listener, err := net.Listen("tcp", cfg.Server.Address)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", cfg.Server.Address, err)
+	}
+
+	server := &http.Server{Handler: newRouter(cfg, authService, usersService, tasksService, taskChangeBus,
+		checklistService, tagsService, projectsService, webhooksService, remindersService)}
+	// Потоки событий не завершаются сами, Shutdown дождался бы их только по таймауту
+	server.RegisterOnShutdown(taskChangeBus.Close)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	log.Printf("Application started on %s", listener.Addr())
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("serve http: %w", err)
+	case <-ctx.Done():
+	}
+
+	log.Println("Shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown http server: %w", err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve http: %w", err)
+	}
+
+	return nil
+}
+
+func newRouter(cfg *config.Config, authService interfaces.AuthService, usersService interfaces.UsersService,
+	tasksService interfaces.TasksService, taskChangeStream interfaces.TaskChangeStream,
+	checklistService interfaces.ChecklistService,
+	tagsService interfaces.TagsService, projectsService interfaces.ProjectsService,
+	webhooksService interfaces.WebhooksService, remindersService interfaces.RemindersService) *gin.Engine {
+	// gin.Default() пишет в журнал адрес целиком, вместе с токенами в нём
+	r := gin.New()
+	r.Use(middleware.Logger(gin.DefaultWriter), gin.Recovery())
+
+	// Добавляем CORS middleware первым
+	r.Use(middleware.Cors(cfg.Cors.AllowedOrigins))
+	r.Use(middleware.ErrorHandler())
+	authMiddleware := middleware.Auth(authService)
+	timeZoneMiddleware := middleware.TimeZone(usersService)
+	streamAuthMiddleware := middleware.StreamAuth(authService)
+	calendarFeedMiddleware := middleware.CalendarFeedAuth(usersService)
+
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	authHandler := handlers.NewAuthHandler(authService)
+	tasksHandler := handlers.NewTasksHandler(tasksService)
+	taskChangesHandler := handlers.NewTaskChangesHandler(taskChangeStream, cfg.Events.HeartbeatInterval)
+	checklistHandler := handlers.NewChecklistHandler(checklistService)
+	tagsHandler := handlers.NewTagsHandler(tagsService)
+	projectsHandler := handlers.NewProjectsHandler(projectsService)
+	usersHandler := handlers.NewUsersHandler(usersService)
+	webhooksHandler := handlers.NewWebhooksHandler(webhooksService)
+	remindersHandler := handlers.NewRemindersHandler(remindersService)
+	calendarHandler := handlers.NewCalendarHandler(tasksService, usersService)
+	routes.SetupRoutes(r, authMiddleware, timeZoneMiddleware, streamAuthMiddleware, calendarFeedMiddleware,
+		authHandler, tasksHandler, taskChangesHandler, checklistHandler, remindersHandler, tagsHandler,
+		projectsHandler, usersHandler, webhooksHandler, calendarHandler)
+
+	return r
+}
+
+// newNotifier собирает каналы напоминаний из reminders.notifiers; названия проверены при загрузке конфигурации
+func newNotifier(cfg config.RemindersConfig, webhooksService interfaces.WebhooksService) interfaces.Notifier {
+	channels := make(notifiers.Notifiers, 0, len(cfg.Notifiers))
+	for _, name := range cfg.Notifiers {
+		switch name {
+		case config.NotifierLog:
+			channels = append(channels, notifiers.NewLogNotifier())
+		case config.NotifierSMTP:
+			channels = append(channels, notifiers.NewSMTPNotifier(cfg.SMTP))
+		case config.NotifierWebhook:
+			channels = append(channels, notifiers.NewWebhookNotifier(webhooksService))
+		}
+	}
+
+	return channels
+}
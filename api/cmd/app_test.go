@@ -0,0 +1,55 @@
+package main
+
+import (
+	"HITS_ToDoList_Tests/internal/config"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+func testConfig() *config.Config {
+	cfg := config.Default()
+	cfg.Server.Address = "127.0.0.1:0"
+	cfg.Database.Driver = config.DriverMemory
+	cfg.Auth.JWTSecret = "0123456789abcdef"
+	return cfg
+}
+
+// Тест остановки приложения по отмене контекста
+func TestRun_GracefulShutdown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	result := make(chan error, 1)
+	go func() {
+		result <- run(ctx, testConfig())
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-result:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("application did not stop")
+	}
+}
+
+// Тест ошибки запуска на занятом адресе
+func TestRun_ListenError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	cfg := testConfig()
+	cfg.Server.Address = listener.Addr().String()
+
+	err = run(context.Background(), cfg)
+
+	assert.ErrorContains(t, err, "listen on")
+}
@@ -2,48 +2,48 @@ package main
 
 import (
 	_ "HITS_ToDoList_Tests/docs"
-	"HITS_ToDoList_Tests/internal/application/services"
-	"HITS_ToDoList_Tests/internal/delivery/handlers"
-	"HITS_ToDoList_Tests/internal/delivery/middleware"
-	"HITS_ToDoList_Tests/internal/delivery/routes"
-	"HITS_ToDoList_Tests/internal/infrastructure/db"
-	"HITS_ToDoList_Tests/internal/infrastructure/repositories"
-	"HITS_ToDoList_Tests/internal/infrastructure/schedulers"
-	"github.com/gin-gonic/gin"
-	"github.com/swaggo/files"
-	"github.com/swaggo/gin-swagger"
+	"HITS_ToDoList_Tests/internal/config"
+	"context"
+	"flag"
 	"log"
-	"time"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
+// @title ToDo List API
+// @version 1.0
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT access token from /auth/login, prefixed with "Bearer "
 func main() {
-	dbConn, err := db.NewPostgresConnection("localhost", "postgres", "123456", "ToDoDb",
-		"5432")
-	if err != nil {
-		log.Fatalf("Failed to connect to db: %v", err)
+	configPath := flag.String("config", os.Getenv("TODO_CONFIG_FILE"), "path to the YAML config file")
+	flag.Parse()
+
+	if flag.Arg(0) == "migrate" {
+		dbCfg, err := config.LoadDatabase(*configPath)
+		if err != nil {
+			log.Fatalf("Failed to load config: %v", err)
+		}
+
+		if err := runMigrate(*dbCfg, flag.Args()[1:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
 	}
 
-	if err = db.Migrate(dbConn); err != nil {
-		log.Fatalf("Failed to migrate db: %v", err)
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	tasksRepository := repositories.NewTasksRepository(dbConn)
-	tasksService := services.NewTasksService(tasksRepository)
-
-	schedulers.StartTasksDeadlineScheduling(tasksService, time.Second)
-
-	r := gin.Default()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	// Добавляем CORS middleware первым
-	r.Use(middleware.Cors())
-	r.Use(middleware.ErrorHandler())
-
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-
-	tasksHandler := handlers.NewTasksHandler(tasksService)
-	routes.SetupRoutes(r, tasksHandler)
-
-	r.Run(":8080")
+	if err := run(ctx, cfg); err != nil {
+		log.Fatalf("Application failed: %v", err)
+	}
 
-	log.Println("Application started")
+	log.Println("Application stopped")
 }
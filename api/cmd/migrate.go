@@ -0,0 +1,63 @@
+package main
+
+import (
+	"HITS_ToDoList_Tests/internal/config"
+	"HITS_ToDoList_Tests/internal/infrastructure/db"
+	"HITS_ToDoList_Tests/internal/infrastructure/storage"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const migrateUsage = "usage: migrate [up | down [steps] | status]"
+
+// runMigrate выполняет подкоманду migrate: up применяет все миграции, down откатывает последние steps
+// (по умолчанию одну), status печатает состояние каждой миграции.
+func runMigrate(cfg config.DatabaseConfig, args []string) error {
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	dbConn, err := storage.Connect(cfg)
+	if err != nil {
+		return err
+	}
+	if sqlDB, err := dbConn.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	switch action {
+	case "up":
+		if len(args) > 1 {
+			return errors.New(migrateUsage)
+		}
+		return db.Migrate(dbConn)
+	case "down":
+		steps := 1
+		if len(args) > 2 {
+			return errors.New(migrateUsage)
+		}
+		if len(args) == 2 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("invalid steps %q: %s", args[1], migrateUsage)
+			}
+		}
+		return db.Rollback(dbConn, steps)
+	case "status":
+		states, err := db.MigrationsStatus(dbConn)
+		if err != nil {
+			return err
+		}
+		for _, state := range states {
+			appliedAt := "pending"
+			if state.AppliedAt != nil {
+				appliedAt = "applied " + state.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%4d  %-30s %s\n", state.Version, state.Name, appliedAt)
+		}
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
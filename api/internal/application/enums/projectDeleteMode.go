@@ -0,0 +1,22 @@
+package enums
+
+import "fmt"
+
+// ProjectDeleteMode определяет, что происходит с задачами удаляемого проекта
+type ProjectDeleteMode string
+
+const (
+	// MoveToInbox переносит задачи в Inbox пользователя
+	MoveToInbox ProjectDeleteMode = "inbox"
+	// Cascade удаляет задачи вместе с проектом
+	Cascade ProjectDeleteMode = "cascade"
+)
+
+func ValidateProjectDeleteMode(mode ProjectDeleteMode) error {
+	switch mode {
+	case MoveToInbox, Cascade:
+		return nil
+	default:
+		return fmt.Errorf("invalid ProjectDeleteMode: %q", mode)
+	}
+}
@@ -0,0 +1,12 @@
+package errors
+
+import "HITS_ToDoList_Tests/internal/domain/models"
+
+// VersionConflictError — задача изменена с тех пор, как клиент получил её версию; Current — её текущее состояние
+type VersionConflictError struct {
+	Current *models.Task
+}
+
+func (e VersionConflictError) Error() string {
+	return "PreconditionFailed"
+}
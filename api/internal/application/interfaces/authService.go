@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type AuthService interface {
+	Register(email string, password string) (*models.User, error)
+	Login(email string, password string) (string, time.Time, error)
+	Authenticate(token string) (uuid.UUID, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type ChecklistService interface {
+	GetItems(userID uuid.UUID, taskID uuid.UUID) ([]*models.ChecklistItem, error)
+	AddItem(userID uuid.UUID, taskID uuid.UUID, name string) (*models.ChecklistItem, error)
+	UpdateItem(userID uuid.UUID, taskID uuid.UUID, itemID uuid.UUID, name string,
+		isDone bool) (*models.ChecklistItem, error)
+	DeleteItem(userID uuid.UUID, taskID uuid.UUID, itemID uuid.UUID) error
+}
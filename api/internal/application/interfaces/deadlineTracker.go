@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// DeadlineTracker узнаёт от сервиса об изменении дедлайнов активных задач, чтобы планировщик
+// просыпался ровно к ближайшему из них
+type DeadlineTracker interface {
+	Track(taskID uuid.UUID, deadline time.Time)
+	Untrack(taskID uuid.UUID)
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+import "HITS_ToDoList_Tests/internal/domain/models"
+
+// Notifier доставляет напоминание о дедлайне владельцу задачи по своему каналу
+type Notifier interface {
+	Notify(notification models.Notification) error
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/application/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type ProjectsService interface {
+	GetProjects(userID uuid.UUID, archived *bool) ([]*models.Project, error)
+	GetProject(userID uuid.UUID, projectID uuid.UUID) (*models.Project, error)
+	CreateProject(userID uuid.UUID, name string, color *string) (*models.Project, error)
+	UpdateProject(userID uuid.UUID, projectID uuid.UUID, name string, color *string,
+		isArchived bool) (*models.Project, error)
+	DeleteProject(userID uuid.UUID, projectID uuid.UUID, mode enums.ProjectDeleteMode) error
+}
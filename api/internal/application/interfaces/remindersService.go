@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+// RemindersService хранит напоминания задач и отправляет их через Notifier. Publish пересчитывает
+// время напоминаний изменённой задачи, SendDueReminders и TrackReminders вызывает планировщик дедлайнов
+type RemindersService interface {
+	TaskChangePublisher
+	GetReminders(userID uuid.UUID, taskID uuid.UUID) ([]*models.TaskReminder, error)
+	// SetReminders заменяет напоминания задачи: за сколько минут до дедлайна напомнить
+	SetReminders(userID uuid.UUID, taskID uuid.UUID, minutesBefore []int) ([]*models.TaskReminder, error)
+	SendDueReminders(now time.Time)
+	// TrackReminders передаёт планировщику время напоминаний, наступающих не позже until
+	TrackReminders(until time.Time)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type TagsService interface {
+	GetTags(userID uuid.UUID) ([]*models.Tag, error)
+	CreateTag(userID uuid.UUID, name string) (*models.Tag, error)
+	UpdateTag(userID uuid.UUID, tagID uuid.UUID, name string) (*models.Tag, error)
+	DeleteTag(userID uuid.UUID, tagID uuid.UUID) error
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// TaskChangePublisher получает от сервиса задач изменения, уже зафиксированные в хранилище
+type TaskChangePublisher interface {
+	Publish(change models.TaskChange)
+}
+
+// TaskChangeStream рассылает опубликованные изменения задач подписанным клиентам
+type TaskChangeStream interface {
+	TaskChangePublisher
+	// Subscribe подписывает на изменения задач пользователя. lastEventID — ID последнего полученного
+	// клиентом события при переподключении; nil — только новые изменения
+	Subscribe(userID uuid.UUID, lastEventID *uint64) *models.TaskChangeSubscription
+}
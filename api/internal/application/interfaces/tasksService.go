@@ -2,18 +2,47 @@ package interfaces
 
 import (
 	appEnums "HITS_ToDoList_Tests/internal/application/enums"
+	"HITS_ToDoList_Tests/internal/application/macros"
 	"HITS_ToDoList_Tests/internal/domain/enums"
+	domainInterfaces "HITS_ToDoList_Tests/internal/domain/interfaces"
 	"HITS_ToDoList_Tests/internal/domain/models"
 	"github.com/google/uuid"
 	"time"
 )
 
+// expectedVersion в изменяющих методах — версия задачи, которую видел клиент: если задачу успели изменить,
+// возвращается errors.VersionConflictError с её текущим состоянием; nil — без проверки
 type TasksService interface {
-	CreateTask(name string, description *string, deadline *time.Time, priority *enums.Priority) (*models.Task, error)
-	GetAllTasks(sorting *appEnums.Sorting) ([]*models.Task, error)
-	DeleteTask(taskID uuid.UUID) error
-	UpdateTask(taskID uuid.UUID, name string, description *string, deadline *time.Time,
-		priority *enums.Priority) (*models.Task, error)
-	ToggleTaskStatus(taskID uuid.UUID, isDone bool) (*models.Task, error)
+	CreateTask(userID uuid.UUID, name string, description *string, deadline *time.Time,
+		priority *enums.Priority, recurrence *string, tags []string, projectID *uuid.UUID,
+		location *time.Location) (*models.Task, error)
+	GetAllTasks(userID uuid.UUID, filter *models.TasksFilter, sorting *appEnums.Sorting) ([]*models.Task, error)
+	GetTasksPage(userID uuid.UUID, filter *models.TasksFilter, sorting *appEnums.Sorting, cursor *string,
+		limit *int) ([]*models.Task, *string, error)
+	GetTask(userID uuid.UUID, taskID uuid.UUID) (*models.Task, error)
+	DeleteTask(userID uuid.UUID, taskID uuid.UUID, expectedVersion *int) error
+	GetDeletedTasks(userID uuid.UUID) ([]*models.Task, error)
+	RestoreTask(userID uuid.UUID, taskID uuid.UUID) (*models.Task, error)
+	UpdateTask(userID uuid.UUID, taskID uuid.UUID, name string, description *string, deadline *time.Time,
+		priority *enums.Priority, recurrence *string, tags []string, projectID *uuid.UUID,
+		location *time.Location, expectedVersion *int) (*models.Task, error)
+	PatchTask(userID uuid.UUID, taskID uuid.UUID, patch models.TaskPatch, location *time.Location,
+		expectedVersion *int) (*models.Task, error)
+	// BulkTasks возвращает результаты по действиям и признак того, что изменения сохранены
+	BulkTasks(userID uuid.UUID, operations []models.TaskOperation, atomic bool,
+		location *time.Location) ([]models.TaskOperationResult, bool, error)
+	// ImportTasks возвращает результаты по задачам в порядке tasks
+	ImportTasks(userID uuid.UUID, tasks []models.TaskImport, keepOverdue bool,
+		location *time.Location) ([]models.TaskImportResult, error)
+	ToggleTaskStatus(userID uuid.UUID, taskID uuid.UUID, isDone bool, completeItems bool,
+		expectedVersion *int) (*models.Task, error)
+	ParseTask(userID uuid.UUID, name string, location *time.Location) (*macros.Result, *models.Project, error)
+	GetTaskHistory(userID uuid.UUID, taskID uuid.UUID, cursor *string,
+		limit *int) ([]*models.TaskEvent, *string, error)
+	// WithinTx выполняет fn в одной транзакции с изменениями задач через tasks: так другие сервисы меняют
+	// задачи вместе со своими данными через repos. Изменения задач публикуются после фиксации
+	WithinTx(fn func(tasks TasksService, repos domainInterfaces.Repositories) error) error
 	UpdateTaskStatuses()
+	PurgeDeletedTasks(before time.Time)
+	TrackActiveDeadlines(until time.Time)
 }
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type UsersService interface {
+	GetProfile(userID uuid.UUID) (*models.User, error)
+	UpdateProfile(userID uuid.UUID, timeZone *string) (*models.User, error)
+	GetLocation(userID uuid.UUID) (*time.Location, error)
+	// CreateCalendarFeed выдаёт новый секретный токен ленты календаря; прежний перестаёт действовать
+	CreateCalendarFeed(userID uuid.UUID) (string, error)
+	DeleteCalendarFeed(userID uuid.UUID) error
+	// AuthenticateCalendarFeed возвращает владельца ленты по токену из её адреса
+	AuthenticateCalendarFeed(token string) (uuid.UUID, error)
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+import "HITS_ToDoList_Tests/internal/domain/models"
+
+// WebhookSender отправляет тело отправки на адрес подписки, подписывая его ключом подписки.
+// Ошибка — адрес недоступен или ответил не 2xx
+type WebhookSender interface {
+	Send(webhook *models.Webhook, delivery *models.WebhookDelivery) error
+}
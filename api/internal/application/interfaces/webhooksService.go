@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+// WebhooksService управляет подписками пользователя и отправляет им изменения задач.
+// Publish ставит изменение в очередь отправок подходящих подписок, DeliverPending отправляет очередь
+type WebhooksService interface {
+	TaskChangePublisher
+	GetWebhooks(userID uuid.UUID) ([]*models.Webhook, error)
+	GetWebhook(userID uuid.UUID, webhookID uuid.UUID) (*models.Webhook, error)
+	// secret == nil — ключ подписи генерируется
+	CreateWebhook(userID uuid.UUID, url string, secret *string, eventTypes []enums.TaskChangeType,
+		priorities []enums.Priority) (*models.Webhook, error)
+	// secret == nil оставляет прежний ключ подписи
+	UpdateWebhook(userID uuid.UUID, webhookID uuid.UUID, url string, secret *string,
+		eventTypes []enums.TaskChangeType, priorities []enums.Priority) (*models.Webhook, error)
+	DeleteWebhook(userID uuid.UUID, webhookID uuid.UUID) error
+	// GetDeadLetters возвращает отправки всех подписок пользователя, исчерпавшие попытки
+	GetDeadLetters(userID uuid.UUID) ([]*models.WebhookDelivery, error)
+	// RetryDelivery возвращает неудавшуюся отправку в очередь с новым набором попыток
+	RetryDelivery(userID uuid.UUID, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+	DeliverPending(now time.Time)
+}
@@ -0,0 +1,368 @@
+package macros
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Kind — вид макроса
+type Kind string
+
+const (
+	Priority Kind = "priority"
+	Deadline Kind = "deadline"
+	Tag      Kind = "tag"
+	Project  Kind = "project"
+)
+
+// Macro — макрос из названия задачи. Reason заполняется у нераспознанных макросов,
+// Kind у них пуст, если вид макроса определить не удалось
+type Macro struct {
+	Kind   Kind
+	Text   string
+	Reason string
+}
+
+// Result — разобранное название задачи. Распознанные макросы удаляются из названия вместе с одним
+// отделяющим их пробелом, остальной текст, включая переводы строк и повторные пробелы, не меняется.
+// Нераспознанные макросы остаются в названии как обычный текст
+type Result struct {
+	Name     string
+	Priority *enums.Priority
+	Deadline *time.Time
+	// Нормализованные имена меток без повторов
+	Tags []string
+	// Имя проекта из макроса @project; проект по имени ищет сервис
+	Project *string
+
+	Recognized   []Macro
+	Unrecognized []Macro
+}
+
+var (
+	priorities = map[string]enums.Priority{
+		"!1": enums.Critical,
+		"!2": enums.High,
+		"!3": enums.Medium,
+		"!4": enums.Low,
+	}
+
+	weekdays = map[string]time.Weekday{
+		"mon": time.Monday, "monday": time.Monday, "пн": time.Monday,
+		"tue": time.Tuesday, "tuesday": time.Tuesday, "вт": time.Tuesday,
+		"wed": time.Wednesday, "wednesday": time.Wednesday, "ср": time.Wednesday,
+		"thu": time.Thursday, "thursday": time.Thursday, "чт": time.Thursday,
+		"fri": time.Friday, "friday": time.Friday, "пт": time.Friday,
+		"sat": time.Saturday, "saturday": time.Saturday, "сб": time.Saturday,
+		"sun": time.Sunday, "sunday": time.Sunday, "вс": time.Sunday,
+	}
+
+	tagPattern      = regexp.MustCompile(`^#[\p{L}\p{N}_-]+$`)
+	projectPattern  = regexp.MustCompile(`^@[\p{L}\p{N}_-]+$`)
+	datePattern     = regexp.MustCompile(`^(\d{2})[.-](\d{2})[.-](\d{4})$`)
+	isoDatePattern  = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})(?:t(\d{2}):(\d{2}))?$`)
+	timePattern     = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+	durationPattern = regexp.MustCompile(`^(\d{1,4})(m|h|d|w)$`)
+)
+
+// Parse разбирает макросы в названии задачи:
+//
+//   - !1 … !4 — приоритет от Critical до Low;
+//   - !before <дата> [ЧЧ:ММ] — дедлайн; дата — today/tomorrow (сегодня/завтра), день недели (fri, пт),
+//     ДД.ММ.ГГГГ, ДД-ММ-ГГГГ или ГГГГ-ММ-ДД; ISO-форма ГГГГ-ММ-ДДTЧЧ:ММ задаёт и время;
+//     без даты (!before 18:00) — ближайшее такое время. Без времени дедлайн — конец указанного дня (23:59:59);
+//   - !in <N><m|h|d|w> — дедлайн через N минут, часов, дней или недель;
+//   - #tag — метка, @project — проект.
+//
+// Макросы разбираются слева направо. Меток может быть несколько, из остальных макросов применяется
+// первый, повторные остаются в названии и попадают в Unrecognized. Даты и время понимаются
+// в часовом поясе now, относительные даты отсчитываются от now.
+func Parse(input string, now time.Time) *Result {
+	result := &Result{}
+	tokens, spans := splitFields(input)
+	// Участки input, занятые распознанными макросами
+	var removed []span
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		lower := strings.ToLower(token)
+
+		switch {
+		case priorities[token] != "":
+			if result.Priority != nil {
+				result.unrecognized(Priority, token, "Duplicate priority macro; the first one is used")
+				continue
+			}
+			priority := priorities[token]
+			result.Priority = &priority
+			result.recognized(Priority, token)
+			removed = append(removed, spans[i])
+
+		case lower == "!before" || lower == "!in":
+			var deadline time.Time
+			var consumed int
+			var reason string
+			if lower == "!before" {
+				deadline, consumed, reason = parseBefore(tokens[i+1:], now)
+			} else {
+				deadline, consumed, reason = parseIn(tokens[i+1:], now)
+			}
+
+			if reason != "" {
+				result.unrecognized(Deadline, token, reason)
+				continue
+			}
+
+			macro := tokens[i : i+1+consumed]
+			macroSpan := span{start: spans[i].start, end: spans[i+consumed].end}
+			i += consumed
+			if result.Deadline != nil {
+				result.unrecognized(Deadline, strings.Join(macro, " "),
+					"Duplicate deadline macro; the first one is used")
+				continue
+			}
+			result.Deadline = &deadline
+			result.recognized(Deadline, strings.Join(macro, " "))
+			removed = append(removed, macroSpan)
+
+		case tagPattern.MatchString(token):
+			tag := models.NormalizeTagName(token)
+			if !slices.Contains(result.Tags, tag) {
+				result.Tags = append(result.Tags, tag)
+			}
+			result.recognized(Tag, token)
+			removed = append(removed, spans[i])
+
+		case projectPattern.MatchString(token):
+			if result.Project != nil {
+				result.unrecognized(Project, token, "Duplicate project macro; the first one is used")
+				continue
+			}
+			project := strings.TrimPrefix(token, "@")
+			result.Project = &project
+			result.recognized(Project, token)
+			removed = append(removed, spans[i])
+
+		case len(token) > 1 && strings.HasPrefix(token, "!"):
+			result.unrecognized("", token, "Unknown macro")
+		}
+	}
+
+	result.Name = strings.TrimSpace(cut(input, removed))
+	return result
+}
+
+// span — участок строки [start, end) в байтах
+type span struct {
+	start int
+	end   int
+}
+
+// splitFields делит строку на слова так же, как strings.Fields, и возвращает их положение в ней
+func splitFields(input string) ([]string, []span) {
+	var tokens []string
+	var spans []span
+
+	start := -1
+	for i, r := range input {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				tokens = append(tokens, input[start:i])
+				spans = append(spans, span{start: start, end: i})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, input[start:])
+		spans = append(spans, span{start: start, end: len(input)})
+	}
+
+	return tokens, spans
+}
+
+// cut удаляет из input участки removed (по возрастанию) вместе с пробелами и табуляциями после них,
+// а если после участка их нет — перед ним. Переводы строк остаются на месте
+func cut(input string, removed []span) string {
+	var name strings.Builder
+	last := 0
+
+	for _, macro := range removed {
+		start, end := macro.start, macro.end
+		for end < len(input) && isBlank(input[end]) {
+			end++
+		}
+		if end == macro.end {
+			for start > last && isBlank(input[start-1]) {
+				start--
+			}
+		}
+
+		name.WriteString(input[last:start])
+		last = end
+	}
+	name.WriteString(input[last:])
+
+	return name.String()
+}
+
+func isBlank(b byte) bool {
+	return b == ' ' || b == '\t'
+}
+
+func (result *Result) recognized(kind Kind, text string) {
+	result.Recognized = append(result.Recognized, Macro{Kind: kind, Text: text})
+}
+
+func (result *Result) unrecognized(kind Kind, text string, reason string) {
+	result.Unrecognized = append(result.Unrecognized, Macro{Kind: kind, Text: text, Reason: reason})
+}
+
+const (
+	expectedDateReason     = "Expected a date or time after !before"
+	invalidDateReason      = "Invalid date"
+	expectedDurationReason = "Expected a duration like 30m, 2h, 3d or 1w after !in"
+)
+
+// Разбор "!before": дедлайн и число использованных токенов после макроса или причина, по которой
+// макрос не распознан
+func parseBefore(tokens []string, now time.Time) (time.Time, int, string) {
+	if len(tokens) == 0 {
+		return time.Time{}, 0, expectedDateReason
+	}
+
+	first := strings.ToLower(tokens[0])
+	location := now.Location()
+	year, month, day := now.Date()
+
+	// Только время — ближайший такой момент, сегодня или завтра
+	if hour, minute, ok := parseClock(first); ok {
+		deadline := time.Date(year, month, day, hour, minute, 0, 0, location)
+		if !deadline.After(now) {
+			deadline = deadline.AddDate(0, 0, 1)
+		}
+		return deadline, 1, ""
+	}
+
+	if matches := isoDatePattern.FindStringSubmatch(first); matches != nil && matches[4] != "" {
+		date, ok := makeDate(matches[1], matches[2], matches[3], location)
+		hour, minute, timeOK := parseClock(matches[4] + ":" + matches[5])
+		if !ok || !timeOK {
+			return time.Time{}, 0, invalidDateReason
+		}
+		return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, location), 1, ""
+	}
+
+	hour, minute, hasTime := 0, 0, false
+	if len(tokens) > 1 {
+		hour, minute, hasTime = parseClock(tokens[1])
+	}
+	// Без времени дедлайн — конец дня: задача «до пятницы» просрочена только с наступлением субботы
+	at := func(date time.Time) time.Time {
+		if !hasTime {
+			return time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, location)
+		}
+		return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, location)
+	}
+	consumed := 1
+	if hasTime {
+		consumed = 2
+	}
+
+	today := time.Date(year, month, day, 0, 0, 0, 0, location)
+	switch first {
+	case "today", "сегодня":
+		return at(today), consumed, ""
+	case "tomorrow", "завтра":
+		return at(today.AddDate(0, 0, 1)), consumed, ""
+	}
+
+	if weekday, ok := weekdays[first]; ok {
+		// Ближайший такой день недели, дедлайн в который ещё не прошёл
+		for offset := 0; offset <= 7; offset++ {
+			date := today.AddDate(0, 0, offset)
+			if date.Weekday() == weekday && at(date).After(now) {
+				return at(date), consumed, ""
+			}
+		}
+	}
+
+	var date time.Time
+	var ok bool
+	if matches := datePattern.FindStringSubmatch(first); matches != nil {
+		date, ok = makeDate(matches[3], matches[2], matches[1], location)
+	} else if matches := isoDatePattern.FindStringSubmatch(first); matches != nil {
+		date, ok = makeDate(matches[1], matches[2], matches[3], location)
+	} else {
+		return time.Time{}, 0, expectedDateReason
+	}
+
+	if !ok {
+		return time.Time{}, 0, invalidDateReason
+	}
+
+	return at(date), consumed, ""
+}
+
+// Разбор "!in": дни и недели прибавляются по календарю, чтобы переход на летнее время не сдвигал часы
+func parseIn(tokens []string, now time.Time) (time.Time, int, string) {
+	if len(tokens) == 0 {
+		return time.Time{}, 0, expectedDurationReason
+	}
+
+	matches := durationPattern.FindStringSubmatch(strings.ToLower(tokens[0]))
+	if matches == nil {
+		return time.Time{}, 0, expectedDurationReason
+	}
+
+	amount, _ := strconv.Atoi(matches[1])
+	now = now.Truncate(time.Minute)
+
+	switch matches[2] {
+	case "m":
+		return now.Add(time.Duration(amount) * time.Minute), 1, ""
+	case "h":
+		return now.Add(time.Duration(amount) * time.Hour), 1, ""
+	case "d":
+		return now.AddDate(0, 0, amount), 1, ""
+	default:
+		return now.AddDate(0, 0, 7*amount), 1, ""
+	}
+}
+
+func parseClock(value string) (int, int, bool) {
+	matches := timePattern.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, 0, false
+	}
+
+	hour, _ := strconv.Atoi(matches[1])
+	minute, _ := strconv.Atoi(matches[2])
+	if hour > 23 || minute > 59 {
+		return 0, 0, false
+	}
+
+	return hour, minute, true
+}
+
+// Дата без нормализации: 31.02 не превращается в 2 или 3 марта
+func makeDate(year string, month string, day string, location *time.Location) (time.Time, bool) {
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+
+	date := time.Date(y, time.Month(m), d, 0, 0, 0, 0, location)
+	if date.Year() != y || date.Month() != time.Month(m) || date.Day() != d {
+		return time.Time{}, false
+	}
+
+	return date, true
+}
@@ -0,0 +1,229 @@
+package macros
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Тест разбора макросов дедлайна
+func TestParse_Deadline(t *testing.T) {
+	novosibirsk, err := time.LoadLocation("Asia/Novosibirsk")
+	assert.NoError(t, err)
+	// Среда, 14 февраля 2024, 10:00
+	now := time.Date(2024, 2, 14, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		now      time.Time
+		wantName string
+		want     *time.Time
+	}{
+		{
+			name:     "Дата через точку",
+			input:    "Отчёт !before 15.02.2024",
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 2, 15, 23, 59, 59, 0, time.UTC)),
+		},
+		{
+			name:     "Дата через дефис со временем",
+			input:    "Отчёт !before 15-02-2024 14:30 срочно",
+			wantName: "Отчёт срочно",
+			want:     utils.Ptr(time.Date(2024, 2, 15, 14, 30, 0, 0, time.UTC)),
+		},
+		{
+			name:     "ISO-дата",
+			input:    "Отчёт !before 2024-03-01",
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 3, 1, 23, 59, 59, 0, time.UTC)),
+		},
+		{
+			name:     "ISO-дата со временем",
+			input:    "Отчёт !before 2024-03-01T09:15",
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 3, 1, 9, 15, 0, 0, time.UTC)),
+		},
+		{
+			name:     "Завтра",
+			input:    "Отчёт !before Tomorrow",
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 2, 15, 23, 59, 59, 0, time.UTC)),
+		},
+		{
+			name:     "Сегодня без времени — до конца дня",
+			input:    "Отчёт !before today",
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 2, 14, 23, 59, 59, 0, time.UTC)),
+		},
+		{
+			name:     "Сегодняшний день недели без времени",
+			input:    "Отчёт !before wed",
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 2, 14, 23, 59, 59, 0, time.UTC)),
+		},
+		{
+			name:     "Сегодня со временем",
+			input:    "Отчёт !before сегодня 18:00",
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 2, 14, 18, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:     "День недели со временем",
+			input:    "Отчёт !before fri 18:00",
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 2, 16, 18, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:     "Сегодняшний день недели с прошедшим временем — через неделю",
+			input:    "Отчёт !before ср 9:00",
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 2, 21, 9, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:     "Только время, уже прошедшее сегодня",
+			input:    "Отчёт !before 08:30",
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 2, 15, 8, 30, 0, 0, time.UTC)),
+		},
+		{
+			name:     "Через три дня",
+			input:    "Отчёт !in 3d",
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 2, 17, 10, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:     "Через 90 минут",
+			input:    "!IN 90m Отчёт",
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 2, 14, 11, 30, 0, 0, time.UTC)),
+		},
+		{
+			name:     "Относительная дата в часовом поясе now",
+			input:    "Отчёт !before tomorrow",
+			now:      time.Date(2024, 2, 14, 20, 0, 0, 0, time.UTC).In(novosibirsk),
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 2, 16, 23, 59, 59, 0, novosibirsk)),
+		},
+		{
+			name:     "Дата — конец местного дня",
+			input:    "Отчёт !before 15.02.2024",
+			now:      now.In(novosibirsk),
+			wantName: "Отчёт",
+			want:     utils.Ptr(time.Date(2024, 2, 15, 16, 59, 59, 0, time.UTC)),
+		},
+		{
+			name:     "Несуществующая дата",
+			input:    "Отчёт !before 31.02.2024",
+			wantName: "Отчёт !before 31.02.2024",
+		},
+		{
+			name:     "Макрос без даты",
+			input:    "Отчёт !before",
+			wantName: "Отчёт !before",
+		},
+		{
+			name:     "Повторный дедлайн не применяется",
+			input:    "Отчёт !in 1w !before tomorrow",
+			wantName: "Отчёт !before tomorrow",
+			want:     utils.Ptr(time.Date(2024, 2, 21, 10, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.now.IsZero() {
+				tt.now = now
+			}
+
+			result := Parse(tt.input, tt.now)
+
+			assert.Equal(t, tt.wantName, result.Name)
+			if tt.want == nil {
+				assert.Nil(t, result.Deadline)
+				assert.Len(t, result.Unrecognized, 1)
+				assert.Equal(t, Deadline, result.Unrecognized[0].Kind)
+			} else {
+				assert.NotNil(t, result.Deadline)
+				assert.True(t, tt.want.Equal(*result.Deadline), "deadline %v", result.Deadline)
+			}
+		})
+	}
+}
+
+// Тест приоритета, меток, проекта и нераспознанных макросов
+func TestParse(t *testing.T) {
+	now := time.Date(2024, 2, 14, 10, 0, 0, 0, time.UTC)
+
+	result := Parse("  Отчёт !2 #Work @Работа за !10 квартал  !1 #work #urgent @дом !срочно", now)
+
+	// Двойной пробел перед !1 был в исходном названии
+	assert.Equal(t, "Отчёт за !10 квартал  !1 @дом !срочно", result.Name)
+	assert.Equal(t, enums.High, *result.Priority)
+	assert.Equal(t, []string{"work", "urgent"}, result.Tags)
+	assert.Equal(t, "Работа", *result.Project)
+	assert.Nil(t, result.Deadline)
+	assert.Equal(t, []Macro{
+		{Kind: Priority, Text: "!2"},
+		{Kind: Tag, Text: "#Work"},
+		{Kind: Project, Text: "@Работа"},
+		{Kind: Tag, Text: "#work"},
+		{Kind: Tag, Text: "#urgent"},
+	}, result.Recognized)
+	assert.Equal(t, []Macro{
+		{Text: "!10", Reason: "Unknown macro"},
+		{Kind: Priority, Text: "!1", Reason: "Duplicate priority macro; the first one is used"},
+		{Kind: Project, Text: "@дом", Reason: "Duplicate project macro; the first one is used"},
+		{Text: "!срочно", Reason: "Unknown macro"},
+	}, result.Unrecognized)
+}
+
+// Тест сохранения текста названия: удаляются только распознанные макросы
+func TestParse_Name(t *testing.T) {
+	now := time.Date(2024, 2, 14, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		wantName string
+	}{
+		{
+			name:     "Название без макросов не меняется",
+			input:    "Купить:\n\t- молоко\n\t- хлеб  (обязательно)",
+			wantName: "Купить:\n\t- молоко\n\t- хлеб  (обязательно)",
+		},
+		{
+			name:     "Пробелы по краям убираются",
+			input:    "\n  Отчёт  \t",
+			wantName: "Отчёт",
+		},
+		{
+			name:     "Макрос удаляется вместе с одним отделяющим пробелом",
+			input:    "Отчёт  за квартал #work !1",
+			wantName: "Отчёт  за квартал",
+		},
+		{
+			name:     "Перевод строки перед макросом остаётся",
+			input:    "Отчёт\n#work детали\nвторая строка",
+			wantName: "Отчёт\nдетали\nвторая строка",
+		},
+		{
+			name:     "Многословный дедлайн удаляется целиком",
+			input:    "Отчёт !before 15.02.2024   14:30\tсрочно",
+			wantName: "Отчёт срочно",
+		},
+		{
+			name:     "Макрос в конце строки удаляется вместе с пробелом перед ним",
+			input:    "Отчёт @Работа\nДетали",
+			wantName: "Отчёт\nДетали",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantName, Parse(tt.input, now).Name)
+		})
+	}
+}
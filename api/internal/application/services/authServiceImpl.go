@@ -0,0 +1,137 @@
+package services
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	appInterfaces "HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/application/validators"
+	domainInterfaces "HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	defaultErrors "errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"time"
+)
+
+type AuthServiceImpl struct {
+	usersRepository domainInterfaces.UsersRepository
+	secret          []byte
+	tokenTTL        time.Duration
+}
+
+func NewAuthService(usersRepository domainInterfaces.UsersRepository, secret []byte,
+	tokenTTL time.Duration) appInterfaces.AuthService {
+	return &AuthServiceImpl{usersRepository: usersRepository, secret: secret, tokenTTL: tokenTTL}
+}
+
+func (service *AuthServiceImpl) Register(email string, password string) (*models.User, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	if err := validators.ValidateCredentials(email, password); err != nil {
+		return nil, err
+	}
+
+	existing, err := service.usersRepository.GetByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, emailTakenError()
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := models.NewUser(email, string(passwordHash))
+
+	// Параллельная регистрация с тем же email проходит проверку выше, но не уникальный индекс
+	err = service.usersRepository.Add(*user)
+	if defaultErrors.Is(err, domainInterfaces.ErrUserEmailTaken) {
+		return nil, emailTakenError()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (service *AuthServiceImpl) Login(email string, password string) (string, time.Time, error) {
+	invalidCredentialsErr := errors.ApplicationError{
+		StatusCode: 401,
+		Code:       "Unauthorized",
+		Errors:     map[string]string{"message": "Invalid email or password"},
+	}
+
+	user, err := service.usersRepository.GetByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if user == nil {
+		return "", time.Time{}, invalidCredentialsErr
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return "", time.Time{}, invalidCredentialsErr
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(service.tokenTTL)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   user.ID.String(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}).SignedString(service.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+func (service *AuthServiceImpl) Authenticate(token string) (uuid.UUID, error) {
+	unauthorizedErr := errors.ApplicationError{
+		StatusCode: 401,
+		Code:       "Unauthorized",
+		Errors:     map[string]string{"message": "Invalid or expired token"},
+	}
+
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return service.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return uuid.Nil, unauthorizedErr
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, unauthorizedErr
+	}
+
+	// Токен удалённого пользователя больше не действителен
+	user, err := service.usersRepository.GetByID(userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if user == nil {
+		return uuid.Nil, unauthorizedErr
+	}
+
+	return userID, nil
+}
+
+func emailTakenError() errors.ApplicationError {
+	return errors.ApplicationError{
+		StatusCode: 409,
+		Code:       "Conflict",
+		Errors:     map[string]string{"email": "User with this email already exists"},
+	}
+}
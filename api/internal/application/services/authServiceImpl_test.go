@@ -0,0 +1,292 @@
+package services
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	domainInterfaces "HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Мок репозитория пользователей
+type MockUsersRepository struct {
+	mock.Mock
+}
+
+func (m *MockUsersRepository) Add(user models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUsersRepository) GetByID(id uuid.UUID) (*models.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUsersRepository) Update(user models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUsersRepository) GetByEmail(email string) (*models.User, error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUsersRepository) GetByCalendarTokenHash(hash string) (*models.User, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+var testSecret = []byte("test-secret")
+
+func newTestUser(t *testing.T, email string, password string) *models.User {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	assert.NoError(t, err)
+	return models.NewUser(email, string(hash))
+}
+
+// Тест регистрации пользователя
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		password   string
+		mockSetup  func(*MockUsersRepository)
+		wantStatus int
+	}{
+		{
+			name:     "Успешная регистрация",
+			email:    " User@Example.com ",
+			password: "password123",
+			mockSetup: func(m *MockUsersRepository) {
+				m.On("GetByEmail", "user@example.com").Return(nil, nil)
+				m.On("Add", mock.MatchedBy(func(user models.User) bool {
+					return user.Email == "user@example.com" &&
+						bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("password123")) == nil
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "Невалидный email",
+			email:      "not-an-email",
+			password:   "password123",
+			mockSetup:  func(m *MockUsersRepository) {},
+			wantStatus: 400,
+		},
+		{
+			name:       "Короткий пароль",
+			email:      "user@example.com",
+			password:   "short",
+			mockSetup:  func(m *MockUsersRepository) {},
+			wantStatus: 400,
+		},
+		{
+			// 37 символов, но 74 байта: bcrypt ограничивает длину пароля в байтах
+			name:       "Слишком длинный пароль",
+			email:      "user@example.com",
+			password:   strings.Repeat("я", 37),
+			mockSetup:  func(m *MockUsersRepository) {},
+			wantStatus: 400,
+		},
+		{
+			name:     "Email занят параллельной регистрацией",
+			email:    "user@example.com",
+			password: "password123",
+			mockSetup: func(m *MockUsersRepository) {
+				m.On("GetByEmail", "user@example.com").Return(nil, nil)
+				m.On("Add", mock.Anything).Return(domainInterfaces.ErrUserEmailTaken)
+			},
+			wantStatus: 409,
+		},
+		{
+			name:     "Email уже занят",
+			email:    "user@example.com",
+			password: "password123",
+			mockSetup: func(m *MockUsersRepository) {
+				m.On("GetByEmail", "user@example.com").Return(models.NewUser("user@example.com", "hash"), nil)
+			},
+			wantStatus: 409,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUsersRepository)
+			tt.mockSetup(mockRepo)
+
+			service := NewAuthService(mockRepo, testSecret, time.Hour)
+			user, err := service.Register(tt.email, tt.password)
+
+			if tt.wantStatus != 0 {
+				assert.Error(t, err)
+				assert.Nil(t, user)
+				if appErr, ok := err.(errors.ApplicationError); ok {
+					assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, user)
+				assert.NotEqual(t, "password123", user.PasswordHash)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест входа и проверки выданного токена
+func TestLoginAndAuthenticate(t *testing.T) {
+	user := newTestUser(t, "user@example.com", "password123")
+
+	tests := []struct {
+		name      string
+		email     string
+		password  string
+		mockSetup func(*MockUsersRepository)
+		wantErr   bool
+	}{
+		{
+			name:     "Успешный вход",
+			email:    "user@example.com",
+			password: "password123",
+			mockSetup: func(m *MockUsersRepository) {
+				m.On("GetByEmail", "user@example.com").Return(user, nil)
+				m.On("GetByID", user.ID).Return(user, nil)
+			},
+		},
+		{
+			name:     "Неверный пароль",
+			email:    "user@example.com",
+			password: "wrong-password",
+			mockSetup: func(m *MockUsersRepository) {
+				m.On("GetByEmail", "user@example.com").Return(user, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "Несуществующий пользователь",
+			email:    "nobody@example.com",
+			password: "password123",
+			mockSetup: func(m *MockUsersRepository) {
+				m.On("GetByEmail", "nobody@example.com").Return(nil, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUsersRepository)
+			tt.mockSetup(mockRepo)
+
+			service := NewAuthService(mockRepo, testSecret, time.Hour)
+			token, expiresAt, err := service.Login(tt.email, tt.password)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+				if appErr, ok := err.(errors.ApplicationError); ok {
+					assert.Equal(t, 401, appErr.StatusCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+
+				userID, err := service.Authenticate(token)
+				assert.NoError(t, err)
+				assert.Equal(t, user.ID, userID)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест отклонения недействительных токенов
+func TestAuthenticateInvalidToken(t *testing.T) {
+	user := newTestUser(t, "user@example.com", "password123")
+
+	sign := func(secret []byte, method jwt.SigningMethod, claims jwt.RegisteredClaims) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(secret)
+		assert.NoError(t, err)
+		return token
+	}
+
+	validClaims := jwt.RegisteredClaims{
+		Subject:   user.ID.String(),
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+
+	tests := []struct {
+		name      string
+		token     string
+		mockSetup func(*MockUsersRepository)
+	}{
+		{
+			name:      "Мусор вместо токена",
+			token:     "garbage",
+			mockSetup: func(m *MockUsersRepository) {},
+		},
+		{
+			name:      "Подпись другим ключом",
+			token:     sign([]byte("other-secret"), jwt.SigningMethodHS256, validClaims),
+			mockSetup: func(m *MockUsersRepository) {},
+		},
+		{
+			name:      "Другой алгоритм подписи",
+			token:     sign(testSecret, jwt.SigningMethodHS512, validClaims),
+			mockSetup: func(m *MockUsersRepository) {},
+		},
+		{
+			name: "Истёкший токен",
+			token: sign(testSecret, jwt.SigningMethodHS256, jwt.RegisteredClaims{
+				Subject:   user.ID.String(),
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+			}),
+			mockSetup: func(m *MockUsersRepository) {},
+		},
+		{
+			name:      "Токен без срока действия",
+			token:     sign(testSecret, jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: user.ID.String()}),
+			mockSetup: func(m *MockUsersRepository) {},
+		},
+		{
+			name:  "Удалённый пользователь",
+			token: sign(testSecret, jwt.SigningMethodHS256, validClaims),
+			mockSetup: func(m *MockUsersRepository) {
+				m.On("GetByID", user.ID).Return(nil, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUsersRepository)
+			tt.mockSetup(mockRepo)
+
+			service := NewAuthService(mockRepo, testSecret, time.Hour)
+			userID, err := service.Authenticate(tt.token)
+
+			assert.Error(t, err)
+			assert.Equal(t, uuid.Nil, userID)
+			if appErr, ok := err.(errors.ApplicationError); ok {
+				assert.Equal(t, 401, appErr.StatusCode)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
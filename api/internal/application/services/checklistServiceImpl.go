@@ -0,0 +1,167 @@
+package services
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	appInterfaces "HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/application/validators"
+	domainInterfaces "HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+type ChecklistServiceImpl struct {
+	tasksRepository          domainInterfaces.TasksRepository
+	checklistItemsRepository domainInterfaces.ChecklistItemsRepository
+	unitOfWork               domainInterfaces.UnitOfWork
+}
+
+// Пункты меняются в транзакции unitOfWork с блокировкой задачи, поэтому выполнение задачи не пропустит
+// пункт, добавленный параллельно; без unitOfWork изменения выполняются вне транзакции
+func NewChecklistService(tasksRepository domainInterfaces.TasksRepository,
+	checklistItemsRepository domainInterfaces.ChecklistItemsRepository,
+	unitOfWork domainInterfaces.UnitOfWork) appInterfaces.ChecklistService {
+	return &ChecklistServiceImpl{
+		tasksRepository:          tasksRepository,
+		checklistItemsRepository: checklistItemsRepository,
+		unitOfWork:               unitOfWork,
+	}
+}
+
+func (service *ChecklistServiceImpl) GetItems(userID uuid.UUID, taskID uuid.UUID) ([]*models.ChecklistItem, error) {
+	if _, err := findOwnedTask(service.tasksRepository, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	return service.checklistItemsRepository.GetByTaskID(taskID)
+}
+
+func (service *ChecklistServiceImpl) AddItem(userID uuid.UUID, taskID uuid.UUID, name string) (*models.ChecklistItem,
+	error) {
+	name = strings.TrimSpace(name)
+	if err := validators.ValidateChecklistItem(name); err != nil {
+		return nil, err
+	}
+
+	var item *models.ChecklistItem
+	err := service.withinTx(func(txService *ChecklistServiceImpl) error {
+		var err error
+		item, err = txService.addItem(userID, taskID, name)
+		return err
+	})
+
+	return item, err
+}
+
+func (service *ChecklistServiceImpl) addItem(userID uuid.UUID, taskID uuid.UUID, name string) (*models.ChecklistItem,
+	error) {
+	if _, err := lockOwnedTask(service.tasksRepository, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	items, err := service.checklistItemsRepository.GetByTaskID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Новый пункт добавляется в конец списка
+	position := 0
+	for _, item := range items {
+		position = max(position, item.Position+1)
+	}
+
+	item := models.NewChecklistItem(taskID, name, position)
+	if err := service.checklistItemsRepository.Add(*item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (service *ChecklistServiceImpl) UpdateItem(userID uuid.UUID, taskID uuid.UUID, itemID uuid.UUID, name string,
+	isDone bool) (*models.ChecklistItem, error) {
+	name = strings.TrimSpace(name)
+	if err := validators.ValidateChecklistItem(name); err != nil {
+		return nil, err
+	}
+
+	var item *models.ChecklistItem
+	err := service.withinTx(func(txService *ChecklistServiceImpl) error {
+		var err error
+		item, err = txService.updateItem(userID, taskID, itemID, name, isDone)
+		return err
+	})
+
+	return item, err
+}
+
+func (service *ChecklistServiceImpl) updateItem(userID uuid.UUID, taskID uuid.UUID, itemID uuid.UUID, name string,
+	isDone bool) (*models.ChecklistItem, error) {
+	item, err := service.getOwnedItem(userID, taskID, itemID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Name = name
+	item.IsDone = isDone
+	item.ChangedAt = utils.Ptr(time.Now())
+
+	if err := service.checklistItemsRepository.Update(*item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (service *ChecklistServiceImpl) DeleteItem(userID uuid.UUID, taskID uuid.UUID, itemID uuid.UUID) error {
+	return service.withinTx(func(txService *ChecklistServiceImpl) error {
+		if _, err := txService.getOwnedItem(userID, taskID, itemID, true); err != nil {
+			return err
+		}
+
+		return txService.checklistItemsRepository.DeleteByID(itemID)
+	})
+}
+
+// lock блокирует задачу пункта до конца транзакции
+func (service *ChecklistServiceImpl) getOwnedItem(userID uuid.UUID, taskID uuid.UUID, itemID uuid.UUID,
+	lock bool) (*models.ChecklistItem, error) {
+	find := findOwnedTask
+	if lock {
+		find = lockOwnedTask
+	}
+	if _, err := find(service.tasksRepository, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	item, err := service.checklistItemsRepository.GetByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item == nil || item.TaskID != taskID {
+		return nil, errors.ApplicationError{
+			StatusCode: 404,
+			Code:       "NotFound",
+			Errors:     map[string]string{"message": "Checklist item not found"},
+		}
+	}
+
+	return item, nil
+}
+
+func (service *ChecklistServiceImpl) withinTx(fn func(txService *ChecklistServiceImpl) error) error {
+	if service.unitOfWork == nil {
+		return fn(service)
+	}
+
+	return service.unitOfWork.WithinTx(func(repos domainInterfaces.Repositories) error {
+		return fn(&ChecklistServiceImpl{
+			tasksRepository:          repos.Tasks,
+			checklistItemsRepository: repos.ChecklistItems,
+			unitOfWork:               repos.UnitOfWork,
+		})
+	})
+}
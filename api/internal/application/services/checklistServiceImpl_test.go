@@ -0,0 +1,188 @@
+package services
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+// Мок репозитория пунктов чек-листа
+type MockChecklistItemsRepository struct {
+	mock.Mock
+}
+
+func (m *MockChecklistItemsRepository) Add(item models.ChecklistItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockChecklistItemsRepository) GetByID(id uuid.UUID) (*models.ChecklistItem, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChecklistItem), args.Error(1)
+}
+
+func (m *MockChecklistItemsRepository) GetByTaskID(taskID uuid.UUID) ([]*models.ChecklistItem, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]*models.ChecklistItem), args.Error(1)
+}
+
+func (m *MockChecklistItemsRepository) GetProgress(
+	taskIDs []uuid.UUID) (map[uuid.UUID]models.ChecklistProgress, error) {
+	args := m.Called(taskIDs)
+	return args.Get(0).(map[uuid.UUID]models.ChecklistProgress), args.Error(1)
+}
+
+func (m *MockChecklistItemsRepository) Update(item models.ChecklistItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockChecklistItemsRepository) MarkAllDone(taskID uuid.UUID, now time.Time) error {
+	args := m.Called(taskID, now)
+	return args.Error(0)
+}
+
+func (m *MockChecklistItemsRepository) DeleteByID(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockChecklistItemsRepository) DeleteByTaskID(taskID uuid.UUID) error {
+	args := m.Called(taskID)
+	return args.Error(0)
+}
+
+// Репозиторий для тестов, не касающихся чек-листов: у задач нет пунктов
+func newChecklistItemsRepositoryStub() *MockChecklistItemsRepository {
+	stub := new(MockChecklistItemsRepository)
+	stub.On("GetProgress", mock.Anything).Return(map[uuid.UUID]models.ChecklistProgress{}, nil).Maybe()
+	stub.On("DeleteByTaskID", mock.Anything).Return(nil).Maybe()
+	return stub
+}
+
+// Тест добавления пункта чек-листа
+func TestAddChecklistItem(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	tests := []struct {
+		name         string
+		itemName     string
+		mockSetup    func(*MockTasksRepository, *MockChecklistItemsRepository)
+		wantPosition int
+		wantStatus   int
+	}{
+		{
+			name:     "Добавление пункта в конец списка",
+			itemName: "  Купить молоко ",
+			mockSetup: func(tasks *MockTasksRepository, items *MockChecklistItemsRepository) {
+				tasks.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: userID}, nil)
+				items.On("GetByTaskID", taskID).Return([]*models.ChecklistItem{
+					{TaskID: taskID, Position: 0},
+					{TaskID: taskID, Position: 3},
+				}, nil)
+				items.On("Add", mock.MatchedBy(func(item models.ChecklistItem) bool {
+					return item.Name == "Купить молоко" && item.Position == 4 && item.TaskID == taskID
+				})).Return(nil)
+			},
+			wantPosition: 4,
+		},
+		{
+			name:       "Пустое название",
+			itemName:   "   ",
+			mockSetup:  func(*MockTasksRepository, *MockChecklistItemsRepository) {},
+			wantStatus: 400,
+		},
+		{
+			name:     "Задача другого пользователя",
+			itemName: "Пункт",
+			mockSetup: func(tasks *MockTasksRepository, items *MockChecklistItemsRepository) {
+				tasks.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: uuid.New()}, nil)
+			},
+			wantStatus: 404,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasksRepo := new(MockTasksRepository)
+			itemsRepo := new(MockChecklistItemsRepository)
+			tt.mockSetup(tasksRepo, itemsRepo)
+
+			service := NewChecklistService(tasksRepo, itemsRepo, nil)
+			item, err := service.AddItem(userID, taskID, tt.itemName)
+
+			if tt.wantStatus != 0 {
+				assert.Nil(t, item)
+				var appErr errors.ApplicationError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantPosition, item.Position)
+			}
+			tasksRepo.AssertExpectations(t)
+			itemsRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест изменения и удаления пункта чек-листа
+func TestUpdateAndDeleteChecklistItem(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+	itemID := uuid.New()
+
+	t.Run("Отметка пункта выполненным", func(t *testing.T) {
+		tasksRepo := new(MockTasksRepository)
+		tasksRepo.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: userID}, nil)
+		itemsRepo := new(MockChecklistItemsRepository)
+		itemsRepo.On("GetByID", itemID).Return(&models.ChecklistItem{ID: itemID, TaskID: taskID, Name: "old"}, nil)
+		itemsRepo.On("Update", mock.MatchedBy(func(item models.ChecklistItem) bool {
+			return item.Name == "new" && item.IsDone && item.ChangedAt != nil
+		})).Return(nil)
+
+		service := NewChecklistService(tasksRepo, itemsRepo, nil)
+		item, err := service.UpdateItem(userID, taskID, itemID, "new", true)
+
+		assert.NoError(t, err)
+		assert.True(t, item.IsDone)
+		itemsRepo.AssertExpectations(t)
+	})
+
+	t.Run("Пункт из другой задачи", func(t *testing.T) {
+		tasksRepo := new(MockTasksRepository)
+		tasksRepo.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: userID}, nil)
+		itemsRepo := new(MockChecklistItemsRepository)
+		itemsRepo.On("GetByID", itemID).Return(&models.ChecklistItem{ID: itemID, TaskID: uuid.New()}, nil)
+
+		service := NewChecklistService(tasksRepo, itemsRepo, nil)
+		err := service.DeleteItem(userID, taskID, itemID)
+
+		var appErr errors.ApplicationError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, 404, appErr.StatusCode)
+		itemsRepo.AssertNotCalled(t, "DeleteByID", itemID)
+	})
+
+	t.Run("Удаление пункта", func(t *testing.T) {
+		tasksRepo := new(MockTasksRepository)
+		tasksRepo.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: userID}, nil)
+		itemsRepo := new(MockChecklistItemsRepository)
+		itemsRepo.On("GetByID", itemID).Return(&models.ChecklistItem{ID: itemID, TaskID: taskID}, nil)
+		itemsRepo.On("DeleteByID", itemID).Return(nil)
+
+		service := NewChecklistService(tasksRepo, itemsRepo, nil)
+		err := service.DeleteItem(userID, taskID, itemID)
+
+		assert.NoError(t, err)
+		itemsRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	appEnums "HITS_ToDoList_Tests/internal/application/enums"
+	"HITS_ToDoList_Tests/internal/application/errors"
+	appInterfaces "HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/application/validators"
+	domainInterfaces "HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+type ProjectsServiceImpl struct {
+	projectsRepository domainInterfaces.ProjectsRepository
+	tasksRepository    domainInterfaces.TasksRepository
+	tasksService       appInterfaces.TasksService
+}
+
+// tasksService удаляет задачи проекта при каскадном удалении вместе с их пунктами, метками и дедлайнами;
+// в его транзакции выполняется всё удаление проекта
+func NewProjectsService(projectsRepository domainInterfaces.ProjectsRepository,
+	tasksRepository domainInterfaces.TasksRepository,
+	tasksService appInterfaces.TasksService) appInterfaces.ProjectsService {
+	return &ProjectsServiceImpl{
+		projectsRepository: projectsRepository,
+		tasksRepository:    tasksRepository,
+		tasksService:       tasksService,
+	}
+}
+
+// Inbox создаётся при первом обращении к списку проектов
+func (service *ProjectsServiceImpl) GetProjects(userID uuid.UUID, archived *bool) ([]*models.Project, error) {
+	if _, err := service.getOrCreateInbox(userID); err != nil {
+		return nil, err
+	}
+
+	return service.projectsRepository.GetByOwnerID(userID, archived)
+}
+
+func (service *ProjectsServiceImpl) GetProject(userID uuid.UUID, projectID uuid.UUID) (*models.Project, error) {
+	return findOwnedProject(service.projectsRepository, userID, projectID)
+}
+
+func (service *ProjectsServiceImpl) CreateProject(userID uuid.UUID, name string,
+	color *string) (*models.Project, error) {
+	name = strings.TrimSpace(name)
+	if err := validators.ValidateProject(name, color); err != nil {
+		return nil, err
+	}
+
+	project := models.NewProject(userID, name, color)
+	if err := service.projectsRepository.Add(*project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (service *ProjectsServiceImpl) UpdateProject(userID uuid.UUID, projectID uuid.UUID, name string, color *string,
+	isArchived bool) (*models.Project, error) {
+	name = strings.TrimSpace(name)
+	if err := validators.ValidateProject(name, color); err != nil {
+		return nil, err
+	}
+
+	project, err := findOwnedProject(service.projectsRepository, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.IsInbox && isArchived {
+		return nil, errors.ApplicationError{
+			StatusCode: 409,
+			Code:       "Conflict",
+			Errors:     map[string]string{"isArchived": "Inbox project cannot be archived"},
+		}
+	}
+
+	project.Name = name
+	project.Color = color
+	project.IsArchived = isArchived
+	project.ChangedAt = utils.Ptr(time.Now())
+
+	if err := service.projectsRepository.Update(*project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// Задачи удаляемого проекта либо удаляются вместе с ним, либо переносятся в Inbox; сам Inbox удалить нельзя.
+// Всё удаление выполняется в одной транзакции: при ошибке и проект, и его задачи остаются как были
+func (service *ProjectsServiceImpl) DeleteProject(userID uuid.UUID, projectID uuid.UUID,
+	mode appEnums.ProjectDeleteMode) error {
+	return service.tasksService.WithinTx(func(tasksService appInterfaces.TasksService,
+		repos domainInterfaces.Repositories) error {
+		txService := *service
+		txService.projectsRepository = repos.Projects
+		txService.tasksRepository = repos.Tasks
+		txService.tasksService = tasksService
+		return txService.deleteProject(userID, projectID, mode)
+	})
+}
+
+func (service *ProjectsServiceImpl) deleteProject(userID uuid.UUID, projectID uuid.UUID,
+	mode appEnums.ProjectDeleteMode) error {
+	project, err := findOwnedProject(service.projectsRepository, userID, projectID)
+	if err != nil {
+		return err
+	}
+
+	if project.IsInbox {
+		return errors.ApplicationError{
+			StatusCode: 409,
+			Code:       "Conflict",
+			Errors:     map[string]string{"message": "Inbox project cannot be deleted"},
+		}
+	}
+
+	// Задачи меняются по одной через сервис задач, чтобы каждое изменение попало в журнал, получило
+	// новую версию и дошло до клиентов и вебхуков. Задачи из корзины не трогаются: при восстановлении
+	// задача из удалённого проекта попадает в Inbox
+	tasks, err := service.tasksRepository.GetAll(&models.TasksFilter{OwnerID: &userID, ProjectID: &projectID}, nil)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case appEnums.Cascade:
+		for _, task := range tasks {
+			if err := service.tasksService.DeleteTask(userID, task.ID, nil); err != nil {
+				return err
+			}
+		}
+	case appEnums.MoveToInbox:
+		inbox, err := service.getOrCreateInbox(userID)
+		if err != nil {
+			return err
+		}
+
+		patch := models.TaskPatch{ProjectID: utils.Ptr(&inbox.ID)}
+		for _, task := range tasks {
+			if _, err := service.tasksService.PatchTask(userID, task.ID, patch, nil, nil); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("invalid project delete mode: %q", mode)
+	}
+
+	return service.projectsRepository.DeleteByID(projectID)
+}
+
+// Если Inbox одновременно создал другой запрос, уникальный индекс отклонит вставку, и используется уже созданный.
+// Вставка выполняется во вложенной транзакции: в Postgres отклонённая вставка прерывает всю транзакцию,
+// и прочитать созданный Inbox после неё было бы нельзя
+func (service *ProjectsServiceImpl) getOrCreateInbox(userID uuid.UUID) (*models.Project, error) {
+	inbox, err := service.projectsRepository.GetInbox(userID)
+	if err != nil || inbox != nil {
+		return inbox, err
+	}
+
+	inbox = models.NewInboxProject(userID)
+	err = service.tasksService.WithinTx(func(_ appInterfaces.TasksService, repos domainInterfaces.Repositories) error {
+		return repos.Projects.Add(*inbox)
+	})
+	if err != nil {
+		existing, getErr := service.projectsRepository.GetInbox(userID)
+		if getErr != nil || existing == nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	return inbox, nil
+}
+
+// Проекты других пользователей неотличимы от несуществующих
+func findOwnedProject(projectsRepository domainInterfaces.ProjectsRepository, userID uuid.UUID,
+	projectID uuid.UUID) (*models.Project, error) {
+	project, err := projectsRepository.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if project == nil || project.OwnerID != userID {
+		return nil, errors.ApplicationError{
+			StatusCode: 404,
+			Code:       "NotFound",
+			Errors:     map[string]string{"message": "Project not found"},
+		}
+	}
+
+	return project, nil
+}
@@ -0,0 +1,410 @@
+package services
+
+import (
+	appEnums "HITS_ToDoList_Tests/internal/application/enums"
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/application/macros"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	defaultErrors "errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+// Мок репозитория проектов
+type MockProjectsRepository struct {
+	mock.Mock
+}
+
+func (m *MockProjectsRepository) Add(project models.Project) error {
+	args := m.Called(project)
+	return args.Error(0)
+}
+
+func (m *MockProjectsRepository) GetByID(id uuid.UUID) (*models.Project, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Project), args.Error(1)
+}
+
+func (m *MockProjectsRepository) GetByOwnerID(ownerID uuid.UUID, archived *bool) ([]*models.Project, error) {
+	args := m.Called(ownerID, archived)
+	return args.Get(0).([]*models.Project), args.Error(1)
+}
+
+func (m *MockProjectsRepository) GetInbox(ownerID uuid.UUID) (*models.Project, error) {
+	args := m.Called(ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Project), args.Error(1)
+}
+
+func (m *MockProjectsRepository) Update(project models.Project) error {
+	args := m.Called(project)
+	return args.Error(0)
+}
+
+func (m *MockProjectsRepository) DeleteByID(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// Тест создания и изменения проекта
+func TestCreateAndUpdateProject(t *testing.T) {
+	userID := uuid.New()
+	projectID := uuid.New()
+
+	tests := []struct {
+		name       string
+		action     func(service *ProjectsServiceImpl) (*models.Project, error)
+		mockSetup  func(*MockProjectsRepository)
+		wantName   string
+		wantStatus int
+	}{
+		{
+			name: "Создание проекта",
+			action: func(service *ProjectsServiceImpl) (*models.Project, error) {
+				return service.CreateProject(userID, "  Работа ", utils.Ptr("#1E88E5"))
+			},
+			mockSetup: func(repo *MockProjectsRepository) {
+				repo.On("Add", mock.MatchedBy(func(project models.Project) bool {
+					return project.Name == "Работа" && project.OwnerID == userID && !project.IsInbox
+				})).Return(nil)
+			},
+			wantName: "Работа",
+		},
+		{
+			name: "Некорректный цвет",
+			action: func(service *ProjectsServiceImpl) (*models.Project, error) {
+				return service.CreateProject(userID, "Работа", utils.Ptr("blue"))
+			},
+			mockSetup:  func(*MockProjectsRepository) {},
+			wantStatus: 400,
+		},
+		{
+			name: "Архивирование проекта",
+			action: func(service *ProjectsServiceImpl) (*models.Project, error) {
+				return service.UpdateProject(userID, projectID, "Старое", nil, true)
+			},
+			mockSetup: func(repo *MockProjectsRepository) {
+				repo.On("GetByID", projectID).Return(
+					&models.Project{ID: projectID, OwnerID: userID, Name: "Работа"}, nil)
+				repo.On("Update", mock.MatchedBy(func(project models.Project) bool {
+					return project.Name == "Старое" && project.IsArchived && project.ChangedAt != nil
+				})).Return(nil)
+			},
+			wantName: "Старое",
+		},
+		{
+			name: "Архивирование Inbox",
+			action: func(service *ProjectsServiceImpl) (*models.Project, error) {
+				return service.UpdateProject(userID, projectID, "Inbox", nil, true)
+			},
+			mockSetup: func(repo *MockProjectsRepository) {
+				repo.On("GetByID", projectID).Return(
+					&models.Project{ID: projectID, OwnerID: userID, IsInbox: true}, nil)
+			},
+			wantStatus: 409,
+		},
+		{
+			name: "Проект другого пользователя",
+			action: func(service *ProjectsServiceImpl) (*models.Project, error) {
+				return service.UpdateProject(userID, projectID, "Работа", nil, false)
+			},
+			mockSetup: func(repo *MockProjectsRepository) {
+				repo.On("GetByID", projectID).Return(&models.Project{ID: projectID, OwnerID: uuid.New()}, nil)
+			},
+			wantStatus: 404,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectsRepo := new(MockProjectsRepository)
+			tt.mockSetup(projectsRepo)
+
+			service := &ProjectsServiceImpl{projectsRepository: projectsRepo}
+			project, err := tt.action(service)
+
+			if tt.wantStatus != 0 {
+				assert.Nil(t, project)
+				var appErr errors.ApplicationError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantName, project.Name)
+			}
+			projectsRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест удаления проекта с переносом задач в Inbox и каскадно
+func TestDeleteProject(t *testing.T) {
+	userID := uuid.New()
+	projectID := uuid.New()
+	taskID := uuid.New()
+	project := &models.Project{ID: projectID, OwnerID: userID, Name: "Работа"}
+
+	t.Run("Перенос задач в созданный Inbox", func(t *testing.T) {
+		projectsRepo := new(MockProjectsRepository)
+		projectsRepo.On("GetByID", projectID).Return(project, nil)
+		projectsRepo.On("GetInbox", userID).Return(nil, nil)
+		var inbox models.Project
+		projectsRepo.On("Add", mock.MatchedBy(func(project models.Project) bool {
+			return project.IsInbox && project.OwnerID == userID
+		})).Run(func(args mock.Arguments) {
+			inbox = args.Get(0).(models.Project)
+			projectsRepo.On("GetByID", inbox.ID).Return(&inbox, nil)
+		}).Return(nil)
+		projectsRepo.On("DeleteByID", projectID).Return(nil)
+		tasksRepo := new(MockTasksRepository)
+		task := &models.Task{ID: taskID, OwnerID: userID, Name: "Отчёт", Status: enums.Active,
+			Priority: enums.Medium, ProjectID: &projectID, Version: 1}
+		tasksRepo.On("GetAll", mock.MatchedBy(func(filter *models.TasksFilter) bool {
+			return *filter.OwnerID == userID && *filter.ProjectID == projectID
+		}), (*appEnums.Sorting)(nil)).Return([]*models.Task{task}, nil)
+		tasksRepo.On("GetByID", taskID).Return(task, nil)
+		tasksRepo.On("Update", mock.MatchedBy(func(task models.Task) bool {
+			return task.ID == taskID && *task.ProjectID == inbox.ID
+		})).Return(nil).Once()
+		eventsRepo := new(MockTaskEventsRepository)
+		eventsRepo.On("Add", mock.MatchedBy(func(event models.TaskEvent) bool {
+			return event.TaskID == taskID && event.Type == enums.TaskUpdated &&
+				len(event.Changes) == 1 && event.Changes[0].Field == "projectId"
+		})).Return(nil).Once()
+		recorder := &taskChangesRecorder{}
+
+		tasksService := NewTasksService(tasksRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			projectsRepo, eventsRepo, nil, nil, recorder)
+		service := NewProjectsService(projectsRepo, tasksRepo, tasksService)
+		err := service.DeleteProject(userID, projectID, appEnums.MoveToInbox)
+
+		assert.NoError(t, err)
+		projectsRepo.AssertExpectations(t)
+		tasksRepo.AssertExpectations(t)
+		eventsRepo.AssertExpectations(t)
+		assert.Equal(t, []enums.TaskChangeType{enums.TaskChangeUpdated}, changeTypes(recorder.changes))
+		assert.Equal(t, inbox.ID, *recorder.changes[0].Task.ProjectID)
+	})
+
+	t.Run("Каскадное удаление задач", func(t *testing.T) {
+		projectsRepo := new(MockProjectsRepository)
+		projectsRepo.On("GetByID", projectID).Return(project, nil)
+		projectsRepo.On("DeleteByID", projectID).Return(nil)
+		tasksRepo := new(MockTasksRepository)
+		task := &models.Task{ID: taskID, OwnerID: userID, ProjectID: &projectID}
+		tasksRepo.On("GetAll", mock.MatchedBy(func(filter *models.TasksFilter) bool {
+			return *filter.OwnerID == userID && *filter.ProjectID == projectID
+		}), (*appEnums.Sorting)(nil)).Return([]*models.Task{task}, nil)
+		tasksRepo.On("GetByID", taskID).Return(task, nil)
+		tasksRepo.On("Update", mock.MatchedBy(func(task models.Task) bool {
+			return task.ID == taskID && task.DeletedAt != nil
+		})).Return(nil)
+
+		tasksService := NewTasksService(tasksRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			projectsRepo, newTaskEventsRepositoryStub(), nil, nil, nil)
+		service := NewProjectsService(projectsRepo, tasksRepo, tasksService)
+		err := service.DeleteProject(userID, projectID, appEnums.Cascade)
+
+		assert.NoError(t, err)
+		projectsRepo.AssertExpectations(t)
+		tasksRepo.AssertExpectations(t)
+	})
+
+	t.Run("Ошибка удаления проекта откатывает удаление задач", func(t *testing.T) {
+		projectsRepo := new(MockProjectsRepository)
+		projectsRepo.On("GetByID", projectID).Return(project, nil)
+		projectsRepo.On("DeleteByID", projectID).Return(defaultErrors.New("db is down"))
+		tasksRepo := new(MockTasksRepository)
+		task := &models.Task{ID: taskID, OwnerID: userID, ProjectID: &projectID}
+		tasksRepo.On("GetAll", mock.Anything, (*appEnums.Sorting)(nil)).Return([]*models.Task{task}, nil)
+		tasksRepo.On("GetByID", taskID).Return(task, nil)
+		tasksRepo.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+		uow := &unitOfWorkStub{repos: interfaces.Repositories{
+			Tasks:          tasksRepo,
+			ChecklistItems: newChecklistItemsRepositoryStub(),
+			Tags:           newTagsRepositoryStub(),
+			Projects:       projectsRepo,
+			TaskEvents:     newTaskEventsRepositoryStub(),
+		}}
+		recorder := &taskChangesRecorder{}
+
+		tasksService := NewTasksService(tasksRepo, uow.repos.ChecklistItems, uow.repos.Tags, projectsRepo,
+			uow.repos.TaskEvents, uow, nil, recorder)
+		service := NewProjectsService(projectsRepo, tasksRepo, tasksService)
+		err := service.DeleteProject(userID, projectID, appEnums.Cascade)
+
+		assert.EqualError(t, err, "db is down")
+		// Удаление задачи — вложенная транзакция, и ошибка проекта откатывает внешнюю вместе с ней
+		assert.Len(t, uow.results, 2)
+		assert.NoError(t, uow.results[0])
+		assert.Error(t, uow.results[1])
+		assert.Empty(t, recorder.changes)
+	})
+
+	t.Run("Удаление Inbox", func(t *testing.T) {
+		projectsRepo := new(MockProjectsRepository)
+		projectsRepo.On("GetByID", projectID).Return(
+			&models.Project{ID: projectID, OwnerID: userID, IsInbox: true}, nil)
+
+		tasksService := NewTasksService(new(MockTasksRepository), newChecklistItemsRepositoryStub(),
+			newTagsRepositoryStub(), projectsRepo, newTaskEventsRepositoryStub(), nil, nil, nil)
+		service := NewProjectsService(projectsRepo, new(MockTasksRepository), tasksService)
+		err := service.DeleteProject(userID, projectID, appEnums.Cascade)
+
+		var appErr errors.ApplicationError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, 409, appErr.StatusCode)
+		projectsRepo.AssertNotCalled(t, "DeleteByID", projectID)
+	})
+}
+
+// Тест выбора проекта при создании задачи
+func TestCreateTask_Project(t *testing.T) {
+	userID := uuid.New()
+	projectID := uuid.New()
+
+	tests := []struct {
+		name       string
+		project    *models.Project
+		wantStatus int
+	}{
+		{
+			name:    "Свой проект",
+			project: &models.Project{ID: projectID, OwnerID: userID},
+		},
+		{
+			name:       "Архивный проект",
+			project:    &models.Project{ID: projectID, OwnerID: userID, IsArchived: true},
+			wantStatus: 400,
+		},
+		{
+			name:       "Проект другого пользователя",
+			project:    &models.Project{ID: projectID, OwnerID: uuid.New()},
+			wantStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectsRepo := new(MockProjectsRepository)
+			projectsRepo.On("GetByID", projectID).Return(tt.project, nil)
+			tasksRepo := new(MockTasksRepository)
+			if tt.wantStatus == 0 {
+				tasksRepo.On("Add", mock.MatchedBy(func(task models.Task) bool {
+					return task.ProjectID != nil && *task.ProjectID == projectID
+				})).Return(nil)
+			}
+
+			service := NewTasksService(tasksRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+				projectsRepo, newTaskEventsRepositoryStub(), nil, nil, nil)
+			task, err := service.CreateTask(userID, "Задача", nil, nil, nil, nil, nil, &projectID, nil)
+
+			if tt.wantStatus != 0 {
+				assert.Nil(t, task)
+				var appErr errors.ApplicationError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, projectID, *task.ProjectID)
+			}
+			tasksRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест макроса @project: проект ищется по имени, пробелы в имени заменяются на _
+func TestCreateTask_ProjectMacro(t *testing.T) {
+	userID := uuid.New()
+	project := &models.Project{ID: uuid.New(), OwnerID: userID, Name: "Домашние дела"}
+	explicitID := uuid.New()
+
+	tests := []struct {
+		name       string
+		taskName   string
+		projectID  *uuid.UUID
+		wantID     uuid.UUID
+		wantStatus int
+	}{
+		{
+			name:     "Проект из макроса",
+			taskName: "Уборка @домашние_дела",
+			wantID:   project.ID,
+		},
+		{
+			name:      "Явный проект важнее макроса",
+			taskName:  "Уборка @домашние_дела",
+			projectID: &explicitID,
+			wantID:    explicitID,
+		},
+		{
+			name:       "Неизвестный проект",
+			taskName:   "Уборка @дача",
+			wantStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectsRepo := new(MockProjectsRepository)
+			projectsRepo.On("GetByOwnerID", userID, utils.Ptr(false)).Return([]*models.Project{project}, nil).Maybe()
+			projectsRepo.On("GetByID", project.ID).Return(project, nil).Maybe()
+			projectsRepo.On("GetByID", explicitID).Return(&models.Project{ID: explicitID, OwnerID: userID}, nil).Maybe()
+			tasksRepo := new(MockTasksRepository)
+			if tt.wantStatus == 0 {
+				tasksRepo.On("Add", mock.MatchedBy(func(task models.Task) bool {
+					return task.Name == "Уборка" && task.ProjectID != nil && *task.ProjectID == tt.wantID
+				})).Return(nil)
+			}
+
+			service := NewTasksService(tasksRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+				projectsRepo, newTaskEventsRepositoryStub(), nil, nil, nil)
+			task, err := service.CreateTask(userID, tt.taskName, nil, nil, nil, nil, nil, tt.projectID, nil)
+
+			if tt.wantStatus != 0 {
+				assert.Nil(t, task)
+				var appErr errors.ApplicationError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				assert.Contains(t, appErr.Errors, "project")
+			} else {
+				assert.NoError(t, err)
+			}
+			tasksRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест предпросмотра: неизвестный проект попадает в нераспознанные макросы
+func TestParseTask(t *testing.T) {
+	userID := uuid.New()
+	project := &models.Project{ID: uuid.New(), OwnerID: userID, Name: "Работа"}
+	projectsRepo := new(MockProjectsRepository)
+	projectsRepo.On("GetByOwnerID", userID, utils.Ptr(false)).Return([]*models.Project{project}, nil)
+	service := NewTasksService(new(MockTasksRepository), newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+		projectsRepo, newTaskEventsRepositoryStub(), nil, nil, nil)
+
+	result, found, err := service.ParseTask(userID, "Отчёт @работа !2", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, project, found)
+	assert.Equal(t, "Отчёт", result.Name)
+	assert.Len(t, result.Recognized, 2)
+
+	result, found, err = service.ParseTask(userID, "Отчёт @дача !2", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+	assert.Nil(t, result.Project)
+	assert.Equal(t, []macros.Macro{{Kind: macros.Priority, Text: "!2"}}, result.Recognized)
+	assert.Equal(t, []macros.Macro{{Kind: macros.Project, Text: "@дача", Reason: "Project not found"}},
+		result.Unrecognized)
+}
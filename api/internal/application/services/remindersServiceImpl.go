@@ -0,0 +1,283 @@
+package services
+
+import (
+	appInterfaces "HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/application/validators"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	domainInterfaces "HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+type RemindersServiceImpl struct {
+	tasksRepository         domainInterfaces.TasksRepository
+	usersRepository         domainInterfaces.UsersRepository
+	taskRemindersRepository domainInterfaces.TaskRemindersRepository
+	notifier                appInterfaces.Notifier
+	reminderTracker         appInterfaces.DeadlineTracker
+}
+
+// reminderTracker получает время ближайшего неотправленного напоминания каждой задачи, чтобы
+// планировщик проснулся к нему; может быть nil, если планировщик не запущен
+func NewRemindersService(tasksRepository domainInterfaces.TasksRepository,
+	usersRepository domainInterfaces.UsersRepository, taskRemindersRepository domainInterfaces.TaskRemindersRepository,
+	notifier appInterfaces.Notifier, reminderTracker appInterfaces.DeadlineTracker) appInterfaces.RemindersService {
+	return &RemindersServiceImpl{
+		tasksRepository:         tasksRepository,
+		usersRepository:         usersRepository,
+		taskRemindersRepository: taskRemindersRepository,
+		notifier:                notifier,
+		reminderTracker:         reminderTracker,
+	}
+}
+
+func (service *RemindersServiceImpl) GetReminders(userID uuid.UUID, taskID uuid.UUID) ([]*models.TaskReminder,
+	error) {
+	if _, err := findOwnedTask(service.tasksRepository, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	return service.taskRemindersRepository.GetByTaskID(taskID)
+}
+
+// Напоминание, которое уже было отправлено для текущего дедлайна, при замене списка не повторяется
+func (service *RemindersServiceImpl) SetReminders(userID uuid.UUID, taskID uuid.UUID,
+	minutesBefore []int) ([]*models.TaskReminder, error) {
+	if err := validators.ValidateReminders(minutesBefore); err != nil {
+		return nil, err
+	}
+
+	task, err := findOwnedTask(service.tasksRepository, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := service.taskRemindersRepository.GetByTaskID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reminders := make([]*models.TaskReminder, len(minutesBefore))
+	for i, minutes := range minutesBefore {
+		reminders[i] = models.NewTaskReminder(taskID, minutes)
+		reminders[i].Schedule(task, now)
+		for _, previous := range existing {
+			if previous.MinutesBefore == minutes && previous.RemindAt != nil && reminders[i].RemindAt != nil &&
+				previous.RemindAt.Equal(*reminders[i].RemindAt) {
+				reminders[i].SentAt = previous.SentAt
+			}
+		}
+	}
+	slices.SortFunc(reminders, func(a, b *models.TaskReminder) int {
+		return b.MinutesBefore - a.MinutesBefore
+	})
+
+	if err := service.taskRemindersRepository.ReplaceForTask(taskID, derefReminders(reminders)); err != nil {
+		return nil, err
+	}
+
+	service.track(taskID, reminders)
+
+	return reminders, nil
+}
+
+// Изменённая задача получает новое время напоминаний: перенос дедлайна снова включает их, выполнение,
+// просрочка и удаление отключают. Напоминания повторяющейся задачи переходят на следующую задачу серии
+func (service *RemindersServiceImpl) Publish(change models.TaskChange) {
+	if change.Type == enums.TaskChangeReminder {
+		return
+	}
+
+	reminders, err := service.taskRemindersRepository.GetByTaskID(change.Task.ID)
+	if err != nil {
+		fmt.Println("Failed to get reminders of task", change.Task.ID, err.Error())
+		return
+	}
+	if len(reminders) == 0 {
+		return
+	}
+
+	service.reschedule(&change.Task, reminders, time.Now())
+	service.track(change.Task.ID, reminders)
+
+	// Следующая задача серии создаётся при выполнении или просрочке текущей
+	if (change.Type == enums.TaskChangeToggled || change.Type == enums.TaskChangeOverdue) &&
+		change.Task.NextOccurrenceID != nil {
+		service.copyToNextOccurrence(*change.Task.NextOccurrenceID, reminders)
+	}
+}
+
+// Если время нескольких напоминаний задачи наступило одновременно (например, после простоя сервера),
+// отправляется одно. Напоминание отмечается отправленным до отправки, поэтому при сбое канала
+// оно теряется, но никогда не приходит дважды
+func (service *RemindersServiceImpl) SendDueReminders(now time.Time) {
+	due, err := service.taskRemindersRepository.GetPending(now)
+	if err != nil {
+		fmt.Println("Failed to get due reminders", err.Error())
+		return
+	}
+
+	var taskIDs []uuid.UUID
+	for _, reminder := range due {
+		if !slices.Contains(taskIDs, reminder.TaskID) {
+			taskIDs = append(taskIDs, reminder.TaskID)
+		}
+	}
+
+	for _, taskID := range taskIDs {
+		service.sendTaskReminders(taskID, now)
+	}
+}
+
+func (service *RemindersServiceImpl) TrackReminders(until time.Time) {
+	if service.reminderTracker == nil {
+		return
+	}
+
+	pending, err := service.taskRemindersRepository.GetPending(until)
+	if err != nil {
+		fmt.Println("Failed to get pending reminders", err.Error())
+		return
+	}
+
+	tracked := map[uuid.UUID]bool{}
+	for _, reminder := range pending {
+		// Напоминания упорядочены по времени, первое напоминание задачи — ближайшее
+		if !tracked[reminder.TaskID] {
+			tracked[reminder.TaskID] = true
+			service.reminderTracker.Track(reminder.TaskID, *reminder.RemindAt)
+		}
+	}
+}
+
+func (service *RemindersServiceImpl) sendTaskReminders(taskID uuid.UUID, now time.Time) {
+	task, err := service.tasksRepository.GetByID(taskID)
+	if err != nil {
+		fmt.Println("Failed to get task", taskID, err.Error())
+		return
+	}
+
+	reminders, err := service.taskRemindersRepository.GetByTaskID(taskID)
+	if err != nil {
+		fmt.Println("Failed to get reminders of task", taskID, err.Error())
+		return
+	}
+
+	if task == nil {
+		// Задача удалена окончательно в обход сервиса напоминаний
+		if err := service.taskRemindersRepository.ReplaceForTask(taskID, nil); err != nil {
+			fmt.Println("Failed to delete reminders of task", taskID, err.Error())
+		}
+		return
+	}
+
+	// Задачу могли изменить в обход сервиса, поэтому время напоминаний сверяется с ней перед отправкой
+	service.reschedule(task, reminders, now)
+
+	due := false
+	for _, reminder := range reminders {
+		if !reminder.Pending() || reminder.RemindAt.After(now) {
+			continue
+		}
+
+		reminder.SentAt = &now
+		if err := service.taskRemindersRepository.Update(*reminder); err != nil {
+			fmt.Println("Failed to save reminder", reminder.ID, err.Error())
+			continue
+		}
+		due = true
+	}
+
+	service.track(taskID, reminders)
+
+	if !due || !task.Deadline.After(now) {
+		return
+	}
+
+	user, err := service.usersRepository.GetByID(task.OwnerID)
+	if err != nil || user == nil {
+		fmt.Println("Failed to get owner of task", taskID, err)
+		return
+	}
+
+	notification := models.Notification{Email: user.Email, Task: *task, Left: task.Deadline.Sub(now)}
+	if err := service.notifier.Notify(notification); err != nil {
+		fmt.Println("Failed to send reminder of task", taskID, err.Error())
+	}
+}
+
+// Сохраняет напоминания, время которых изменилось
+func (service *RemindersServiceImpl) reschedule(task *models.Task, reminders []*models.TaskReminder,
+	now time.Time) {
+	for _, reminder := range reminders {
+		if !reminder.Schedule(task, now) {
+			continue
+		}
+		if err := service.taskRemindersRepository.Update(*reminder); err != nil {
+			fmt.Println("Failed to save reminder", reminder.ID, err.Error())
+		}
+	}
+}
+
+// Следующая задача серии получает те же напоминания, если своих у неё ещё нет
+func (service *RemindersServiceImpl) copyToNextOccurrence(nextID uuid.UUID, reminders []*models.TaskReminder) {
+	next, err := service.tasksRepository.GetByID(nextID)
+	if err != nil || next == nil {
+		fmt.Println("Failed to get next occurrence", nextID, err)
+		return
+	}
+
+	existing, err := service.taskRemindersRepository.GetByTaskID(nextID)
+	if err != nil {
+		fmt.Println("Failed to get reminders of task", nextID, err.Error())
+		return
+	}
+	if len(existing) > 0 {
+		return
+	}
+
+	copied := make([]*models.TaskReminder, len(reminders))
+	for i, reminder := range reminders {
+		copied[i] = models.NewTaskReminder(nextID, reminder.MinutesBefore)
+		copied[i].Schedule(next, time.Now())
+	}
+
+	if err := service.taskRemindersRepository.ReplaceForTask(nextID, derefReminders(copied)); err != nil {
+		fmt.Println("Failed to copy reminders to task", nextID, err.Error())
+		return
+	}
+
+	service.track(nextID, copied)
+}
+
+func (service *RemindersServiceImpl) track(taskID uuid.UUID, reminders []*models.TaskReminder) {
+	if service.reminderTracker == nil {
+		return
+	}
+
+	var next *time.Time
+	for _, reminder := range reminders {
+		if reminder.Pending() && (next == nil || reminder.RemindAt.Before(*next)) {
+			next = reminder.RemindAt
+		}
+	}
+
+	if next == nil {
+		service.reminderTracker.Untrack(taskID)
+	} else {
+		service.reminderTracker.Track(taskID, *next)
+	}
+}
+
+func derefReminders(reminders []*models.TaskReminder) []models.TaskReminder {
+	values := make([]models.TaskReminder, len(reminders))
+	for i, reminder := range reminders {
+		values[i] = *reminder
+	}
+
+	return values
+}
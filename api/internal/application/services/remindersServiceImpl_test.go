@@ -0,0 +1,313 @@
+package services
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	defaultErrors "errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+// Мок репозитория напоминаний
+type MockTaskRemindersRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskRemindersRepository) GetByTaskID(taskID uuid.UUID) ([]*models.TaskReminder, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]*models.TaskReminder), args.Error(1)
+}
+
+func (m *MockTaskRemindersRepository) GetPending(until time.Time) ([]*models.TaskReminder, error) {
+	args := m.Called(until)
+	return args.Get(0).([]*models.TaskReminder), args.Error(1)
+}
+
+func (m *MockTaskRemindersRepository) ReplaceForTask(taskID uuid.UUID, reminders []models.TaskReminder) error {
+	args := m.Called(taskID, reminders)
+	return args.Error(0)
+}
+
+func (m *MockTaskRemindersRepository) Update(reminder models.TaskReminder) error {
+	args := m.Called(reminder)
+	return args.Error(0)
+}
+
+// Мок канала уведомлений
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(notification models.Notification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+// Тест замены напоминаний задачи
+func TestSetReminders(t *testing.T) {
+	userID := uuid.New()
+	deadline := time.Now().Add(48 * time.Hour)
+
+	tests := []struct {
+		name          string
+		minutesBefore []int
+		owner         uuid.UUID
+		wantErrCode   int
+	}{
+		{
+			name:          "Несколько напоминаний",
+			minutesBefore: []int{60, 24 * 60},
+			owner:         userID,
+		},
+		{
+			name:          "Отключение напоминаний",
+			minutesBefore: []int{},
+			owner:         userID,
+		},
+		{
+			name:          "Повторяющееся напоминание",
+			minutesBefore: []int{60, 60},
+			owner:         userID,
+			wantErrCode:   400,
+		},
+		{
+			name:          "Больше тридцати дней",
+			minutesBefore: []int{31 * 24 * 60},
+			owner:         userID,
+			wantErrCode:   400,
+		},
+		{
+			name:          "Слишком много напоминаний",
+			minutesBefore: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+			owner:         userID,
+			wantErrCode:   400,
+		},
+		{
+			name:          "Чужая задача",
+			minutesBefore: []int{60},
+			owner:         uuid.New(),
+			wantErrCode:   404,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := models.NewTask("Отчёт", nil, &deadline, nil, nil)
+			task.OwnerID = tt.owner
+
+			tasksRepo := new(MockTasksRepository)
+			tasksRepo.On("GetByID", task.ID).Return(task, nil).Maybe()
+			remindersRepo := new(MockTaskRemindersRepository)
+			tracker := new(MockDeadlineTracker)
+			var replaced []models.TaskReminder
+			if tt.wantErrCode == 0 {
+				remindersRepo.On("GetByTaskID", task.ID).Return([]*models.TaskReminder{}, nil)
+				remindersRepo.On("ReplaceForTask", task.ID, mock.Anything).Run(func(args mock.Arguments) {
+					replaced = args.Get(1).([]models.TaskReminder)
+				}).Return(nil)
+				if len(tt.minutesBefore) > 0 {
+					tracker.On("Track", task.ID, deadline.Add(-24*time.Hour)).Return()
+				} else {
+					tracker.On("Untrack", task.ID).Return()
+				}
+			}
+
+			service := NewRemindersService(tasksRepo, nil, remindersRepo, nil, tracker)
+			reminders, err := service.SetReminders(userID, task.ID, tt.minutesBefore)
+
+			if tt.wantErrCode != 0 {
+				var appErr errors.ApplicationError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantErrCode, appErr.StatusCode)
+				if tt.wantErrCode == 400 {
+					assert.Contains(t, appErr.Errors, "minutesBefore")
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, replaced, len(tt.minutesBefore))
+				for i, reminder := range reminders {
+					// Самое раннее напоминание — первое
+					if i > 0 {
+						assert.Greater(t, reminders[i-1].MinutesBefore, reminder.MinutesBefore)
+					}
+					assert.Equal(t, deadline.Add(-time.Duration(reminder.MinutesBefore)*time.Minute),
+						*reminder.RemindAt)
+					assert.True(t, reminder.Pending())
+				}
+			}
+			remindersRepo.AssertExpectations(t)
+			tracker.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Отправленное напоминание не повторяется", func(t *testing.T) {
+		task := models.NewTask("Отчёт", nil, &deadline, nil, nil)
+		task.OwnerID = userID
+		sent := models.NewTaskReminder(task.ID, 60)
+		sent.Schedule(task, time.Now())
+		sent.SentAt = utils.Ptr(time.Now())
+
+		tasksRepo := new(MockTasksRepository)
+		tasksRepo.On("GetByID", task.ID).Return(task, nil)
+		remindersRepo := new(MockTaskRemindersRepository)
+		remindersRepo.On("GetByTaskID", task.ID).Return([]*models.TaskReminder{sent}, nil)
+		remindersRepo.On("ReplaceForTask", task.ID, mock.Anything).Return(nil)
+
+		service := NewRemindersService(tasksRepo, nil, remindersRepo, nil, nil)
+		reminders, err := service.SetReminders(userID, task.ID, []int{60, 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, sent.SentAt, reminders[0].SentAt)
+		assert.True(t, reminders[1].Pending())
+	})
+}
+
+// Тест отправки наступивших напоминаний
+func TestSendDueReminders(t *testing.T) {
+	now := time.Now()
+	user := &models.User{ID: uuid.New(), Email: "user@example.com"}
+
+	newTaskWithReminders := func(deadline time.Time) (*models.Task, *models.TaskReminder, *models.TaskReminder) {
+		task := models.NewTask("Отчёт", nil, &deadline, nil, nil)
+		task.OwnerID = user.ID
+		// Оба напоминания запланированы до их наступления
+		early := models.NewTaskReminder(task.ID, 60)
+		late := models.NewTaskReminder(task.ID, 10)
+		for _, reminder := range []*models.TaskReminder{early, late} {
+			reminder.Schedule(task, now.Add(-2*time.Hour))
+		}
+		return task, early, late
+	}
+
+	t.Run("Одно уведомление о наступившем напоминании", func(t *testing.T) {
+		task, early, late := newTaskWithReminders(now.Add(30 * time.Minute))
+
+		tasksRepo := new(MockTasksRepository)
+		tasksRepo.On("GetByID", task.ID).Return(task, nil)
+		usersRepo := new(MockUsersRepository)
+		usersRepo.On("GetByID", user.ID).Return(user, nil)
+		remindersRepo := new(MockTaskRemindersRepository)
+		remindersRepo.On("GetPending", now).Return([]*models.TaskReminder{early}, nil)
+		remindersRepo.On("GetByTaskID", task.ID).Return([]*models.TaskReminder{early, late}, nil)
+		remindersRepo.On("Update", mock.MatchedBy(func(reminder models.TaskReminder) bool {
+			return reminder.ID == early.ID && reminder.SentAt != nil && reminder.SentAt.Equal(now)
+		})).Return(nil).Once()
+		notifier := new(MockNotifier)
+		notifier.On("Notify", models.Notification{Email: user.Email, Task: *task, Left: 30 * time.Minute}).
+			Return(nil).Once()
+		tracker := new(MockDeadlineTracker)
+		tracker.On("Track", task.ID, *late.RemindAt).Return()
+
+		service := NewRemindersService(tasksRepo, usersRepo, remindersRepo, notifier, tracker)
+		service.SendDueReminders(now)
+
+		remindersRepo.AssertExpectations(t)
+		notifier.AssertExpectations(t)
+		tracker.AssertExpectations(t)
+	})
+
+	t.Run("Сбой канала не приводит к повторной отправке", func(t *testing.T) {
+		task, early, late := newTaskWithReminders(now.Add(5 * time.Minute))
+
+		tasksRepo := new(MockTasksRepository)
+		tasksRepo.On("GetByID", task.ID).Return(task, nil)
+		usersRepo := new(MockUsersRepository)
+		usersRepo.On("GetByID", user.ID).Return(user, nil)
+		remindersRepo := new(MockTaskRemindersRepository)
+		remindersRepo.On("GetPending", now).Return([]*models.TaskReminder{early, late}, nil)
+		remindersRepo.On("GetByTaskID", task.ID).Return([]*models.TaskReminder{early, late}, nil)
+		remindersRepo.On("Update", mock.Anything).Return(nil).Twice()
+		notifier := new(MockNotifier)
+		notifier.On("Notify", mock.Anything).Return(defaultErrors.New("connection refused")).Once()
+
+		service := NewRemindersService(tasksRepo, usersRepo, remindersRepo, notifier, nil)
+		service.SendDueReminders(now)
+
+		assert.False(t, early.Pending())
+		assert.False(t, late.Pending())
+		remindersRepo.AssertExpectations(t)
+		notifier.AssertExpectations(t)
+	})
+
+	t.Run("Удалённая задача", func(t *testing.T) {
+		taskID := uuid.New()
+		reminder := models.NewTaskReminder(taskID, 60)
+		reminder.RemindAt = utils.Ptr(now.Add(-time.Minute))
+
+		tasksRepo := new(MockTasksRepository)
+		tasksRepo.On("GetByID", taskID).Return(nil, nil)
+		remindersRepo := new(MockTaskRemindersRepository)
+		remindersRepo.On("GetPending", now).Return([]*models.TaskReminder{reminder}, nil)
+		remindersRepo.On("GetByTaskID", taskID).Return([]*models.TaskReminder{reminder}, nil)
+		remindersRepo.On("ReplaceForTask", taskID, []models.TaskReminder(nil)).Return(nil)
+		notifier := new(MockNotifier)
+
+		service := NewRemindersService(tasksRepo, nil, remindersRepo, notifier, nil)
+		service.SendDueReminders(now)
+
+		remindersRepo.AssertExpectations(t)
+		notifier.AssertNotCalled(t, "Notify", mock.Anything)
+	})
+}
+
+// Тест пересчёта напоминаний по изменению задачи
+func TestRemindersPublish(t *testing.T) {
+	deadline := time.Now().Add(48 * time.Hour)
+	task := models.NewTask("Отчёт", nil, &deadline, nil, nil)
+	reminder := models.NewTaskReminder(task.ID, 60)
+	reminder.Schedule(task, time.Now())
+	reminder.SentAt = utils.Ptr(time.Now())
+
+	t.Run("Перенос дедлайна", func(t *testing.T) {
+		moved := *task
+		moved.Deadline = utils.Ptr(deadline.Add(24 * time.Hour))
+		stored := *reminder
+
+		remindersRepo := new(MockTaskRemindersRepository)
+		remindersRepo.On("GetByTaskID", task.ID).Return([]*models.TaskReminder{&stored}, nil)
+		remindersRepo.On("Update", mock.MatchedBy(func(reminder models.TaskReminder) bool {
+			return reminder.Pending() && reminder.RemindAt.Equal(moved.Deadline.Add(-time.Hour))
+		})).Return(nil)
+		tracker := new(MockDeadlineTracker)
+		tracker.On("Track", task.ID, moved.Deadline.Add(-time.Hour)).Return()
+
+		service := NewRemindersService(nil, nil, remindersRepo, nil, tracker)
+		service.Publish(models.TaskChange{Type: enums.TaskChangeUpdated, Task: moved})
+
+		remindersRepo.AssertExpectations(t)
+		tracker.AssertExpectations(t)
+	})
+
+	t.Run("Напоминания переходят на следующую задачу серии", func(t *testing.T) {
+		completed := *task
+		completed.Status = enums.Completed
+		next := models.NewTask("Отчёт", nil, utils.Ptr(deadline.Add(7*24*time.Hour)), nil, nil)
+		completed.NextOccurrenceID = &next.ID
+		stored := *reminder
+
+		tasksRepo := new(MockTasksRepository)
+		tasksRepo.On("GetByID", next.ID).Return(next, nil)
+		remindersRepo := new(MockTaskRemindersRepository)
+		remindersRepo.On("GetByTaskID", task.ID).Return([]*models.TaskReminder{&stored}, nil)
+		remindersRepo.On("GetByTaskID", next.ID).Return([]*models.TaskReminder{}, nil)
+		remindersRepo.On("Update", mock.Anything).Return(nil)
+		remindersRepo.On("ReplaceForTask", next.ID, mock.MatchedBy(func(reminders []models.TaskReminder) bool {
+			return len(reminders) == 1 && reminders[0].MinutesBefore == 60 && reminders[0].Pending()
+		})).Return(nil)
+		tracker := new(MockDeadlineTracker)
+		tracker.On("Untrack", task.ID).Return()
+		tracker.On("Track", next.ID, next.Deadline.Add(-time.Hour)).Return()
+
+		service := NewRemindersService(tasksRepo, nil, remindersRepo, nil, tracker)
+		service.Publish(models.TaskChange{Type: enums.TaskChangeToggled, Task: completed})
+
+		assert.Nil(t, stored.RemindAt)
+		remindersRepo.AssertExpectations(t)
+		tracker.AssertExpectations(t)
+	})
+}
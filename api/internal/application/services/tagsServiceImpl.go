@@ -0,0 +1,106 @@
+package services
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	appInterfaces "HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/application/validators"
+	domainInterfaces "HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type TagsServiceImpl struct {
+	tagsRepository domainInterfaces.TagsRepository
+}
+
+func NewTagsService(tagsRepository domainInterfaces.TagsRepository) appInterfaces.TagsService {
+	return &TagsServiceImpl{tagsRepository: tagsRepository}
+}
+
+func (service *TagsServiceImpl) GetTags(userID uuid.UUID) ([]*models.Tag, error) {
+	return service.tagsRepository.GetByOwnerID(userID)
+}
+
+func (service *TagsServiceImpl) CreateTag(userID uuid.UUID, name string) (*models.Tag, error) {
+	name = models.NormalizeTagName(name)
+	if err := validators.ValidateTag(name); err != nil {
+		return nil, err
+	}
+
+	if err := service.checkNameIsFree(userID, uuid.Nil, name); err != nil {
+		return nil, err
+	}
+
+	tag := models.NewTag(userID, name)
+	if err := service.tagsRepository.Add(*tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (service *TagsServiceImpl) UpdateTag(userID uuid.UUID, tagID uuid.UUID, name string) (*models.Tag, error) {
+	name = models.NormalizeTagName(name)
+	if err := validators.ValidateTag(name); err != nil {
+		return nil, err
+	}
+
+	tag, err := service.getOwnedTag(userID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.checkNameIsFree(userID, tagID, name); err != nil {
+		return nil, err
+	}
+
+	tag.Name = name
+	if err := service.tagsRepository.Update(*tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (service *TagsServiceImpl) DeleteTag(userID uuid.UUID, tagID uuid.UUID) error {
+	if _, err := service.getOwnedTag(userID, tagID); err != nil {
+		return err
+	}
+
+	return service.tagsRepository.DeleteByID(tagID)
+}
+
+// Метки других пользователей неотличимы от несуществующих
+func (service *TagsServiceImpl) getOwnedTag(userID uuid.UUID, tagID uuid.UUID) (*models.Tag, error) {
+	tag, err := service.tagsRepository.GetByID(tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag == nil || tag.OwnerID != userID {
+		return nil, errors.ApplicationError{
+			StatusCode: 404,
+			Code:       "NotFound",
+			Errors:     map[string]string{"message": "Tag not found"},
+		}
+	}
+
+	return tag, nil
+}
+
+func (service *TagsServiceImpl) checkNameIsFree(userID uuid.UUID, tagID uuid.UUID, name string) error {
+	existing, err := service.tagsRepository.GetByNames(userID, []string{name})
+	if err != nil {
+		return err
+	}
+
+	if len(existing) > 0 && existing[0].ID != tagID {
+		return errors.ApplicationError{
+			StatusCode: 409,
+			Code:       "Conflict",
+			Errors:     map[string]string{"name": "Tag with this name already exists"},
+		}
+	}
+
+	return nil
+}
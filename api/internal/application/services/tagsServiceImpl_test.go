@@ -0,0 +1,293 @@
+package services
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+// Мок репозитория меток
+type MockTagsRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagsRepository) Add(tag models.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagsRepository) GetByID(id uuid.UUID) (*models.Tag, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagsRepository) GetByOwnerID(ownerID uuid.UUID) ([]*models.Tag, error) {
+	args := m.Called(ownerID)
+	return args.Get(0).([]*models.Tag), args.Error(1)
+}
+
+func (m *MockTagsRepository) GetByNames(ownerID uuid.UUID, names []string) ([]*models.Tag, error) {
+	args := m.Called(ownerID, names)
+	return args.Get(0).([]*models.Tag), args.Error(1)
+}
+
+func (m *MockTagsRepository) Update(tag models.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagsRepository) DeleteByID(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTagsRepository) GetByTaskIDs(taskIDs []uuid.UUID) (map[uuid.UUID][]models.Tag, error) {
+	args := m.Called(taskIDs)
+	return args.Get(0).(map[uuid.UUID][]models.Tag), args.Error(1)
+}
+
+func (m *MockTagsRepository) GetTaskIDs(tagIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(tagIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockTagsRepository) SetTaskTags(taskID uuid.UUID, tagIDs []uuid.UUID) error {
+	args := m.Called(taskID, tagIDs)
+	return args.Error(0)
+}
+
+// Репозиторий для тестов, не касающихся меток: у задач нет меток
+func newTagsRepositoryStub() *MockTagsRepository {
+	stub := new(MockTagsRepository)
+	stub.On("GetByTaskIDs", mock.Anything).Return(map[uuid.UUID][]models.Tag{}, nil).Maybe()
+	stub.On("SetTaskTags", mock.Anything, []uuid.UUID(nil)).Return(nil).Maybe()
+	return stub
+}
+
+// Тест создания и переименования метки
+func TestCreateAndUpdateTag(t *testing.T) {
+	userID := uuid.New()
+	existing := &models.Tag{ID: uuid.New(), OwnerID: userID, Name: "work"}
+
+	tests := []struct {
+		name       string
+		tagID      *uuid.UUID
+		tagName    string
+		mockSetup  func(*MockTagsRepository)
+		wantName   string
+		wantStatus int
+	}{
+		{
+			name:    "Имя нормализуется",
+			tagName: " #Home ",
+			mockSetup: func(repo *MockTagsRepository) {
+				repo.On("GetByNames", userID, []string{"home"}).Return([]*models.Tag{}, nil)
+				repo.On("Add", mock.MatchedBy(func(tag models.Tag) bool {
+					return tag.Name == "home" && tag.OwnerID == userID
+				})).Return(nil)
+			},
+			wantName: "home",
+		},
+		{
+			name:    "Имя уже занято",
+			tagName: "Work",
+			mockSetup: func(repo *MockTagsRepository) {
+				repo.On("GetByNames", userID, []string{"work"}).Return([]*models.Tag{existing}, nil)
+			},
+			wantStatus: 409,
+		},
+		{
+			name:       "Пробел в имени",
+			tagName:    "two words",
+			mockSetup:  func(*MockTagsRepository) {},
+			wantStatus: 400,
+		},
+		{
+			name:       "Пустое имя",
+			tagName:    "#",
+			mockSetup:  func(*MockTagsRepository) {},
+			wantStatus: 400,
+		},
+		{
+			name:    "Переименование",
+			tagID:   &existing.ID,
+			tagName: "job",
+			mockSetup: func(repo *MockTagsRepository) {
+				repo.On("GetByID", existing.ID).Return(&models.Tag{ID: existing.ID, OwnerID: userID, Name: "work"}, nil)
+				repo.On("GetByNames", userID, []string{"job"}).Return([]*models.Tag{}, nil)
+				repo.On("Update", mock.MatchedBy(func(tag models.Tag) bool {
+					return tag.ID == existing.ID && tag.Name == "job"
+				})).Return(nil)
+			},
+			wantName: "job",
+		},
+		{
+			name:    "Переименование в то же имя",
+			tagID:   &existing.ID,
+			tagName: "WORK",
+			mockSetup: func(repo *MockTagsRepository) {
+				repo.On("GetByID", existing.ID).Return(&models.Tag{ID: existing.ID, OwnerID: userID, Name: "work"}, nil)
+				repo.On("GetByNames", userID, []string{"work"}).Return([]*models.Tag{existing}, nil)
+				repo.On("Update", mock.AnythingOfType("models.Tag")).Return(nil)
+			},
+			wantName: "work",
+		},
+		{
+			name:    "Чужая метка",
+			tagID:   &existing.ID,
+			tagName: "job",
+			mockSetup: func(repo *MockTagsRepository) {
+				repo.On("GetByID", existing.ID).Return(&models.Tag{ID: existing.ID, OwnerID: uuid.New()}, nil)
+			},
+			wantStatus: 404,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockTagsRepository)
+			tt.mockSetup(repo)
+			service := NewTagsService(repo)
+
+			var tag *models.Tag
+			var err error
+			if tt.tagID == nil {
+				tag, err = service.CreateTag(userID, tt.tagName)
+			} else {
+				tag, err = service.UpdateTag(userID, *tt.tagID, tt.tagName)
+			}
+
+			if tt.wantStatus != 0 {
+				assert.Nil(t, tag)
+				var appErr errors.ApplicationError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantName, tag.Name)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест меток задачи из списка и макроса #tag
+func TestCreateTask_Tags(t *testing.T) {
+	userID := uuid.New()
+	work := &models.Tag{ID: uuid.New(), OwnerID: userID, Name: "work"}
+
+	tasksRepo := new(MockTasksRepository)
+	tasksRepo.On("Add", mock.MatchedBy(func(task models.Task) bool {
+		return task.Name == "Отчёт за квартал" && task.Priority == enums.High
+	})).Return(nil)
+
+	tagsRepo := new(MockTagsRepository)
+	tagsRepo.On("GetByNames", userID, []string{"work", "urgent"}).Return([]*models.Tag{work}, nil)
+	var created models.Tag
+	tagsRepo.On("Add", mock.MatchedBy(func(tag models.Tag) bool {
+		return tag.Name == "urgent" && tag.OwnerID == userID
+	})).Run(func(args mock.Arguments) {
+		created = args.Get(0).(models.Tag)
+	}).Return(nil)
+	tagsRepo.On("SetTaskTags", mock.AnythingOfType("uuid.UUID"), mock.MatchedBy(func(tagIDs []uuid.UUID) bool {
+		return len(tagIDs) == 2 && tagIDs[0] == created.ID && tagIDs[1] == work.ID
+	})).Return(nil)
+
+	service := NewTasksService(tasksRepo, newChecklistItemsRepositoryStub(), tagsRepo,
+		new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+	task, err := service.CreateTask(userID, "Отчёт #urgent за квартал !2 #Work", nil, nil, nil, nil,
+		[]string{"work"}, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"urgent", "work"}, []string{task.Tags[0].Name, task.Tags[1].Name})
+	tasksRepo.AssertExpectations(t)
+	tagsRepo.AssertExpectations(t)
+}
+
+// Тест изменения меток при редактировании задачи
+func TestUpdateTask_Tags(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+	home := models.Tag{ID: uuid.New(), OwnerID: userID, Name: "home"}
+	work := models.Tag{ID: uuid.New(), OwnerID: userID, Name: "work"}
+
+	tests := []struct {
+		name     string
+		taskName string
+		tags     []string
+		setup    func(*MockTagsRepository, map[uuid.UUID][]models.Tag)
+		wantTags []string
+	}{
+		{
+			name:     "Без списка метки не меняются",
+			taskName: "Задача",
+			setup:    func(*MockTagsRepository, map[uuid.UUID][]models.Tag) {},
+			wantTags: []string{"home"},
+		},
+		{
+			name:     "Макрос добавляет метку к текущим",
+			taskName: "Задача #work",
+			setup: func(repo *MockTagsRepository, stored map[uuid.UUID][]models.Tag) {
+				repo.On("GetByNames", userID, []string{"home", "work"}).Return([]*models.Tag{&home, &work}, nil)
+				repo.On("SetTaskTags", taskID, []uuid.UUID{home.ID, work.ID}).Run(func(mock.Arguments) {
+					stored[taskID] = []models.Tag{home, work}
+				}).Return(nil)
+			},
+			wantTags: []string{"home", "work"},
+		},
+		{
+			name:     "Список заменяет метки",
+			taskName: "Задача",
+			tags:     []string{"work"},
+			setup: func(repo *MockTagsRepository, stored map[uuid.UUID][]models.Tag) {
+				repo.On("GetByNames", userID, []string{"work"}).Return([]*models.Tag{&work}, nil)
+				repo.On("SetTaskTags", taskID, []uuid.UUID{work.ID}).Run(func(mock.Arguments) {
+					stored[taskID] = []models.Tag{work}
+				}).Return(nil)
+			},
+			wantTags: []string{"work"},
+		},
+		{
+			name:     "Пустой список снимает метки",
+			taskName: "Задача",
+			tags:     []string{},
+			setup: func(repo *MockTagsRepository, stored map[uuid.UUID][]models.Tag) {
+				repo.On("SetTaskTags", taskID, []uuid.UUID{}).Run(func(mock.Arguments) {
+					delete(stored, taskID)
+				}).Return(nil)
+			},
+			wantTags: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasksRepo := new(MockTasksRepository)
+			tasksRepo.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: userID, Status: enums.Active}, nil)
+			tasksRepo.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+			stored := map[uuid.UUID][]models.Tag{taskID: {home}}
+			tagsRepo := new(MockTagsRepository)
+			tagsRepo.On("GetByTaskIDs", []uuid.UUID{taskID}).Return(stored, nil)
+			tt.setup(tagsRepo, stored)
+
+			service := NewTasksService(tasksRepo, newChecklistItemsRepositoryStub(), tagsRepo,
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			task, err := service.UpdateTask(userID, taskID, tt.taskName, nil, nil, nil, nil, tt.tags, nil, nil, nil)
+
+			assert.NoError(t, err)
+			names := []string{}
+			for _, tag := range task.Tags {
+				names = append(names, tag.Name)
+			}
+			assert.Equal(t, tt.wantTags, names)
+			tagsRepo.AssertExpectations(t)
+		})
+	}
+}
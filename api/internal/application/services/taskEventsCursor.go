@@ -0,0 +1,44 @@
+package services
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type taskEventsCursorPayload struct {
+	ID        uuid.UUID `json:"id"`
+	CreatedAt time.Time `json:"c"`
+}
+
+func encodeTaskEventsCursor(event *models.TaskEvent) (string, error) {
+	data, err := json.Marshal(taskEventsCursorPayload{ID: event.ID, CreatedAt: event.CreatedAt})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeTaskEventsCursor(value string) (*models.TaskEventsCursor, error) {
+	invalidCursorErr := errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "ValidationFailed",
+		Errors:     map[string]string{"cursor": "Invalid cursor"},
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, invalidCursorErr
+	}
+
+	var payload taskEventsCursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.ID == uuid.Nil {
+		return nil, invalidCursorErr
+	}
+
+	return &models.TaskEventsCursor{ID: payload.ID, CreatedAt: payload.CreatedAt}, nil
+}
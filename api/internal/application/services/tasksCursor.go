@@ -0,0 +1,74 @@
+package services
+
+import (
+	appEnums "HITS_ToDoList_Tests/internal/application/enums"
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Содержимое курсора; сортировка сохраняется, чтобы курсор нельзя было применить к другому порядку
+type tasksCursorPayload struct {
+	Sorting   string         `json:"s,omitempty"`
+	ID        uuid.UUID      `json:"id"`
+	CreatedAt time.Time      `json:"c"`
+	Deadline  *time.Time     `json:"d,omitempty"`
+	Priority  enums.Priority `json:"p"`
+}
+
+func encodeTasksCursor(cursor *models.TasksCursor, sorting *appEnums.Sorting) (string, error) {
+	payload := tasksCursorPayload{
+		ID:        cursor.ID,
+		CreatedAt: cursor.CreatedAt,
+		Deadline:  cursor.Deadline,
+		Priority:  cursor.Priority,
+	}
+	if sorting != nil {
+		payload.Sorting = string(*sorting)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeTasksCursor(value string, sorting *appEnums.Sorting) (*models.TasksCursor, error) {
+	invalidCursorErr := errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "ValidationFailed",
+		Errors:     map[string]string{"cursor": "Invalid cursor"},
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, invalidCursorErr
+	}
+
+	var payload tasksCursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, invalidCursorErr
+	}
+
+	expectedSorting := ""
+	if sorting != nil {
+		expectedSorting = string(*sorting)
+	}
+	if payload.Sorting != expectedSorting {
+		invalidCursorErr.Errors["cursor"] = "Cursor was issued for a different sorting"
+		return nil, invalidCursorErr
+	}
+
+	return &models.TasksCursor{
+		ID:        payload.ID,
+		CreatedAt: payload.CreatedAt,
+		Deadline:  payload.Deadline,
+		Priority:  payload.Priority,
+	}, nil
+}
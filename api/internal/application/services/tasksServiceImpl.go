@@ -4,206 +4,1515 @@ import (
 	appEnums "HITS_ToDoList_Tests/internal/application/enums"
 	"HITS_ToDoList_Tests/internal/application/errors"
 	appInterfaces "HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/application/macros"
 	"HITS_ToDoList_Tests/internal/application/validators"
 	"HITS_ToDoList_Tests/internal/domain/enums"
 	domainInterfaces "HITS_ToDoList_Tests/internal/domain/interfaces"
 	"HITS_ToDoList_Tests/internal/domain/models"
 	"HITS_ToDoList_Tests/internal/pkg/utils"
+	defaultErrors "errors"
 	"fmt"
 	"github.com/google/uuid"
-	"regexp"
+	"slices"
 	"strings"
 	"time"
 )
 
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	maxImportSize   = 1000
+)
+
 type TasksServiceImpl struct {
-	tasksRepository domainInterfaces.TasksRepository
+	tasksRepository          domainInterfaces.TasksRepository
+	checklistItemsRepository domainInterfaces.ChecklistItemsRepository
+	tagsRepository           domainInterfaces.TagsRepository
+	projectsRepository       domainInterfaces.ProjectsRepository
+	taskEventsRepository     domainInterfaces.TaskEventsRepository
+	unitOfWork               domainInterfaces.UnitOfWork
+	deadlineTracker          appInterfaces.DeadlineTracker
+	taskChangePublisher      appInterfaces.TaskChangePublisher
+	// Действия, отложенные до фиксации текущей транзакции; nil вне транзакции
+	afterCommit *[]func()
+}
+
+// unitOfWork должен работать с теми же репозиториями; без него изменения выполняются вне транзакции.
+// deadlineTracker может быть nil, если планировщик дедлайнов не запущен, taskChangePublisher — если изменения
+// задач никому не рассылаются
+func NewTasksService(tasksRepository domainInterfaces.TasksRepository,
+	checklistItemsRepository domainInterfaces.ChecklistItemsRepository, tagsRepository domainInterfaces.TagsRepository,
+	projectsRepository domainInterfaces.ProjectsRepository, taskEventsRepository domainInterfaces.TaskEventsRepository,
+	unitOfWork domainInterfaces.UnitOfWork, deadlineTracker appInterfaces.DeadlineTracker,
+	taskChangePublisher appInterfaces.TaskChangePublisher) appInterfaces.TasksService {
+	return &TasksServiceImpl{
+		tasksRepository:          tasksRepository,
+		checklistItemsRepository: checklistItemsRepository,
+		tagsRepository:           tagsRepository,
+		projectsRepository:       projectsRepository,
+		taskEventsRepository:     taskEventsRepository,
+		unitOfWork:               unitOfWork,
+		deadlineTracker:          deadlineTracker,
+		taskChangePublisher:      taskChangePublisher,
+	}
 }
 
-func NewTasksService(tasksRepository domainInterfaces.TasksRepository) appInterfaces.TasksService {
-	return &TasksServiceImpl{tasksRepository: tasksRepository}
+// Метки из tags и макросов #tag, которых ещё нет у пользователя, создаются. Даты в макросах понимаются
+// в часовом поясе location; nil — UTC
+func (service *TasksServiceImpl) CreateTask(userID uuid.UUID, name string, description *string, deadline *time.Time,
+	priority *enums.Priority, recurrence *string, tags []string, projectID *uuid.UUID,
+	location *time.Location) (*models.Task, error) {
+	return service.taskWithinTx(func(txService *TasksServiceImpl) (*models.Task, error) {
+		return txService.createTask(userID, name, description, deadline, priority, recurrence, tags, projectID,
+			location, enums.Active)
+	})
 }
 
-func (service *TasksServiceImpl) CreateTask(
-	name string, description *string, deadline *time.Time, priority *enums.Priority) (*models.Task, error) {
-	parseTaskName(&name, &deadline, &priority)
+// Дедлайн проверяется только у задачи, создаваемой активной: импортированная выполненная или просроченная
+// задача сохраняет прошедший дедлайн
+func (service *TasksServiceImpl) createTask(userID uuid.UUID, name string, description *string, deadline *time.Time,
+	priority *enums.Priority, recurrence *string, tags []string, projectID *uuid.UUID, location *time.Location,
+	status enums.Status) (*models.Task, error) {
+	now := localNow(location)
+	if err := service.applyMacros(userID, &name, &deadline, &priority, &tags, &projectID, now); err != nil {
+		return nil, err
+	}
+
+	if err := validators.ValidateTaskPatch(name, deadline, status == enums.Active, recurrence, now); err != nil {
+		return nil, err
+	}
+
+	if projectID != nil {
+		if err := service.checkProject(userID, *projectID); err != nil {
+			return nil, err
+		}
+	}
 
-	if err := validators.ValidateTask(name, deadline); err != nil {
+	taskTags, err := service.resolveTags(userID, tags)
+	if err != nil {
 		return nil, err
 	}
 
-	task := models.NewTask(name, description, deadline, nil, priority)
+	task := models.NewTask(name, description, deadline, &status, priority)
+	task.OwnerID = userID
+	task.Recurrence = normalizeRecurrence(recurrence)
+	task.ProjectID = projectID
+	task.TimeZone = timeZoneName(location)
 
 	if err := service.tasksRepository.Add(*task); err != nil {
 		return nil, err
 	}
 
+	if len(taskTags) > 0 {
+		if err := service.setTaskTags(task, taskTags); err != nil {
+			return nil, err
+		}
+	}
+
+	// Серия выполненной или просроченной задачи продолжается, как после выполнения или просрочки
+	if status != enums.Active && task.Recurrence != nil {
+		if err := service.createNextOccurrence(task, time.Now(), &userID); err != nil {
+			return nil, err
+		}
+		if err := service.tasksRepository.Update(*task); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := service.recordEvent(task.ID, &userID, enums.TaskCreated, models.DiffTasks(nil, task)); err != nil {
+		return nil, err
+	}
+
+	service.trackDeadline(task)
+	service.publishChange(enums.TaskChangeCreated, task)
+
 	return task, nil
 }
 
-func (service *TasksServiceImpl) GetAllTasks(sorting *appEnums.Sorting) ([]*models.Task, error) {
-	tasks, err := service.tasksRepository.GetAll(sorting)
+func (service *TasksServiceImpl) GetAllTasks(userID uuid.UUID, filter *models.TasksFilter,
+	sorting *appEnums.Sorting) ([]*models.Task, error) {
+	if err := validators.ValidateTasksFilter(filter); err != nil {
+		return nil, err
+	}
+
+	scoped, err := service.scopedFilter(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := service.tasksRepository.GetAll(scoped, sorting)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := service.fillTaskDetails(tasks...); err != nil {
+		return nil, err
+	}
+
 	return tasks, nil
 }
 
-func (service *TasksServiceImpl) DeleteTask(taskID uuid.UUID) error {
-	task, err := service.tasksRepository.GetByID(taskID)
+func (service *TasksServiceImpl) GetTasksPage(userID uuid.UUID, filter *models.TasksFilter, sorting *appEnums.Sorting,
+	cursor *string, limit *int) ([]*models.Task, *string, error) {
+	if err := validators.ValidateTasksFilter(filter); err != nil {
+		return nil, nil, err
+	}
+
+	pageSize := defaultPageSize
+	if limit != nil {
+		pageSize = *limit
+	}
+	if err := validators.ValidatePageSize(pageSize, maxPageSize); err != nil {
+		return nil, nil, err
+	}
+
+	var after *models.TasksCursor
+	if cursor != nil {
+		var err error
+		if after, err = decodeTasksCursor(*cursor, sorting); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	scoped, err := service.scopedFilter(userID, filter)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	if task == nil {
-		return errors.ApplicationError{
-			StatusCode: 404,
-			Code:       "NotFound",
-			Errors:     map[string]string{"message": "Task not found"},
+	page, err := service.tasksRepository.GetPage(scoped, sorting, after, pageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := service.fillTaskDetails(page.Items...); err != nil {
+		return nil, nil, err
+	}
+
+	if !page.HasMore || len(page.Items) == 0 {
+		return page.Items, nil, nil
+	}
+
+	nextCursor, err := encodeTasksCursor(models.NewTasksCursor(page.Items[len(page.Items)-1]), sorting)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return page.Items, &nextCursor, nil
+}
+
+// История изменений задачи в хронологическом порядке
+func (service *TasksServiceImpl) GetTaskHistory(userID uuid.UUID, taskID uuid.UUID, cursor *string,
+	limit *int) ([]*models.TaskEvent, *string, error) {
+	if _, err := service.getOwnedTaskWithTrash(userID, taskID); err != nil {
+		return nil, nil, err
+	}
+
+	pageSize := defaultPageSize
+	if limit != nil {
+		pageSize = *limit
+	}
+	if err := validators.ValidatePageSize(pageSize, maxPageSize); err != nil {
+		return nil, nil, err
+	}
+
+	var after *models.TaskEventsCursor
+	if cursor != nil {
+		var err error
+		if after, err = decodeTaskEventsCursor(*cursor); err != nil {
+			return nil, nil, err
 		}
 	}
 
-	if err := service.tasksRepository.DeleteByID(taskID); err != nil {
+	page, err := service.taskEventsRepository.GetPage(taskID, after, pageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !page.HasMore || len(page.Items) == 0 {
+		return page.Items, nil, nil
+	}
+
+	nextCursor, err := encodeTaskEventsCursor(page.Items[len(page.Items)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return page.Items, &nextCursor, nil
+}
+
+func (service *TasksServiceImpl) GetTask(userID uuid.UUID, taskID uuid.UUID) (*models.Task, error) {
+	task, err := service.getOwnedTask(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.fillTaskDetails(task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// Задача переносится в корзину вместе с чек-листом и метками; окончательно её удаляет PurgeDeletedTasks
+func (service *TasksServiceImpl) DeleteTask(userID uuid.UUID, taskID uuid.UUID, expectedVersion *int) error {
+	return service.withinTx(func(txService *TasksServiceImpl) error {
+		return txService.deleteTask(userID, taskID, expectedVersion)
+	})
+}
+
+func (service *TasksServiceImpl) deleteTask(userID uuid.UUID, taskID uuid.UUID, expectedVersion *int) error {
+	task, err := service.lockOwnedTask(userID, taskID)
+	if err != nil {
+		return err
+	}
+
+	if err := service.fillTaskDetails(task); err != nil {
+		return err
+	}
+
+	if err := checkTaskVersion(task, expectedVersion); err != nil {
 		return err
 	}
 
+	task.DeletedAt = utils.Ptr(time.Now())
+	if err := service.saveTask(task); err != nil {
+		return err
+	}
+
+	service.untrackDeadline(taskID)
+
+	if err := service.recordEvent(taskID, &userID, enums.TaskDeleted, models.DiffTasks(task, nil)); err != nil {
+		return err
+	}
+
+	service.publishChange(enums.TaskChangeDeleted, task)
 	return nil
 }
 
-func (service *TasksServiceImpl) UpdateTask(taskID uuid.UUID, name string, description *string, deadline *time.Time,
-	priority *enums.Priority) (*models.Task, error) {
-	parseTaskName(&name, &deadline, &priority)
-	if err := validators.ValidateTask(name, deadline); err != nil {
+// tags == nil оставляет метки задачи без изменений (макросы #tag при этом добавляются к ним),
+// иначе набор меток заменяется. projectID == nil оставляет задачу в прежнем проекте
+func (service *TasksServiceImpl) UpdateTask(userID uuid.UUID, taskID uuid.UUID, name string, description *string,
+	deadline *time.Time, priority *enums.Priority, recurrence *string, tags []string,
+	projectID *uuid.UUID, location *time.Location, expectedVersion *int) (*models.Task, error) {
+	return service.taskWithinTx(func(txService *TasksServiceImpl) (*models.Task, error) {
+		return txService.updateTask(userID, taskID, name, description, deadline, priority, recurrence, tags, projectID,
+			location, expectedVersion)
+	})
+}
+
+func (service *TasksServiceImpl) updateTask(userID uuid.UUID, taskID uuid.UUID, name string, description *string,
+	deadline *time.Time, priority *enums.Priority, recurrence *string, tags []string,
+	projectID *uuid.UUID, location *time.Location, expectedVersion *int) (*models.Task, error) {
+	now := localNow(location)
+	var macroTags []string
+	if err := service.applyMacros(userID, &name, &deadline, &priority, &macroTags, &projectID, now); err != nil {
+		return nil, err
+	}
+	if err := validators.ValidateTask(name, deadline, recurrence, now); err != nil {
 		return nil, err
 	}
 
-	task, err := service.tasksRepository.GetByID(taskID)
+	task, err := service.getTaskForUpdate(userID, taskID, expectedVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	if task == nil {
-		return nil, errors.ApplicationError{
-			StatusCode: 404,
-			Code:       "NotFound",
-			Errors:     map[string]string{"message": "Task not found"},
-		}
+	if projectID == nil {
+		projectID = task.ProjectID
+	}
+
+	if tags == nil && len(macroTags) > 0 {
+		tags = tagNames(task.Tags)
+	}
+	if tags != nil {
+		tags = append(tags, macroTags...)
 	}
 
-	task.Name = name
-	task.Description = description
+	if priority == nil {
+		priority = utils.Ptr(enums.Medium)
+	}
 
-	if priority != nil {
-		task.Priority = *priority
-	} else {
-		task.Priority = enums.Medium
+	return service.applyTaskUpdate(userID, task, taskUpdate{
+		name:            name,
+		description:     description,
+		deadline:        deadline,
+		deadlineChanged: true,
+		priority:        *priority,
+		recurrence:      recurrence,
+		tags:            tags,
+		projectID:       projectID,
+	}, location, now)
+}
+
+// Поля, которых нет в patch, не меняются. Макросы разбираются только в новом названии и задают поля,
+// которых нет в patch. Статус просроченной задачи пересчитывается, только если перенесён дедлайн
+func (service *TasksServiceImpl) PatchTask(userID uuid.UUID, taskID uuid.UUID, patch models.TaskPatch,
+	location *time.Location, expectedVersion *int) (*models.Task, error) {
+	return service.taskWithinTx(func(txService *TasksServiceImpl) (*models.Task, error) {
+		return txService.patchTask(userID, taskID, patch, location, expectedVersion)
+	})
+}
+
+func (service *TasksServiceImpl) patchTask(userID uuid.UUID, taskID uuid.UUID, patch models.TaskPatch,
+	location *time.Location, expectedVersion *int) (*models.Task, error) {
+	now := localNow(location)
+	var macroTags []string
+	if patch.Name != nil {
+		if err := service.applyPatchMacros(userID, &patch, &macroTags, now); err != nil {
+			return nil, err
+		}
 	}
 
-	task.Deadline = deadline
-	if task.Status == enums.Late {
-		task.Status = enums.Completed
-	} else if task.Status == enums.Overdue {
-		task.Status = enums.Active
+	task, err := service.getTaskForUpdate(userID, taskID, expectedVersion)
+	if err != nil {
+		return nil, err
 	}
 
-	task.ChangedAt = utils.Ptr(time.Now())
+	update := taskUpdate{
+		name:        task.Name,
+		description: task.Description,
+		deadline:    task.Deadline,
+		priority:    task.Priority,
+		recurrence:  task.Recurrence,
+		projectID:   task.ProjectID,
+	}
+	if patch.Name != nil {
+		update.name = *patch.Name
+	}
+	if patch.Description != nil {
+		update.description = *patch.Description
+	}
+	if patch.Deadline != nil {
+		update.deadline = *patch.Deadline
+		update.deadlineChanged = true
+	}
+	if patch.Priority != nil {
+		update.priority = enums.Medium
+		if *patch.Priority != nil {
+			update.priority = **patch.Priority
+		}
+	}
+	if patch.Recurrence != nil {
+		update.recurrence = *patch.Recurrence
+	}
+	if patch.ProjectID != nil {
+		update.projectID = *patch.ProjectID
+	}
+	if patch.Tags != nil {
+		update.tags = append([]string{}, *patch.Tags...)
+	} else if len(macroTags) > 0 {
+		update.tags = tagNames(task.Tags)
+	}
+	if update.tags != nil {
+		update.tags = append(update.tags, macroTags...)
+	}
 
-	if err := service.tasksRepository.Update(*task); err != nil {
+	if err := validators.ValidateTaskPatch(update.name, update.deadline, update.deadlineChanged, update.recurrence,
+		now); err != nil {
 		return nil, err
 	}
 
-	return task, nil
+	return service.applyTaskUpdate(userID, task, update, location, now)
 }
 
-func (service *TasksServiceImpl) ToggleTaskStatus(taskID uuid.UUID, isDone bool) (*models.Task, error) {
-	task, err := service.tasksRepository.GetByID(taskID)
+// Новые значения полей задачи: tags == nil оставляет метки без изменений, дедлайн и часовой пояс
+// меняются, только если deadlineChanged
+type taskUpdate struct {
+	name            string
+	description     *string
+	deadline        *time.Time
+	deadlineChanged bool
+	priority        enums.Priority
+	recurrence      *string
+	tags            []string
+	projectID       *uuid.UUID
+}
+
+// Задача для изменения вместе с чек-листом и метками, если клиент видел её текущую версию
+func (service *TasksServiceImpl) getTaskForUpdate(userID uuid.UUID, taskID uuid.UUID,
+	expectedVersion *int) (*models.Task, error) {
+	task, err := service.lockOwnedTask(userID, taskID)
 	if err != nil {
 		return nil, err
 	}
 
-	if task == nil {
-		return nil, errors.ApplicationError{
-			StatusCode: 404,
-			Code:       "NotFound",
-			Errors:     map[string]string{"message": "Task not found"},
+	if err := service.fillTaskDetails(task); err != nil {
+		return nil, err
+	}
+
+	if err := checkTaskVersion(task, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// Общая часть UpdateTask и PatchTask: значения уже проверены
+func (service *TasksServiceImpl) applyTaskUpdate(userID uuid.UUID, task *models.Task, update taskUpdate,
+	location *time.Location, now time.Time) (*models.Task, error) {
+	before := *task
+
+	if update.projectID != nil && (task.ProjectID == nil || *task.ProjectID != *update.projectID) {
+		if err := service.checkProject(userID, *update.projectID); err != nil {
+			return nil, err
 		}
 	}
+	task.ProjectID = update.projectID
 
-	if isDone {
-		if task.Deadline != nil && time.Now().After(*task.Deadline) {
-			task.Status = enums.Late
-		} else {
-			task.Status = enums.Completed
+	// Метки сохраняются после задачи, чтобы при конфликте версий не менять их
+	if update.tags != nil {
+		tags, err := service.resolveTags(userID, update.tags)
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		if task.Deadline != nil && time.Now().After(*task.Deadline) {
-			task.Status = enums.Overdue
-		} else {
+		task.Tags = tags
+	}
+
+	task.Name = update.name
+	task.Description = update.description
+	task.Priority = update.priority
+	task.Recurrence = normalizeRecurrence(update.recurrence)
+
+	// Новый дедлайн всегда в будущем, поэтому задача перестаёт быть просроченной
+	if update.deadlineChanged {
+		task.Deadline = update.deadline
+		task.TimeZone = timeZoneName(location)
+		if task.Status == enums.Late {
+			task.Status = enums.Completed
+		} else if task.Status == enums.Overdue {
 			task.Status = enums.Active
 		}
 	}
 
-	task.ChangedAt = utils.Ptr(time.Now())
+	task.ChangedAt = utils.Ptr(now)
+
+	if err := service.saveTask(task); err != nil {
+		return nil, err
+	}
+
+	if update.tags != nil {
+		if err := service.setTaskTags(task, task.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := service.recordEvent(task.ID, &userID, enums.TaskUpdated, models.DiffTasks(&before, task)); err != nil {
+		return nil, err
+	}
 
-	if err := service.tasksRepository.Update(*task); err != nil {
+	if err := service.fillTaskDetails(task); err != nil {
 		return nil, err
 	}
 
+	service.trackDeadline(task)
+	service.publishChange(enums.TaskChangeUpdated, task)
+
 	return task, nil
 }
 
-func (service *TasksServiceImpl) UpdateTaskStatuses() {
-	tasks, err := service.tasksRepository.GetAll(nil)
+var errBulkRolledBack = defaultErrors.New("bulk operation rolled back")
+
+// Действия выполняются по порядку в одной транзакции. В atomic-режиме первая ошибка откатывает все действия
+// и у остальных в результате ошибка NotApplied; иначе откатывается только неудавшееся действие.
+// Ошибки, не относящиеся к отдельному действию, прерывают и откатывают весь пакет
+func (service *TasksServiceImpl) BulkTasks(userID uuid.UUID, operations []models.TaskOperation, atomic bool,
+	location *time.Location) ([]models.TaskOperationResult, bool, error) {
+	results := make([]models.TaskOperationResult, len(operations))
+	failedIndex := -1
+
+	err := service.withinTx(func(txService *TasksServiceImpl) error {
+		for i, operation := range operations {
+			// Каждое действие открывает вложенную транзакцию, и его неудача откатывает только его
+			task, err := txService.runOperation(userID, operation, location)
+			if err != nil && !isOperationError(err) {
+				return err
+			}
+
+			results[i] = models.TaskOperationResult{Task: task, Err: err}
+			if err != nil && atomic {
+				failedIndex = i
+				return errBulkRolledBack
+			}
+		}
+		return nil
+	})
+	if failedIndex >= 0 {
+		for i := range results {
+			if i != failedIndex {
+				results[i] = models.TaskOperationResult{Err: errors.ApplicationError{
+					StatusCode: 424,
+					Code:       "NotApplied",
+					Errors: map[string]string{
+						"message": fmt.Sprintf("Operation %d failed, all operations were rolled back", failedIndex),
+					},
+				}}
+			}
+		}
+		return results, false, nil
+	}
 	if err != nil {
-		fmt.Println("Failed to get all tasks", err.Error())
-		return
+		return nil, false, err
 	}
 
-	curTime := time.Now()
-	for _, task := range tasks {
-		if task.Status == enums.Active && task.Deadline != nil && curTime.After(*task.Deadline) {
-			task.Status = enums.Overdue
-			task.ChangedAt = &curTime
-			if err := service.tasksRepository.Update(*task); err != nil {
-				fmt.Println("Failed to update task", err.Error())
-			}
+	return results, true, nil
+}
+
+func (service *TasksServiceImpl) runOperation(userID uuid.UUID, operation models.TaskOperation,
+	location *time.Location) (*models.Task, error) {
+	switch operation.Type {
+	case enums.OperationCreate:
+		return service.CreateTask(userID, operation.Name, operation.Description, operation.Deadline,
+			operation.Priority, operation.Recurrence, operation.Tags, operation.ProjectID, location)
+	case enums.OperationUpdate:
+		return service.UpdateTask(userID, operation.TaskID, operation.Name, operation.Description, operation.Deadline,
+			operation.Priority, operation.Recurrence, operation.Tags, operation.ProjectID, location, operation.Version)
+	case enums.OperationToggle:
+		return service.ToggleTaskStatus(userID, operation.TaskID, operation.IsDone, operation.CompleteItems,
+			operation.Version)
+	case enums.OperationDelete:
+		return nil, service.DeleteTask(userID, operation.TaskID, operation.Version)
+	default:
+		return nil, errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"op": fmt.Sprintf("Unsupported operation %q", operation.Type)},
 		}
 	}
 }
 
-func parseTaskName(name *string, deadline **time.Time, priority **enums.Priority) {
-	cleanName := *name
+// Задачи импортируются по порядку, каждая в своей вложенной транзакции, поэтому ошибка в одной задаче
+// не отменяет остальные. Невыполненная задача с прошедшим дедлайном пропускается или, если keepOverdue,
+// создаётся просроченной; выполненная задача с прошедшим дедлайном создаётся со статусом Late.
+// Ошибки, не относящиеся к отдельной задаче, прерывают и откатывают весь импорт
+func (service *TasksServiceImpl) ImportTasks(userID uuid.UUID, tasks []models.TaskImport, keepOverdue bool,
+	location *time.Location) ([]models.TaskImportResult, error) {
+	if err := validators.ValidateImportSize(len(tasks), maxImportSize); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.TaskImportResult, len(tasks))
+	now := time.Now()
 
-	if *deadline == nil {
-		pattern := regexp.MustCompile(`!before (\d{2}[.-]\d{2}[.-]\d{4})`)
-		matches := pattern.FindStringSubmatch(cleanName)
-
-		if len(matches) == 2 {
-			date, err := time.Parse("02.01.2006", strings.ReplaceAll(matches[1], "-", "."))
-			if err == nil {
-				*deadline = &date
-				cleanName = pattern.ReplaceAllString(cleanName, "")
+	err := service.withinTx(func(txService *TasksServiceImpl) error {
+		for i, imported := range tasks {
+			status := importStatus(imported, now)
+			if status == enums.Overdue && !keepOverdue {
+				results[i] = models.TaskImportResult{Skipped: true}
+				continue
+			}
+
+			task, err := txService.taskWithinTx(func(taskTxService *TasksServiceImpl) (*models.Task, error) {
+				return taskTxService.createTask(userID, imported.Name, imported.Description, imported.Deadline,
+					imported.Priority, imported.Recurrence, imported.Tags, nil, location, status)
+			})
+			if err != nil && !isOperationError(err) {
+				return err
 			}
+
+			results[i] = models.TaskImportResult{Task: task, Err: err}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if *priority == nil {
-		patterns := map[string]enums.Priority{
-			`!1`: enums.Critical,
-			`!2`: enums.High,
-			`!3`: enums.Medium,
-			`!4`: enums.Low,
-		}
-
-		for macro, p := range patterns {
-			if strings.Contains(cleanName, macro) {
-				*priority = &p
-				cleanName = strings.ReplaceAll(cleanName, macro, "")
-				break
+	return results, nil
+}
+
+func importStatus(task models.TaskImport, now time.Time) enums.Status {
+	pastDeadline := task.Deadline != nil && !task.Deadline.After(now)
+	switch {
+	case task.Done && pastDeadline:
+		return enums.Late
+	case task.Done:
+		return enums.Completed
+	case pastDeadline:
+		return enums.Overdue
+	default:
+		return enums.Active
+	}
+}
+
+// Ошибка, которую можно сообщить клиенту в результате отдельного действия
+func isOperationError(err error) bool {
+	var appErr errors.ApplicationError
+	var conflict errors.VersionConflictError
+	return defaultErrors.As(err, &appErr) || defaultErrors.As(err, &conflict)
+}
+
+// fn выполняется в транзакции с копией сервиса, работающей через репозитории транзакции;
+// вызов withinTx у этой копии открывает вложенную транзакцию. Действия onCommit выполняются
+// после фиксации внешней транзакции и отбрасываются при откате
+func (service *TasksServiceImpl) withinTx(fn func(txService *TasksServiceImpl) error) error {
+	if service.unitOfWork == nil {
+		return fn(service)
+	}
+
+	var afterCommit []func()
+	err := service.unitOfWork.WithinTx(func(repos domainInterfaces.Repositories) error {
+		txService := *service
+		txService.tasksRepository = repos.Tasks
+		txService.checklistItemsRepository = repos.ChecklistItems
+		txService.tagsRepository = repos.Tags
+		txService.projectsRepository = repos.Projects
+		txService.taskEventsRepository = repos.TaskEvents
+		txService.unitOfWork = repos.UnitOfWork
+		txService.afterCommit = &afterCommit
+		return fn(&txService)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, action := range afterCommit {
+		service.onCommit(action)
+	}
+
+	return nil
+}
+
+func (service *TasksServiceImpl) WithinTx(fn func(tasks appInterfaces.TasksService,
+	repos domainInterfaces.Repositories) error) error {
+	return service.withinTx(func(txService *TasksServiceImpl) error {
+		return fn(txService, domainInterfaces.Repositories{
+			Tasks:          txService.tasksRepository,
+			ChecklistItems: txService.checklistItemsRepository,
+			Tags:           txService.tagsRepository,
+			Projects:       txService.projectsRepository,
+			TaskEvents:     txService.taskEventsRepository,
+			UnitOfWork:     txService.unitOfWork,
+		})
+	})
+}
+
+func (service *TasksServiceImpl) taskWithinTx(fn func(txService *TasksServiceImpl) (*models.Task,
+	error)) (*models.Task, error) {
+	var task *models.Task
+	err := service.withinTx(func(txService *TasksServiceImpl) error {
+		var err error
+		task, err = fn(txService)
+		return err
+	})
+
+	return task, err
+}
+
+func (service *TasksServiceImpl) onCommit(action func()) {
+	if service.afterCommit == nil {
+		action()
+		return
+	}
+
+	*service.afterCommit = append(*service.afterCommit, action)
+}
+
+// Выполнить задачу с невыполненными пунктами чек-листа можно только с completeItems: тогда пункты
+// отмечаются выполненными вместе с задачей
+func (service *TasksServiceImpl) ToggleTaskStatus(userID uuid.UUID, taskID uuid.UUID, isDone bool,
+	completeItems bool, expectedVersion *int) (*models.Task, error) {
+	return service.taskWithinTx(func(txService *TasksServiceImpl) (*models.Task, error) {
+		return txService.toggleTaskStatus(userID, taskID, isDone, completeItems, expectedVersion)
+	})
+}
+
+func (service *TasksServiceImpl) toggleTaskStatus(userID uuid.UUID, taskID uuid.UUID, isDone bool,
+	completeItems bool, expectedVersion *int) (*models.Task, error) {
+	task, err := service.lockOwnedTask(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.fillTaskDetails(task); err != nil {
+		return nil, err
+	}
+	before := *task
+
+	if err := checkTaskVersion(task, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	if isDone && task.Checklist.Done < task.Checklist.Total {
+		if !completeItems {
+			return nil, errors.ApplicationError{
+				StatusCode: 409,
+				Code:       "Conflict",
+				Errors: map[string]string{
+					"items": fmt.Sprintf("%d of %d checklist items are not done",
+						task.Checklist.Total-task.Checklist.Done, task.Checklist.Total),
+				},
 			}
 		}
+
+		if err := service.checklistItemsRepository.MarkAllDone(taskID, time.Now()); err != nil {
+			return nil, err
+		}
+		task.Checklist.Done = task.Checklist.Total
+	}
+
+	if isDone {
+		if task.Deadline != nil && time.Now().After(*task.Deadline) {
+			task.Status = enums.Late
+		} else {
+			task.Status = enums.Completed
+		}
+
+		if err := service.createNextOccurrence(task, time.Now(), &userID); err != nil {
+			return nil, err
+		}
+	} else {
+		if task.Deadline != nil && time.Now().After(*task.Deadline) {
+			task.Status = enums.Overdue
+		} else {
+			task.Status = enums.Active
+		}
+	}
+
+	task.ChangedAt = utils.Ptr(time.Now())
+
+	if err := service.saveTask(task); err != nil {
+		return nil, err
+	}
+
+	if err := service.recordEvent(task.ID, &userID, enums.TaskStatusChanged,
+		models.DiffTasks(&before, task)); err != nil {
+		return nil, err
+	}
+
+	service.trackDeadline(task)
+	service.publishChange(enums.TaskChangeToggled, task)
+
+	return task, nil
+}
+
+// Задачи переводятся в Overdue одной транзакцией. Следующее повторение и запись в историю сохраняются
+// во вложенных транзакциях, чтобы ошибка по одной задаче не отменяла остальные
+func (service *TasksServiceImpl) UpdateTaskStatuses() {
+	now := time.Now()
+
+	err := service.withinTx(func(txService *TasksServiceImpl) error {
+		tasks, err := txService.tasksRepository.MarkOverdue(now)
+		if err != nil {
+			return err
+		}
+
+		// Без меток и чек-листа клиенты всё равно узнают о просрочке
+		if err := txService.fillTaskDetails(tasks...); err != nil {
+			fmt.Println("Failed to get details of overdue tasks", err.Error())
+		}
+
+		for _, task := range tasks {
+			txService.untrackDeadline(task.ID)
+
+			// MarkOverdue выбирает только активные задачи
+			before := *task
+			before.Status = enums.Active
+
+			if task.Recurrence != nil && task.NextOccurrenceID == nil {
+				err := txService.withinTx(func(taskTxService *TasksServiceImpl) error {
+					if err := taskTxService.createNextOccurrence(task, now, nil); err != nil {
+						return err
+					}
+					return taskTxService.tasksRepository.Update(*task)
+				})
+				if err != nil {
+					fmt.Println("Failed to create next occurrence of task", task.ID, err.Error())
+					task.NextOccurrenceID = before.NextOccurrenceID
+				}
+			}
+
+			err := txService.withinTx(func(taskTxService *TasksServiceImpl) error {
+				return taskTxService.recordEvent(task.ID, nil, enums.TaskStatusChanged, models.DiffTasks(&before, task))
+			})
+			if err != nil {
+				fmt.Println("Failed to record status change of task", task.ID, err.Error())
+			}
+
+			txService.publishChange(enums.TaskChangeOverdue, task)
+		}
+
+		return nil
+	})
+	if err != nil {
+		fmt.Println("Failed to mark overdue tasks", err.Error())
+	}
+}
+
+// Корзина пользователя: последние удалённые задачи первыми
+func (service *TasksServiceImpl) GetDeletedTasks(userID uuid.UUID) ([]*models.Task, error) {
+	tasks, err := service.tasksRepository.GetAll(&models.TasksFilter{OwnerID: &userID, Deleted: true}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(tasks, func(a, b *models.Task) int {
+		if result := b.DeletedAt.Compare(*a.DeletedAt); result != 0 {
+			return result
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	if err := service.fillTaskDetails(tasks...); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// Задача возвращается из корзины в прежний проект, а если он за это время удалён — в Inbox
+func (service *TasksServiceImpl) RestoreTask(userID uuid.UUID, taskID uuid.UUID) (*models.Task, error) {
+	return service.taskWithinTx(func(txService *TasksServiceImpl) (*models.Task, error) {
+		return txService.restoreTask(userID, taskID)
+	})
+}
+
+// Задача блокируется так же, как при окончательном удалении, поэтому восстановление и очистка
+// корзины не выполняются одновременно
+func (service *TasksServiceImpl) restoreTask(userID uuid.UUID, taskID uuid.UUID) (*models.Task, error) {
+	task, err := service.tasksRepository.GetDeletedForUpdate(taskID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if task == nil || task.OwnerID != userID {
+		return nil, errors.ApplicationError{
+			StatusCode: 404,
+			Code:       "NotFound",
+			Errors:     map[string]string{"message": "Task not found in trash"},
+		}
+	}
+
+	if err := service.fillTaskDetails(task); err != nil {
+		return nil, err
+	}
+	before := *task
+
+	if task.ProjectID != nil {
+		project, err := service.projectsRepository.GetByID(*task.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+
+		if project == nil {
+			inbox, err := service.projectsRepository.GetInbox(userID)
+			if err != nil {
+				return nil, err
+			}
+
+			task.ProjectID = nil
+			if inbox != nil {
+				task.ProjectID = &inbox.ID
+			}
+		}
+	}
+
+	task.DeletedAt = nil
+	task.ChangedAt = utils.Ptr(time.Now())
+
+	if err := service.saveTask(task); err != nil {
+		return nil, err
+	}
+
+	if err := service.recordEvent(task.ID, &userID, enums.TaskRestored, models.DiffTasks(&before, task)); err != nil {
+		return nil, err
+	}
+
+	service.trackDeadline(task)
+	// Для клиентов задача из корзины появляется в списках заново
+	service.publishChange(enums.TaskChangeCreated, task)
+
+	return task, nil
+}
+
+// PurgeDeletedTasks окончательно удаляет задачи, перенесённые в корзину раньше before,
+// вместе с их чек-листами, привязками меток и журналом изменений
+func (service *TasksServiceImpl) PurgeDeletedTasks(before time.Time) {
+	tasks, err := service.tasksRepository.GetAll(&models.TasksFilter{Deleted: true, DeletedBefore: &before}, nil)
+	if err != nil {
+		fmt.Println("Failed to get deleted tasks", err.Error())
+		return
+	}
+
+	for _, task := range tasks {
+		err := service.withinTx(func(txService *TasksServiceImpl) error {
+			return txService.purgeTask(task.ID, before)
+		})
+		if err != nil {
+			fmt.Println("Failed to purge task", task.ID, err.Error())
+		}
+	}
+}
+
+// Задача перечитывается с блокировкой: если её успели восстановить после выборки, она пропускается
+func (service *TasksServiceImpl) purgeTask(taskID uuid.UUID, before time.Time) error {
+	task, err := service.tasksRepository.GetDeletedForUpdate(taskID, &before)
+	if err != nil || task == nil {
+		return err
+	}
+
+	if err := service.checklistItemsRepository.DeleteByTaskID(taskID); err != nil {
+		return err
+	}
+
+	if err := service.tagsRepository.SetTaskTags(taskID, nil); err != nil {
+		return err
+	}
+
+	if err := service.taskEventsRepository.DeleteByTaskID(taskID); err != nil {
+		return err
+	}
+
+	return service.tasksRepository.DeleteByID(taskID)
+}
+
+// TrackActiveDeadlines передаёт планировщику дедлайны активных задач, наступающие не позже until
+func (service *TasksServiceImpl) TrackActiveDeadlines(until time.Time) {
+	if service.deadlineTracker == nil {
+		return
+	}
+
+	tasks, err := service.tasksRepository.GetAll(&models.TasksFilter{
+		Statuses:   []enums.Status{enums.Active},
+		DeadlineTo: &until,
+	}, nil)
+	if err != nil {
+		fmt.Println("Failed to get active tasks", err.Error())
+		return
+	}
+
+	for _, task := range tasks {
+		service.trackDeadline(task)
+	}
+}
+
+// Планировщику нужны только активные задачи с дедлайном, остальные снимаются с отслеживания.
+// В транзакции планировщик узнаёт об изменении только после её фиксации
+func (service *TasksServiceImpl) trackDeadline(task *models.Task) {
+	if service.deadlineTracker == nil {
+		return
+	}
+
+	if task.Status == enums.Active && task.Deadline != nil {
+		taskID, deadline := task.ID, *task.Deadline
+		service.onCommit(func() {
+			service.deadlineTracker.Track(taskID, deadline)
+		})
+	} else {
+		service.untrackDeadline(task.ID)
+	}
+}
+
+// Клиенты получают копию задачи и только после фиксации транзакции
+func (service *TasksServiceImpl) publishChange(changeType enums.TaskChangeType, task *models.Task) {
+	if service.taskChangePublisher == nil {
+		return
+	}
+
+	change := models.TaskChange{Type: changeType, Task: *task, OccurredAt: time.Now()}
+	service.onCommit(func() {
+		service.taskChangePublisher.Publish(change)
+	})
+}
+
+func (service *TasksServiceImpl) untrackDeadline(taskID uuid.UUID) {
+	if service.deadlineTracker == nil {
+		return
+	}
+
+	service.onCommit(func() {
+		service.deadlineTracker.Untrack(taskID)
+	})
+}
+
+// Следующая задача серии создаётся один раз — при выполнении или просрочке текущей — с дедлайном,
+// сдвинутым по правилу повторения. Текущая задача остаётся в истории и получает ссылку на следующую.
+// Метки переносятся, пункты чек-листа копируются невыполненными.
+// actorID == nil — следующую задачу создал планировщик
+func (service *TasksServiceImpl) createNextOccurrence(task *models.Task, now time.Time, actorID *uuid.UUID) error {
+	if task.Recurrence == nil || task.Deadline == nil || task.NextOccurrenceID != nil {
+		return nil
+	}
+
+	recurrence, err := models.ParseRecurrence(*task.Recurrence)
+	if err != nil {
+		return err
+	}
+
+	// Повторения считаются в часовом поясе задачи, чтобы дедлайн сохранял местное время
+	// и при переходе на летнее время
+	location, err := models.LoadTimeZone(task.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	deadline, rest, ok := recurrence.NextAfter(task.Deadline.In(location), now)
+	if !ok {
+		return nil
+	}
+
+	next := models.NewTask(task.Name, task.Description, &deadline, nil, &task.Priority)
+	next.OwnerID = task.OwnerID
+	next.TimeZone = task.TimeZone
+	next.Recurrence = utils.Ptr(rest.String())
+	next.ProjectID = task.ProjectID
+
+	if err := service.tasksRepository.Add(*next); err != nil {
+		return err
+	}
+
+	items, err := service.checklistItemsRepository.GetByTaskID(task.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if err := service.checklistItemsRepository.Add(
+			*models.NewChecklistItem(next.ID, item.Name, item.Position)); err != nil {
+			return err
+		}
+	}
+
+	tags, err := service.tagsRepository.GetByTaskIDs([]uuid.UUID{task.ID})
+	if err != nil {
+		return err
+	}
+
+	if len(tags[task.ID]) > 0 {
+		if err := service.setTaskTags(next, tags[task.ID]); err != nil {
+			return err
+		}
+	}
+
+	if err := service.recordEvent(next.ID, actorID, enums.TaskCreated, models.DiffTasks(nil, next)); err != nil {
+		return err
+	}
+
+	task.NextOccurrenceID = &next.ID
+	service.trackDeadline(next)
+	service.publishChange(enums.TaskChangeCreated, next)
+
+	return nil
+}
+
+// Изменение без изменённых полей в журнал не попадает
+func (service *TasksServiceImpl) recordEvent(taskID uuid.UUID, actorID *uuid.UUID, eventType enums.TaskEventType,
+	changes models.FieldChanges) error {
+	if len(changes) == 0 && eventType != enums.TaskCreated && eventType != enums.TaskDeleted &&
+		eventType != enums.TaskRestored {
+		return nil
+	}
+
+	return service.taskEventsRepository.Add(*models.NewTaskEvent(taskID, actorID, eventType, changes))
+}
+
+func (service *TasksServiceImpl) getOwnedTask(userID uuid.UUID, taskID uuid.UUID) (*models.Task, error) {
+	return findOwnedTask(service.tasksRepository, userID, taskID)
+}
+
+// История задачи доступна и после её переноса в корзину
+func (service *TasksServiceImpl) getOwnedTaskWithTrash(userID uuid.UUID, taskID uuid.UUID) (*models.Task, error) {
+	task, err := service.tasksRepository.GetByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task == nil {
+		if task, err = service.tasksRepository.GetDeleted(taskID); err != nil {
+			return nil, err
+		}
+	}
+
+	return checkTaskOwner(task, userID)
+}
+
+func (service *TasksServiceImpl) lockOwnedTask(userID uuid.UUID, taskID uuid.UUID) (*models.Task, error) {
+	return lockOwnedTask(service.tasksRepository, userID, taskID)
+}
+
+// Задача сохраняется с проверкой версии; если её успели изменить параллельно, возвращается
+// конфликт с текущим состоянием. После сохранения task.Version совпадает с версией в хранилище
+func (service *TasksServiceImpl) saveTask(task *models.Task) error {
+	err := service.tasksRepository.Update(*task)
+	if defaultErrors.Is(err, domainInterfaces.ErrTaskVersionConflict) {
+		current, err := findOwnedTask(service.tasksRepository, task.OwnerID, task.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := service.fillTaskDetails(current); err != nil {
+			return err
+		}
+
+		return errors.VersionConflictError{Current: current}
+	}
+	if err != nil {
+		return err
+	}
+
+	task.Version++
+	return nil
+}
+
+// Задачу можно поместить только в свой активный проект
+func (service *TasksServiceImpl) checkProject(userID uuid.UUID, projectID uuid.UUID) error {
+	project, err := service.projectsRepository.GetByID(projectID)
+	if err != nil {
+		return err
+	}
+
+	if project == nil || project.OwnerID != userID {
+		return errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "ValidationFailed",
+			Errors:     map[string]string{"projectId": "Project not found"},
+		}
+	}
+
+	if project.IsArchived {
+		return errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "ValidationFailed",
+			Errors:     map[string]string{"projectId": "Project is archived"},
+		}
+	}
+
+	return nil
+}
+
+// Прогресс чек-листа и метки хранятся отдельно от задач и загружаются для всей выборки сразу
+func (service *TasksServiceImpl) fillTaskDetails(tasks ...*models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	taskIDs := make([]uuid.UUID, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+
+	progress, err := service.checklistItemsRepository.GetProgress(taskIDs)
+	if err != nil {
+		return err
+	}
+
+	tags, err := service.tagsRepository.GetByTaskIDs(taskIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		task.Checklist = progress[task.ID]
+		task.Tags = tags[task.ID]
+	}
+
+	return nil
+}
+
+// Метки ищутся по нормализованным именам, недостающие создаются
+func (service *TasksServiceImpl) resolveTags(userID uuid.UUID, names []string) ([]models.Tag, error) {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = models.NormalizeTagName(name)
+		if err := validators.ValidateTag(name); err != nil {
+			return nil, errors.ApplicationError{
+				StatusCode: 400,
+				Code:       "ValidationFailed",
+				Errors:     map[string]string{"tags": fmt.Sprintf("Invalid tag %q", name)},
+			}
+		}
+		if !slices.Contains(normalized, name) {
+			normalized = append(normalized, name)
+		}
+	}
+
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+
+	existing, err := service.tagsRepository.GetByNames(userID, normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]models.Tag, 0, len(normalized))
+	for _, tag := range existing {
+		tags = append(tags, *tag)
+	}
+
+	for _, name := range normalized {
+		if slices.ContainsFunc(existing, func(tag *models.Tag) bool { return tag.Name == name }) {
+			continue
+		}
+
+		tag := models.NewTag(userID, name)
+		if err := service.tagsRepository.Add(*tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, *tag)
+	}
+
+	slices.SortFunc(tags, func(a, b models.Tag) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return tags, nil
+}
+
+func (service *TasksServiceImpl) setTaskTags(task *models.Task, tags []models.Tag) error {
+	tagIDs := make([]uuid.UUID, len(tags))
+	for i, tag := range tags {
+		tagIDs[i] = tag.ID
+	}
+
+	if err := service.tagsRepository.SetTaskTags(task.ID, tagIDs); err != nil {
+		return err
+	}
+
+	task.Tags = tags
+	return nil
+}
+
+// Фильтр ограничивается задачами пользователя, а имена меток заменяются на ID подходящих задач.
+// Проект фильтра должен принадлежать пользователю
+func (service *TasksServiceImpl) scopedFilter(userID uuid.UUID, filter *models.TasksFilter) (*models.TasksFilter,
+	error) {
+	scoped := ownedBy(userID, filter)
+	if scoped.ProjectID != nil {
+		if _, err := findOwnedProject(service.projectsRepository, userID, *scoped.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(scoped.Tags) == 0 {
+		return scoped, nil
+	}
+
+	names := make([]string, len(scoped.Tags))
+	for i, name := range scoped.Tags {
+		names[i] = models.NormalizeTagName(name)
+	}
+
+	tags, err := service.tagsRepository.GetByNames(userID, names)
+	if err != nil {
+		return nil, err
+	}
+
+	tagIDs := make([]uuid.UUID, len(tags))
+	for i, tag := range tags {
+		tagIDs[i] = tag.ID
+	}
+
+	if scoped.IDs, err = service.tagsRepository.GetTaskIDs(tagIDs); err != nil {
+		return nil, err
+	}
+	scoped.Tags = nil
+
+	return scoped, nil
+}
+
+// Задачи других пользователей неотличимы от несуществующих
+func findOwnedTask(tasksRepository domainInterfaces.TasksRepository, userID uuid.UUID,
+	taskID uuid.UUID) (*models.Task, error) {
+	task, err := tasksRepository.GetByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkTaskOwner(task, userID)
+}
+
+// Задача для изменения: в транзакции она блокируется до её конца, и параллельные изменения ждут своей очереди
+func lockOwnedTask(tasksRepository domainInterfaces.TasksRepository, userID uuid.UUID,
+	taskID uuid.UUID) (*models.Task, error) {
+	task, err := tasksRepository.GetByIDForUpdate(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkTaskOwner(task, userID)
+}
+
+func checkTaskOwner(task *models.Task, userID uuid.UUID) (*models.Task, error) {
+	if task == nil || task.OwnerID != userID {
+		return nil, errors.ApplicationError{
+			StatusCode: 404,
+			Code:       "NotFound",
+			Errors:     map[string]string{"message": "Task not found"},
+		}
+	}
+
+	return task, nil
+}
+
+// expectedVersion == nil — клиент не требует проверки версии
+func checkTaskVersion(task *models.Task, expectedVersion *int) error {
+	if expectedVersion != nil && *expectedVersion != task.Version {
+		return errors.VersionConflictError{Current: task}
+	}
+
+	return nil
+}
+
+func ownedBy(userID uuid.UUID, filter *models.TasksFilter) *models.TasksFilter {
+	scoped := models.TasksFilter{}
+	if filter != nil {
+		scoped = *filter
+	}
+	scoped.OwnerID = &userID
+
+	return &scoped
+}
+
+func localNow(location *time.Location) time.Time {
+	if location == nil {
+		return time.Now().UTC()
+	}
+
+	return time.Now().In(location)
+}
+
+// UTC не сохраняется: у задачи без часового пояса дедлайн и так считается в UTC
+func timeZoneName(location *time.Location) *string {
+	if location == nil || location == time.UTC {
+		return nil
+	}
+
+	return utils.Ptr(location.String())
+}
+
+func tagNames(tags []models.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// Правило хранится в каноническом виде; валидность проверена ValidateTask
+func normalizeRecurrence(recurrence *string) *string {
+	if recurrence == nil {
+		return nil
+	}
+
+	parsed, err := models.ParseRecurrence(*recurrence)
+	if err != nil {
+		return nil
+	}
+
+	return utils.Ptr(parsed.String())
+}
+
+// Предпросмотр разбора названия: макрос @project с неизвестным проектом считается нераспознанным
+func (service *TasksServiceImpl) ParseTask(userID uuid.UUID, name string,
+	location *time.Location) (*macros.Result, *models.Project, error) {
+	result := macros.Parse(name, localNow(location))
+	if result.Project == nil {
+		return result, nil, nil
+	}
+
+	project, err := service.findProjectByName(userID, *result.Project)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if project == nil {
+		index := slices.IndexFunc(result.Recognized, func(macro macros.Macro) bool {
+			return macro.Kind == macros.Project
+		})
+		macro := result.Recognized[index]
+		macro.Reason = "Project not found"
+		result.Recognized = slices.Delete(result.Recognized, index, index+1)
+		result.Unrecognized = append(result.Unrecognized, macro)
+		result.Project = nil
+	}
+
+	return result, project, nil
+}
+
+// Макросы заполняют только поля, не заданные явно; метки из макросов добавляются к tags
+func (service *TasksServiceImpl) applyMacros(userID uuid.UUID, name *string, deadline **time.Time,
+	priority **enums.Priority, tags *[]string, projectID **uuid.UUID, now time.Time) error {
+	result := macros.Parse(*name, now)
+	*name = result.Name
+
+	if *deadline == nil {
+		*deadline = result.Deadline
+	}
+	if *priority == nil {
+		*priority = result.Priority
+	}
+	*tags = append(*tags, result.Tags...)
+
+	if *projectID != nil || result.Project == nil {
+		return nil
+	}
+
+	project, err := service.findProjectByName(userID, *result.Project)
+	if err != nil {
+		return err
+	}
+
+	if project == nil {
+		return errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "ValidationFailed",
+			Errors:     map[string]string{"project": fmt.Sprintf("Project \"%s\" not found", *result.Project)},
+		}
+	}
+
+	*projectID = &project.ID
+
+	return nil
+}
+
+// Макросы в новом названии задают только поля, которых нет в patch
+func (service *TasksServiceImpl) applyPatchMacros(userID uuid.UUID, patch *models.TaskPatch, macroTags *[]string,
+	now time.Time) error {
+	name := *patch.Name
+	var deadline *time.Time
+	var priority *enums.Priority
+	var projectID *uuid.UUID
+	if patch.Deadline != nil {
+		deadline = *patch.Deadline
+	}
+	if patch.Priority != nil {
+		priority = *patch.Priority
+	}
+	if patch.ProjectID != nil {
+		projectID = *patch.ProjectID
+	}
+
+	if err := service.applyMacros(userID, &name, &deadline, &priority, macroTags, &projectID, now); err != nil {
+		return err
+	}
+
+	patch.Name = &name
+	if deadline != nil {
+		patch.Deadline = &deadline
+	}
+	if priority != nil {
+		patch.Priority = &priority
+	}
+	if projectID != nil {
+		patch.ProjectID = &projectID
+	}
+
+	return nil
+}
+
+// Имя в макросе @project не содержит пробелов, поэтому пробелы в названии проекта заменяются на _.
+// Архивные проекты не ищутся
+func (service *TasksServiceImpl) findProjectByName(userID uuid.UUID, name string) (*models.Project, error) {
+	projects, err := service.projectsRepository.GetByOwnerID(userID, utils.Ptr(false))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		if strings.EqualFold(strings.ReplaceAll(project.Name, " ", "_"), name) {
+			return project, nil
+		}
 	}
 
-	*name = strings.TrimSpace(cleanName)
+	return nil, nil
 }
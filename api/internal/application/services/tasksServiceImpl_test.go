@@ -3,7 +3,9 @@ package services
 import (
 	appEnums "HITS_ToDoList_Tests/internal/application/enums"
 	"HITS_ToDoList_Tests/internal/application/errors"
+	appInterfaces "HITS_ToDoList_Tests/internal/application/interfaces"
 	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
 	"HITS_ToDoList_Tests/internal/domain/models"
 	"HITS_ToDoList_Tests/internal/pkg/utils"
 	"fmt"
@@ -24,11 +26,20 @@ func (m *MockTasksRepository) Add(task models.Task) error {
 	return args.Error(0)
 }
 
-func (m *MockTasksRepository) GetAll(sorting *appEnums.Sorting) ([]*models.Task, error) {
-	args := m.Called(sorting)
+func (m *MockTasksRepository) GetAll(filter *models.TasksFilter, sorting *appEnums.Sorting) ([]*models.Task, error) {
+	args := m.Called(filter, sorting)
 	return args.Get(0).([]*models.Task), args.Error(1)
 }
 
+func (m *MockTasksRepository) GetPage(filter *models.TasksFilter, sorting *appEnums.Sorting,
+	after *models.TasksCursor, limit int) (*models.TasksPage, error) {
+	args := m.Called(filter, sorting, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TasksPage), args.Error(1)
+}
+
 func (m *MockTasksRepository) GetByID(id uuid.UUID) (*models.Task, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -37,6 +48,27 @@ func (m *MockTasksRepository) GetByID(id uuid.UUID) (*models.Task, error) {
 	return args.Get(0).(*models.Task), args.Error(1)
 }
 
+// Блокировка в моке не проверяется: чтение задачи для изменения ожидается как GetByID
+func (m *MockTasksRepository) GetByIDForUpdate(id uuid.UUID) (*models.Task, error) {
+	return m.GetByID(id)
+}
+
+func (m *MockTasksRepository) GetDeleted(id uuid.UUID) (*models.Task, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Task), args.Error(1)
+}
+
+func (m *MockTasksRepository) GetDeletedForUpdate(id uuid.UUID, deletedBefore *time.Time) (*models.Task, error) {
+	args := m.Called(id, deletedBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Task), args.Error(1)
+}
+
 func (m *MockTasksRepository) DeleteByID(taskID uuid.UUID) error {
 	args := m.Called(taskID)
 	return args.Error(0)
@@ -47,11 +79,65 @@ func (m *MockTasksRepository) Update(task models.Task) error {
 	return args.Error(0)
 }
 
+func (m *MockTasksRepository) MarkOverdue(now time.Time) ([]*models.Task, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Task), args.Error(1)
+}
+
+// Мок планировщика дедлайнов
+type MockDeadlineTracker struct {
+	mock.Mock
+}
+
+func (m *MockDeadlineTracker) Track(taskID uuid.UUID, deadline time.Time) {
+	m.Called(taskID, deadline)
+}
+
+func (m *MockDeadlineTracker) Untrack(taskID uuid.UUID) {
+	m.Called(taskID)
+}
+
+type MockTaskEventsRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskEventsRepository) Add(event models.TaskEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockTaskEventsRepository) GetPage(taskID uuid.UUID, after *models.TaskEventsCursor,
+	limit int) (*models.TaskEventsPage, error) {
+	args := m.Called(taskID, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskEventsPage), args.Error(1)
+}
+
+func (m *MockTaskEventsRepository) DeleteByTaskID(taskID uuid.UUID) error {
+	args := m.Called(taskID)
+	return args.Error(0)
+}
+
+func newTaskEventsRepositoryStub() *MockTaskEventsRepository {
+	stub := new(MockTaskEventsRepository)
+	stub.On("Add", mock.Anything).Return(nil).Maybe()
+	return stub
+}
+
 // Тест на создание задачи
 func TestCreateTask(t *testing.T) {
+	userID := uuid.New()
 	now := time.Now()
 	tomorrow := now.AddDate(0, 0, 1).UTC().Truncate(24 * time.Hour)
 	yesterday := now.AddDate(0, 0, -1).UTC().Truncate(24 * time.Hour)
+	// Дедлайн из макроса без времени — конец дня
+	todayEnd := tomorrow.Add(-time.Second)
+	tomorrowEnd := tomorrow.Add(24*time.Hour - time.Second)
 
 	tests := []struct {
 		name        string
@@ -66,7 +152,9 @@ func TestCreateTask(t *testing.T) {
 			name:     "Создание задачи без макросов",
 			taskName: "Тестовая задача",
 			mockSetup: func(m *MockTasksRepository) {
-				m.On("Add", mock.AnythingOfType("models.Task")).Return(nil)
+				m.On("Add", mock.MatchedBy(func(task models.Task) bool {
+					return task.OwnerID == userID
+				})).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -167,7 +255,7 @@ func TestCreateTask(t *testing.T) {
 					if task.Deadline == nil {
 						return false
 					}
-					return *task.Deadline == tomorrow && task.Priority == enums.Medium
+					return task.Deadline.Equal(tomorrowEnd) && task.Priority == enums.Medium
 				})).Return(nil)
 			},
 			wantErr: false,
@@ -180,7 +268,7 @@ func TestCreateTask(t *testing.T) {
 					if task.Deadline == nil {
 						return false
 					}
-					return *task.Deadline == tomorrow && task.Priority == enums.Medium
+					return task.Deadline.Equal(tomorrowEnd) && task.Priority == enums.Medium
 				})).Return(nil)
 			},
 			wantErr: false,
@@ -192,10 +280,14 @@ func TestCreateTask(t *testing.T) {
 			wantErr:   true,
 		},
 		{
-			name:      "Создание задачи с макросом дедлайна в настоящем",
-			taskName:  "Задача с дедлайном !before " + now.Format("02.01.2006"),
-			mockSetup: func(m *MockTasksRepository) {},
-			wantErr:   true,
+			name:     "Создание задачи с макросом сегодняшней даты",
+			taskName: "Задача с дедлайном !before " + now.UTC().Format("02.01.2006"),
+			mockSetup: func(m *MockTasksRepository) {
+				m.On("Add", mock.MatchedBy(func(task models.Task) bool {
+					return task.Deadline != nil && task.Deadline.Equal(todayEnd)
+				})).Return(nil)
+			},
+			wantErr: false,
 		},
 		{
 			name:     "Создание задачи с макросом дедлайна и явным указанием дедлайна",
@@ -214,7 +306,7 @@ func TestCreateTask(t *testing.T) {
 			taskName: "Задача с дедлайном и приоритетом !before " + tomorrow.Format("02.01.2006") + " !1",
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("Add", mock.MatchedBy(func(task models.Task) bool {
-					return *task.Deadline == tomorrow && task.Priority == enums.Critical
+					return task.Deadline.Equal(tomorrowEnd) && task.Priority == enums.Critical
 				})).Return(nil)
 			},
 			wantErr: false,
@@ -226,8 +318,10 @@ func TestCreateTask(t *testing.T) {
 			mockRepo := new(MockTasksRepository)
 			tt.mockSetup(mockRepo)
 
-			service := NewTasksService(mockRepo)
-			task, err := service.CreateTask(tt.taskName, tt.description, tt.deadline, tt.priority)
+			service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			task, err := service.CreateTask(userID, tt.taskName, tt.description, tt.deadline, tt.priority, nil, nil,
+				nil, nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -242,6 +336,7 @@ func TestCreateTask(t *testing.T) {
 }
 
 func TestGetAllTasks(t *testing.T) {
+	userID := uuid.New()
 	now := time.Now()
 	tomorrow := now.AddDate(0, 0, 1).UTC().Truncate(24 * time.Hour)
 	yesterday := now.AddDate(0, 0, -1).UTC().Truncate(24 * time.Hour)
@@ -279,8 +374,17 @@ func TestGetAllTasks(t *testing.T) {
 		},
 	}
 
+	filter := &models.TasksFilter{
+		Statuses:   []enums.Status{enums.Active, enums.Overdue},
+		Priorities: []enums.Priority{enums.Critical},
+		Query:      utils.Ptr("задача"),
+	}
+	scopedFilter := *filter
+	scopedFilter.OwnerID = &userID
+
 	tests := []struct {
 		name      string
+		filter    *models.TasksFilter
 		sorting   *appEnums.Sorting
 		mockSetup func(*MockTasksRepository)
 		wantErr   bool
@@ -289,7 +393,7 @@ func TestGetAllTasks(t *testing.T) {
 			name:    "Получение всех задач без сортировки",
 			sorting: nil,
 			mockSetup: func(m *MockTasksRepository) {
-				m.On("GetAll", (*appEnums.Sorting)(nil)).Return(mockTasks, nil)
+				m.On("GetAll", &models.TasksFilter{OwnerID: &userID}, (*appEnums.Sorting)(nil)).Return(mockTasks, nil)
 			},
 			wantErr: false,
 		},
@@ -297,7 +401,7 @@ func TestGetAllTasks(t *testing.T) {
 			name:    "Получение всех задач с сортировкой по приоритету (по возрастанию)",
 			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.PriorityAsc)),
 			mockSetup: func(m *MockTasksRepository) {
-				m.On("GetAll", (*appEnums.Sorting)(utils.Ptr(appEnums.PriorityAsc))).Return(mockTasks, nil)
+				m.On("GetAll", &models.TasksFilter{OwnerID: &userID}, (*appEnums.Sorting)(utils.Ptr(appEnums.PriorityAsc))).Return(mockTasks, nil)
 			},
 			wantErr: false,
 		},
@@ -305,7 +409,7 @@ func TestGetAllTasks(t *testing.T) {
 			name:    "Получение всех задач с сортировкой по приоритету (по убыванию)",
 			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.PriorityDesc)),
 			mockSetup: func(m *MockTasksRepository) {
-				m.On("GetAll", (*appEnums.Sorting)(utils.Ptr(appEnums.PriorityDesc))).Return(mockTasks, nil)
+				m.On("GetAll", &models.TasksFilter{OwnerID: &userID}, (*appEnums.Sorting)(utils.Ptr(appEnums.PriorityDesc))).Return(mockTasks, nil)
 			},
 			wantErr: false,
 		},
@@ -313,7 +417,7 @@ func TestGetAllTasks(t *testing.T) {
 			name:    "Получение всех задач с сортировкой по дате создания (по возрастанию)",
 			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.CreateAsc)),
 			mockSetup: func(m *MockTasksRepository) {
-				m.On("GetAll", (*appEnums.Sorting)(utils.Ptr(appEnums.CreateAsc))).Return(mockTasks, nil)
+				m.On("GetAll", &models.TasksFilter{OwnerID: &userID}, (*appEnums.Sorting)(utils.Ptr(appEnums.CreateAsc))).Return(mockTasks, nil)
 			},
 			wantErr: false,
 		},
@@ -321,7 +425,7 @@ func TestGetAllTasks(t *testing.T) {
 			name:    "Получение всех задач с сортировкой по дате создания (по убыванию)",
 			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.CreateDesc)),
 			mockSetup: func(m *MockTasksRepository) {
-				m.On("GetAll", (*appEnums.Sorting)(utils.Ptr(appEnums.CreateDesc))).Return(mockTasks, nil)
+				m.On("GetAll", &models.TasksFilter{OwnerID: &userID}, (*appEnums.Sorting)(utils.Ptr(appEnums.CreateDesc))).Return(mockTasks, nil)
 			},
 			wantErr: false,
 		},
@@ -329,7 +433,7 @@ func TestGetAllTasks(t *testing.T) {
 			name:    "Получение всех задач с сортировкой по дедлайну (по возрастанию)",
 			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineAsc)),
 			mockSetup: func(m *MockTasksRepository) {
-				m.On("GetAll", (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineAsc))).Return(mockTasks, nil)
+				m.On("GetAll", &models.TasksFilter{OwnerID: &userID}, (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineAsc))).Return(mockTasks, nil)
 			},
 			wantErr: false,
 		},
@@ -337,15 +441,41 @@ func TestGetAllTasks(t *testing.T) {
 			name:    "Получение всех задач с сортировкой по дедлайну (по убыванию)",
 			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineDesc)),
 			mockSetup: func(m *MockTasksRepository) {
-				m.On("GetAll", (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineDesc))).Return(mockTasks, nil)
+				m.On("GetAll", &models.TasksFilter{OwnerID: &userID}, (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineDesc))).Return(mockTasks, nil)
 			},
 			wantErr: false,
 		},
+		{
+			name:    "Получение задач с фильтрацией",
+			filter:  filter,
+			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineAsc)),
+			mockSetup: func(m *MockTasksRepository) {
+				m.On("GetAll", &scopedFilter, (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineAsc))).Return(mockTasks, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Фильтрация с невалидным статусом",
+			filter: &models.TasksFilter{
+				Statuses: []enums.Status{"Invalid"},
+			},
+			mockSetup: func(m *MockTasksRepository) {},
+			wantErr:   true,
+		},
+		{
+			name: "Фильтрация с перевёрнутым диапазоном дедлайна",
+			filter: &models.TasksFilter{
+				DeadlineFrom: &tomorrow,
+				DeadlineTo:   &yesterday,
+			},
+			mockSetup: func(m *MockTasksRepository) {},
+			wantErr:   true,
+		},
 		{
 			name:    "Невалидная сортировка",
 			sorting: utils.Ptr(appEnums.Sorting("Invalid")),
 			mockSetup: func(m *MockTasksRepository) {
-				m.On("GetAll", utils.Ptr(appEnums.Sorting("Invalid"))).Return([]*models.Task{}, fmt.Errorf("invalid sorting: Invalid"))
+				m.On("GetAll", &models.TasksFilter{OwnerID: &userID}, utils.Ptr(appEnums.Sorting("Invalid"))).Return([]*models.Task{}, fmt.Errorf("invalid sorting: Invalid"))
 			},
 			wantErr: true,
 		},
@@ -356,8 +486,9 @@ func TestGetAllTasks(t *testing.T) {
 			mockRepo := new(MockTasksRepository)
 			tt.mockSetup(mockRepo)
 
-			service := NewTasksService(mockRepo)
-			tasks, err := service.GetAllTasks(tt.sorting)
+			service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			tasks, err := service.GetAllTasks(userID, tt.filter, tt.sorting)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -371,7 +502,115 @@ func TestGetAllTasks(t *testing.T) {
 	}
 }
 
+func TestGetTasksPage(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	sorting := (*appEnums.Sorting)(utils.Ptr(appEnums.CreateDesc))
+
+	mockTasks := []*models.Task{
+		{ID: uuid.New(), Name: "Задача 1", CreatedAt: now, Status: enums.Active, Priority: enums.Medium},
+		{ID: uuid.New(), Name: "Задача 2", CreatedAt: now.Add(-time.Hour), Status: enums.Active, Priority: enums.High},
+	}
+	lastCursor := models.NewTasksCursor(mockTasks[1])
+
+	validCursor, err := encodeTasksCursor(lastCursor, sorting)
+	assert.NoError(t, err)
+	otherSortingCursor, err := encodeTasksCursor(lastCursor, nil)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		sorting        *appEnums.Sorting
+		cursor         *string
+		limit          *int
+		mockSetup      func(*MockTasksRepository)
+		wantNextCursor bool
+		wantErr        bool
+	}{
+		{
+			name:    "Первая страница при наличии следующей",
+			sorting: sorting,
+			limit:   utils.Ptr(2),
+			mockSetup: func(m *MockTasksRepository) {
+				m.On("GetPage", &models.TasksFilter{OwnerID: &userID}, sorting, (*models.TasksCursor)(nil), 2).
+					Return(&models.TasksPage{Items: mockTasks, HasMore: true}, nil)
+			},
+			wantNextCursor: true,
+		},
+		{
+			name:    "Последняя страница",
+			sorting: sorting,
+			cursor:  &validCursor,
+			mockSetup: func(m *MockTasksRepository) {
+				m.On("GetPage", &models.TasksFilter{OwnerID: &userID}, sorting, mock.MatchedBy(func(c *models.TasksCursor) bool {
+					return c != nil && c.ID == lastCursor.ID && c.CreatedAt.Equal(lastCursor.CreatedAt)
+				}), defaultPageSize).Return(&models.TasksPage{Items: mockTasks[:1]}, nil)
+			},
+			wantNextCursor: false,
+		},
+		{
+			name:      "Курсор другой сортировки",
+			sorting:   sorting,
+			cursor:    &otherSortingCursor,
+			mockSetup: func(m *MockTasksRepository) {},
+			wantErr:   true,
+		},
+		{
+			name:      "Повреждённый курсор",
+			sorting:   sorting,
+			cursor:    utils.Ptr("not-a-cursor"),
+			mockSetup: func(m *MockTasksRepository) {},
+			wantErr:   true,
+		},
+		{
+			name:      "Слишком большой размер страницы",
+			limit:     utils.Ptr(maxPageSize + 1),
+			mockSetup: func(m *MockTasksRepository) {},
+			wantErr:   true,
+		},
+		{
+			name:      "Нулевой размер страницы",
+			limit:     utils.Ptr(0),
+			mockSetup: func(m *MockTasksRepository) {},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTasksRepository)
+			tt.mockSetup(mockRepo)
+
+			service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			tasks, nextCursor, err := service.GetTasksPage(userID, nil, tt.sorting, tt.cursor, tt.limit)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if appErr, ok := err.(errors.ApplicationError); ok {
+					assert.Equal(t, 400, appErr.StatusCode)
+				}
+				assert.Nil(t, tasks)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, tasks)
+			if tt.wantNextCursor {
+				assert.NotNil(t, nextCursor)
+				decoded, err := decodeTasksCursor(*nextCursor, tt.sorting)
+				assert.NoError(t, err)
+				assert.Equal(t, tasks[len(tasks)-1].ID, decoded.ID)
+			} else {
+				assert.Nil(t, nextCursor)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestDeleteTask(t *testing.T) {
+	userID := uuid.New()
 	taskID := uuid.New()
 	tests := []struct {
 		name      string
@@ -383,11 +622,21 @@ func TestDeleteTask(t *testing.T) {
 			name:   "Успешное удаление задачи",
 			taskID: taskID,
 			mockSetup: func(m *MockTasksRepository) {
-				m.On("GetByID", taskID).Return(&models.Task{ID: taskID}, nil)
-				m.On("DeleteByID", taskID).Return(nil)
+				m.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: userID}, nil)
+				m.On("Update", mock.MatchedBy(func(task models.Task) bool {
+					return task.ID == taskID && task.DeletedAt != nil
+				})).Return(nil)
 			},
 			wantErr: false,
 		},
+		{
+			name:   "Удаление задачи другого пользователя",
+			taskID: taskID,
+			mockSetup: func(m *MockTasksRepository) {
+				m.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: uuid.New()}, nil)
+			},
+			wantErr: true,
+		},
 		{
 			name:   "Удаление несуществующей задачи",
 			taskID: taskID,
@@ -403,8 +652,9 @@ func TestDeleteTask(t *testing.T) {
 			mockRepo := new(MockTasksRepository)
 			tt.mockSetup(mockRepo)
 
-			service := NewTasksService(mockRepo)
-			err := service.DeleteTask(tt.taskID)
+			service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			err := service.DeleteTask(userID, tt.taskID, nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -420,6 +670,7 @@ func TestDeleteTask(t *testing.T) {
 }
 
 func TestToggleTaskStatus(t *testing.T) {
+	userID := uuid.New()
 	taskID := uuid.New()
 	now := time.Now()
 	deadline := now.Add(24 * time.Hour)
@@ -438,6 +689,7 @@ func TestToggleTaskStatus(t *testing.T) {
 			isDone: true,
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Status:   enums.Active,
 					Deadline: &deadline,
@@ -454,6 +706,7 @@ func TestToggleTaskStatus(t *testing.T) {
 			isDone: false,
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Status:   enums.Completed,
 					Deadline: &deadline,
@@ -470,6 +723,7 @@ func TestToggleTaskStatus(t *testing.T) {
 			isDone: false,
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Status:   enums.Completed,
 					Deadline: &pastDeadline,
@@ -486,6 +740,7 @@ func TestToggleTaskStatus(t *testing.T) {
 			isDone: true,
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Status:   enums.Overdue,
 					Deadline: &pastDeadline,
@@ -502,6 +757,7 @@ func TestToggleTaskStatus(t *testing.T) {
 			isDone: false,
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Status:   enums.Late,
 					Deadline: &pastDeadline,
@@ -512,6 +768,20 @@ func TestToggleTaskStatus(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:   "Смена статуса задачи другого пользователя",
+			taskID: taskID,
+			isDone: true,
+			mockSetup: func(m *MockTasksRepository) {
+				m.On("GetByID", taskID).Return(&models.Task{
+					ID:       taskID,
+					OwnerID:  uuid.New(),
+					Status:   enums.Active,
+					Deadline: &deadline,
+				}, nil)
+			},
+			wantErr: true,
+		},
 		{
 			name:   "Смена статуса несуществующей задачи",
 			taskID: taskID,
@@ -528,8 +798,9 @@ func TestToggleTaskStatus(t *testing.T) {
 			mockRepo := new(MockTasksRepository)
 			tt.mockSetup(mockRepo)
 
-			service := NewTasksService(mockRepo)
-			task, err := service.ToggleTaskStatus(tt.taskID, tt.isDone)
+			service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			task, err := service.ToggleTaskStatus(userID, tt.taskID, tt.isDone, false, nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -544,10 +815,14 @@ func TestToggleTaskStatus(t *testing.T) {
 }
 
 func TestUpdateTask(t *testing.T) {
+	userID := uuid.New()
 	taskID := uuid.New()
 	now := time.Now()
 	tomorrow := now.AddDate(0, 0, 1).UTC().Truncate(24 * time.Hour)
 	yesterday := now.AddDate(0, 0, -1).UTC().Truncate(24 * time.Hour)
+	// Дедлайн из макроса без времени — конец дня
+	todayEnd := tomorrow.Add(-time.Second)
+	tomorrowEnd := tomorrow.Add(24*time.Hour - time.Second)
 
 	tests := []struct {
 		name        string
@@ -565,6 +840,7 @@ func TestUpdateTask(t *testing.T) {
 			taskName: "Тестовая задача",
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
@@ -590,6 +866,7 @@ func TestUpdateTask(t *testing.T) {
 			priority: utils.Ptr(enums.High),
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
@@ -607,6 +884,7 @@ func TestUpdateTask(t *testing.T) {
 			taskName: "Задача с приоритетом !1",
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
@@ -624,6 +902,7 @@ func TestUpdateTask(t *testing.T) {
 			taskName: "Задача с приоритетом !2",
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
@@ -641,6 +920,7 @@ func TestUpdateTask(t *testing.T) {
 			taskName: "Задача с приоритетом !3",
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
@@ -658,6 +938,7 @@ func TestUpdateTask(t *testing.T) {
 			taskName: "Задача с приоритетом !4",
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
@@ -675,6 +956,7 @@ func TestUpdateTask(t *testing.T) {
 			taskName: "Задача с приоритетом !5",
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
@@ -693,6 +975,7 @@ func TestUpdateTask(t *testing.T) {
 			priority: utils.Ptr(enums.High),
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
@@ -711,6 +994,7 @@ func TestUpdateTask(t *testing.T) {
 			deadline: &tomorrow,
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
@@ -728,13 +1012,14 @@ func TestUpdateTask(t *testing.T) {
 			taskName: "Задача с дедлайном !before " + tomorrow.Format("02.01.2006"),
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
 					Priority: enums.Medium,
 				}, nil)
 				m.On("Update", mock.MatchedBy(func(task models.Task) bool {
-					return *task.Deadline == tomorrow && task.Priority == enums.Medium
+					return task.Deadline.Equal(tomorrowEnd) && task.Priority == enums.Medium
 				})).Return(nil)
 			},
 			wantErr: false,
@@ -745,13 +1030,14 @@ func TestUpdateTask(t *testing.T) {
 			taskName: "Задача с дедлайном !before " + tomorrow.Format("02-01-2006"),
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
 					Priority: enums.Medium,
 				}, nil)
 				m.On("Update", mock.MatchedBy(func(task models.Task) bool {
-					return *task.Deadline == tomorrow && task.Priority == enums.Medium
+					return task.Deadline.Equal(tomorrowEnd) && task.Priority == enums.Medium
 				})).Return(nil)
 			},
 			wantErr: false,
@@ -762,6 +1048,7 @@ func TestUpdateTask(t *testing.T) {
 			taskName: "Задача с дедлайном !before " + yesterday.Format("02.01.2006"),
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
@@ -771,18 +1058,22 @@ func TestUpdateTask(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:     "Обновление задачи с макросом дедлайна в настоящем",
+			name:     "Обновление задачи с макросом сегодняшней даты",
 			taskID:   taskID,
-			taskName: "Задача с дедлайном !before " + now.Format("02.01.2006"),
+			taskName: "Задача с дедлайном !before " + now.UTC().Format("02.01.2006"),
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
 					Priority: enums.Medium,
 				}, nil)
+				m.On("Update", mock.MatchedBy(func(task models.Task) bool {
+					return task.Deadline != nil && task.Deadline.Equal(todayEnd)
+				})).Return(nil)
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
 			name:     "Обновление задачи с макросом дедлайна и явным указанием дедлайна",
@@ -791,6 +1082,7 @@ func TestUpdateTask(t *testing.T) {
 			deadline: utils.Ptr(tomorrow.AddDate(0, 0, 1)),
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
@@ -809,17 +1101,33 @@ func TestUpdateTask(t *testing.T) {
 			taskName: "Задача с дедлайном и приоритетом !before " + tomorrow.Format("02.01.2006") + " !1",
 			mockSetup: func(m *MockTasksRepository) {
 				m.On("GetByID", taskID).Return(&models.Task{
+					OwnerID:  userID,
 					ID:       taskID,
 					Name:     "Тестовая задача",
 					Status:   enums.Active,
 					Priority: enums.Medium,
 				}, nil)
 				m.On("Update", mock.MatchedBy(func(task models.Task) bool {
-					return *task.Deadline == tomorrow && task.Priority == enums.Critical
+					return task.Deadline.Equal(tomorrowEnd) && task.Priority == enums.Critical
 				})).Return(nil)
 			},
 			wantErr: false,
 		},
+		{
+			name:     "Обновление задачи другого пользователя",
+			taskID:   taskID,
+			taskName: "Тестовая задача",
+			mockSetup: func(m *MockTasksRepository) {
+				m.On("GetByID", taskID).Return(&models.Task{
+					ID:       taskID,
+					OwnerID:  uuid.New(),
+					Name:     "Тестовая задача",
+					Status:   enums.Active,
+					Priority: enums.Medium,
+				}, nil)
+			},
+			wantErr: true,
+		},
 		{
 			name:     "Обновление несуществующей задачи",
 			taskID:   taskID,
@@ -836,8 +1144,10 @@ func TestUpdateTask(t *testing.T) {
 			mockRepo := new(MockTasksRepository)
 			tt.mockSetup(mockRepo)
 
-			service := NewTasksService(mockRepo)
-			task, err := service.UpdateTask(tt.taskID, tt.taskName, tt.description, tt.deadline, tt.priority)
+			service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			task, err := service.UpdateTask(userID, tt.taskID, tt.taskName, tt.description, tt.deadline, tt.priority,
+				nil, nil, nil, nil, nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -850,3 +1160,1210 @@ func TestUpdateTask(t *testing.T) {
 		})
 	}
 }
+
+// Тест перевода просроченных задач в Overdue
+func TestUpdateTaskStatuses(t *testing.T) {
+	overdueTask := &models.Task{ID: uuid.New(), Status: enums.Overdue}
+
+	mockRepo := new(MockTasksRepository)
+	mockRepo.On("MarkOverdue", mock.AnythingOfType("time.Time")).Return([]*models.Task{overdueTask}, nil)
+	mockTracker := new(MockDeadlineTracker)
+	mockTracker.On("Untrack", overdueTask.ID).Return()
+
+	service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+		new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, mockTracker, nil)
+	service.UpdateTaskStatuses()
+
+	mockRepo.AssertExpectations(t)
+	mockTracker.AssertExpectations(t)
+}
+
+// Тест передачи дедлайнов планировщику при изменении задач
+func TestDeadlineTracking(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+	deadline := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	t.Run("Создание задачи с дедлайном", func(t *testing.T) {
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("Add", mock.AnythingOfType("models.Task")).Return(nil)
+		mockTracker := new(MockDeadlineTracker)
+		mockTracker.On("Track", mock.AnythingOfType("uuid.UUID"), deadline).Return()
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, mockTracker, nil)
+		_, err := service.CreateTask(userID, "Задача", nil, &deadline, nil, nil, nil, nil, nil)
+
+		assert.NoError(t, err)
+		mockTracker.AssertExpectations(t)
+	})
+
+	t.Run("Выполнение задачи", func(t *testing.T) {
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(&models.Task{
+			ID:       taskID,
+			OwnerID:  userID,
+			Status:   enums.Active,
+			Deadline: &deadline,
+		}, nil)
+		mockRepo.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+		mockTracker := new(MockDeadlineTracker)
+		mockTracker.On("Untrack", taskID).Return()
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, mockTracker, nil)
+		_, err := service.ToggleTaskStatus(userID, taskID, true, false, nil)
+
+		assert.NoError(t, err)
+		mockTracker.AssertExpectations(t)
+	})
+
+	t.Run("Удаление задачи", func(t *testing.T) {
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: userID}, nil)
+		mockRepo.On("Update", mock.MatchedBy(func(task models.Task) bool {
+			return task.ID == taskID && task.DeletedAt != nil
+		})).Return(nil)
+		mockTracker := new(MockDeadlineTracker)
+		mockTracker.On("Untrack", taskID).Return()
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, mockTracker, nil)
+		err := service.DeleteTask(userID, taskID, nil)
+
+		assert.NoError(t, err)
+		mockTracker.AssertExpectations(t)
+	})
+
+	t.Run("Загрузка ближайших дедлайнов", func(t *testing.T) {
+		until := time.Now().Add(time.Minute)
+
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetAll", &models.TasksFilter{
+			Statuses:   []enums.Status{enums.Active},
+			DeadlineTo: &until,
+		}, (*appEnums.Sorting)(nil)).Return([]*models.Task{
+			{ID: taskID, Status: enums.Active, Deadline: &deadline},
+		}, nil)
+		mockTracker := new(MockDeadlineTracker)
+		mockTracker.On("Track", taskID, deadline).Return()
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, mockTracker, nil)
+		service.TrackActiveDeadlines(until)
+
+		mockRepo.AssertExpectations(t)
+		mockTracker.AssertExpectations(t)
+	})
+}
+
+// Тест выполнения задачи с невыполненными пунктами чек-листа
+func TestToggleTaskStatus_Checklist(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	tests := []struct {
+		name          string
+		isDone        bool
+		completeItems bool
+		progress      models.ChecklistProgress
+		mockSetup     func(*MockTasksRepository, *MockChecklistItemsRepository)
+		wantStatus    int
+		wantProgress  models.ChecklistProgress
+	}{
+		{
+			name:       "Выполнение блокируется невыполненными пунктами",
+			isDone:     true,
+			progress:   models.ChecklistProgress{Done: 3, Total: 5},
+			mockSetup:  func(*MockTasksRepository, *MockChecklistItemsRepository) {},
+			wantStatus: 409,
+		},
+		{
+			name:          "Выполнение отмечает пункты выполненными",
+			isDone:        true,
+			completeItems: true,
+			progress:      models.ChecklistProgress{Done: 3, Total: 5},
+			mockSetup: func(tasks *MockTasksRepository, items *MockChecklistItemsRepository) {
+				items.On("MarkAllDone", taskID, mock.AnythingOfType("time.Time")).Return(nil)
+				tasks.On("Update", mock.MatchedBy(func(task models.Task) bool {
+					return task.Status == enums.Completed
+				})).Return(nil)
+			},
+			wantProgress: models.ChecklistProgress{Done: 5, Total: 5},
+		},
+		{
+			name:     "Все пункты уже выполнены",
+			isDone:   true,
+			progress: models.ChecklistProgress{Done: 2, Total: 2},
+			mockSetup: func(tasks *MockTasksRepository, items *MockChecklistItemsRepository) {
+				tasks.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+			},
+			wantProgress: models.ChecklistProgress{Done: 2, Total: 2},
+		},
+		{
+			name:     "Возврат в работу не зависит от пунктов",
+			isDone:   false,
+			progress: models.ChecklistProgress{Done: 1, Total: 2},
+			mockSetup: func(tasks *MockTasksRepository, items *MockChecklistItemsRepository) {
+				tasks.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+			},
+			wantProgress: models.ChecklistProgress{Done: 1, Total: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasksRepo := new(MockTasksRepository)
+			tasksRepo.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: userID, Status: enums.Active},
+				nil)
+			itemsRepo := new(MockChecklistItemsRepository)
+			itemsRepo.On("GetProgress", []uuid.UUID{taskID}).Return(map[uuid.UUID]models.ChecklistProgress{
+				taskID: tt.progress,
+			}, nil)
+			tt.mockSetup(tasksRepo, itemsRepo)
+
+			service := NewTasksService(tasksRepo, itemsRepo, newTagsRepositoryStub(),
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			task, err := service.ToggleTaskStatus(userID, taskID, tt.isDone, tt.completeItems, nil)
+
+			if tt.wantStatus != 0 {
+				assert.Nil(t, task)
+				var appErr errors.ApplicationError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				tasksRepo.AssertNotCalled(t, "Update", mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantProgress, task.Checklist)
+			}
+			tasksRepo.AssertExpectations(t)
+			itemsRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест создания следующей задачи серии при выполнении повторяющейся задачи
+func TestToggleTaskStatus_Recurring(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+	deadline := time.Now().Add(time.Hour).Truncate(time.Second)
+	description := "Описание"
+
+	tests := []struct {
+		name      string
+		task      models.Task
+		isDone    bool
+		mockSetup func(*MockTasksRepository, *MockChecklistItemsRepository)
+		wantNext  bool
+	}{
+		{
+			name: "Выполнение создаёт следующую задачу",
+			task: models.Task{ID: taskID, OwnerID: userID, Name: "Полить цветы", Description: &description,
+				Deadline: &deadline, Status: enums.Active, Priority: enums.High,
+				Recurrence: utils.Ptr("FREQ=WEEKLY;COUNT=3")},
+			isDone: true,
+			mockSetup: func(tasks *MockTasksRepository, items *MockChecklistItemsRepository) {
+				tasks.On("Add", mock.MatchedBy(func(next models.Task) bool {
+					return next.ID != taskID && next.OwnerID == userID && next.Name == "Полить цветы" &&
+						next.Description == &description && next.Priority == enums.High &&
+						next.Status == enums.Active && next.Deadline.Equal(deadline.AddDate(0, 0, 7)) &&
+						*next.Recurrence == "FREQ=WEEKLY;COUNT=2"
+				})).Return(nil)
+				items.On("GetByTaskID", taskID).Return([]*models.ChecklistItem{
+					{ID: uuid.New(), TaskID: taskID, Name: "Кухня", IsDone: true, Position: 1},
+				}, nil)
+				items.On("Add", mock.MatchedBy(func(item models.ChecklistItem) bool {
+					return item.TaskID != taskID && item.Name == "Кухня" && !item.IsDone && item.Position == 1
+				})).Return(nil)
+				tasks.On("Update", mock.MatchedBy(func(task models.Task) bool {
+					return task.ID == taskID && task.Status == enums.Completed && task.NextOccurrenceID != nil
+				})).Return(nil)
+			},
+			wantNext: true,
+		},
+		{
+			name: "Следующая задача уже создана",
+			task: models.Task{ID: taskID, OwnerID: userID, Name: "Полить цветы", Deadline: &deadline,
+				Status: enums.Active, Recurrence: utils.Ptr("FREQ=DAILY"), NextOccurrenceID: utils.Ptr(uuid.New())},
+			isDone: true,
+			mockSetup: func(tasks *MockTasksRepository, items *MockChecklistItemsRepository) {
+				tasks.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+			},
+			wantNext: true,
+		},
+		{
+			name: "Серия закончилась",
+			task: models.Task{ID: taskID, OwnerID: userID, Name: "Полить цветы", Deadline: &deadline,
+				Status: enums.Active, Recurrence: utils.Ptr("FREQ=DAILY;COUNT=1")},
+			isDone: true,
+			mockSetup: func(tasks *MockTasksRepository, items *MockChecklistItemsRepository) {
+				tasks.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+			},
+		},
+		{
+			name: "Возврат в работу не создаёт задачу",
+			task: models.Task{ID: taskID, OwnerID: userID, Name: "Полить цветы", Deadline: &deadline,
+				Status: enums.Completed, Recurrence: utils.Ptr("FREQ=DAILY")},
+			isDone: false,
+			mockSetup: func(tasks *MockTasksRepository, items *MockChecklistItemsRepository) {
+				tasks.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasksRepo := new(MockTasksRepository)
+			stored := tt.task
+			tasksRepo.On("GetByID", taskID).Return(&stored, nil)
+			itemsRepo := newChecklistItemsRepositoryStub()
+			tt.mockSetup(tasksRepo, itemsRepo)
+
+			service := NewTasksService(tasksRepo, itemsRepo, newTagsRepositoryStub(),
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			task, err := service.ToggleTaskStatus(userID, taskID, tt.isDone, false, nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNext, task.NextOccurrenceID != nil)
+			if !tt.wantNext {
+				tasksRepo.AssertNotCalled(t, "Add", mock.Anything)
+			}
+			tasksRepo.AssertExpectations(t)
+			itemsRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест создания следующей задачи серии при просрочке повторяющейся задачи
+func TestUpdateTaskStatuses_Recurring(t *testing.T) {
+	deadline := time.Now().Add(-time.Minute).Truncate(time.Second)
+	recurring := &models.Task{ID: uuid.New(), Status: enums.Overdue, Deadline: &deadline,
+		Recurrence: utils.Ptr("FREQ=DAILY")}
+	spawned := &models.Task{ID: uuid.New(), Status: enums.Overdue, Deadline: &deadline,
+		Recurrence: utils.Ptr("FREQ=DAILY"), NextOccurrenceID: utils.Ptr(uuid.New())}
+
+	mockRepo := new(MockTasksRepository)
+	mockRepo.On("MarkOverdue", mock.AnythingOfType("time.Time")).Return([]*models.Task{recurring, spawned}, nil)
+	mockRepo.On("Add", mock.MatchedBy(func(next models.Task) bool {
+		return next.Deadline.Equal(deadline.AddDate(0, 0, 1)) && next.Status == enums.Active
+	})).Return(nil).Once()
+	mockRepo.On("Update", mock.MatchedBy(func(task models.Task) bool {
+		return task.ID == recurring.ID && task.NextOccurrenceID != nil
+	})).Return(nil).Once()
+	itemsRepo := newChecklistItemsRepositoryStub()
+	itemsRepo.On("GetByTaskID", recurring.ID).Return([]*models.ChecklistItem{}, nil)
+
+	service := NewTasksService(mockRepo, itemsRepo, newTagsRepositoryStub(),
+		new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+	service.UpdateTaskStatuses()
+
+	mockRepo.AssertExpectations(t)
+	itemsRepo.AssertExpectations(t)
+}
+
+// Тест дедлайна из макроса в часовом поясе пользователя
+func TestCreateTask_TimeZone(t *testing.T) {
+	userID := uuid.New()
+	novosibirsk, err := time.LoadLocation("Asia/Novosibirsk")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		location     *time.Location
+		wantTimeZone *string
+	}{
+		{
+			name:         "Часовой пояс запроса",
+			location:     novosibirsk,
+			wantTimeZone: utils.Ptr("Asia/Novosibirsk"),
+		},
+		{
+			name:     "Без часового пояса — UTC",
+			location: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location := tt.location
+			if location == nil {
+				location = time.UTC
+			}
+			year, month, day := time.Now().In(location).Date()
+			endOfDay := time.Date(year, month, day, 23, 59, 59, 0, location)
+
+			mockRepo := new(MockTasksRepository)
+			mockRepo.On("Add", mock.MatchedBy(func(task models.Task) bool {
+				return task.Deadline.Equal(endOfDay) && assert.ObjectsAreEqual(tt.wantTimeZone, task.TimeZone)
+			})).Return(nil)
+
+			service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			task, err := service.CreateTask(userID, "Отчёт !before today", nil, nil, nil, nil, nil, nil, tt.location)
+
+			assert.NoError(t, err)
+			assert.Equal(t, "Отчёт", task.Name)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест повторения в часовом поясе задачи: день недели в BYDAY — местный
+func TestToggleTaskStatus_RecurringTimeZone(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+	novosibirsk, err := time.LoadLocation("Asia/Novosibirsk")
+	assert.NoError(t, err)
+
+	// Ближайший понедельник 02:00 по Новосибирску — в UTC это ещё воскресенье
+	deadline := time.Now().In(novosibirsk).AddDate(0, 0, 1)
+	for deadline.Weekday() != time.Monday {
+		deadline = deadline.AddDate(0, 0, 1)
+	}
+	deadline = time.Date(deadline.Year(), deadline.Month(), deadline.Day(), 2, 0, 0, 0, novosibirsk)
+	stored := deadline.UTC()
+
+	mockRepo := new(MockTasksRepository)
+	mockRepo.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: userID, Name: "Планёрка",
+		Deadline: &stored, TimeZone: utils.Ptr("Asia/Novosibirsk"), Status: enums.Active,
+		Recurrence: utils.Ptr("FREQ=WEEKLY;BYDAY=MO")}, nil)
+	mockRepo.On("Add", mock.MatchedBy(func(next models.Task) bool {
+		return next.Deadline.Equal(deadline.AddDate(0, 0, 7)) && *next.TimeZone == "Asia/Novosibirsk"
+	})).Return(nil)
+	mockRepo.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+	itemsRepo := newChecklistItemsRepositoryStub()
+	itemsRepo.On("GetByTaskID", taskID).Return([]*models.ChecklistItem{}, nil)
+
+	service := NewTasksService(mockRepo, itemsRepo, newTagsRepositoryStub(),
+		new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+	_, err = service.ToggleTaskStatus(userID, taskID, true, false, nil)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// Тест журнала изменений задачи
+func TestTaskHistory(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+	newTask := func() *models.Task {
+		return &models.Task{ID: taskID, OwnerID: userID, Name: "Отчёт", Status: enums.Active, Priority: enums.Medium}
+	}
+
+	t.Run("Изменение записывается со старым и новым значением", func(t *testing.T) {
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(newTask(), nil)
+		mockRepo.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+		eventsRepo := new(MockTaskEventsRepository)
+		eventsRepo.On("Add", mock.MatchedBy(func(event models.TaskEvent) bool {
+			return event.TaskID == taskID && *event.ActorID == userID && event.Type == enums.TaskUpdated &&
+				assert.ObjectsAreEqual(models.FieldChanges{
+					{Field: "name", Old: utils.Ptr("Отчёт"), New: utils.Ptr("Годовой отчёт")},
+				}, event.Changes)
+		})).Return(nil).Once()
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), eventsRepo, nil, nil, nil)
+		_, err := service.UpdateTask(userID, taskID, "Годовой отчёт", nil, nil, nil, nil, nil, nil, nil, nil)
+
+		assert.NoError(t, err)
+		eventsRepo.AssertExpectations(t)
+	})
+
+	t.Run("Сохранение без изменений не записывается", func(t *testing.T) {
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(newTask(), nil)
+		mockRepo.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+		eventsRepo := new(MockTaskEventsRepository)
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), eventsRepo, nil, nil, nil)
+		_, err := service.UpdateTask(userID, taskID, "Отчёт", nil, nil, nil, nil, nil, nil, nil, nil)
+
+		assert.NoError(t, err)
+		eventsRepo.AssertNotCalled(t, "Add", mock.Anything)
+	})
+
+	t.Run("Просрочка записывается без автора", func(t *testing.T) {
+		overdue := newTask()
+		overdue.Status = enums.Overdue
+
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("MarkOverdue", mock.AnythingOfType("time.Time")).Return([]*models.Task{overdue}, nil)
+		eventsRepo := new(MockTaskEventsRepository)
+		eventsRepo.On("Add", mock.MatchedBy(func(event models.TaskEvent) bool {
+			return event.ActorID == nil && event.Type == enums.TaskStatusChanged &&
+				assert.ObjectsAreEqual(models.FieldChanges{
+					{Field: "status", Old: utils.Ptr("Active"), New: utils.Ptr("Overdue")},
+				}, event.Changes)
+		})).Return(nil).Once()
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), eventsRepo, nil, nil, nil)
+		service.UpdateTaskStatuses()
+
+		eventsRepo.AssertExpectations(t)
+	})
+
+	t.Run("Постраничная выдача", func(t *testing.T) {
+		events := []*models.TaskEvent{
+			models.NewTaskEvent(taskID, &userID, enums.TaskCreated, nil),
+			models.NewTaskEvent(taskID, &userID, enums.TaskUpdated, nil),
+		}
+
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(newTask(), nil)
+		eventsRepo := new(MockTaskEventsRepository)
+		eventsRepo.On("GetPage", taskID, (*models.TaskEventsCursor)(nil), 2).
+			Return(&models.TaskEventsPage{Items: events, HasMore: true}, nil)
+		eventsRepo.On("GetPage", taskID, mock.MatchedBy(func(after *models.TaskEventsCursor) bool {
+			return after != nil && after.ID == events[1].ID && after.CreatedAt.Equal(events[1].CreatedAt)
+		}), defaultPageSize).Return(&models.TaskEventsPage{}, nil)
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), eventsRepo, nil, nil, nil)
+		page, cursor, err := service.GetTaskHistory(userID, taskID, nil, utils.Ptr(2))
+		assert.NoError(t, err)
+		assert.Equal(t, events, page)
+		assert.NotNil(t, cursor)
+
+		page, cursor, err = service.GetTaskHistory(userID, taskID, cursor, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, page)
+		assert.Nil(t, cursor)
+		eventsRepo.AssertExpectations(t)
+	})
+
+	t.Run("Задача в корзине", func(t *testing.T) {
+		deleted := newTask()
+		deleted.DeletedAt = utils.Ptr(time.Now())
+		events := []*models.TaskEvent{models.NewTaskEvent(taskID, &userID, enums.TaskDeleted, nil)}
+
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(nil, nil)
+		mockRepo.On("GetDeleted", taskID).Return(deleted, nil)
+		eventsRepo := new(MockTaskEventsRepository)
+		eventsRepo.On("GetPage", taskID, (*models.TaskEventsCursor)(nil), defaultPageSize).
+			Return(&models.TaskEventsPage{Items: events}, nil)
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), eventsRepo, nil, nil, nil)
+		page, _, err := service.GetTaskHistory(userID, taskID, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, events, page)
+
+		_, _, err = service.GetTaskHistory(uuid.New(), taskID, nil, nil)
+		assert.Equal(t, 404, err.(errors.ApplicationError).StatusCode)
+	})
+
+	t.Run("Ошибки", func(t *testing.T) {
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(newTask(), nil)
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), new(MockTaskEventsRepository), nil, nil, nil)
+
+		_, _, err := service.GetTaskHistory(uuid.New(), taskID, nil, nil)
+		assert.Equal(t, 404, err.(errors.ApplicationError).StatusCode)
+
+		_, _, err = service.GetTaskHistory(userID, taskID, utils.Ptr("не-курсор"), nil)
+		assert.Equal(t, 400, err.(errors.ApplicationError).StatusCode)
+
+		_, _, err = service.GetTaskHistory(userID, taskID, nil, utils.Ptr(0))
+		assert.Equal(t, 400, err.(errors.ApplicationError).StatusCode)
+	})
+}
+
+// Тест корзины: просмотр, восстановление и окончательное удаление по истечении срока хранения
+func TestTrash(t *testing.T) {
+	userID := uuid.New()
+	projectID := uuid.New()
+	newDeletedTask := func(deletedAt time.Time) *models.Task {
+		return &models.Task{ID: uuid.New(), OwnerID: userID, Name: "Отчёт", Status: enums.Active,
+			Priority: enums.Medium, ProjectID: &projectID, DeletedAt: &deletedAt}
+	}
+
+	t.Run("Последние удалённые идут первыми", func(t *testing.T) {
+		older := newDeletedTask(time.Now().Add(-time.Hour))
+		newer := newDeletedTask(time.Now())
+
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetAll", &models.TasksFilter{OwnerID: &userID, Deleted: true}, (*appEnums.Sorting)(nil)).
+			Return([]*models.Task{older, newer}, nil)
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+		tasks, err := service.GetDeletedTasks(userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.Task{newer, older}, tasks)
+	})
+
+	t.Run("Восстановление в прежний проект", func(t *testing.T) {
+		task := newDeletedTask(time.Now())
+
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetDeletedForUpdate", task.ID, (*time.Time)(nil)).Return(task, nil)
+		mockRepo.On("Update", mock.MatchedBy(func(task models.Task) bool {
+			return task.DeletedAt == nil && *task.ProjectID == projectID
+		})).Return(nil).Once()
+		projectsRepo := new(MockProjectsRepository)
+		projectsRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID, OwnerID: userID}, nil)
+		eventsRepo := new(MockTaskEventsRepository)
+		eventsRepo.On("Add", mock.MatchedBy(func(event models.TaskEvent) bool {
+			return event.Type == enums.TaskRestored
+		})).Return(nil).Once()
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			projectsRepo, eventsRepo, nil, nil, nil)
+		restored, err := service.RestoreTask(userID, task.ID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, restored.DeletedAt)
+		mockRepo.AssertExpectations(t)
+		eventsRepo.AssertExpectations(t)
+	})
+
+	t.Run("Восстановление в Inbox, если проект удалён", func(t *testing.T) {
+		task := newDeletedTask(time.Now())
+		inbox := &models.Project{ID: uuid.New(), OwnerID: userID, IsInbox: true}
+
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetDeletedForUpdate", task.ID, (*time.Time)(nil)).Return(task, nil)
+		mockRepo.On("Update", mock.MatchedBy(func(task models.Task) bool {
+			return task.DeletedAt == nil && *task.ProjectID == inbox.ID
+		})).Return(nil).Once()
+		projectsRepo := new(MockProjectsRepository)
+		projectsRepo.On("GetByID", projectID).Return(nil, nil)
+		projectsRepo.On("GetInbox", userID).Return(inbox, nil)
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			projectsRepo, newTaskEventsRepositoryStub(), nil, nil, nil)
+		restored, err := service.RestoreTask(userID, task.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, inbox.ID, *restored.ProjectID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Задачи нет в корзине", func(t *testing.T) {
+		taskID := uuid.New()
+
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetDeletedForUpdate", taskID, (*time.Time)(nil)).Return(nil, nil)
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+		_, err := service.RestoreTask(userID, taskID)
+
+		assert.Equal(t, 404, err.(errors.ApplicationError).StatusCode)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Окончательное удаление вместе с чек-листом, метками и журналом", func(t *testing.T) {
+		before := time.Now().Add(-time.Hour)
+		task := newDeletedTask(before.Add(-time.Hour))
+
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetAll", &models.TasksFilter{Deleted: true, DeletedBefore: &before}, (*appEnums.Sorting)(nil)).
+			Return([]*models.Task{task}, nil)
+		mockRepo.On("GetDeletedForUpdate", task.ID, &before).Return(task, nil).Once()
+		mockRepo.On("DeleteByID", task.ID).Return(nil).Once()
+		itemsRepo := new(MockChecklistItemsRepository)
+		itemsRepo.On("DeleteByTaskID", task.ID).Return(nil).Once()
+		tagsRepo := new(MockTagsRepository)
+		tagsRepo.On("SetTaskTags", task.ID, []uuid.UUID(nil)).Return(nil).Once()
+		eventsRepo := new(MockTaskEventsRepository)
+		eventsRepo.On("DeleteByTaskID", task.ID).Return(nil).Once()
+
+		service := NewTasksService(mockRepo, itemsRepo, tagsRepo, new(MockProjectsRepository), eventsRepo,
+			nil, nil, nil)
+		service.PurgeDeletedTasks(before)
+
+		mockRepo.AssertExpectations(t)
+		itemsRepo.AssertExpectations(t)
+		tagsRepo.AssertExpectations(t)
+		eventsRepo.AssertExpectations(t)
+	})
+
+	t.Run("Задача, восстановленная после выборки, не удаляется", func(t *testing.T) {
+		before := time.Now().Add(-time.Hour)
+		task := newDeletedTask(before.Add(-time.Hour))
+
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetAll", &models.TasksFilter{Deleted: true, DeletedBefore: &before}, (*appEnums.Sorting)(nil)).
+			Return([]*models.Task{task}, nil)
+		mockRepo.On("GetDeletedForUpdate", task.ID, &before).Return(nil, nil).Once()
+		itemsRepo := new(MockChecklistItemsRepository)
+		tagsRepo := new(MockTagsRepository)
+		eventsRepo := new(MockTaskEventsRepository)
+
+		service := NewTasksService(mockRepo, itemsRepo, tagsRepo, new(MockProjectsRepository), eventsRepo,
+			nil, nil, nil)
+		service.PurgeDeletedTasks(before)
+
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "DeleteByID", mock.Anything)
+		itemsRepo.AssertNotCalled(t, "DeleteByTaskID", mock.Anything)
+		tagsRepo.AssertNotCalled(t, "SetTaskTags", mock.Anything, mock.Anything)
+		eventsRepo.AssertNotCalled(t, "DeleteByTaskID", mock.Anything)
+	})
+}
+
+// Тест проверки версии задачи при изменении
+func TestTaskVersion(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+	newTask := func(version int) *models.Task {
+		return &models.Task{ID: taskID, OwnerID: userID, Name: "Отчёт", Status: enums.Active, Priority: enums.Medium,
+			Version: version}
+	}
+
+	t.Run("Сохранение увеличивает версию", func(t *testing.T) {
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(newTask(3), nil)
+		mockRepo.On("Update", mock.MatchedBy(func(task models.Task) bool {
+			return task.Version == 3
+		})).Return(nil).Once()
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+		task, err := service.UpdateTask(userID, taskID, "Годовой отчёт", nil, nil, nil, nil, nil, nil, nil,
+			utils.Ptr(3))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, task.Version)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Клиент видел устаревшую версию", func(t *testing.T) {
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(newTask(3), nil)
+		tagsRepo := new(MockTagsRepository)
+		tagsRepo.On("GetByTaskIDs", mock.Anything).Return(map[uuid.UUID][]models.Tag{}, nil)
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), tagsRepo,
+			new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+
+		_, err := service.UpdateTask(userID, taskID, "Годовой отчёт", nil, nil, nil, nil, []string{}, nil, nil,
+			utils.Ptr(2))
+		var conflict errors.VersionConflictError
+		assert.ErrorAs(t, err, &conflict)
+		assert.Equal(t, 3, conflict.Current.Version)
+		assert.Equal(t, "Отчёт", conflict.Current.Name)
+
+		_, err = service.ToggleTaskStatus(userID, taskID, true, false, utils.Ptr(2))
+		assert.ErrorAs(t, err, &conflict)
+
+		err = service.DeleteTask(userID, taskID, utils.Ptr(2))
+		assert.ErrorAs(t, err, &conflict)
+
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+		tagsRepo.AssertNotCalled(t, "SetTaskTags", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Задачу изменили между чтением и сохранением", func(t *testing.T) {
+		changed := newTask(4)
+		changed.Name = "Чужое изменение"
+
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(newTask(3), nil).Once()
+		mockRepo.On("GetByID", taskID).Return(changed, nil).Once()
+		mockRepo.On("Update", mock.AnythingOfType("models.Task")).Return(interfaces.ErrTaskVersionConflict)
+		eventsRepo := new(MockTaskEventsRepository)
+
+		service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+			new(MockProjectsRepository), eventsRepo, nil, nil, nil)
+		_, err := service.UpdateTask(userID, taskID, "Годовой отчёт", nil, nil, nil, nil, nil, nil, nil, nil)
+
+		var conflict errors.VersionConflictError
+		assert.ErrorAs(t, err, &conflict)
+		assert.Equal(t, changed, conflict.Current)
+		eventsRepo.AssertNotCalled(t, "Add", mock.Anything)
+	})
+}
+
+// Тест частичного изменения задачи
+func TestPatchTask(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+	projectID := uuid.New()
+	workTag := models.Tag{ID: uuid.New(), OwnerID: userID, Name: "work"}
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour).UTC().Truncate(time.Second)
+	nextWeek := now.Add(7 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	newTask := func() *models.Task {
+		return &models.Task{ID: taskID, OwnerID: userID, Name: "Отчёт", Description: utils.Ptr("Квартальный"),
+			Status: enums.Active, Priority: enums.High, ProjectID: &projectID, Version: 3}
+	}
+	overdueTask := func() *models.Task {
+		task := newTask()
+		task.Status = enums.Overdue
+		task.Deadline = &yesterday
+		return task
+	}
+
+	tests := []struct {
+		name            string
+		task            func() *models.Task
+		patch           models.TaskPatch
+		expectedVersion *int
+		setTags         []uuid.UUID
+		wantErr         error
+		check           func(t *testing.T, task *models.Task)
+	}{
+		{
+			name:  "Меняется только название, остальные поля сохраняются",
+			task:  newTask,
+			patch: models.TaskPatch{Name: utils.Ptr("Годовой отчёт")},
+			check: func(t *testing.T, task *models.Task) {
+				assert.Equal(t, "Годовой отчёт", task.Name)
+				assert.Equal(t, utils.Ptr("Квартальный"), task.Description)
+				assert.Equal(t, enums.High, task.Priority)
+				assert.Equal(t, &projectID, task.ProjectID)
+				assert.Equal(t, []models.Tag{workTag}, task.Tags)
+			},
+		},
+		{
+			name: "null сбрасывает поля",
+			task: newTask,
+			patch: models.TaskPatch{
+				Description: utils.Ptr[*string](nil),
+				Priority:    utils.Ptr[*enums.Priority](nil),
+				ProjectID:   utils.Ptr[*uuid.UUID](nil),
+				Tags:        utils.Ptr([]string{}),
+			},
+			setTags: []uuid.UUID{},
+			check: func(t *testing.T, task *models.Task) {
+				assert.Equal(t, "Отчёт", task.Name)
+				assert.Nil(t, task.Description)
+				assert.Equal(t, enums.Medium, task.Priority)
+				assert.Nil(t, task.ProjectID)
+			},
+		},
+		{
+			name:  "Прошедший дедлайн не проверяется, если его не меняли",
+			task:  overdueTask,
+			patch: models.TaskPatch{Name: utils.Ptr("Годовой отчёт")},
+			check: func(t *testing.T, task *models.Task) {
+				assert.Equal(t, enums.Overdue, task.Status)
+				assert.Equal(t, &yesterday, task.Deadline)
+			},
+		},
+		{
+			name:  "Перенос дедлайна снимает просрочку",
+			task:  overdueTask,
+			patch: models.TaskPatch{Deadline: utils.Ptr(&nextWeek)},
+			check: func(t *testing.T, task *models.Task) {
+				assert.Equal(t, enums.Active, task.Status)
+				assert.Equal(t, &nextWeek, task.Deadline)
+			},
+		},
+		{
+			name:  "Новый дедлайн в прошлом",
+			task:  newTask,
+			patch: models.TaskPatch{Deadline: utils.Ptr(&yesterday)},
+			wantErr: errors.ApplicationError{
+				StatusCode: 400,
+				Code:       "ValidationFailed",
+				Errors:     map[string]string{"deadline": "Deadline must be in the future"},
+			},
+		},
+		{
+			name:  "Название нельзя сбросить",
+			task:  newTask,
+			patch: models.TaskPatch{Name: utils.Ptr("")},
+			wantErr: errors.ApplicationError{
+				StatusCode: 400,
+				Code:       "ValidationFailed",
+				Errors:     map[string]string{"name": "Name is required"},
+			},
+		},
+		{
+			name:            "Клиент видел устаревшую версию",
+			task:            newTask,
+			patch:           models.TaskPatch{Name: utils.Ptr("Годовой отчёт")},
+			expectedVersion: utils.Ptr(2),
+			wantErr:         errors.VersionConflictError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTasksRepository)
+			mockRepo.On("GetByID", taskID).Return(tt.task(), nil)
+			mockRepo.On("Update", mock.AnythingOfType("models.Task")).Return(nil).Maybe()
+			tagsRepo := new(MockTagsRepository)
+			tagsRepo.On("GetByTaskIDs", mock.Anything).
+				Return(map[uuid.UUID][]models.Tag{taskID: {workTag}}, nil).Maybe()
+			if tt.setTags != nil {
+				tagsRepo.On("SetTaskTags", taskID, tt.setTags).Return(nil).Once()
+			}
+
+			service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), tagsRepo,
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			task, err := service.PatchTask(userID, taskID, tt.patch, nil, tt.expectedVersion)
+
+			if tt.wantErr != nil {
+				if _, ok := tt.wantErr.(errors.VersionConflictError); ok {
+					var conflict errors.VersionConflictError
+					assert.ErrorAs(t, err, &conflict)
+				} else {
+					assert.Equal(t, tt.wantErr, err)
+				}
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, 4, task.Version)
+			tt.check(t, task)
+			tagsRepo.AssertExpectations(t)
+			if tt.setTags == nil {
+				tagsRepo.AssertNotCalled(t, "SetTaskTags", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// Тест пакетного выполнения действий
+func TestBulkTasks(t *testing.T) {
+	userID := uuid.New()
+	missingID := uuid.New()
+	operations := []models.TaskOperation{
+		{Type: enums.OperationCreate, Name: "Новая задача"},
+		{Type: enums.OperationDelete, TaskID: missingID},
+		{Type: enums.OperationToggle, TaskID: missingID, IsDone: true},
+	}
+	notFound := errors.ApplicationError{
+		StatusCode: 404,
+		Code:       "NotFound",
+		Errors:     map[string]string{"message": "Task not found"},
+	}
+	notApplied := errors.ApplicationError{
+		StatusCode: 424,
+		Code:       "NotApplied",
+		Errors:     map[string]string{"message": "Operation 1 failed, all operations were rolled back"},
+	}
+
+	tests := []struct {
+		name          string
+		atomic        bool
+		addErr        error
+		wantCreated   bool
+		wantErrs      []error
+		wantCommitted bool
+		wantErr       bool
+	}{
+		{
+			name:          "Неудавшиеся действия пропускаются",
+			wantCreated:   true,
+			wantErrs:      []error{nil, notFound, notFound},
+			wantCommitted: true,
+		},
+		{
+			name:     "В atomic-режиме ошибка отменяет все действия",
+			atomic:   true,
+			wantErrs: []error{notApplied, notFound, notApplied},
+		},
+		{
+			name:    "Ошибка хранилища прерывает пакет",
+			addErr:  fmt.Errorf("db is down"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTasksRepository)
+			mockRepo.On("Add", mock.AnythingOfType("models.Task")).Return(tt.addErr)
+			mockRepo.On("GetByID", missingID).Return(nil, nil)
+
+			service := NewTasksService(mockRepo, newChecklistItemsRepositoryStub(), newTagsRepositoryStub(),
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			results, committed, err := service.BulkTasks(userID, operations, tt.atomic, nil)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, results)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCommitted, committed)
+			assert.Len(t, results, len(operations))
+			for i, result := range results {
+				if tt.wantErrs[i] != nil {
+					assert.Equal(t, tt.wantErrs[i], result.Err, "operation %d", i)
+				} else {
+					assert.NoError(t, result.Err, "operation %d", i)
+				}
+			}
+			assert.Equal(t, tt.wantCreated, results[0].Task != nil)
+		})
+	}
+}
+
+// Тест импорта задач
+func TestImportTasks(t *testing.T) {
+	userID := uuid.New()
+	future := time.Now().Add(time.Hour).Truncate(time.Second)
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	tasks := []models.TaskImport{
+		{Name: "Будущая", Deadline: &future},
+		{Name: "Просроченная", Deadline: &past},
+		{Name: "Выполненная поздно", Deadline: &past, Done: true},
+		{Name: "abc"},
+		{Name: "Повторяющаяся", Deadline: &past, Recurrence: utils.Ptr("FREQ=DAILY")},
+	}
+	invalidName := errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "ValidationFailed",
+		Errors:     map[string]string{"name": "Name is required"},
+	}
+
+	tests := []struct {
+		name         string
+		tasks        []models.TaskImport
+		keepOverdue  bool
+		addErr       error
+		wantStatuses []enums.Status
+		wantErr      error
+	}{
+		{
+			name:         "Просроченные задачи пропускаются",
+			tasks:        tasks,
+			wantStatuses: []enums.Status{enums.Active, "", enums.Late, "", ""},
+		},
+		{
+			name:         "Просроченные задачи сохраняются",
+			tasks:        tasks,
+			keepOverdue:  true,
+			wantStatuses: []enums.Status{enums.Active, enums.Overdue, enums.Late, "", enums.Overdue},
+		},
+		{
+			name:    "Ошибка хранилища прерывает импорт",
+			tasks:   tasks,
+			addErr:  fmt.Errorf("db is down"),
+			wantErr: fmt.Errorf("db is down"),
+		},
+		{
+			name:  "Слишком много задач",
+			tasks: make([]models.TaskImport, maxImportSize+1),
+			wantErr: errors.ApplicationError{
+				StatusCode: 400,
+				Code:       "ValidationFailed",
+				Errors:     map[string]string{"tasks": "File must contain between 1 and 1000 tasks"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasksRepo := new(MockTasksRepository)
+			tasksRepo.On("Add", mock.AnythingOfType("models.Task")).Return(tt.addErr)
+			tasksRepo.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+			itemsRepo := newChecklistItemsRepositoryStub()
+			itemsRepo.On("GetByTaskID", mock.Anything).Return([]*models.ChecklistItem{}, nil).Maybe()
+
+			service := NewTasksService(tasksRepo, itemsRepo, newTagsRepositoryStub(),
+				new(MockProjectsRepository), newTaskEventsRepositoryStub(), nil, nil, nil)
+			results, err := service.ImportTasks(userID, tt.tasks, tt.keepOverdue, nil)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.Nil(t, results)
+				return
+			}
+
+			assert.NoError(t, err)
+			if !assert.Len(t, results, len(tt.tasks)) {
+				return
+			}
+			for i, result := range results {
+				switch {
+				case i == 3:
+					assert.Equal(t, invalidName, result.Err)
+				case tt.wantStatuses[i] == "":
+					assert.True(t, result.Skipped, "task %d", i)
+					assert.Nil(t, result.Task, "task %d", i)
+				default:
+					assert.NoError(t, result.Err, "task %d", i)
+					assert.Equal(t, tt.wantStatuses[i], result.Task.Status, "task %d", i)
+					assert.Equal(t, userID, result.Task.OwnerID)
+				}
+			}
+			if tt.keepOverdue {
+				// Серия просроченной задачи продолжается
+				assert.NotNil(t, results[4].Task.NextOccurrenceID)
+			}
+		})
+	}
+}
+
+// UnitOfWork поверх тех же моков: считает транзакции и запоминает, чем они закончились
+type unitOfWorkStub struct {
+	repos   interfaces.Repositories
+	results []error
+}
+
+func (uow *unitOfWorkStub) WithinTx(fn func(repos interfaces.Repositories) error) error {
+	repos := uow.repos
+	repos.UnitOfWork = uow
+	err := fn(repos)
+	uow.results = append(uow.results, err)
+	return err
+}
+
+// Тест выполнения изменений задачи в транзакции
+func TestTasksServiceTransactions(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+	deadline := time.Now().Add(time.Hour)
+
+	newService := func(mockRepo *MockTasksRepository, tracker *MockDeadlineTracker) (appInterfaces.TasksService,
+		*unitOfWorkStub) {
+		uow := &unitOfWorkStub{repos: interfaces.Repositories{
+			Tasks:          mockRepo,
+			ChecklistItems: newChecklistItemsRepositoryStub(),
+			Tags:           newTagsRepositoryStub(),
+			Projects:       new(MockProjectsRepository),
+			TaskEvents:     newTaskEventsRepositoryStub(),
+		}}
+		return NewTasksService(mockRepo, uow.repos.ChecklistItems, uow.repos.Tags, uow.repos.Projects,
+			uow.repos.TaskEvents, uow, tracker, nil), uow
+	}
+
+	t.Run("Планировщик узнаёт о дедлайне после фиксации", func(t *testing.T) {
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("Add", mock.AnythingOfType("models.Task")).Return(nil)
+		tracker := new(MockDeadlineTracker)
+		service, uow := newService(mockRepo, tracker)
+		tracker.On("Track", mock.Anything, deadline).Run(func(mock.Arguments) {
+			assert.Equal(t, []error{nil}, uow.results, "Track вызван до фиксации транзакции")
+		}).Once()
+
+		_, err := service.CreateTask(userID, "Отчёт", nil, &deadline, nil, nil, nil, nil, nil)
+
+		assert.NoError(t, err)
+		tracker.AssertExpectations(t)
+	})
+
+	t.Run("Каждое изменение выполняется в своей транзакции", func(t *testing.T) {
+		task := &models.Task{ID: taskID, OwnerID: userID, Name: "Отчёт", Status: enums.Active,
+			Priority: enums.Medium, Deadline: &deadline, Version: 1}
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(task, nil)
+		mockRepo.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+		tracker := new(MockDeadlineTracker)
+		tracker.On("Untrack", taskID).Return()
+		service, uow := newService(mockRepo, tracker)
+
+		_, err := service.ToggleTaskStatus(userID, taskID, true, false, nil)
+		assert.NoError(t, err)
+		err = service.DeleteTask(userID, taskID, nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []error{nil, nil}, uow.results)
+		tracker.AssertNumberOfCalls(t, "Untrack", 2)
+	})
+
+	t.Run("При откате планировщик не меняется", func(t *testing.T) {
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(nil, nil)
+		tracker := new(MockDeadlineTracker)
+		service, uow := newService(mockRepo, tracker)
+
+		err := service.DeleteTask(userID, taskID, nil)
+
+		assert.Error(t, err)
+		assert.Len(t, uow.results, 1)
+		assert.Error(t, uow.results[0])
+		tracker.AssertNotCalled(t, "Untrack", mock.Anything)
+	})
+}
+
+// Запоминает опубликованные изменения задач
+type taskChangesRecorder struct {
+	changes []models.TaskChange
+}
+
+func (r *taskChangesRecorder) Publish(change models.TaskChange) {
+	r.changes = append(r.changes, change)
+}
+
+func changeTypes(changes []models.TaskChange) []enums.TaskChangeType {
+	types := make([]enums.TaskChangeType, len(changes))
+	for i, change := range changes {
+		types[i] = change.Type
+	}
+	return types
+}
+
+// Тест публикации изменений задач для клиентов
+func TestTaskChangePublishing(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	newService := func(mockRepo *MockTasksRepository) (appInterfaces.TasksService, *taskChangesRecorder) {
+		uow := &unitOfWorkStub{repos: interfaces.Repositories{
+			Tasks:          mockRepo,
+			ChecklistItems: newChecklistItemsRepositoryStub(),
+			Tags:           newTagsRepositoryStub(),
+			Projects:       new(MockProjectsRepository),
+			TaskEvents:     newTaskEventsRepositoryStub(),
+		}}
+		recorder := &taskChangesRecorder{}
+		return NewTasksService(mockRepo, uow.repos.ChecklistItems, uow.repos.Tags, uow.repos.Projects,
+			uow.repos.TaskEvents, uow, nil, recorder), recorder
+	}
+
+	t.Run("Создание, выполнение и удаление", func(t *testing.T) {
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("Add", mock.AnythingOfType("models.Task")).Return(nil)
+		mockRepo.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: userID, Name: "Отчёт",
+			Status: enums.Active, Priority: enums.Medium, Version: 1}, nil)
+		mockRepo.On("Update", mock.AnythingOfType("models.Task")).Return(nil)
+		service, recorder := newService(mockRepo)
+
+		created, err := service.CreateTask(userID, "Отчёт", nil, nil, nil, nil, nil, nil, nil)
+		assert.NoError(t, err)
+		_, err = service.ToggleTaskStatus(userID, taskID, true, false, nil)
+		assert.NoError(t, err)
+		err = service.DeleteTask(userID, taskID, nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []enums.TaskChangeType{enums.TaskChangeCreated, enums.TaskChangeToggled,
+			enums.TaskChangeDeleted}, changeTypes(recorder.changes))
+		assert.Equal(t, created.ID, recorder.changes[0].Task.ID)
+		assert.Equal(t, userID, recorder.changes[0].Task.OwnerID)
+		assert.Equal(t, enums.Completed, recorder.changes[1].Task.Status)
+		assert.NotNil(t, recorder.changes[2].Task.DeletedAt)
+	})
+
+	t.Run("Изменение, откаченное транзакцией, не публикуется", func(t *testing.T) {
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("GetByID", taskID).Return(&models.Task{ID: taskID, OwnerID: userID, Name: "Отчёт",
+			Status: enums.Active, Priority: enums.Medium, Version: 1}, nil)
+		mockRepo.On("Update", mock.AnythingOfType("models.Task")).Return(fmt.Errorf("database is locked"))
+		service, recorder := newService(mockRepo)
+
+		_, err := service.UpdateTask(userID, taskID, "Годовой отчёт", nil, nil, nil, nil, nil, nil, nil, nil)
+
+		assert.Error(t, err)
+		assert.Empty(t, recorder.changes)
+	})
+
+	t.Run("Просрочка задачи планировщиком", func(t *testing.T) {
+		overdueTask := &models.Task{ID: taskID, OwnerID: userID, Status: enums.Overdue}
+		mockRepo := new(MockTasksRepository)
+		mockRepo.On("MarkOverdue", mock.AnythingOfType("time.Time")).Return([]*models.Task{overdueTask}, nil)
+		service, recorder := newService(mockRepo)
+
+		service.UpdateTaskStatuses()
+
+		assert.Equal(t, []enums.TaskChangeType{enums.TaskChangeOverdue}, changeTypes(recorder.changes))
+		assert.Equal(t, taskID, recorder.changes[0].Task.ID)
+	})
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	appInterfaces "HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/application/validators"
+	domainInterfaces "HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"time"
+)
+
+type UsersServiceImpl struct {
+	usersRepository domainInterfaces.UsersRepository
+}
+
+func NewUsersService(usersRepository domainInterfaces.UsersRepository) appInterfaces.UsersService {
+	return &UsersServiceImpl{usersRepository: usersRepository}
+}
+
+func (service *UsersServiceImpl) GetProfile(userID uuid.UUID) (*models.User, error) {
+	user, err := service.usersRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, errors.ApplicationError{
+			StatusCode: 404,
+			Code:       "NotFound",
+			Errors:     map[string]string{"message": "User not found"},
+		}
+	}
+
+	return user, nil
+}
+
+// timeZone == nil сбрасывает часовой пояс профиля на UTC
+func (service *UsersServiceImpl) UpdateProfile(userID uuid.UUID, timeZone *string) (*models.User, error) {
+	if err := validators.ValidateProfile(timeZone); err != nil {
+		return nil, err
+	}
+
+	user, err := service.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.TimeZone = timeZone
+
+	if err := service.usersRepository.Update(*user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Часовой пояс из профиля; если он не задан или больше не поддерживается, используется UTC
+func (service *UsersServiceImpl) GetLocation(userID uuid.UUID) (*time.Location, error) {
+	user, err := service.usersRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return time.UTC, nil
+	}
+
+	location, err := models.LoadTimeZone(user.TimeZone)
+	if err != nil {
+		return time.UTC, nil
+	}
+
+	return location, nil
+}
+
+func (service *UsersServiceImpl) CreateCalendarFeed(userID uuid.UUID) (string, error) {
+	user, err := service.GetProfile(userID)
+	if err != nil {
+		return "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	hash := hashCalendarToken(token)
+	user.CalendarTokenHash = &hash
+	if err := service.usersRepository.Update(*user); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (service *UsersServiceImpl) DeleteCalendarFeed(userID uuid.UUID) error {
+	user, err := service.GetProfile(userID)
+	if err != nil {
+		return err
+	}
+
+	user.CalendarTokenHash = nil
+
+	return service.usersRepository.Update(*user)
+}
+
+// Неизвестный токен неотличим от несуществующего адреса
+func (service *UsersServiceImpl) AuthenticateCalendarFeed(token string) (uuid.UUID, error) {
+	user, err := service.usersRepository.GetByCalendarTokenHash(hashCalendarToken(token))
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if user == nil {
+		return uuid.Nil, errors.ApplicationError{
+			StatusCode: 404,
+			Code:       "NotFound",
+			Errors:     map[string]string{"message": "Calendar feed not found"},
+		}
+	}
+
+	return user.ID, nil
+}
+
+func hashCalendarToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+// Тест изменения часового пояса в профиле
+func TestUpdateProfile(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		timeZone   *string
+		wantStatus int
+	}{
+		{
+			name:     "Часовой пояс IANA",
+			timeZone: utils.Ptr("Asia/Novosibirsk"),
+		},
+		{
+			name:     "Сброс на UTC",
+			timeZone: nil,
+		},
+		{
+			name:       "Неизвестный часовой пояс",
+			timeZone:   utils.Ptr("Mars/Olympus"),
+			wantStatus: 400,
+		},
+		{
+			name:       "Часовой пояс сервера",
+			timeZone:   utils.Ptr("Local"),
+			wantStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockUsersRepository)
+			if tt.wantStatus == 0 {
+				repo.On("GetByID", userID).Return(&models.User{ID: userID, TimeZone: utils.Ptr("Europe/Moscow")}, nil)
+				repo.On("Update", mock.MatchedBy(func(user models.User) bool {
+					return user.ID == userID && assert.ObjectsAreEqual(tt.timeZone, user.TimeZone)
+				})).Return(nil)
+			}
+
+			service := NewUsersService(repo)
+			user, err := service.UpdateProfile(userID, tt.timeZone)
+
+			if tt.wantStatus != 0 {
+				assert.Nil(t, user)
+				var appErr errors.ApplicationError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.timeZone, user.TimeZone)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест определения часового пояса пользователя
+func TestGetLocation(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name string
+		user *models.User
+		want string
+	}{
+		{
+			name: "Часовой пояс из профиля",
+			user: &models.User{ID: userID, TimeZone: utils.Ptr("Asia/Novosibirsk")},
+			want: "Asia/Novosibirsk",
+		},
+		{
+			name: "Часовой пояс не задан",
+			user: &models.User{ID: userID},
+			want: "UTC",
+		},
+		{
+			name: "Неподдерживаемый часовой пояс",
+			user: &models.User{ID: userID, TimeZone: utils.Ptr("Mars/Olympus")},
+			want: "UTC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockUsersRepository)
+			repo.On("GetByID", userID).Return(tt.user, nil)
+
+			location, err := NewUsersService(repo).GetLocation(userID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, location.String())
+		})
+	}
+
+	t.Run("Ошибка репозитория", func(t *testing.T) {
+		repo := new(MockUsersRepository)
+		repo.On("GetByID", userID).Return(nil, assert.AnError)
+
+		location, err := NewUsersService(repo).GetLocation(userID)
+
+		assert.Nil(t, location)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+// Тест выдачи токена ленты календаря и входа по нему
+func TestCalendarFeed(t *testing.T) {
+	user := &models.User{ID: uuid.New(), CalendarTokenHash: utils.Ptr("previous")}
+
+	repo := new(MockUsersRepository)
+	repo.On("GetByID", user.ID).Return(user, nil)
+	var stored models.User
+	repo.On("Update", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(models.User)
+	}).Return(nil)
+
+	service := NewUsersService(repo)
+	token, err := service.CreateCalendarFeed(user.ID)
+
+	assert.NoError(t, err)
+	assert.Len(t, token, 43)
+	// Хранится только хеш токена, прежний токен заменяется
+	if assert.NotNil(t, stored.CalendarTokenHash) {
+		assert.NotEqual(t, token, *stored.CalendarTokenHash)
+		assert.NotEqual(t, "previous", *stored.CalendarTokenHash)
+	}
+
+	t.Run("Вход по токену", func(t *testing.T) {
+		repo.On("GetByCalendarTokenHash", *stored.CalendarTokenHash).Return(&stored, nil)
+
+		userID, err := service.AuthenticateCalendarFeed(token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, userID)
+	})
+
+	t.Run("Неизвестный токен", func(t *testing.T) {
+		repo.On("GetByCalendarTokenHash", mock.Anything).Return(nil, nil)
+
+		_, err := service.AuthenticateCalendarFeed("unknown")
+
+		var appErr errors.ApplicationError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, 404, appErr.StatusCode)
+	})
+
+	t.Run("Отключение ленты", func(t *testing.T) {
+		assert.NoError(t, service.DeleteCalendarFeed(user.ID))
+		assert.Nil(t, stored.CalendarTokenHash)
+	})
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	appInterfaces "HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/application/validators"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	domainInterfaces "HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	// Сколько отправок обрабатывается за один проход
+	webhookDeliveryBatchSize = 100
+	maxWebhookRetryBackoff   = 6 * time.Hour
+)
+
+type WebhooksServiceImpl struct {
+	webhooksRepository          domainInterfaces.WebhooksRepository
+	webhookDeliveriesRepository domainInterfaces.WebhookDeliveriesRepository
+	sender                      appInterfaces.WebhookSender
+	maxAttempts                 int
+	retryBackoff                time.Duration
+	allowPrivateNetworks        bool
+}
+
+// После неудачной попытки n следующая делается через retryBackoff·2^(n-1), но не позже чем через
+// maxWebhookRetryBackoff; после maxAttempts попыток отправка считается неудавшейся.
+// Без allowPrivateNetworks подписку нельзя направить на loopback и адреса внутренней сети
+func NewWebhooksService(webhooksRepository domainInterfaces.WebhooksRepository,
+	webhookDeliveriesRepository domainInterfaces.WebhookDeliveriesRepository, sender appInterfaces.WebhookSender,
+	maxAttempts int, retryBackoff time.Duration, allowPrivateNetworks bool) appInterfaces.WebhooksService {
+	return &WebhooksServiceImpl{
+		webhooksRepository:          webhooksRepository,
+		webhookDeliveriesRepository: webhookDeliveriesRepository,
+		sender:                      sender,
+		maxAttempts:                 maxAttempts,
+		retryBackoff:                retryBackoff,
+		allowPrivateNetworks:        allowPrivateNetworks,
+	}
+}
+
+func (service *WebhooksServiceImpl) GetWebhooks(userID uuid.UUID) ([]*models.Webhook, error) {
+	return service.webhooksRepository.GetByOwnerID(userID)
+}
+
+func (service *WebhooksServiceImpl) GetWebhook(userID uuid.UUID, webhookID uuid.UUID) (*models.Webhook, error) {
+	return service.getOwnedWebhook(userID, webhookID)
+}
+
+func (service *WebhooksServiceImpl) CreateWebhook(userID uuid.UUID, url string, secret *string,
+	eventTypes []enums.TaskChangeType, priorities []enums.Priority) (*models.Webhook, error) {
+	if err := validators.ValidateWebhook(url, secret, eventTypes, priorities,
+		service.allowPrivateNetworks); err != nil {
+		return nil, err
+	}
+
+	if secret == nil {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = &generated
+	}
+
+	webhook := models.NewWebhook(userID, url, *secret, eventTypes, priorities)
+	if err := service.webhooksRepository.Add(*webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (service *WebhooksServiceImpl) UpdateWebhook(userID uuid.UUID, webhookID uuid.UUID, url string, secret *string,
+	eventTypes []enums.TaskChangeType, priorities []enums.Priority) (*models.Webhook, error) {
+	if err := validators.ValidateWebhook(url, secret, eventTypes, priorities,
+		service.allowPrivateNetworks); err != nil {
+		return nil, err
+	}
+
+	webhook, err := service.getOwnedWebhook(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.URL = url
+	if secret != nil {
+		webhook.Secret = *secret
+	}
+	webhook.EventTypes = eventTypes
+	webhook.Priorities = priorities
+
+	if err := service.webhooksRepository.Update(*webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// Неотправленные и неудавшиеся отправки подписки удаляются вместе с ней
+func (service *WebhooksServiceImpl) DeleteWebhook(userID uuid.UUID, webhookID uuid.UUID) error {
+	if _, err := service.getOwnedWebhook(userID, webhookID); err != nil {
+		return err
+	}
+
+	if err := service.webhookDeliveriesRepository.DeleteByWebhookID(webhookID); err != nil {
+		return err
+	}
+
+	return service.webhooksRepository.DeleteByID(webhookID)
+}
+
+func (service *WebhooksServiceImpl) GetDeadLetters(userID uuid.UUID) ([]*models.WebhookDelivery, error) {
+	webhooks, err := service.webhooksRepository.GetByOwnerID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookIDs := make([]uuid.UUID, len(webhooks))
+	for i, webhook := range webhooks {
+		webhookIDs[i] = webhook.ID
+	}
+
+	return service.webhookDeliveriesRepository.GetByWebhookIDs(webhookIDs, enums.DeliveryFailed)
+}
+
+func (service *WebhooksServiceImpl) RetryDelivery(userID uuid.UUID,
+	deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	delivery, err := service.webhookDeliveriesRepository.GetByID(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery != nil {
+		webhook, err := service.webhooksRepository.GetByID(delivery.WebhookID)
+		if err != nil {
+			return nil, err
+		}
+		if webhook == nil || webhook.OwnerID != userID {
+			delivery = nil
+		}
+	}
+
+	if delivery == nil || delivery.Status != enums.DeliveryFailed {
+		return nil, errors.ApplicationError{
+			StatusCode: 404,
+			Code:       "NotFound",
+			Errors:     map[string]string{"message": "Failed delivery not found"},
+		}
+	}
+
+	delivery.Status = enums.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+
+	if err := service.webhookDeliveriesRepository.Update(*delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Изменение ставится в очередь каждой подходящей подписке владельца задачи; отправляет их DeliverPending
+func (service *WebhooksServiceImpl) Publish(change models.TaskChange) {
+	webhooks, err := service.webhooksRepository.GetByOwnerID(change.Task.OwnerID)
+	if err != nil {
+		fmt.Println("Failed to get webhooks of user", change.Task.OwnerID, err.Error())
+		return
+	}
+
+	var payload []byte
+	for _, webhook := range webhooks {
+		if !webhook.Matches(change) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = json.Marshal(newWebhookPayload(change)); err != nil {
+				fmt.Println("Failed to encode webhook payload of task", change.Task.ID, err.Error())
+				return
+			}
+		}
+
+		delivery := models.NewWebhookDelivery(webhook.ID, change.Type, string(payload))
+		if err := service.webhookDeliveriesRepository.Add(*delivery); err != nil {
+			fmt.Println("Failed to enqueue webhook delivery", webhook.ID, err.Error())
+		}
+	}
+}
+
+// Отправки, время попытки которых наступило к now, отправляются по очереди. Отправка подписки,
+// удалённой за это время, пропускается
+func (service *WebhooksServiceImpl) DeliverPending(now time.Time) {
+	deliveries, err := service.webhookDeliveriesRepository.GetDue(now, webhookDeliveryBatchSize)
+	if err != nil {
+		fmt.Println("Failed to get pending webhook deliveries", err.Error())
+		return
+	}
+
+	for _, delivery := range deliveries {
+		webhook, err := service.webhooksRepository.GetByID(delivery.WebhookID)
+		if err != nil {
+			fmt.Println("Failed to get webhook", delivery.WebhookID, err.Error())
+			continue
+		}
+		if webhook == nil {
+			continue
+		}
+
+		delivery.Attempts++
+		if err := service.sender.Send(webhook, delivery); err != nil {
+			delivery.LastError = utils.Ptr(err.Error())
+			if delivery.Attempts >= service.maxAttempts {
+				delivery.Status = enums.DeliveryFailed
+			} else {
+				delivery.NextAttemptAt = now.Add(service.backoff(delivery.Attempts))
+			}
+		} else {
+			delivery.Status = enums.DeliveryDelivered
+			delivery.DeliveredAt = utils.Ptr(now)
+			delivery.LastError = nil
+		}
+
+		if err := service.webhookDeliveriesRepository.Update(*delivery); err != nil {
+			fmt.Println("Failed to save webhook delivery", delivery.ID, err.Error())
+		}
+	}
+}
+
+func (service *WebhooksServiceImpl) backoff(attempts int) time.Duration {
+	backoff := service.retryBackoff
+	for i := 1; i < attempts && backoff < maxWebhookRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxWebhookRetryBackoff)
+}
+
+// Подписки других пользователей неотличимы от несуществующих
+func (service *WebhooksServiceImpl) getOwnedWebhook(userID uuid.UUID, webhookID uuid.UUID) (*models.Webhook, error) {
+	webhook, err := service.webhooksRepository.GetByID(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook == nil || webhook.OwnerID != userID {
+		return nil, errors.ApplicationError{
+			StatusCode: 404,
+			Code:       "NotFound",
+			Errors:     map[string]string{"message": "Webhook not found"},
+		}
+	}
+
+	return webhook, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// Тело запроса подписке: вид изменения и задача после него
+type webhookPayload struct {
+	Event      enums.TaskChangeType `json:"event"`
+	OccurredAt time.Time            `json:"occurredAt"`
+	Task       webhookTask          `json:"task"`
+}
+
+type webhookTask struct {
+	ID          uuid.UUID      `json:"id"`
+	Name        string         `json:"name"`
+	Description *string        `json:"description"`
+	Deadline    *time.Time     `json:"deadline"`
+	Status      enums.Status   `json:"status"`
+	Priority    enums.Priority `json:"priority"`
+	ProjectID   *uuid.UUID     `json:"projectId"`
+	Tags        []string       `json:"tags"`
+	Version     int            `json:"version"`
+	DeletedAt   *time.Time     `json:"deletedAt"`
+}
+
+func newWebhookPayload(change models.TaskChange) webhookPayload {
+	return webhookPayload{
+		Event:      change.Type,
+		OccurredAt: change.OccurredAt,
+		Task: webhookTask{
+			ID:          change.Task.ID,
+			Name:        change.Task.Name,
+			Description: change.Task.Description,
+			Deadline:    change.Task.Deadline,
+			Status:      change.Task.Status,
+			Priority:    change.Task.Priority,
+			ProjectID:   change.Task.ProjectID,
+			Tags:        tagNames(change.Task.Tags),
+			Version:     change.Task.Version,
+			DeletedAt:   change.Task.DeletedAt,
+		},
+	}
+}
@@ -0,0 +1,413 @@
+package services
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	defaultErrors "errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+// Мок репозитория подписок
+type MockWebhooksRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhooksRepository) Add(webhook models.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhooksRepository) GetByID(id uuid.UUID) (*models.Webhook, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhooksRepository) GetByOwnerID(ownerID uuid.UUID) ([]*models.Webhook, error) {
+	args := m.Called(ownerID)
+	return args.Get(0).([]*models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhooksRepository) Update(webhook models.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhooksRepository) DeleteByID(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// Мок репозитория отправок
+type MockWebhookDeliveriesRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookDeliveriesRepository) Add(delivery models.WebhookDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookDeliveriesRepository) GetByID(id uuid.UUID) (*models.WebhookDelivery, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveriesRepository) GetDue(now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]*models.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveriesRepository) GetByWebhookIDs(webhookIDs []uuid.UUID,
+	status enums.WebhookDeliveryStatus) ([]*models.WebhookDelivery, error) {
+	args := m.Called(webhookIDs, status)
+	return args.Get(0).([]*models.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveriesRepository) Update(delivery models.WebhookDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookDeliveriesRepository) DeleteByWebhookID(webhookID uuid.UUID) error {
+	args := m.Called(webhookID)
+	return args.Error(0)
+}
+
+// Мок отправителя запросов подпискам
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) Send(webhook *models.Webhook, delivery *models.WebhookDelivery) error {
+	args := m.Called(webhook, delivery)
+	return args.Error(0)
+}
+
+// Тест проверки подписки при создании
+func TestCreateWebhook(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		url                  string
+		secret               *string
+		eventTypes           []enums.TaskChangeType
+		allowPrivateNetworks bool
+		wantErrKey           string
+	}{
+		{
+			name: "Ключ подписи генерируется",
+			url:  "https://example.com/hook",
+		},
+		{
+			name:       "Свой ключ подписи",
+			url:        "https://example.com/hook",
+			secret:     utils.Ptr("0123456789abcdef"),
+			eventTypes: []enums.TaskChangeType{enums.TaskChangeCreated, enums.TaskChangeOverdue},
+		},
+		{
+			name:       "localhost",
+			url:        "http://localhost:8080/hook",
+			wantErrKey: "url",
+		},
+		{
+			name:       "Loopback-адрес",
+			url:        "http://127.0.0.1:8080/hook",
+			wantErrKey: "url",
+		},
+		{
+			name:       "Loopback-адрес IPv6",
+			url:        "http://[::1]/hook",
+			wantErrKey: "url",
+		},
+		{
+			name:       "Loopback-адрес в виде IPv4-mapped IPv6",
+			url:        "http://[::ffff:127.0.0.1]/hook",
+			wantErrKey: "url",
+		},
+		{
+			name:       "Частный адрес",
+			url:        "http://10.0.0.5/hook",
+			wantErrKey: "url",
+		},
+		{
+			name:       "Частный адрес IPv6",
+			url:        "http://[fd00::1]/hook",
+			wantErrKey: "url",
+		},
+		{
+			name:       "Адрес облачных метаданных",
+			url:        "http://169.254.169.254/latest/meta-data",
+			wantErrKey: "url",
+		},
+		{
+			name:       "Link-local адрес IPv6 с зоной",
+			url:        "http://[fe80::1%25eth0]/hook",
+			wantErrKey: "url",
+		},
+		{
+			name:       "Неуказанный адрес",
+			url:        "http://0.0.0.0:8080/hook",
+			wantErrKey: "url",
+		},
+		{
+			name:                 "Внутренняя сеть разрешена настройкой",
+			url:                  "http://localhost:8080/hook",
+			allowPrivateNetworks: true,
+		},
+		{
+			name:       "Относительный URL",
+			url:        "/hook",
+			wantErrKey: "url",
+		},
+		{
+			name:       "Не HTTP",
+			url:        "ftp://example.com/hook",
+			wantErrKey: "url",
+		},
+		{
+			name:       "Короткий ключ подписи",
+			url:        "https://example.com/hook",
+			secret:     utils.Ptr("secret"),
+			wantErrKey: "secret",
+		},
+		{
+			name:       "Неизвестный вид изменения",
+			url:        "https://example.com/hook",
+			eventTypes: []enums.TaskChangeType{"renamed"},
+			wantErrKey: "eventTypes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhooksRepo := new(MockWebhooksRepository)
+			if tt.wantErrKey == "" {
+				webhooksRepo.On("Add", mock.Anything).Return(nil)
+			}
+
+			service := &WebhooksServiceImpl{webhooksRepository: webhooksRepo,
+				allowPrivateNetworks: tt.allowPrivateNetworks}
+			webhook, err := service.CreateWebhook(userID, tt.url, tt.secret, tt.eventTypes, nil)
+
+			if tt.wantErrKey != "" {
+				assert.Nil(t, webhook)
+				var appErr errors.ApplicationError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, 400, appErr.StatusCode)
+				assert.Contains(t, appErr.Errors, tt.wantErrKey)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, userID, webhook.OwnerID)
+				if tt.secret != nil {
+					assert.Equal(t, *tt.secret, webhook.Secret)
+				} else {
+					assert.Len(t, webhook.Secret, 64)
+				}
+			}
+			webhooksRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест постановки изменения в очередь подходящих подписок
+func TestWebhooksPublish(t *testing.T) {
+	userID := uuid.New()
+	all := models.NewWebhook(userID, "https://example.com/all", "0123456789abcdef", nil, nil)
+	criticalOverdue := models.NewWebhook(userID, "https://example.com/critical", "0123456789abcdef",
+		[]enums.TaskChangeType{enums.TaskChangeOverdue}, []enums.Priority{enums.Critical})
+	created := models.NewWebhook(userID, "https://example.com/created", "0123456789abcdef",
+		[]enums.TaskChangeType{enums.TaskChangeCreated}, nil)
+
+	task := models.NewTask("Отчёт", nil, nil, nil, utils.Ptr(enums.Critical))
+	task.OwnerID = userID
+	change := models.TaskChange{Type: enums.TaskChangeOverdue, Task: *task, OccurredAt: time.Now()}
+
+	webhooksRepo := new(MockWebhooksRepository)
+	webhooksRepo.On("GetByOwnerID", userID).Return([]*models.Webhook{all, criticalOverdue, created}, nil)
+	deliveriesRepo := new(MockWebhookDeliveriesRepository)
+	var enqueued []models.WebhookDelivery
+	deliveriesRepo.On("Add", mock.Anything).Run(func(args mock.Arguments) {
+		enqueued = append(enqueued, args.Get(0).(models.WebhookDelivery))
+	}).Return(nil)
+
+	service := &WebhooksServiceImpl{webhooksRepository: webhooksRepo, webhookDeliveriesRepository: deliveriesRepo}
+	service.Publish(change)
+
+	assert.Len(t, enqueued, 2)
+	assert.Equal(t, []uuid.UUID{all.ID, criticalOverdue.ID}, []uuid.UUID{enqueued[0].WebhookID, enqueued[1].WebhookID})
+	for _, delivery := range enqueued {
+		assert.Equal(t, enums.DeliveryPending, delivery.Status)
+		assert.Equal(t, enums.TaskChangeOverdue, delivery.EventType)
+
+		var payload map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(delivery.Payload), &payload))
+		assert.Equal(t, "overdue", payload["event"])
+		assert.Equal(t, task.ID.String(), payload["task"].(map[string]any)["id"])
+		assert.Equal(t, "Critical", payload["task"].(map[string]any)["priority"])
+	}
+}
+
+// Тест отправки очереди: успех, повтор с растущей паузой и исчерпание попыток
+func TestDeliverPending(t *testing.T) {
+	now := time.Now()
+	webhook := models.NewWebhook(uuid.New(), "https://example.com/hook", "0123456789abcdef", nil, nil)
+
+	tests := []struct {
+		name            string
+		attempts        int
+		sendErr         error
+		wantStatus      enums.WebhookDeliveryStatus
+		wantNextAttempt time.Time
+	}{
+		{
+			name:       "Получатель принял запрос",
+			wantStatus: enums.DeliveryDelivered,
+		},
+		{
+			name:            "Первая неудача",
+			sendErr:         defaultErrors.New("webhook responded with status 500"),
+			wantStatus:      enums.DeliveryPending,
+			wantNextAttempt: now.Add(time.Minute),
+		},
+		{
+			name:            "Третья неудача",
+			attempts:        2,
+			sendErr:         defaultErrors.New("webhook responded with status 500"),
+			wantStatus:      enums.DeliveryPending,
+			wantNextAttempt: now.Add(4 * time.Minute),
+		},
+		{
+			name:            "Пауза не длиннее шести часов",
+			attempts:        10,
+			sendErr:         defaultErrors.New("connection refused"),
+			wantStatus:      enums.DeliveryPending,
+			wantNextAttempt: now.Add(6 * time.Hour),
+		},
+		{
+			name:       "Последняя попытка",
+			attempts:   19,
+			sendErr:    defaultErrors.New("connection refused"),
+			wantStatus: enums.DeliveryFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery := models.NewWebhookDelivery(webhook.ID, enums.TaskChangeCreated, `{}`)
+			delivery.Attempts = tt.attempts
+			nextAttemptAt := delivery.NextAttemptAt
+
+			webhooksRepo := new(MockWebhooksRepository)
+			webhooksRepo.On("GetByID", webhook.ID).Return(webhook, nil)
+			deliveriesRepo := new(MockWebhookDeliveriesRepository)
+			deliveriesRepo.On("GetDue", now, webhookDeliveryBatchSize).
+				Return([]*models.WebhookDelivery{delivery}, nil)
+			var saved models.WebhookDelivery
+			deliveriesRepo.On("Update", mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(0).(models.WebhookDelivery)
+			}).Return(nil)
+			sender := new(MockWebhookSender)
+			sender.On("Send", webhook, mock.Anything).Return(tt.sendErr)
+
+			service := NewWebhooksService(webhooksRepo, deliveriesRepo, sender, 20, time.Minute, false)
+			service.DeliverPending(now)
+
+			assert.Equal(t, tt.wantStatus, saved.Status)
+			assert.Equal(t, tt.attempts+1, saved.Attempts)
+			switch tt.wantStatus {
+			case enums.DeliveryDelivered:
+				assert.Equal(t, now, *saved.DeliveredAt)
+				assert.Nil(t, saved.LastError)
+			case enums.DeliveryPending:
+				assert.Equal(t, tt.wantNextAttempt, saved.NextAttemptAt)
+				assert.Equal(t, tt.sendErr.Error(), *saved.LastError)
+			case enums.DeliveryFailed:
+				assert.Equal(t, nextAttemptAt, saved.NextAttemptAt)
+				assert.Equal(t, tt.sendErr.Error(), *saved.LastError)
+			}
+			sender.AssertExpectations(t)
+		})
+	}
+}
+
+// Тест возврата неудавшейся отправки в очередь
+func TestRetryDelivery(t *testing.T) {
+	userID := uuid.New()
+	webhook := models.NewWebhook(userID, "https://example.com/hook", "0123456789abcdef", nil, nil)
+	foreign := models.NewWebhook(uuid.New(), "https://example.com/hook", "0123456789abcdef", nil, nil)
+
+	failed := func(webhookID uuid.UUID) *models.WebhookDelivery {
+		delivery := models.NewWebhookDelivery(webhookID, enums.TaskChangeCreated, `{}`)
+		delivery.Status = enums.DeliveryFailed
+		delivery.Attempts = 8
+		return delivery
+	}
+	pending := models.NewWebhookDelivery(webhook.ID, enums.TaskChangeCreated, `{}`)
+
+	tests := []struct {
+		name       string
+		delivery   *models.WebhookDelivery
+		wantStatus int
+	}{
+		{
+			name:     "Неудавшаяся отправка",
+			delivery: failed(webhook.ID),
+		},
+		{
+			name:       "Отправка ещё в очереди",
+			delivery:   pending,
+			wantStatus: 404,
+		},
+		{
+			name:       "Отправка чужой подписки",
+			delivery:   failed(foreign.ID),
+			wantStatus: 404,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhooksRepo := new(MockWebhooksRepository)
+			webhooksRepo.On("GetByID", webhook.ID).Return(webhook, nil).Maybe()
+			webhooksRepo.On("GetByID", foreign.ID).Return(foreign, nil).Maybe()
+			deliveriesRepo := new(MockWebhookDeliveriesRepository)
+			deliveriesRepo.On("GetByID", tt.delivery.ID).Return(tt.delivery, nil)
+			if tt.wantStatus == 0 {
+				deliveriesRepo.On("Update", mock.MatchedBy(func(delivery models.WebhookDelivery) bool {
+					return delivery.Status == enums.DeliveryPending && delivery.Attempts == 0
+				})).Return(nil)
+			}
+
+			service := &WebhooksServiceImpl{
+				webhooksRepository:          webhooksRepo,
+				webhookDeliveriesRepository: deliveriesRepo,
+			}
+			delivery, err := service.RetryDelivery(userID, tt.delivery.ID)
+
+			if tt.wantStatus != 0 {
+				assert.Nil(t, delivery)
+				var appErr errors.ApplicationError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, enums.DeliveryPending, delivery.Status)
+			}
+			deliveriesRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package validators
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+const maxChecklistItemNameLength = 500
+
+func ValidateChecklistItem(name string) error {
+	err := errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "ValidationFailed",
+		Errors:     map[string]string{},
+	}
+
+	if name == "" {
+		err.Errors["name"] = "Name is required"
+	} else if utf8.RuneCountInString(name) > maxChecklistItemNameLength {
+		err.Errors["name"] = fmt.Sprintf("Name must be at most %d characters long", maxChecklistItemNameLength)
+	}
+
+	if len(err.Errors) > 0 {
+		return err
+	}
+
+	return nil
+}
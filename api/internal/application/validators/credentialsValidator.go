@@ -0,0 +1,34 @@
+package validators
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"fmt"
+	"net/mail"
+)
+
+// bcrypt учитывает не больше 72 байт пароля и отклоняет более длинные
+const maxPasswordBytes = 72
+
+func ValidateCredentials(email string, password string) error {
+	err := errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "ValidationFailed",
+		Errors:     map[string]string{},
+	}
+
+	if address, parseErr := mail.ParseAddress(email); parseErr != nil || address.Address != email {
+		err.Errors["email"] = "Email is invalid"
+	}
+
+	if len(password) < 8 {
+		err.Errors["password"] = "Password must be at least 8 characters long"
+	} else if len(password) > maxPasswordBytes {
+		err.Errors["password"] = fmt.Sprintf("Password must be at most %d bytes long", maxPasswordBytes)
+	}
+
+	if len(err.Errors) > 0 {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package validators
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"fmt"
+)
+
+func ValidateImportSize(size int, maxSize int) error {
+	if size < 1 || size > maxSize {
+		return errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "ValidationFailed",
+			Errors:     map[string]string{"tasks": fmt.Sprintf("File must contain between 1 and %d tasks", maxSize)},
+		}
+	}
+
+	return nil
+}
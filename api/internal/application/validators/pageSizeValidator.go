@@ -0,0 +1,18 @@
+package validators
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"fmt"
+)
+
+func ValidatePageSize(limit int, maxLimit int) error {
+	if limit < 1 || limit > maxLimit {
+		return errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "ValidationFailed",
+			Errors:     map[string]string{"limit": fmt.Sprintf("Limit must be between 1 and %d", maxLimit)},
+		}
+	}
+
+	return nil
+}
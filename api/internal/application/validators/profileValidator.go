@@ -0,0 +1,18 @@
+package validators
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/models"
+)
+
+func ValidateProfile(timeZone *string) error {
+	if _, err := models.LoadTimeZone(timeZone); err != nil {
+		return errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "ValidationFailed",
+			Errors:     map[string]string{"timeZone": "Unknown time zone"},
+		}
+	}
+
+	return nil
+}
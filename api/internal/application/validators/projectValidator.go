@@ -0,0 +1,36 @@
+package validators
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+const maxProjectNameLength = 100
+
+var projectColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+func ValidateProject(name string, color *string) error {
+	err := errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "ValidationFailed",
+		Errors:     map[string]string{},
+	}
+
+	if name == "" {
+		err.Errors["name"] = "Name is required"
+	} else if utf8.RuneCountInString(name) > maxProjectNameLength {
+		err.Errors["name"] = fmt.Sprintf("Name must be at most %d characters long", maxProjectNameLength)
+	}
+
+	if color != nil && !projectColorPattern.MatchString(*color) {
+		err.Errors["color"] = "Color must be in #RRGGBB format"
+	}
+
+	if len(err.Errors) > 0 {
+		return err
+	}
+
+	return nil
+}
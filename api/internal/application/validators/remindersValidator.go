@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	maxRemindersPerTask = 10
+	// Напоминание можно поставить не раньше чем за 30 дней до дедлайна
+	maxReminderMinutesBefore = 30 * 24 * 60
+)
+
+func ValidateReminders(minutesBefore []int) error {
+	err := errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "ValidationFailed",
+		Errors:     map[string]string{},
+	}
+
+	if len(minutesBefore) > maxRemindersPerTask {
+		err.Errors["minutesBefore"] = fmt.Sprintf("Task can have at most %d reminders", maxRemindersPerTask)
+	}
+
+	for i, minutes := range minutesBefore {
+		if minutes < 1 || minutes > maxReminderMinutesBefore {
+			err.Errors["minutesBefore"] = fmt.Sprintf("Reminder must be between 1 and %d minutes before the deadline",
+				maxReminderMinutesBefore)
+		} else if slices.Contains(minutesBefore[:i], minutes) {
+			err.Errors["minutesBefore"] = fmt.Sprintf("Duplicate reminder %d minutes before the deadline", minutes)
+		}
+	}
+
+	if len(err.Errors) > 0 {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package validators
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+const maxTagNameLength = 50
+
+// Имя метки должно записываться макросом #tag, поэтому пробелы и знаки препинания в нём недопустимы
+var tagNamePattern = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+
+func ValidateTag(name string) error {
+	err := errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "ValidationFailed",
+		Errors:     map[string]string{},
+	}
+
+	if name == "" {
+		err.Errors["name"] = "Name is required"
+	} else if utf8.RuneCountInString(name) > maxTagNameLength {
+		err.Errors["name"] = fmt.Sprintf("Name must be at most %d characters long", maxTagNameLength)
+	} else if !tagNamePattern.MatchString(name) {
+		err.Errors["name"] = "Name may contain only letters, digits, '_' and '-'"
+	}
+
+	if len(err.Errors) > 0 {
+		return err
+	}
+
+	return nil
+}
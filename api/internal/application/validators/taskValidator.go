@@ -2,10 +2,19 @@ package validators
 
 import (
 	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/models"
 	"time"
 )
 
-func ValidateTask(name string, deadline *time.Time) error {
+// now — текущее время в часовом поясе пользователя
+func ValidateTask(name string, deadline *time.Time, recurrence *string, now time.Time) error {
+	return ValidateTaskPatch(name, deadline, true, recurrence, now)
+}
+
+// Проверка задачи после частичного изменения: прежний дедлайн может быть уже в прошлом,
+// поэтому он проверяется, только если deadlineChanged
+func ValidateTaskPatch(name string, deadline *time.Time, deadlineChanged bool, recurrence *string,
+	now time.Time) error {
 	err := errors.ApplicationError{
 		StatusCode: 400,
 		Code:       "ValidationFailed",
@@ -16,10 +25,18 @@ func ValidateTask(name string, deadline *time.Time) error {
 		err.Errors["name"] = "Name is required"
 	}
 
-	if deadline != nil && !deadline.After(time.Now()) {
+	if deadlineChanged && deadline != nil && !deadline.After(now) {
 		err.Errors["deadline"] = "Deadline must be in the future"
 	}
 
+	if recurrence != nil {
+		if _, parseErr := models.ParseRecurrence(*recurrence); parseErr != nil {
+			err.Errors["recurrence"] = "Invalid recurrence rule: " + parseErr.Error()
+		} else if deadline == nil {
+			err.Errors["recurrence"] = "Recurring task requires a deadline"
+		}
+	}
+
 	if len(err.Errors) > 0 {
 		return err
 	}
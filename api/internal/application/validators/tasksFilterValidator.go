@@ -0,0 +1,47 @@
+package validators
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+)
+
+func ValidateTasksFilter(filter *models.TasksFilter) error {
+	if filter == nil {
+		return nil
+	}
+
+	err := errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "ValidationFailed",
+		Errors:     map[string]string{},
+	}
+
+	for _, status := range filter.Statuses {
+		if validationErr := enums.ValidateStatus(status); validationErr != nil {
+			err.Errors["status"] = validationErr.Error()
+			break
+		}
+	}
+
+	for _, priority := range filter.Priorities {
+		if validationErr := enums.ValidatePriority(priority); validationErr != nil {
+			err.Errors["priority"] = validationErr.Error()
+			break
+		}
+	}
+
+	if filter.DeadlineFrom != nil && filter.DeadlineTo != nil && filter.DeadlineFrom.After(*filter.DeadlineTo) {
+		err.Errors["deadlineTo"] = "deadlineTo must not be earlier than deadlineFrom"
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && filter.CreatedFrom.After(*filter.CreatedTo) {
+		err.Errors["createdTo"] = "createdTo must not be earlier than createdFrom"
+	}
+
+	if len(err.Errors) > 0 {
+		return err
+	}
+
+	return nil
+}
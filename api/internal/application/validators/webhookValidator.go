@@ -0,0 +1,75 @@
+package validators
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+const minWebhookSecretLength = 16
+
+// secret == nil — ключ не задан и будет сгенерирован. Без allowPrivateNetworks адрес подписки
+// не может указывать на сам сервер или его внутреннюю сеть
+func ValidateWebhook(webhookURL string, secret *string, eventTypes []enums.TaskChangeType,
+	priorities []enums.Priority, allowPrivateNetworks bool) error {
+	err := errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "ValidationFailed",
+		Errors:     map[string]string{},
+	}
+
+	if parsed, parseErr := url.Parse(webhookURL); parseErr != nil || parsed.Host == "" ||
+		(parsed.Scheme != "http" && parsed.Scheme != "https") {
+		err.Errors["url"] = "URL must be an absolute http or https URL"
+	} else if !allowPrivateNetworks && isPrivateHost(parsed.Hostname()) {
+		err.Errors["url"] = "URL must not point to a loopback, private, link-local or unspecified address"
+	}
+
+	if secret != nil && len(*secret) < minWebhookSecretLength {
+		err.Errors["secret"] = fmt.Sprintf("Secret must be at least %d characters long", minWebhookSecretLength)
+	}
+
+	for _, eventType := range eventTypes {
+		if enums.ValidateTaskChangeType(eventType) != nil {
+			err.Errors["eventTypes"] = fmt.Sprintf("Unsupported event type %q", eventType)
+		}
+	}
+
+	for _, priority := range priorities {
+		if enums.ValidatePriority(priority) != nil {
+			err.Errors["priorities"] = fmt.Sprintf("Unsupported priority %q", priority)
+		}
+	}
+
+	if len(err.Errors) > 0 {
+		return err
+	}
+
+	return nil
+}
+
+// IsPrivateIP сообщает, что адрес относится к самому серверу или внутренней сети:
+// loopback, частные диапазоны, link-local (в том числе 169.254.169.254 облачных метаданных)
+// и неуказанный адрес
+func IsPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified()
+}
+
+// Доменные имена проверяются только на localhost: во что они разрешатся при отправке, здесь
+// не узнать, поэтому адрес соединения проверяет ещё и отправитель
+func isPrivateHost(host string) bool {
+	// Зона IPv6-адреса (fe80::1%eth0) не влияет на его диапазон
+	if zone := strings.IndexByte(host, '%'); zone >= 0 {
+		host = host[:zone]
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return IsPrivateIP(ip)
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	return host == "localhost" || strings.HasSuffix(host, ".localhost")
+}
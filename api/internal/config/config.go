@@ -0,0 +1,427 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const envPrefix = "TODO_"
+
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
+const (
+	NotifierLog     = "log"
+	NotifierSMTP    = "smtp"
+	NotifierWebhook = "webhook"
+)
+
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Scheduler SchedulerConfig `yaml:"scheduler"`
+	Trash     TrashConfig     `yaml:"trash"`
+	Events    EventsConfig    `yaml:"events"`
+	Webhooks  WebhooksConfig  `yaml:"webhooks"`
+	Reminders RemindersConfig `yaml:"reminders"`
+	Cors      CorsConfig      `yaml:"cors"`
+	Auth      AuthConfig      `yaml:"auth"`
+}
+
+type ServerConfig struct {
+	Address         string        `yaml:"address"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+}
+
+type DatabaseConfig struct {
+	Driver      string `yaml:"driver"`
+	Path        string `yaml:"path"`
+	Host        string `yaml:"host"`
+	Port        string `yaml:"port"`
+	User        string `yaml:"user"`
+	Password    string `yaml:"password"`
+	Name        string `yaml:"name"`
+	AutoMigrate bool   `yaml:"autoMigrate"`
+}
+
+type SchedulerConfig struct {
+	Interval time.Duration `yaml:"interval"`
+}
+
+// TrashConfig — корзина задач: удалённые задачи хранятся Retention, раз в PurgeInterval
+// задачи с истёкшим сроком удаляются окончательно
+type TrashConfig struct {
+	Retention     time.Duration `yaml:"retention"`
+	PurgeInterval time.Duration `yaml:"purgeInterval"`
+}
+
+// EventsConfig — поток изменений задач: для переподключившихся клиентов хранятся последние
+// HistorySize событий, раз в HeartbeatInterval в открытый поток пишется комментарий, чтобы прокси
+// не закрывали простаивающее соединение
+type EventsConfig struct {
+	HistorySize       int           `yaml:"historySize"`
+	HeartbeatInterval time.Duration `yaml:"heartbeatInterval"`
+}
+
+// WebhooksConfig — отправка изменений задач подписчикам: очередь проверяется раз в DeliveryInterval,
+// запрос ждёт ответа не дольше Timeout. Неудачная попытка повторяется через RetryBackoff, и каждая
+// следующая пауза вдвое длиннее; после MaxAttempts попыток отправка попадает в список неудавшихся.
+// AllowPrivateNetworks разрешает подписки на loopback и адреса внутренней сети — только для
+// локальной разработки, иначе любой пользователь может слать запросы внутренним сервисам
+type WebhooksConfig struct {
+	DeliveryInterval     time.Duration `yaml:"deliveryInterval"`
+	Timeout              time.Duration `yaml:"timeout"`
+	MaxAttempts          int           `yaml:"maxAttempts"`
+	RetryBackoff         time.Duration `yaml:"retryBackoff"`
+	AllowPrivateNetworks bool          `yaml:"allowPrivateNetworks"`
+}
+
+// RemindersConfig — каналы, через которые отправляются напоминания о дедлайнах: log пишет их в журнал
+// сервера, smtp отправляет письмо владельцу задачи, webhook — событие reminder его вебхукам
+type RemindersConfig struct {
+	Notifiers []string   `yaml:"notifiers"`
+	SMTP      SMTPConfig `yaml:"smtp"`
+}
+
+// SMTPConfig — почтовый сервер для напоминаний. Без Username письма отправляются без авторизации,
+// STARTTLS используется, если сервер его поддерживает
+type SMTPConfig struct {
+	Host     string        `yaml:"host"`
+	Port     int           `yaml:"port"`
+	Username string        `yaml:"username"`
+	Password string        `yaml:"password"`
+	From     string        `yaml:"from"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+type CorsConfig struct {
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+}
+
+type AuthConfig struct {
+	JWTSecret string        `yaml:"jwtSecret"`
+	TokenTTL  time.Duration `yaml:"tokenTTL"`
+}
+
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Address:         ":8080",
+			ShutdownTimeout: 10 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver:      DriverPostgres,
+			Path:        "todo.db",
+			Host:        "localhost",
+			Port:        "5432",
+			User:        "postgres",
+			Name:        "ToDoDb",
+			AutoMigrate: true,
+		},
+		Scheduler: SchedulerConfig{
+			Interval: time.Minute,
+		},
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Events: EventsConfig{
+			HistorySize:       1000,
+			HeartbeatInterval: 15 * time.Second,
+		},
+		Webhooks: WebhooksConfig{
+			DeliveryInterval: 5 * time.Second,
+			Timeout:          10 * time.Second,
+			MaxAttempts:      8,
+			RetryBackoff:     30 * time.Second,
+		},
+		Reminders: RemindersConfig{
+			Notifiers: []string{NotifierLog},
+			SMTP: SMTPConfig{
+				Port:    587,
+				Timeout: 10 * time.Second,
+			},
+		},
+		Cors: CorsConfig{
+			AllowedOrigins: []string{"http://localhost:5173"},
+		},
+		Auth: AuthConfig{
+			TokenTTL: 24 * time.Hour,
+		},
+	}
+}
+
+// Load собирает конфигурацию: значения по умолчанию, затем YAML-файл (если path не пуст),
+// затем переменные окружения с префиксом TODO_. Результат проверяется перед возвратом.
+func Load(path string) (*Config, error) {
+	cfg, err := read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// LoadDatabase собирает конфигурацию так же, как Load, но проверяет только раздел database:
+// подкоманде migrate не нужны секрет JWT и настройки сервера
+func LoadDatabase(path string) (*DatabaseConfig, error) {
+	cfg, err := read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if errs := cfg.Database.validate(); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+
+	return &cfg.Database, nil
+}
+
+func read(path string) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read config file: %w", err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parse config file %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (cfg *Config) applyEnv(lookup func(string) (string, bool)) error {
+	stringFields := map[string]*string{
+		"SERVER_ADDRESS":  &cfg.Server.Address,
+		"DB_DRIVER":       &cfg.Database.Driver,
+		"DB_PATH":         &cfg.Database.Path,
+		"DB_HOST":         &cfg.Database.Host,
+		"DB_PORT":         &cfg.Database.Port,
+		"DB_USER":         &cfg.Database.User,
+		"DB_PASSWORD":     &cfg.Database.Password,
+		"DB_NAME":         &cfg.Database.Name,
+		"AUTH_JWT_SECRET": &cfg.Auth.JWTSecret,
+		"SMTP_HOST":       &cfg.Reminders.SMTP.Host,
+		"SMTP_USERNAME":   &cfg.Reminders.SMTP.Username,
+		"SMTP_PASSWORD":   &cfg.Reminders.SMTP.Password,
+		"SMTP_FROM":       &cfg.Reminders.SMTP.From,
+	}
+	for name, target := range stringFields {
+		if value, ok := lookup(envPrefix + name); ok {
+			*target = value
+		}
+	}
+
+	durationFields := map[string]*time.Duration{
+		"SERVER_SHUTDOWN_TIMEOUT":    &cfg.Server.ShutdownTimeout,
+		"SCHEDULER_INTERVAL":         &cfg.Scheduler.Interval,
+		"TRASH_RETENTION":            &cfg.Trash.Retention,
+		"TRASH_PURGE_INTERVAL":       &cfg.Trash.PurgeInterval,
+		"EVENTS_HEARTBEAT_INTERVAL":  &cfg.Events.HeartbeatInterval,
+		"WEBHOOKS_DELIVERY_INTERVAL": &cfg.Webhooks.DeliveryInterval,
+		"WEBHOOKS_TIMEOUT":           &cfg.Webhooks.Timeout,
+		"WEBHOOKS_RETRY_BACKOFF":     &cfg.Webhooks.RetryBackoff,
+		"SMTP_TIMEOUT":               &cfg.Reminders.SMTP.Timeout,
+		"AUTH_TOKEN_TTL":             &cfg.Auth.TokenTTL,
+	}
+	for name, target := range durationFields {
+		if value, ok := lookup(envPrefix + name); ok {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s%s: %w", envPrefix, name, err)
+			}
+			*target = duration
+		}
+	}
+
+	intFields := map[string]*int{
+		"EVENTS_HISTORY_SIZE":   &cfg.Events.HistorySize,
+		"WEBHOOKS_MAX_ATTEMPTS": &cfg.Webhooks.MaxAttempts,
+		"SMTP_PORT":             &cfg.Reminders.SMTP.Port,
+	}
+	for name, target := range intFields {
+		if value, ok := lookup(envPrefix + name); ok {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s%s: %w", envPrefix, name, err)
+			}
+			*target = parsed
+		}
+	}
+
+	boolFields := map[string]*bool{
+		"DB_AUTO_MIGRATE":                 &cfg.Database.AutoMigrate,
+		"WEBHOOKS_ALLOW_PRIVATE_NETWORKS": &cfg.Webhooks.AllowPrivateNetworks,
+	}
+	for name, target := range boolFields {
+		if value, ok := lookup(envPrefix + name); ok {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s%s: %w", envPrefix, name, err)
+			}
+			*target = parsed
+		}
+	}
+
+	if value, ok := lookup(envPrefix + "CORS_ALLOWED_ORIGINS"); ok {
+		cfg.Cors.AllowedOrigins = splitList(value)
+	}
+	if value, ok := lookup(envPrefix + "REMINDERS_NOTIFIERS"); ok {
+		cfg.Reminders.Notifiers = splitList(value)
+	}
+
+	return nil
+}
+
+func (cfg *Config) Validate() error {
+	var errs []error
+
+	if cfg.Server.Address == "" {
+		errs = append(errs, errors.New("server.address is required"))
+	}
+	if cfg.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdownTimeout must be positive"))
+	}
+
+	errs = append(errs, cfg.Database.validate()...)
+
+	if cfg.Scheduler.Interval <= 0 {
+		errs = append(errs, errors.New("scheduler.interval must be positive"))
+	}
+	if cfg.Trash.Retention <= 0 {
+		errs = append(errs, errors.New("trash.retention must be positive"))
+	}
+	if cfg.Trash.PurgeInterval <= 0 {
+		errs = append(errs, errors.New("trash.purgeInterval must be positive"))
+	}
+	if cfg.Events.HistorySize < 0 {
+		errs = append(errs, errors.New("events.historySize must not be negative"))
+	}
+	if cfg.Events.HeartbeatInterval <= 0 {
+		errs = append(errs, errors.New("events.heartbeatInterval must be positive"))
+	}
+	if cfg.Webhooks.DeliveryInterval <= 0 {
+		errs = append(errs, errors.New("webhooks.deliveryInterval must be positive"))
+	}
+	if cfg.Webhooks.Timeout <= 0 {
+		errs = append(errs, errors.New("webhooks.timeout must be positive"))
+	}
+	if cfg.Webhooks.MaxAttempts < 1 {
+		errs = append(errs, errors.New("webhooks.maxAttempts must be at least 1"))
+	}
+	if cfg.Webhooks.RetryBackoff <= 0 {
+		errs = append(errs, errors.New("webhooks.retryBackoff must be positive"))
+	}
+
+	for _, notifier := range cfg.Reminders.Notifiers {
+		switch notifier {
+		case NotifierLog, NotifierWebhook:
+		case NotifierSMTP:
+			smtp := cfg.Reminders.SMTP
+			if smtp.Host == "" {
+				errs = append(errs, errors.New("reminders.smtp.host is required for the smtp notifier"))
+			}
+			if smtp.Port < 1 || smtp.Port > 65535 {
+				errs = append(errs, fmt.Errorf("reminders.smtp.port must be between 1 and 65535, got %d", smtp.Port))
+			}
+			if smtp.From == "" {
+				errs = append(errs, errors.New("reminders.smtp.from is required for the smtp notifier"))
+			}
+			if smtp.Timeout <= 0 {
+				errs = append(errs, errors.New("reminders.smtp.timeout must be positive"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("reminders.notifiers must contain only %s, %s, %s, got %q",
+				NotifierLog, NotifierSMTP, NotifierWebhook, notifier))
+		}
+	}
+
+	for _, origin := range cfg.Cors.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if parsed, err := url.Parse(origin); err != nil || parsed.Scheme == "" || parsed.Host == "" ||
+			(parsed.Path != "" && parsed.Path != "/") {
+			errs = append(errs, fmt.Errorf("cors.allowedOrigins contains invalid origin %q", origin))
+		}
+	}
+
+	if len(cfg.Auth.JWTSecret) < 16 {
+		errs = append(errs, errors.New("auth.jwtSecret must be at least 16 characters long"))
+	}
+	if cfg.Auth.TokenTTL <= 0 {
+		errs = append(errs, errors.New("auth.tokenTTL must be positive"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func (db *DatabaseConfig) validate() []error {
+	var errs []error
+
+	switch db.Driver {
+	case DriverPostgres:
+		if db.Host == "" {
+			errs = append(errs, errors.New("database.host is required"))
+		}
+		if port, err := strconv.Atoi(db.Port); err != nil || port < 1 || port > 65535 {
+			errs = append(errs, fmt.Errorf("database.port must be a number between 1 and 65535, got %q",
+				db.Port))
+		}
+		if db.User == "" {
+			errs = append(errs, errors.New("database.user is required"))
+		}
+		if db.Name == "" {
+			errs = append(errs, errors.New("database.name is required"))
+		}
+	case DriverSQLite:
+		if db.Path == "" {
+			errs = append(errs, errors.New("database.path is required for the sqlite driver"))
+		}
+	case DriverMemory:
+	default:
+		errs = append(errs, fmt.Errorf("database.driver must be one of %s, %s, %s, got %q",
+			DriverPostgres, DriverSQLite, DriverMemory, db.Driver))
+	}
+
+	return errs
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
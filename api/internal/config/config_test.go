@@ -0,0 +1,240 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// Тест загрузки значений по умолчанию
+func TestLoad_Defaults(t *testing.T) {
+	t.Setenv("TODO_AUTH_JWT_SECRET", "0123456789abcdef")
+
+	cfg, err := Load("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":8080", cfg.Server.Address)
+	assert.Equal(t, 10*time.Second, cfg.Server.ShutdownTimeout)
+	assert.Equal(t, "5432", cfg.Database.Port)
+	assert.True(t, cfg.Database.AutoMigrate)
+	assert.Equal(t, time.Minute, cfg.Scheduler.Interval)
+	assert.Equal(t, 30*24*time.Hour, cfg.Trash.Retention)
+	assert.Equal(t, time.Hour, cfg.Trash.PurgeInterval)
+	assert.Equal(t, 1000, cfg.Events.HistorySize)
+	assert.Equal(t, 15*time.Second, cfg.Events.HeartbeatInterval)
+	assert.Equal(t, 8, cfg.Webhooks.MaxAttempts)
+	assert.Equal(t, 30*time.Second, cfg.Webhooks.RetryBackoff)
+	assert.Equal(t, []string{NotifierLog}, cfg.Reminders.Notifiers)
+	assert.Equal(t, 587, cfg.Reminders.SMTP.Port)
+	assert.Equal(t, []string{"http://localhost:5173"}, cfg.Cors.AllowedOrigins)
+	assert.Equal(t, 24*time.Hour, cfg.Auth.TokenTTL)
+}
+
+// Тест приоритета переменных окружения над файлом
+func TestLoad_FileAndEnv(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  address: ":9090"
+database:
+  host: db.internal
+  port: "6543"
+  password: from-file
+scheduler:
+  interval: 500ms
+trash:
+  retention: 168h
+reminders:
+  smtp:
+    host: smtp.example.com
+    from: todo@example.com
+cors:
+  allowedOrigins: ["https://todo.example.com"]
+auth:
+  jwtSecret: file-secret-0123456789
+  tokenTTL: 2h
+`)
+	t.Setenv("TODO_DB_PASSWORD", "from-env")
+	t.Setenv("TODO_DB_AUTO_MIGRATE", "false")
+	t.Setenv("TODO_TRASH_PURGE_INTERVAL", "10m")
+	t.Setenv("TODO_EVENTS_HISTORY_SIZE", "50")
+	t.Setenv("TODO_CORS_ALLOWED_ORIGINS", "https://a.example.com, https://b.example.com")
+	t.Setenv("TODO_REMINDERS_NOTIFIERS", "log,smtp")
+	t.Setenv("TODO_SMTP_PORT", "2525")
+
+	cfg, err := Load(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":9090", cfg.Server.Address)
+	assert.Equal(t, "db.internal", cfg.Database.Host)
+	assert.Equal(t, "6543", cfg.Database.Port)
+	assert.Equal(t, "postgres", cfg.Database.User)
+	assert.Equal(t, "from-env", cfg.Database.Password)
+	assert.False(t, cfg.Database.AutoMigrate)
+	assert.Equal(t, 500*time.Millisecond, cfg.Scheduler.Interval)
+	assert.Equal(t, 7*24*time.Hour, cfg.Trash.Retention)
+	assert.Equal(t, 10*time.Minute, cfg.Trash.PurgeInterval)
+	assert.Equal(t, 50, cfg.Events.HistorySize)
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, cfg.Cors.AllowedOrigins)
+	assert.Equal(t, []string{NotifierLog, NotifierSMTP}, cfg.Reminders.Notifiers)
+	assert.Equal(t, "smtp.example.com", cfg.Reminders.SMTP.Host)
+	assert.Equal(t, 2525, cfg.Reminders.SMTP.Port)
+	assert.Equal(t, "file-secret-0123456789", cfg.Auth.JWTSecret)
+	assert.Equal(t, 2*time.Hour, cfg.Auth.TokenTTL)
+}
+
+// Тест отклонения некорректной конфигурации
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "Без секрета JWT",
+			wantErr: "auth.jwtSecret",
+		},
+		{
+			name:    "Некорректный порт БД",
+			env:     map[string]string{"TODO_AUTH_JWT_SECRET": "0123456789abcdef", "TODO_DB_PORT": "postgres"},
+			wantErr: "database.port",
+		},
+		{
+			name:    "Некорректный интервал планировщика",
+			env:     map[string]string{"TODO_AUTH_JWT_SECRET": "0123456789abcdef", "TODO_SCHEDULER_INTERVAL": "often"},
+			wantErr: "TODO_SCHEDULER_INTERVAL",
+		},
+		{
+			name:    "Отрицательный интервал планировщика",
+			file:    "scheduler:\n  interval: -1s\nauth:\n  jwtSecret: 0123456789abcdef\n",
+			wantErr: "scheduler.interval",
+		},
+		{
+			name:    "Нулевой срок хранения корзины",
+			file:    "trash:\n  retention: 0s\nauth:\n  jwtSecret: 0123456789abcdef\n",
+			wantErr: "trash.retention",
+		},
+		{
+			name:    "Некорректный размер истории событий",
+			env:     map[string]string{"TODO_AUTH_JWT_SECRET": "0123456789abcdef", "TODO_EVENTS_HISTORY_SIZE": "many"},
+			wantErr: "TODO_EVENTS_HISTORY_SIZE",
+		},
+		{
+			name:    "Нулевой интервал heartbeat",
+			file:    "events:\n  heartbeatInterval: 0s\nauth:\n  jwtSecret: 0123456789abcdef\n",
+			wantErr: "events.heartbeatInterval",
+		},
+		{
+			name:    "Без попыток отправки вебхуков",
+			file:    "webhooks:\n  maxAttempts: 0\nauth:\n  jwtSecret: 0123456789abcdef\n",
+			wantErr: "webhooks.maxAttempts",
+		},
+		{
+			name:    "Неизвестный канал напоминаний",
+			file:    "reminders:\n  notifiers: [log, sms]\nauth:\n  jwtSecret: 0123456789abcdef\n",
+			wantErr: "reminders.notifiers",
+		},
+		{
+			name:    "Напоминания по почте без SMTP-сервера",
+			file:    "reminders:\n  notifiers: [smtp]\nauth:\n  jwtSecret: 0123456789abcdef\n",
+			wantErr: "reminders.smtp.host",
+		},
+		{
+			name:    "Нулевой таймаут остановки сервера",
+			env:     map[string]string{"TODO_AUTH_JWT_SECRET": "0123456789abcdef", "TODO_SERVER_SHUTDOWN_TIMEOUT": "0s"},
+			wantErr: "server.shutdownTimeout",
+		},
+		{
+			name:    "Некорректный origin",
+			env:     map[string]string{"TODO_AUTH_JWT_SECRET": "0123456789abcdef", "TODO_CORS_ALLOWED_ORIGINS": "localhost"},
+			wantErr: "cors.allowedOrigins",
+		},
+		{
+			name:    "Неизвестный драйвер хранилища",
+			env:     map[string]string{"TODO_AUTH_JWT_SECRET": "0123456789abcdef", "TODO_DB_DRIVER": "mongodb"},
+			wantErr: "database.driver",
+		},
+		{
+			name: "SQLite без пути к файлу",
+			env: map[string]string{"TODO_AUTH_JWT_SECRET": "0123456789abcdef", "TODO_DB_DRIVER": "sqlite",
+				"TODO_DB_PATH": ""},
+			wantErr: "database.path",
+		},
+		{
+			name:    "Неизвестное поле в файле",
+			file:    "server:\n  adress: \":8080\"\n",
+			wantErr: "adress",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			path := ""
+			if tt.file != "" {
+				path = writeConfigFile(t, tt.file)
+			}
+
+			cfg, err := Load(path)
+
+			assert.Nil(t, cfg)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+// Тест выбора хранилища без настроек Postgres
+func TestLoad_MemoryDriver(t *testing.T) {
+	t.Setenv("TODO_AUTH_JWT_SECRET", "0123456789abcdef")
+	t.Setenv("TODO_DB_DRIVER", "memory")
+	t.Setenv("TODO_DB_PORT", "")
+
+	cfg, err := Load("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, DriverMemory, cfg.Database.Driver)
+}
+
+// Тест чтения отсутствующего файла
+func TestLoad_MissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+
+	assert.Nil(t, cfg)
+	assert.Error(t, err)
+}
+
+// Тест загрузки конфигурации для подкоманды migrate: проверяется только раздел database
+func TestLoadDatabase(t *testing.T) {
+	t.Setenv("TODO_AUTH_JWT_SECRET", "")
+	t.Setenv("TODO_DB_DRIVER", "sqlite")
+	t.Setenv("TODO_DB_PATH", "todo.db")
+
+	dbCfg, err := LoadDatabase("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, DriverSQLite, dbCfg.Driver)
+	assert.Equal(t, "todo.db", dbCfg.Path)
+}
+
+// Тест отклонения некорректного раздела database при загрузке для подкоманды migrate
+func TestLoadDatabase_Invalid(t *testing.T) {
+	t.Setenv("TODO_DB_DRIVER", "postgres")
+	t.Setenv("TODO_DB_PORT", "postgres")
+
+	dbCfg, err := LoadDatabase("")
+
+	assert.Nil(t, dbCfg)
+	assert.ErrorContains(t, err, "database.port")
+	assert.NotContains(t, err.Error(), "auth.jwtSecret")
+}
@@ -15,4 +15,14 @@ type TaskResponse struct {
 	Deadline    *time.Time     `json:"deadline"`
 	Status      enums.Status   `binding:"required" json:"status"`
 	Priority    enums.Priority `binding:"required" json:"priority"`
+	ProjectID   *uuid.UUID     `json:"projectId"`
+	TimeZone    *string        `json:"timeZone"`
+
+	Recurrence       *string    `json:"recurrence"`
+	NextOccurrenceID *uuid.UUID `json:"nextOccurrenceId"`
+	DeletedAt        *time.Time `json:"deletedAt"`
+	Version          int        `binding:"required" json:"version"`
+
+	Progress ChecklistProgressResponse `binding:"required" json:"progress"`
+	Tags     []TagResponse             `binding:"required" json:"tags"`
 }
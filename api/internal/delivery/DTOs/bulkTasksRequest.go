@@ -0,0 +1,26 @@
+package DTOs
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"github.com/google/uuid"
+)
+
+type BulkTasksRequest struct {
+	// true — все действия или ни одного; false — неудавшиеся действия пропускаются
+	Atomic     bool
+	Operations []BulkOperationRequest `binding:"required,min=1,max=100,dive"`
+}
+
+type BulkOperationRequest struct {
+	Op enums.TaskOperationType `binding:"required,oneof=create update toggle delete"`
+	// Задача для update, toggle и delete
+	ID *uuid.UUID
+	// Ожидаемая версия задачи, как в If-Match; без поля версия не проверяется
+	Version *int
+	// Поля задачи для create и update
+	Task *UpdateTaskRequest
+	// Новый статус для toggle
+	IsDone *bool
+	// Для toggle: отметить выполненными оставшиеся пункты чек-листа
+	CompleteItems bool
+}
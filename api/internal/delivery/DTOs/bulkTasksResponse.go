@@ -0,0 +1,15 @@
+package DTOs
+
+type BulkTasksResponse struct {
+	// false, если в atomic-режиме одно из действий не удалось и все изменения отменены
+	Committed bool                    `binding:"required" json:"committed"`
+	Results   []BulkOperationResponse `binding:"required" json:"results"`
+}
+
+type BulkOperationResponse struct {
+	// Код ответа, который вернул бы отдельный запрос с этим действием
+	StatusCode int `binding:"required" json:"statusCode"`
+	// Задача после действия; при конфликте версий — её текущее состояние
+	Task  *TaskResponse  `json:"task,omitempty"`
+	Error *ErrorResponse `json:"error,omitempty"`
+}
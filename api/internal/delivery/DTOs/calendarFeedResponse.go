@@ -0,0 +1,5 @@
+package DTOs
+
+type CalendarFeedResponse struct {
+	URL string `binding:"required" json:"url"`
+}
@@ -0,0 +1,6 @@
+package DTOs
+
+// Вид записей календаря: VTODO (по умолчанию) или VEVENT
+type CalendarQuery struct {
+	Component *string `form:"component" binding:"omitempty,oneof=VTODO VEVENT"`
+}
@@ -0,0 +1,10 @@
+package DTOs
+
+type CreateChecklistItemRequest struct {
+	Name *string `binding:"required"`
+}
+
+type UpdateChecklistItemRequest struct {
+	Name   *string `binding:"required"`
+	IsDone *bool   `binding:"required"`
+}
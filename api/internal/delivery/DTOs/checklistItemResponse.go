@@ -0,0 +1,21 @@
+package DTOs
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ChecklistItemResponse struct {
+	ID        uuid.UUID  `binding:"required" json:"id"`
+	TaskID    uuid.UUID  `binding:"required" json:"taskId"`
+	CreatedAt time.Time  `binding:"required" json:"createdAt"`
+	ChangedAt *time.Time `json:"changedAt"`
+	Name      string     `binding:"required" json:"name"`
+	IsDone    bool       `binding:"required" json:"isDone"`
+	Position  int        `binding:"required" json:"position"`
+}
+
+type ChecklistProgressResponse struct {
+	Done  int `binding:"required" json:"done"`
+	Total int `binding:"required" json:"total"`
+}
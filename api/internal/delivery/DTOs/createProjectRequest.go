@@ -0,0 +1,7 @@
+package DTOs
+
+type CreateProjectRequest struct {
+	Name *string `binding:"required"`
+	// Цвет в формате #RRGGBB
+	Color *string
+}
@@ -2,6 +2,7 @@ package DTOs
 
 import (
 	"HITS_ToDoList_Tests/internal/domain/enums"
+	"github.com/google/uuid"
 	"time"
 )
 
@@ -10,4 +11,10 @@ type CreateTaskRequest struct {
 	Description *string
 	Deadline    *time.Time
 	Priority    *enums.Priority `binding:"omitempty,oneof=Low Medium High Critical" msg:"Incorrect Priority"`
+	// Правило повторения RRULE, например "FREQ=WEEKLY;BYDAY=MO,TH"
+	Recurrence *string
+	// Имена меток; отсутствующие создаются
+	Tags []string
+	// Проект задачи
+	ProjectID *uuid.UUID
 }
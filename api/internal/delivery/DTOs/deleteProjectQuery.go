@@ -0,0 +1,7 @@
+package DTOs
+
+import "HITS_ToDoList_Tests/internal/application/enums"
+
+type DeleteProjectQuery struct {
+	Mode *enums.ProjectDeleteMode `form:"mode"`
+}
@@ -0,0 +1,8 @@
+package DTOs
+
+// ErrorResponse — тело ответа с ApplicationError
+type ErrorResponse struct {
+	StatusCode int               `binding:"required" json:"statusCode"`
+	Code       string            `binding:"required" json:"code"`
+	Errors     map[string]string `json:"errors"`
+}
@@ -0,0 +1,9 @@
+package DTOs
+
+type ImportTasksQuery struct {
+	// Формат файла; по умолчанию определяется по Content-Type
+	Format *string `form:"format" binding:"omitempty,oneof=csv ical json"`
+	// Что делать с невыполненными задачами с прошедшим дедлайном: skip (по умолчанию) — пропустить,
+	// keep — создать просроченными
+	Overdue *string `form:"overdue" binding:"omitempty,oneof=skip keep"`
+}
@@ -0,0 +1,17 @@
+package DTOs
+
+type ImportTasksResponse struct {
+	Created int                 `binding:"required" json:"created"`
+	Skipped int                 `binding:"required" json:"skipped"`
+	Failed  int                 `binding:"required" json:"failed"`
+	Rows    []ImportRowResponse `binding:"required" json:"rows"`
+}
+
+type ImportRowResponse struct {
+	// Номер задачи в файле с 1; в CSV строка заголовков не считается
+	Row int `binding:"required" json:"row"`
+	// created, skipped (дедлайн прошёл) или failed
+	Result string         `binding:"required" json:"result" enums:"created,skipped,failed"`
+	Task   *TaskResponse  `json:"task,omitempty"`
+	Error  *ErrorResponse `json:"error,omitempty"`
+}
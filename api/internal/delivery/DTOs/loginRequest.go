@@ -0,0 +1,6 @@
+package DTOs
+
+type LoginRequest struct {
+	Email    *string `binding:"required"`
+	Password *string `binding:"required"`
+}
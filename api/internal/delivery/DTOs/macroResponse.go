@@ -0,0 +1,7 @@
+package DTOs
+
+type MacroResponse struct {
+	Kind   string `json:"kind,omitempty"`
+	Text   string `binding:"required" json:"text"`
+	Reason string `json:"reason,omitempty"`
+}
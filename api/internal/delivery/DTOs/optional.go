@@ -0,0 +1,25 @@
+package DTOs
+
+import "encoding/json"
+
+// Optional различает отсутствующее поле JSON (Set == false) и явный null (Set == true, Value == nil)
+type Optional[T any] struct {
+	Set   bool
+	Value *T
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	o.Value = &value
+
+	return nil
+}
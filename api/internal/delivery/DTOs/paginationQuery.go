@@ -0,0 +1,6 @@
+package DTOs
+
+type PaginationQuery struct {
+	Limit  *int    `form:"limit"`
+	Cursor *string `form:"cursor"`
+}
@@ -0,0 +1,5 @@
+package DTOs
+
+type ParseTaskRequest struct {
+	Name *string `binding:"required"`
+}
@@ -0,0 +1,18 @@
+package DTOs
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"github.com/google/uuid"
+	"time"
+)
+
+type ParseTaskResponse struct {
+	Name      string          `binding:"required" json:"name"`
+	Priority  *enums.Priority `json:"priority"`
+	Deadline  *time.Time      `json:"deadline"`
+	Tags      []string        `binding:"required" json:"tags"`
+	ProjectID *uuid.UUID      `json:"projectId"`
+
+	Recognized   []MacroResponse `binding:"required" json:"recognized"`
+	Unrecognized []MacroResponse `binding:"required" json:"unrecognized"`
+}
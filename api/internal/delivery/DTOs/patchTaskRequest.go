@@ -0,0 +1,22 @@
+package DTOs
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"github.com/google/uuid"
+	"time"
+)
+
+// PatchTaskRequest — JSON Merge Patch (RFC 7396): отсутствующее поле не меняется, null сбрасывает его
+type PatchTaskRequest struct {
+	Name        Optional[string]    `json:"name" swaggertype:"string"`
+	Description Optional[string]    `json:"description" swaggertype:"string"`
+	Deadline    Optional[time.Time] `json:"deadline" swaggertype:"string" format:"date-time"`
+	// null сбрасывает приоритет в Medium
+	Priority Optional[enums.Priority] `json:"priority" swaggertype:"string" enums:"Low,Medium,High,Critical"`
+	// Правило повторения RRULE, например "FREQ=WEEKLY;BYDAY=MO,TH"
+	Recurrence Optional[string] `json:"recurrence" swaggertype:"string"`
+	// Имена меток заменяют текущие; null или пустой список снимает все метки
+	Tags Optional[[]string] `json:"tags" swaggertype:"array,string"`
+	// null убирает задачу из проекта
+	ProjectID Optional[uuid.UUID] `json:"projectId" swaggertype:"string" format:"uuid"`
+}
@@ -0,0 +1,16 @@
+package DTOs
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ProjectResponse struct {
+	ID         uuid.UUID  `binding:"required" json:"id"`
+	CreatedAt  time.Time  `binding:"required" json:"createdAt"`
+	ChangedAt  *time.Time `json:"changedAt"`
+	Name       string     `binding:"required" json:"name"`
+	Color      *string    `json:"color"`
+	IsArchived bool       `binding:"required" json:"isArchived"`
+	IsInbox    bool       `binding:"required" json:"isInbox"`
+}
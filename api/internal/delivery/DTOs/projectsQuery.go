@@ -0,0 +1,5 @@
+package DTOs
+
+type ProjectsQuery struct {
+	Archived *bool `form:"archived"`
+}
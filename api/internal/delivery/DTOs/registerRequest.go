@@ -0,0 +1,6 @@
+package DTOs
+
+type RegisterRequest struct {
+	Email    *string `binding:"required"`
+	Password *string `binding:"required"`
+}
@@ -0,0 +1,6 @@
+package DTOs
+
+// Пустой список отключает напоминания задачи
+type RemindersRequest struct {
+	MinutesBefore []int `binding:"required" json:"minutesBefore"`
+}
@@ -0,0 +1,5 @@
+package DTOs
+
+type TagRequest struct {
+	Name *string `binding:"required"`
+}
@@ -0,0 +1,12 @@
+package DTOs
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type TagResponse struct {
+	ID        uuid.UUID `binding:"required" json:"id"`
+	CreatedAt time.Time `binding:"required" json:"createdAt"`
+	Name      string    `binding:"required" json:"name"`
+}
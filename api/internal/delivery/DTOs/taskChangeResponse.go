@@ -0,0 +1,13 @@
+package DTOs
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"time"
+)
+
+type TaskChangeResponse struct {
+	ID         uint64               `binding:"required" json:"id"`
+	Type       enums.TaskChangeType `binding:"required" json:"type" enums:"created,updated,toggled,deleted,overdue"`
+	Task       TaskResponse         `binding:"required" json:"task"`
+	OccurredAt time.Time            `binding:"required" json:"occurredAt"`
+}
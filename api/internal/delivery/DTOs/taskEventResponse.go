@@ -0,0 +1,21 @@
+package DTOs
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"github.com/google/uuid"
+	"time"
+)
+
+type TaskEventResponse struct {
+	ID        uuid.UUID             `binding:"required" json:"id"`
+	CreatedAt time.Time             `binding:"required" json:"createdAt"`
+	ActorID   *uuid.UUID            `json:"actorId"`
+	Type      enums.TaskEventType   `binding:"required" json:"type"`
+	Changes   []FieldChangeResponse `binding:"required" json:"changes"`
+}
+
+type FieldChangeResponse struct {
+	Field string  `binding:"required" json:"field"`
+	Old   *string `json:"old"`
+	New   *string `json:"new"`
+}
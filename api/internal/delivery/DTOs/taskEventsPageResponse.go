@@ -0,0 +1,6 @@
+package DTOs
+
+type TaskEventsPageResponse struct {
+	Items      []TaskEventResponse `binding:"required" json:"items"`
+	NextCursor *string             `json:"nextCursor"`
+}
@@ -0,0 +1,13 @@
+package DTOs
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type TaskReminderResponse struct {
+	ID            uuid.UUID  `binding:"required" json:"id"`
+	MinutesBefore int        `binding:"required" json:"minutesBefore"`
+	RemindAt      *time.Time `json:"remindAt"`
+	SentAt        *time.Time `json:"sentAt"`
+}
@@ -0,0 +1,17 @@
+package DTOs
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"time"
+)
+
+type TasksFilterQuery struct {
+	Status       []enums.Status   `form:"status"`
+	Priority     []enums.Priority `form:"priority"`
+	DeadlineFrom *time.Time       `form:"deadlineFrom" time_format:"2006-01-02T15:04:05Z07:00"`
+	DeadlineTo   *time.Time       `form:"deadlineTo" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedFrom  *time.Time       `form:"createdFrom" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo    *time.Time       `form:"createdTo" time_format:"2006-01-02T15:04:05Z07:00"`
+	Q            *string          `form:"q"`
+	Tag          []string         `form:"tag"`
+}
@@ -0,0 +1,6 @@
+package DTOs
+
+type TasksPageResponse struct {
+	Items      []TaskResponse `binding:"required" json:"items"`
+	NextCursor *string        `json:"nextCursor"`
+}
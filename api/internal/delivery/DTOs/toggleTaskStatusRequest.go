@@ -2,4 +2,6 @@ package DTOs
 
 type ToggleTaskStatusRequest struct {
 	IsDone *bool `binding:"required"`
+	// Отметить выполненными оставшиеся пункты чек-листа; без флага такая задача не может быть выполнена
+	CompleteItems bool
 }
@@ -0,0 +1,9 @@
+package DTOs
+
+import "time"
+
+type TokenResponse struct {
+	AccessToken string    `binding:"required" json:"accessToken"`
+	TokenType   string    `binding:"required" json:"tokenType"`
+	ExpiresAt   time.Time `binding:"required" json:"expiresAt"`
+}
@@ -0,0 +1,5 @@
+package DTOs
+
+type UpdateProfileRequest struct {
+	TimeZone *string `json:"timeZone"`
+}
@@ -0,0 +1,8 @@
+package DTOs
+
+type UpdateProjectRequest struct {
+	Name *string `binding:"required"`
+	// Цвет в формате #RRGGBB
+	Color      *string
+	IsArchived bool
+}
@@ -0,0 +1,13 @@
+package DTOs
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type UserResponse struct {
+	ID        uuid.UUID `binding:"required" json:"id"`
+	CreatedAt time.Time `binding:"required" json:"createdAt"`
+	Email     string    `binding:"required" json:"email"`
+	TimeZone  *string   `json:"timeZone"`
+}
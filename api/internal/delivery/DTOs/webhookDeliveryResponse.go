@@ -0,0 +1,22 @@
+package DTOs
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type WebhookDeliveryResponse struct {
+	ID            uuid.UUID                   `binding:"required" json:"id"`
+	WebhookID     uuid.UUID                   `binding:"required" json:"webhookId"`
+	CreatedAt     time.Time                   `binding:"required" json:"createdAt"`
+	EventType     enums.TaskChangeType        `binding:"required" json:"eventType"`
+	Status        enums.WebhookDeliveryStatus `binding:"required" json:"status"`
+	Attempts      int                         `binding:"required" json:"attempts"`
+	NextAttemptAt time.Time                   `binding:"required" json:"nextAttemptAt"`
+	LastError     *string                     `json:"lastError"`
+	DeliveredAt   *time.Time                  `json:"deliveredAt"`
+	// Тело запроса, отправляемое подписке
+	Payload json.RawMessage `binding:"required" json:"payload" swaggertype:"object"`
+}
@@ -0,0 +1,16 @@
+package DTOs
+
+import "HITS_ToDoList_Tests/internal/domain/enums"
+
+// WebhookRequest — подписка на изменения задач при создании и изменении
+type WebhookRequest struct {
+	// Адрес, на который отправляются POST-запросы с изменениями
+	URL *string `binding:"required"`
+	// Ключ подписи не короче 16 символов; при создании без ключа он генерируется,
+	// при изменении без ключа остаётся прежним
+	Secret *string
+	// Виды изменений (created, updated, toggled, deleted, overdue); пусто — все
+	EventTypes []enums.TaskChangeType
+	// Приоритеты задач; пусто — все
+	Priorities []enums.Priority
+}
@@ -0,0 +1,17 @@
+package DTOs
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"github.com/google/uuid"
+	"time"
+)
+
+type WebhookResponse struct {
+	ID         uuid.UUID              `binding:"required" json:"id"`
+	CreatedAt  time.Time              `binding:"required" json:"createdAt"`
+	URL        string                 `binding:"required" json:"url"`
+	EventTypes []enums.TaskChangeType `binding:"required" json:"eventTypes"`
+	Priorities []enums.Priority       `binding:"required" json:"priorities"`
+	// Ключ подписи возвращается только при создании подписки
+	Secret *string `json:"secret,omitempty"`
+}
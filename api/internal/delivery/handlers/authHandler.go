@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type AuthHandler struct {
+	authService interfaces.AuthService
+}
+
+func NewAuthHandler(authService interfaces.AuthService) *AuthHandler {
+	return &AuthHandler{authService: authService}
+}
+
+// Register
+// @Summary Register a user
+// @Description Create a new user account
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param user body DTOs.RegisterRequest true "User"
+// @Success 201 {object} DTOs.UserResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 409 {object} errors.ApplicationError "Email already taken"
+// @Failure 500 "Internal server error"
+// @Router /auth/register [post]
+func (h *AuthHandler) Register(c *gin.Context) {
+	var request DTOs.RegisterRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	user, err := h.authService.Register(*request.Email, *request.Password)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toUserResponse(user))
+}
+
+// Login
+// @Summary Log in
+// @Description Exchange email and password for a signed JWT access token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body DTOs.LoginRequest true "Credentials"
+// @Success 200 {object} DTOs.TokenResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Invalid credentials"
+// @Failure 500 "Internal server error"
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var request DTOs.LoginRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	token, expiresAt, err := h.authService.Login(*request.Email, *request.Password)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, DTOs.TokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresAt:   expiresAt,
+	})
+}
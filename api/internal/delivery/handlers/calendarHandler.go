@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/delivery/ical"
+	"HITS_ToDoList_Tests/internal/delivery/middleware"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+)
+
+const calendarName = "Задачи"
+
+type CalendarHandler struct {
+	tasksService interfaces.TasksService
+	usersService interfaces.UsersService
+}
+
+func NewCalendarHandler(tasksService interfaces.TasksService, usersService interfaces.UsersService) *CalendarHandler {
+	return &CalendarHandler{tasksService: tasksService, usersService: usersService}
+}
+
+// ExportTasks
+// @Summary Export tasks to iCalendar
+// @Description Render tasks as an RFC 5545 calendar with the same filtering and sorting as GET /tasks.
+// @Description component=VTODO (default) renders every task as a to-do with DUE, PRIORITY (Critical 1,
+// @Description High 3, Medium 5, Low 9) and STATUS (COMPLETED or NEEDS-ACTION); component=VEVENT renders
+// @Description tasks with a deadline as events at the deadline. The same calendar is available without
+// @Description the Authorization header at the secret feed URL from POST /users/me/calendar-feed.
+// @Tags calendar
+// @Produce text/calendar
+// @Param component query string false "Calendar component" Enums(VTODO, VEVENT)
+// @Param sorting query string false "Sorting" Enums(CreateAsc, CreateDesc, PriorityAsc, PriorityDesc, DeadlineAsc, DeadlineDesc)
+// @Param status query []string false "Status" collectionFormat(multi) Enums(Active, Completed, Overdue, Late)
+// @Param priority query []string false "Priority" collectionFormat(multi) Enums(Low, Medium, High, Critical)
+// @Param deadlineFrom query string false "Deadline from (RFC 3339)" format(date-time)
+// @Param deadlineTo query string false "Deadline to (RFC 3339)" format(date-time)
+// @Param createdFrom query string false "Created from (RFC 3339)" format(date-time)
+// @Param createdTo query string false "Created to (RFC 3339)" format(date-time)
+// @Param q query string false "Search in name and description"
+// @Param tag query []string false "Tag name; tasks with any of the tags match" collectionFormat(multi)
+// @Success 200 {string} string "iCalendar"
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks.ics [get]
+func (h *CalendarHandler) ExportTasks(c *gin.Context) {
+	var query DTOs.CalendarQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	component := ical.ToDo
+	if query.Component != nil {
+		component = ical.Component(*query.Component)
+	}
+
+	filter, sorting, ok := bindTasksQuery(c, nil)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.tasksService.GetAllTasks(middleware.CurrentUserID(c), filter, sorting)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", ical.Encode(calendarName, tasks, component))
+}
+
+// ExportFeed
+// @Summary Calendar feed
+// @Description The calendar of GET /tasks.ics at a secret URL for calendar subscriptions, with the same
+// @Description query parameters. The token in the URL replaces the Authorization header.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token"
+// @Param component query string false "Calendar component" Enums(VTODO, VEVENT)
+// @Param status query []string false "Status" collectionFormat(multi) Enums(Active, Completed, Overdue, Late)
+// @Param priority query []string false "Priority" collectionFormat(multi) Enums(Low, Medium, High, Critical)
+// @Param tag query []string false "Tag name; tasks with any of the tags match" collectionFormat(multi)
+// @Success 200 {string} string "iCalendar"
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Router /calendar/{token}/tasks.ics [get]
+func (h *CalendarHandler) ExportFeed(c *gin.Context) {
+	h.ExportTasks(c)
+}
+
+// CreateFeed
+// @Summary Create calendar feed
+// @Description Issue a secret calendar feed URL for subscribing from Thunderbird, Google Calendar and
+// @Description other clients. A new URL replaces the previous one; the URL is returned only here.
+// @Tags calendar
+// @Produce json
+// @Success 201 {object} DTOs.CalendarFeedResponse
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /users/me/calendar-feed [post]
+func (h *CalendarHandler) CreateFeed(c *gin.Context) {
+	token, err := h.usersService.CreateCalendarFeed(middleware.CurrentUserID(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, DTOs.CalendarFeedResponse{URL: feedURL(c, token)})
+}
+
+// DeleteFeed
+// @Summary Delete calendar feed
+// @Description Revoke the secret calendar feed URL
+// @Tags calendar
+// @Success 204 "No Content"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /users/me/calendar-feed [delete]
+func (h *CalendarHandler) DeleteFeed(c *gin.Context) {
+	if err := h.usersService.DeleteCalendarFeed(middleware.CurrentUserID(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Адрес ленты на том же хосте, к которому обратился клиент; за обратным прокси схему задаёт X-Forwarded-Proto
+func feedURL(c *gin.Context, token string) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+
+	feed := url.URL{Scheme: scheme, Host: c.Request.Host, Path: "/calendar/" + token + "/tasks.ics"}
+	return feed.String()
+}
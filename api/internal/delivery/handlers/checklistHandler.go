@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/delivery/middleware"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+type ChecklistHandler struct {
+	checklistService interfaces.ChecklistService
+}
+
+func NewChecklistHandler(checklistService interfaces.ChecklistService) *ChecklistHandler {
+	return &ChecklistHandler{checklistService: checklistService}
+}
+
+// GetItems
+// @Summary Get checklist items
+// @Description Get checklist items of the task in display order
+// @Tags checklist
+// @Produce json
+// @Param id path string true "Task id"
+// @Success 200 {object} []DTOs.ChecklistItemResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{id}/items [get]
+func (h *ChecklistHandler) GetItems(c *gin.Context) {
+	taskID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	items, err := h.checklistService.GetItems(middleware.CurrentUserID(c), taskID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := make([]DTOs.ChecklistItemResponse, len(items))
+	for i, item := range items {
+		response[i] = toChecklistItemResponse(item)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AddItem
+// @Summary Add checklist item
+// @Description Add an item to the end of the task's checklist
+// @Tags checklist
+// @Accept json
+// @Produce json
+// @Param id path string true "Task id"
+// @Param item body DTOs.CreateChecklistItemRequest true "Item"
+// @Success 201 {object} DTOs.ChecklistItemResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{id}/items [post]
+func (h *ChecklistHandler) AddItem(c *gin.Context) {
+	taskID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request DTOs.CreateChecklistItemRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	item, err := h.checklistService.AddItem(middleware.CurrentUserID(c), taskID, *request.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toChecklistItemResponse(item))
+}
+
+// UpdateItem
+// @Summary Update checklist item
+// @Description Rename the item and mark it done or not done
+// @Tags checklist
+// @Accept json
+// @Produce json
+// @Param id path string true "Task id"
+// @Param itemId path string true "Item id"
+// @Param item body DTOs.UpdateChecklistItemRequest true "Item"
+// @Success 200 {object} DTOs.ChecklistItemResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{id}/items/{itemId} [put]
+func (h *ChecklistHandler) UpdateItem(c *gin.Context) {
+	taskID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	itemID, ok := parseUUIDParam(c, "itemId")
+	if !ok {
+		return
+	}
+
+	var request DTOs.UpdateChecklistItemRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	item, err := h.checklistService.UpdateItem(middleware.CurrentUserID(c), taskID, itemID, *request.Name,
+		*request.IsDone)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toChecklistItemResponse(item))
+}
+
+// DeleteItem
+// @Summary Delete checklist item
+// @Description Delete checklist item by ID
+// @Tags checklist
+// @Param id path string true "Task id"
+// @Param itemId path string true "Item id"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{id}/items/{itemId} [delete]
+func (h *ChecklistHandler) DeleteItem(c *gin.Context) {
+	taskID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	itemID, ok := parseUUIDParam(c, "itemId")
+	if !ok {
+		return
+	}
+
+	if err := h.checklistService.DeleteItem(middleware.CurrentUserID(c), taskID, itemID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Некорректный идентификатор в пути — ошибка запроса, а не отсутствующий ресурс
+func parseUUIDParam(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func toChecklistItemResponse(item *models.ChecklistItem) DTOs.ChecklistItemResponse {
+	return DTOs.ChecklistItemResponse{
+		ID:        item.ID,
+		TaskID:    item.TaskID,
+		CreatedAt: item.CreatedAt,
+		ChangedAt: item.ChangedAt,
+		Name:      item.Name,
+		IsDone:    item.IsDone,
+		Position:  item.Position,
+	}
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	appEnums "HITS_ToDoList_Tests/internal/application/enums"
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/delivery/middleware"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ProjectsHandler struct {
+	projectsService interfaces.ProjectsService
+}
+
+func NewProjectsHandler(projectsService interfaces.ProjectsService) *ProjectsHandler {
+	return &ProjectsHandler{projectsService: projectsService}
+}
+
+// GetProjects
+// @Summary Get projects
+// @Description Get projects of the current user: Inbox first, the rest by creation date.
+// @Description Inbox is created on the first request.
+// @Tags projects
+// @Produce json
+// @Param archived query bool false "Only archived (true) or only active (false) projects"
+// @Success 200 {object} []DTOs.ProjectResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /projects [get]
+func (h *ProjectsHandler) GetProjects(c *gin.Context) {
+	var query DTOs.ProjectsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	projects, err := h.projectsService.GetProjects(middleware.CurrentUserID(c), query.Archived)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := make([]DTOs.ProjectResponse, len(projects))
+	for i, project := range projects {
+		response[i] = toProjectResponse(project)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateProject
+// @Summary Create a project
+// @Description Create a project (task list)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param project body DTOs.CreateProjectRequest true "Project"
+// @Success 201 {object} DTOs.ProjectResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /projects [post]
+func (h *ProjectsHandler) CreateProject(c *gin.Context) {
+	var request DTOs.CreateProjectRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	project, err := h.projectsService.CreateProject(middleware.CurrentUserID(c), *request.Name, request.Color)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toProjectResponse(project))
+}
+
+// GetProject
+// @Summary Get a project
+// @Description Get project by ID
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project id"
+// @Success 200 {object} DTOs.ProjectResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /projects/{id} [get]
+func (h *ProjectsHandler) GetProject(c *gin.Context) {
+	projectID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	project, err := h.projectsService.GetProject(middleware.CurrentUserID(c), projectID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toProjectResponse(project))
+}
+
+// UpdateProject
+// @Summary Update a project
+// @Description Rename, recolor, archive or unarchive the project. Inbox cannot be archived.
+// @Description Tasks cannot be added to an archived project.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project id"
+// @Param project body DTOs.UpdateProjectRequest true "Project"
+// @Success 200 {object} DTOs.ProjectResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 409 {object} errors.ApplicationError "Inbox project cannot be archived"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /projects/{id} [put]
+func (h *ProjectsHandler) UpdateProject(c *gin.Context) {
+	projectID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request DTOs.UpdateProjectRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	project, err := h.projectsService.UpdateProject(middleware.CurrentUserID(c), projectID, *request.Name,
+		request.Color, request.IsArchived)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toProjectResponse(project))
+}
+
+// DeleteProject
+// @Summary Delete a project
+// @Description Delete the project. With mode=inbox (default) its tasks are moved to Inbox,
+// @Description with mode=cascade they are deleted too. Inbox cannot be deleted.
+// @Tags projects
+// @Param id path string true "Project id"
+// @Param mode query string false "What to do with the project's tasks" Enums(inbox, cascade)
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 409 {object} errors.ApplicationError "Inbox project cannot be deleted"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /projects/{id} [delete]
+func (h *ProjectsHandler) DeleteProject(c *gin.Context) {
+	projectID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var query DTOs.DeleteProjectQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	mode := appEnums.MoveToInbox
+	if query.Mode != nil {
+		mode = *query.Mode
+	}
+
+	if err := appEnums.ValidateProjectDeleteMode(mode); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"mode": err.Error()},
+		})
+		return
+	}
+
+	if err := h.projectsService.DeleteProject(middleware.CurrentUserID(c), projectID, mode); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func toProjectResponse(project *models.Project) DTOs.ProjectResponse {
+	return DTOs.ProjectResponse{
+		ID:         project.ID,
+		CreatedAt:  project.CreatedAt,
+		ChangedAt:  project.ChangedAt,
+		Name:       project.Name,
+		Color:      project.Color,
+		IsArchived: project.IsArchived,
+		IsInbox:    project.IsInbox,
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/delivery/middleware"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type RemindersHandler struct {
+	remindersService interfaces.RemindersService
+}
+
+func NewRemindersHandler(remindersService interfaces.RemindersService) *RemindersHandler {
+	return &RemindersHandler{remindersService: remindersService}
+}
+
+// GetReminders
+// @Summary Get task reminders
+// @Description Get deadline reminders of the task, the earliest first. remindAt is empty while the task
+// @Description has no deadline or is not active; sentAt is set once the reminder is sent or skipped
+// @Tags reminders
+// @Produce json
+// @Param id path string true "Task id"
+// @Success 200 {object} []DTOs.TaskReminderResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{id}/reminders [get]
+func (h *RemindersHandler) GetReminders(c *gin.Context) {
+	taskID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	reminders, err := h.remindersService.GetReminders(middleware.CurrentUserID(c), taskID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskReminderResponses(reminders))
+}
+
+// SetReminders
+// @Summary Set task reminders
+// @Description Replace deadline reminders of the task with reminders the given numbers of minutes
+// @Description before the deadline (up to 10, each from 1 minute to 30 days). An empty list removes them.
+// @Description Reminders are sent through the configured notifiers: log, e-mail and "reminder" webhooks
+// @Tags reminders
+// @Accept json
+// @Produce json
+// @Param id path string true "Task id"
+// @Param reminders body DTOs.RemindersRequest true "Reminders"
+// @Success 200 {object} []DTOs.TaskReminderResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{id}/reminders [put]
+func (h *RemindersHandler) SetReminders(c *gin.Context) {
+	taskID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request DTOs.RemindersRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	reminders, err := h.remindersService.SetReminders(middleware.CurrentUserID(c), taskID, request.MinutesBefore)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskReminderResponses(reminders))
+}
+
+func toTaskReminderResponses(reminders []*models.TaskReminder) []DTOs.TaskReminderResponse {
+	response := make([]DTOs.TaskReminderResponse, len(reminders))
+	for i, reminder := range reminders {
+		response[i] = DTOs.TaskReminderResponse{
+			ID:            reminder.ID,
+			MinutesBefore: reminder.MinutesBefore,
+			RemindAt:      reminder.RemindAt,
+			SentAt:        reminder.SentAt,
+		}
+	}
+
+	return response
+}
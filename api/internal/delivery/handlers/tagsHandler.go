@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/delivery/middleware"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type TagsHandler struct {
+	tagsService interfaces.TagsService
+}
+
+func NewTagsHandler(tagsService interfaces.TagsService) *TagsHandler {
+	return &TagsHandler{tagsService: tagsService}
+}
+
+// GetTags
+// @Summary Get tags
+// @Description Get all tags of the current user ordered by name
+// @Tags tags
+// @Produce json
+// @Success 200 {object} []DTOs.TagResponse
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tags [get]
+func (h *TagsHandler) GetTags(c *gin.Context) {
+	tags, err := h.tagsService.GetTags(middleware.CurrentUserID(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := make([]DTOs.TagResponse, len(tags))
+	for i, tag := range tags {
+		response[i] = toTagResponse(*tag)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateTag
+// @Summary Create a tag
+// @Description Create a tag. The name is stored in lower case and may contain letters, digits, '_' and '-'
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tag body DTOs.TagRequest true "Tag"
+// @Success 201 {object} DTOs.TagResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 409 {object} errors.ApplicationError "Tag with this name already exists"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tags [post]
+func (h *TagsHandler) CreateTag(c *gin.Context) {
+	var request DTOs.TagRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	tag, err := h.tagsService.CreateTag(middleware.CurrentUserID(c), *request.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTagResponse(*tag))
+}
+
+// UpdateTag
+// @Summary Rename a tag
+// @Description Rename the tag; tasks keep it attached
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag id"
+// @Param tag body DTOs.TagRequest true "Tag"
+// @Success 200 {object} DTOs.TagResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 409 {object} errors.ApplicationError "Tag with this name already exists"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tags/{id} [put]
+func (h *TagsHandler) UpdateTag(c *gin.Context) {
+	tagID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request DTOs.TagRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	tag, err := h.tagsService.UpdateTag(middleware.CurrentUserID(c), tagID, *request.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTagResponse(*tag))
+}
+
+// DeleteTag
+// @Summary Delete a tag
+// @Description Delete the tag and detach it from all tasks
+// @Tags tags
+// @Param id path string true "Tag id"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tags/{id} [delete]
+func (h *TagsHandler) DeleteTag(c *gin.Context) {
+	tagID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.tagsService.DeleteTag(middleware.CurrentUserID(c), tagID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func toTagResponse(tag models.Tag) DTOs.TagResponse {
+	return DTOs.TagResponse{
+		ID:        tag.ID,
+		CreatedAt: tag.CreatedAt,
+		Name:      tag.Name,
+	}
+}
+
+func toTagResponses(tags []models.Tag) []DTOs.TagResponse {
+	response := make([]DTOs.TagResponse, len(tags))
+	for i, tag := range tags {
+		response[i] = toTagResponse(tag)
+	}
+
+	return response
+}
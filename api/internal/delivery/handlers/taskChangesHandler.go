@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/delivery/middleware"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TaskChangesHandler struct {
+	stream            interfaces.TaskChangeStream
+	heartbeatInterval time.Duration
+}
+
+func NewTaskChangesHandler(stream interfaces.TaskChangeStream, heartbeatInterval time.Duration) *TaskChangesHandler {
+	return &TaskChangesHandler{stream: stream, heartbeatInterval: heartbeatInterval}
+}
+
+// StreamTaskChanges
+// @Summary Stream task changes
+// @Description Server-Sent Events stream of the current user's task changes. The event name is the change type:
+// @Description created, updated, toggled, deleted or overdue (the scheduler marked the task overdue);
+// @Description data is a DTOs.TaskChangeResponse with the task after the change.
+// @Description A reconnecting client sends the last received event id in Last-Event-ID and gets the missed
+// @Description changes first. If they are no longer kept, the stream starts with a reset event and the client
+// @Description should reload its tasks. EventSource cannot set headers, so the token may be passed
+// @Description in the access_token query parameter instead.
+// @Tags tasks
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "Id of the last received event"
+// @Param access_token query string false "Bearer token for clients that cannot set the Authorization header"
+// @Success 200 {object} DTOs.TaskChangeResponse
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Security BearerAuth
+// @Router /tasks/events [get]
+func (h *TaskChangesHandler) StreamTaskChanges(c *gin.Context) {
+	lastEventID, known := parseLastEventID(c)
+	subscription := h.stream.Subscribe(middleware.CurrentUserID(c), lastEventID)
+	defer subscription.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	// Запрещает nginx буферизовать поток
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if subscription.Reset || !known {
+		fmt.Fprint(c.Writer, "event: reset\ndata: {}\n\n")
+	}
+	for _, change := range subscription.Missed {
+		writeTaskChange(c.Writer, change)
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case change, ok := <-subscription.Changes:
+			if !ok {
+				return
+			}
+			writeTaskChange(c.Writer, change)
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
+
+// Last-Event-ID, который сервер не выдавал, не позволяет восстановить пропущенное: known == false
+func parseLastEventID(c *gin.Context) (lastEventID *uint64, known bool) {
+	value := strings.TrimSpace(c.GetHeader("Last-Event-ID"))
+	if value == "" {
+		return nil, true
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	return &id, true
+}
+
+func writeTaskChange(w io.Writer, change models.TaskChange) {
+	data, err := json.Marshal(DTOs.TaskChangeResponse{
+		ID:         change.ID,
+		Type:       change.Type,
+		Task:       toTaskResponse(&change.Task),
+		OccurredAt: change.OccurredAt,
+	})
+	if err != nil {
+		fmt.Println("Failed to encode task change", change.ID, err.Error())
+		return
+	}
+
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", change.ID, change.Type, data)
+}
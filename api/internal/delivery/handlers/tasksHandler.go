@@ -4,11 +4,24 @@ import (
 	appEnums "HITS_ToDoList_Tests/internal/application/enums"
 	"HITS_ToDoList_Tests/internal/application/errors"
 	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/application/macros"
 	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/delivery/imports"
+	"HITS_ToDoList_Tests/internal/delivery/middleware"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
 	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	defaultErrors "errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 // @BasePath /tasks
@@ -28,9 +41,13 @@ func NewTasksHandler(tasksService interfaces.TasksService) *TasksHandler {
 // @Accept json
 // @Produce json
 // @Param task body DTOs.CreateTaskRequest true "Task"
+// @Param X-Time-Zone header string false "IANA time zone for macro dates; defaults to the profile time zone"
 // @Success 201 {object} models.Task
+// @Header 201 {string} ETag "Task version for If-Match"
 // @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
 // @Failure 500 "Internal server error"
+// @Security BearerAuth
 // @Router /tasks [post]
 func (h *TasksHandler) CreateTask(c *gin.Context) {
 	var request DTOs.CreateTaskRequest
@@ -43,35 +60,113 @@ func (h *TasksHandler) CreateTask(c *gin.Context) {
 		return
 	}
 
-	task, err := h.tasksService.CreateTask(*request.Name, request.Description, request.Deadline, request.Priority)
+	task, err := h.tasksService.CreateTask(middleware.CurrentUserID(c), *request.Name, request.Description,
+		request.Deadline, request.Priority, request.Recurrence, request.Tags, request.ProjectID,
+		middleware.CurrentLocation(c))
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, DTOs.TaskResponse{
-		ID:          task.ID,
-		CreatedAt:   task.CreatedAt,
-		ChangedAt:   task.ChangedAt,
-		Name:        task.Name,
-		Description: task.Description,
-		Deadline:    task.Deadline,
-		Status:      task.Status,
-		Priority:    task.Priority,
-	})
+	setTaskETag(c, task)
+	c.JSON(http.StatusCreated, toTaskResponse(task))
 }
 
 // GetAllTasks
 // @Summary Get all tasks
-// @Description Get all tasks with optional sorting
+// @Description Get all tasks with optional filtering, full-text search and sorting.
+// @Description Returns a {items, nextCursor} page; pass nextCursor as cursor to get the next one.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param sorting query string false "Sorting" Enums(CreateAsc, CreateDesc, PriorityAsc, PriorityDesc, DeadlineAsc, DeadlineDesc)
-// @Success 200 {object} []models.Task
+// @Param status query []string false "Status" collectionFormat(multi) Enums(Active, Completed, Overdue, Late)
+// @Param priority query []string false "Priority" collectionFormat(multi) Enums(Low, Medium, High, Critical)
+// @Param deadlineFrom query string false "Deadline from (RFC 3339)" format(date-time)
+// @Param deadlineTo query string false "Deadline to (RFC 3339)" format(date-time)
+// @Param createdFrom query string false "Created from (RFC 3339)" format(date-time)
+// @Param createdTo query string false "Created to (RFC 3339)" format(date-time)
+// @Param q query string false "Search in name and description"
+// @Param tag query []string false "Tag name; tasks with any of the tags match" collectionFormat(multi)
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor from the previous page's nextCursor"
+// @Success 200 {object} DTOs.TasksPageResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
 // @Failure 500 "Internal server error"
+// @Security BearerAuth
 // @Router /tasks [get]
 func (h *TasksHandler) GetAllTasks(c *gin.Context) {
+	h.listTasks(c, nil)
+}
+
+// GetProjectTasks
+// @Summary Get project tasks
+// @Description Get tasks of the project with the same filtering, sorting and pagination as GET /tasks.
+// @Description Returns a {items, nextCursor} page; pass nextCursor as cursor to get the next one.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project id"
+// @Param sorting query string false "Sorting" Enums(CreateAsc, CreateDesc, PriorityAsc, PriorityDesc, DeadlineAsc, DeadlineDesc)
+// @Param status query []string false "Status" collectionFormat(multi) Enums(Active, Completed, Overdue, Late)
+// @Param priority query []string false "Priority" collectionFormat(multi) Enums(Low, Medium, High, Critical)
+// @Param deadlineFrom query string false "Deadline from (RFC 3339)" format(date-time)
+// @Param deadlineTo query string false "Deadline to (RFC 3339)" format(date-time)
+// @Param createdFrom query string false "Created from (RFC 3339)" format(date-time)
+// @Param createdTo query string false "Created to (RFC 3339)" format(date-time)
+// @Param q query string false "Search in name and description"
+// @Param tag query []string false "Tag name; tasks with any of the tags match" collectionFormat(multi)
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor from the previous page's nextCursor"
+// @Success 200 {object} DTOs.TasksPageResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /projects/{id}/tasks [get]
+func (h *TasksHandler) GetProjectTasks(c *gin.Context) {
+	projectID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	h.listTasks(c, &projectID)
+}
+
+// projectID != nil ограничивает выборку задачами проекта
+func (h *TasksHandler) listTasks(c *gin.Context, projectID *uuid.UUID) {
+	filter, sorting, ok := bindTasksQuery(c, projectID)
+	if !ok {
+		return
+	}
+
+	var pagination DTOs.PaginationQuery
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	tasks, nextCursor, err := h.tasksService.GetTasksPage(middleware.CurrentUserID(c), filter, sorting,
+		pagination.Cursor, pagination.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, DTOs.TasksPageResponse{
+		Items:      toTaskResponses(tasks),
+		NextCursor: nextCursor,
+	})
+}
+
+// Отбор и сортировка задач из параметров запроса, общие для списков задач и календаря
+func bindTasksQuery(c *gin.Context, projectID *uuid.UUID) (*models.TasksFilter, *appEnums.Sorting, bool) {
 	var sorting = utils.Ptr(c.Query("sorting"))
 	if *sorting == "" {
 		sorting = nil
@@ -85,44 +180,82 @@ func (h *TasksHandler) GetAllTasks(c *gin.Context) {
 				Code:       "InvalidRequest",
 				Errors:     map[string]string{"message": err.Error()},
 			})
-			return
+			return nil, nil, false
 		}
 	}
 
-	tasks, err := h.tasksService.GetAllTasks((*appEnums.Sorting)(sorting))
-	if err != nil {
-		c.Error(err)
+	var query DTOs.TasksFilterQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return nil, nil, false
+	}
+
+	filter := &models.TasksFilter{
+		Statuses:     query.Status,
+		Priorities:   query.Priority,
+		DeadlineFrom: query.DeadlineFrom,
+		DeadlineTo:   query.DeadlineTo,
+		CreatedFrom:  query.CreatedFrom,
+		CreatedTo:    query.CreatedTo,
+		Query:        query.Q,
+		Tags:         query.Tag,
+		ProjectID:    projectID,
+	}
+
+	return filter, (*appEnums.Sorting)(sorting), true
+}
+
+// GetTask
+// @Summary Get task
+// @Description Get task by ID. The ETag header holds the task version to send in If-Match when changing it.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} DTOs.TaskResponse
+// @Header 200 {string} ETag "Task version for If-Match"
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{id} [get]
+func (h *TasksHandler) GetTask(c *gin.Context) {
+	taskID, ok := parseUUIDParam(c, "id")
+	if !ok {
 		return
 	}
 
-	response := make([]DTOs.TaskResponse, len(tasks))
-	for i, item := range tasks {
-		response[i] = DTOs.TaskResponse{
-			ID:          item.ID,
-			CreatedAt:   item.CreatedAt,
-			ChangedAt:   item.ChangedAt,
-			Name:        item.Name,
-			Description: item.Description,
-			Deadline:    item.Deadline,
-			Status:      item.Status,
-			Priority:    item.Priority,
-		}
+	task, err := h.tasksService.GetTask(middleware.CurrentUserID(c), taskID)
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	setTaskETag(c, task)
+	c.JSON(http.StatusOK, toTaskResponse(task))
 }
 
 // DeleteTask
 // @Summary Delete task
-// @Description Delete task by ID
+// @Description Move task to the trash; it can be restored until the trash retention period expires
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param id path string true "id"
+// @Param If-Match header string true "ETag of the task version being deleted; * skips the version check"
 // @Success 204 "No Content"
 // @Failure 400 {object} errors.ApplicationError "Bad request"
 // @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 412 {object} DTOs.TaskResponse "Task was changed; the body is its current version"
+// @Failure 428 {object} errors.ApplicationError "If-Match header is missing"
 // @Failure 500 "Internal server error"
+// @Security BearerAuth
 // @Router /tasks/{id} [delete]
 func (h *TasksHandler) DeleteTask(c *gin.Context) {
 	taskIDParam := c.Param("id")
@@ -137,9 +270,14 @@ func (h *TasksHandler) DeleteTask(c *gin.Context) {
 		return
 	}
 
-	err = h.tasksService.DeleteTask(taskID)
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	err = h.tasksService.DeleteTask(middleware.CurrentUserID(c), taskID, version)
 	if err != nil {
-		c.Error(err)
+		respondTaskError(c, err)
 		return
 	}
 
@@ -154,10 +292,17 @@ func (h *TasksHandler) DeleteTask(c *gin.Context) {
 // @Produce json
 // @Param id path string true "id"
 // @Param task body DTOs.UpdateTaskRequest true "Task"
+// @Param X-Time-Zone header string false "IANA time zone for macro dates; defaults to the profile time zone"
+// @Param If-Match header string true "ETag of the task version being changed; * skips the version check"
 // @Success 200 {object} DTOs.TaskResponse
+// @Header 200 {string} ETag "New task version"
 // @Failure 400 {object} errors.ApplicationError "Bad request"
 // @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 412 {object} DTOs.TaskResponse "Task was changed; the body is its current version"
+// @Failure 428 {object} errors.ApplicationError "If-Match header is missing"
 // @Failure 500 "Internal server error"
+// @Security BearerAuth
 // @Router /tasks/{id} [put]
 func (h *TasksHandler) UpdateTask(c *gin.Context) {
 	taskIDParam := c.Param("id")
@@ -172,6 +317,11 @@ func (h *TasksHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
 	var request DTOs.UpdateTaskRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
 		c.Error(errors.ApplicationError{
@@ -182,23 +332,492 @@ func (h *TasksHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 
-	task, err := h.tasksService.UpdateTask(taskID, *request.Name, request.Description, request.Deadline,
-		request.Priority)
+	task, err := h.tasksService.UpdateTask(middleware.CurrentUserID(c), taskID, *request.Name, request.Description,
+		request.Deadline, request.Priority, request.Recurrence, request.Tags, request.ProjectID,
+		middleware.CurrentLocation(c), version)
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	setTaskETag(c, task)
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
+
+// PatchTask
+// @Summary Partially update task
+// @Description Apply a JSON Merge Patch (RFC 7396): absent fields are kept, null resets a field.
+// @Description The deadline is checked and the overdue status is recalculated only when the patch moves the deadline.
+// @Tags tasks
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "id"
+// @Param task body DTOs.PatchTaskRequest true "Changed task fields"
+// @Param X-Time-Zone header string false "IANA time zone for macro dates; defaults to the profile time zone"
+// @Param If-Match header string true "ETag of the task version being changed; * skips the version check"
+// @Success 200 {object} DTOs.TaskResponse
+// @Header 200 {string} ETag "New task version"
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 412 {object} DTOs.TaskResponse "Task was changed; the body is its current version"
+// @Failure 415 {object} errors.ApplicationError "Unsupported content type"
+// @Failure 428 {object} errors.ApplicationError "If-Match header is missing"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{id} [patch]
+func (h *TasksHandler) PatchTask(c *gin.Context) {
+	taskID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if contentType := c.ContentType(); contentType != "application/merge-patch+json" &&
+		contentType != "application/json" {
+		c.Error(errors.ApplicationError{
+			StatusCode: http.StatusUnsupportedMediaType,
+			Code:       "UnsupportedMediaType",
+			Errors:     map[string]string{"message": "Content-Type must be application/merge-patch+json"},
+		})
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	var request DTOs.PatchTaskRequest
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	if request.Priority.Value != nil {
+		if err := enums.ValidatePriority(*request.Priority.Value); err != nil {
+			c.Error(errors.ApplicationError{
+				StatusCode: 400,
+				Code:       "InvalidRequest",
+				Errors:     map[string]string{"priority": "Incorrect Priority"},
+			})
+			return
+		}
+	}
+
+	task, err := h.tasksService.PatchTask(middleware.CurrentUserID(c), taskID, toTaskPatch(request),
+		middleware.CurrentLocation(c), version)
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	setTaskETag(c, task)
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
+
+// Название нельзя сбросить: null превращается в пустую строку и не проходит проверку
+func toTaskPatch(request DTOs.PatchTaskRequest) models.TaskPatch {
+	var patch models.TaskPatch
+	if request.Name.Set {
+		patch.Name = utils.Ptr("")
+		if request.Name.Value != nil {
+			patch.Name = request.Name.Value
+		}
+	}
+	if request.Description.Set {
+		patch.Description = &request.Description.Value
+	}
+	if request.Deadline.Set {
+		patch.Deadline = &request.Deadline.Value
+	}
+	if request.Priority.Set {
+		patch.Priority = &request.Priority.Value
+	}
+	if request.Recurrence.Set {
+		patch.Recurrence = &request.Recurrence.Value
+	}
+	if request.Tags.Set {
+		patch.Tags = utils.Ptr([]string{})
+		if request.Tags.Value != nil {
+			patch.Tags = request.Tags.Value
+		}
+	}
+	if request.ProjectID.Set {
+		patch.ProjectID = &request.ProjectID.Value
+	}
+	return patch
+}
+
+// BulkTasks
+// @Summary Run several task operations
+// @Description Run create, update, toggle and delete operations in order in one transaction.
+// @Description With atomic=true a failed operation rolls back all of them, otherwise only the failed one.
+// @Description Each result carries the status code and body a separate request would return.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param operations body DTOs.BulkTasksRequest true "Operations"
+// @Param X-Time-Zone header string false "IANA time zone for macro dates; defaults to the profile time zone"
+// @Success 200 {object} DTOs.BulkTasksResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/bulk [post]
+func (h *TasksHandler) BulkTasks(c *gin.Context) {
+	var request DTOs.BulkTasksRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	operations, err := toTaskOperations(request.Operations)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, DTOs.TaskResponse{
-		ID:          task.ID,
-		CreatedAt:   task.CreatedAt,
-		ChangedAt:   task.ChangedAt,
-		Name:        task.Name,
-		Description: task.Description,
-		Deadline:    task.Deadline,
-		Status:      task.Status,
-		Priority:    task.Priority,
-	})
+	results, committed, err := h.tasksService.BulkTasks(middleware.CurrentUserID(c), operations, request.Atomic,
+		middleware.CurrentLocation(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := DTOs.BulkTasksResponse{Committed: committed, Results: make([]DTOs.BulkOperationResponse, len(results))}
+	for i, result := range results {
+		response.Results[i] = toBulkOperationResponse(operations[i].Type, result)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Поля, обязательные для вида действия, проверяются до выполнения пакета
+func toTaskOperations(requests []DTOs.BulkOperationRequest) ([]models.TaskOperation, error) {
+	operations := make([]models.TaskOperation, len(requests))
+	invalid := map[string]string{}
+
+	for i, request := range requests {
+		field := fmt.Sprintf("operations[%d]", i)
+		operation := models.TaskOperation{Type: request.Op, Version: request.Version}
+
+		if request.Op != enums.OperationCreate {
+			if request.ID == nil {
+				invalid[field+".id"] = fmt.Sprintf("Task id is required for %s", request.Op)
+			} else {
+				operation.TaskID = *request.ID
+			}
+		}
+
+		if request.Op == enums.OperationCreate || request.Op == enums.OperationUpdate {
+			if request.Task == nil {
+				invalid[field+".task"] = fmt.Sprintf("Task fields are required for %s", request.Op)
+			} else {
+				operation.Name = *request.Task.Name
+				operation.Description = request.Task.Description
+				operation.Deadline = request.Task.Deadline
+				operation.Priority = request.Task.Priority
+				operation.Recurrence = request.Task.Recurrence
+				operation.Tags = request.Task.Tags
+				operation.ProjectID = request.Task.ProjectID
+			}
+		}
+
+		if request.Op == enums.OperationToggle {
+			if request.IsDone == nil {
+				invalid[field+".isDone"] = "IsDone is required for toggle"
+			} else {
+				operation.IsDone = *request.IsDone
+				operation.CompleteItems = request.CompleteItems
+			}
+		}
+
+		operations[i] = operation
+	}
+
+	if len(invalid) > 0 {
+		return nil, errors.ApplicationError{StatusCode: 400, Code: "InvalidRequest", Errors: invalid}
+	}
+
+	return operations, nil
+}
+
+func toBulkOperationResponse(operationType enums.TaskOperationType,
+	result models.TaskOperationResult) DTOs.BulkOperationResponse {
+	var conflict errors.VersionConflictError
+	var appErr errors.ApplicationError
+
+	switch {
+	case defaultErrors.As(result.Err, &conflict):
+		task := toTaskResponse(conflict.Current)
+		return DTOs.BulkOperationResponse{
+			StatusCode: http.StatusPreconditionFailed,
+			Task:       &task,
+			Error:      &DTOs.ErrorResponse{StatusCode: http.StatusPreconditionFailed, Code: conflict.Error()},
+		}
+	case defaultErrors.As(result.Err, &appErr):
+		return DTOs.BulkOperationResponse{
+			StatusCode: appErr.StatusCode,
+			Error:      &DTOs.ErrorResponse{StatusCode: appErr.StatusCode, Code: appErr.Code, Errors: appErr.Errors},
+		}
+	}
+
+	response := DTOs.BulkOperationResponse{StatusCode: http.StatusOK}
+	switch operationType {
+	case enums.OperationCreate:
+		response.StatusCode = http.StatusCreated
+	case enums.OperationDelete:
+		response.StatusCode = http.StatusNoContent
+	}
+	if result.Task != nil {
+		task := toTaskResponse(result.Task)
+		response.Task = &task
+	}
+
+	return response
+}
+
+// ImportTasks
+// @Summary Import tasks from a file
+// @Description Create tasks from a CSV file, an RFC 5545 calendar with VTODO entries or a JSON array of tasks
+// @Description in the GET /tasks format. The file is the request body or the "file" field of a multipart form;
+// @Description without the format parameter it is taken from the content type or the file extension.
+// @Description The CSV file has a header row. A field is read from the column named after it (name, description,
+// @Description deadline, priority, recurrence, tags, status) unless columns[field]=Header sets another column.
+// @Description Tasks are validated like POST /tasks and created one by one, so a failed task does not affect
+// @Description the others. Unfinished tasks with a past deadline are skipped or, with overdue=keep, created Overdue.
+// @Description The report has a result for every task of the file.
+// @Tags tasks
+// @Accept text/csv,text/calendar,json,mpfd
+// @Produce json
+// @Param format query string false "File format" Enums(csv, ical, json)
+// @Param overdue query string false "Unfinished tasks with a past deadline" Enums(skip, keep)
+// @Param columns[name] query string false "CSV column with the task name"
+// @Param file formData file false "File, if the request is a multipart form"
+// @Param X-Time-Zone header string false "IANA time zone for dates without an offset; defaults to the profile time zone"
+// @Success 200 {object} DTOs.ImportTasksResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 413 {object} errors.ApplicationError "File is too large"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/import [post]
+func (h *TasksHandler) ImportTasks(c *gin.Context) {
+	var query DTOs.ImportTasksQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	data, contentType, fileName, err := readImportFile(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	format, err := importFormat(query.Format, contentType, fileName)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	location := middleware.CurrentLocation(c)
+	rows, err := imports.Decode(format, data, c.QueryMap("columns"), location)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var tasks []models.TaskImport
+	for _, row := range rows {
+		if row.Err == nil {
+			tasks = append(tasks, row.Task)
+		}
+	}
+
+	// Пустой файл сервис отклоняет; файл, в котором не разобрана ни одна задача, получает отчёт
+	var results []models.TaskImportResult
+	if len(tasks) > 0 || len(rows) == 0 {
+		keepOverdue := query.Overdue != nil && *query.Overdue == "keep"
+		results, err = h.tasksService.ImportTasks(middleware.CurrentUserID(c), tasks, keepOverdue, location)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
+	response := DTOs.ImportTasksResponse{Rows: make([]DTOs.ImportRowResponse, len(rows))}
+	for i, row := range rows {
+		result := models.TaskImportResult{Err: row.Err}
+		if row.Err == nil {
+			result, results = results[0], results[1:]
+		}
+
+		response.Rows[i] = toImportRowResponse(i+1, result)
+		switch response.Rows[i].Result {
+		case importCreated:
+			response.Created++
+		case importSkipped:
+			response.Skipped++
+		default:
+			response.Failed++
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+const (
+	maxImportFileSize = 5 << 20
+
+	importCreated = "created"
+	importSkipped = "skipped"
+	importFailed  = "failed"
+)
+
+// Файл — тело запроса или поле file формы multipart/form-data
+func readImportFile(c *gin.Context) ([]byte, string, string, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+
+	contentType, fileName := c.ContentType(), ""
+	var data []byte
+	var err error
+	if contentType == gin.MIMEMultipartPOSTForm {
+		var header *multipart.FileHeader
+		if header, err = c.FormFile("file"); err == nil {
+			var file multipart.File
+			if file, err = header.Open(); err == nil {
+				defer file.Close()
+				contentType, _, _ = mime.ParseMediaType(header.Header.Get("Content-Type"))
+				fileName = header.Filename
+				data, err = io.ReadAll(file)
+			}
+		}
+	} else {
+		data, err = io.ReadAll(c.Request.Body)
+	}
+
+	var tooLarge *http.MaxBytesError
+	switch {
+	case defaultErrors.As(err, &tooLarge):
+		return nil, "", "", errors.ApplicationError{
+			StatusCode: 413,
+			Code:       "PayloadTooLarge",
+			Errors:     map[string]string{"file": fmt.Sprintf("File must not exceed %d MB", maxImportFileSize>>20)},
+		}
+	case err != nil:
+		return nil, "", "", errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"file": err.Error()},
+		}
+	}
+
+	return data, contentType, fileName, nil
+}
+
+func importFormat(format *string, contentType string, fileName string) (imports.Format, error) {
+	if format != nil {
+		return imports.Format(*format), nil
+	}
+
+	switch {
+	case contentType == "text/csv" || strings.HasSuffix(strings.ToLower(fileName), ".csv"):
+		return imports.CSV, nil
+	case contentType == "text/calendar" || strings.HasSuffix(strings.ToLower(fileName), ".ics"):
+		return imports.ICal, nil
+	case contentType == gin.MIMEJSON || strings.HasSuffix(strings.ToLower(fileName), ".json"):
+		return imports.JSON, nil
+	}
+
+	return "", errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "InvalidRequest",
+		Errors:     map[string]string{"format": "Format is not set and cannot be determined from the content type"},
+	}
+}
+
+func toImportRowResponse(row int, result models.TaskImportResult) DTOs.ImportRowResponse {
+	var appErr errors.ApplicationError
+	switch {
+	case result.Skipped:
+		return DTOs.ImportRowResponse{Row: row, Result: importSkipped}
+	case defaultErrors.As(result.Err, &appErr):
+		return DTOs.ImportRowResponse{
+			Row:    row,
+			Result: importFailed,
+			Error:  &DTOs.ErrorResponse{StatusCode: appErr.StatusCode, Code: appErr.Code, Errors: appErr.Errors},
+		}
+	}
+
+	task := toTaskResponse(result.Task)
+	return DTOs.ImportRowResponse{Row: row, Result: importCreated, Task: &task}
+}
+
+// ParseTask
+// @Summary Preview task name macros
+// @Description Parse macros in the task name without creating a task.
+// @Description Returns the cleaned name, the values the macros set and the recognized and unrecognized macros.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param task body DTOs.ParseTaskRequest true "Task name"
+// @Param X-Time-Zone header string false "IANA time zone for macro dates; defaults to the profile time zone"
+// @Success 200 {object} DTOs.ParseTaskResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/parse [post]
+func (h *TasksHandler) ParseTask(c *gin.Context) {
+	var request DTOs.ParseTaskRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	result, project, err := h.tasksService.ParseTask(middleware.CurrentUserID(c), *request.Name,
+		middleware.CurrentLocation(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := DTOs.ParseTaskResponse{
+		Name:         result.Name,
+		Priority:     result.Priority,
+		Deadline:     result.Deadline,
+		Tags:         result.Tags,
+		Recognized:   toMacroResponses(result.Recognized),
+		Unrecognized: toMacroResponses(result.Unrecognized),
+	}
+	if response.Tags == nil {
+		response.Tags = []string{}
+	}
+	if project != nil {
+		response.ProjectID = &project.ID
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // ToggleTaskStatus
@@ -209,10 +828,17 @@ func (h *TasksHandler) UpdateTask(c *gin.Context) {
 // @Produce json
 // @Param id path string true "id"
 // @Param task body DTOs.ToggleTaskStatusRequest true "Task"
+// @Param If-Match header string true "ETag of the task version being changed; * skips the version check"
 // @Success 200 {object} DTOs.TaskResponse
+// @Header 200 {string} ETag "New task version"
 // @Failure 400 {object} errors.ApplicationError "Bad request"
 // @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 409 {object} errors.ApplicationError "Checklist items are not done and completeItems is not set"
+// @Failure 412 {object} DTOs.TaskResponse "Task was changed; the body is its current version"
+// @Failure 428 {object} errors.ApplicationError "If-Match header is missing"
 // @Failure 500 "Internal server error"
+// @Security BearerAuth
 // @Router /tasks/{id}/toggle [patch]
 func (h *TasksHandler) ToggleTaskStatus(c *gin.Context) {
 	taskIDParam := c.Param("id")
@@ -227,6 +853,11 @@ func (h *TasksHandler) ToggleTaskStatus(c *gin.Context) {
 		return
 	}
 
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
 	var request DTOs.ToggleTaskStatusRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
 		c.Error(errors.ApplicationError{
@@ -237,13 +868,176 @@ func (h *TasksHandler) ToggleTaskStatus(c *gin.Context) {
 		return
 	}
 
-	task, err := h.tasksService.ToggleTaskStatus(taskID, *request.IsDone)
+	task, err := h.tasksService.ToggleTaskStatus(middleware.CurrentUserID(c), taskID, *request.IsDone,
+		request.CompleteItems, version)
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	setTaskETag(c, task)
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
+
+// GetTaskHistory
+// @Summary Get task history
+// @Description Get the task's change log in chronological order: every change records the old and new value
+// @Description of each changed field. actorId is null for changes made by the scheduler.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param limit query int false "Page size (1-100)"
+// @Param cursor query string false "Opaque cursor from the previous page's nextCursor"
+// @Success 200 {object} DTOs.TaskEventsPageResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{id}/history [get]
+func (h *TasksHandler) GetTaskHistory(c *gin.Context) {
+	taskID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var pagination DTOs.PaginationQuery
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	events, nextCursor, err := h.tasksService.GetTaskHistory(middleware.CurrentUserID(c), taskID,
+		pagination.Cursor, pagination.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, DTOs.TaskEventsPageResponse{
+		Items:      toTaskEventResponses(events),
+		NextCursor: nextCursor,
+	})
+}
+
+// GetDeletedTasks
+// @Summary Get trash
+// @Description Get tasks moved to the trash, most recently deleted first.
+// @Description Tasks are purged permanently once they stay in the trash longer than trash.retention.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Success 200 {object} []DTOs.TaskResponse
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/trash [get]
+func (h *TasksHandler) GetDeletedTasks(c *gin.Context) {
+	tasks, err := h.tasksService.GetDeletedTasks(middleware.CurrentUserID(c))
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, DTOs.TaskResponse{
+	c.JSON(http.StatusOK, toTaskResponses(tasks))
+}
+
+// RestoreTask
+// @Summary Restore task from trash
+// @Description Move the task back from the trash together with its checklist and tags.
+// @Description If the task's project was deleted meanwhile, the task is restored to Inbox.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} DTOs.TaskResponse
+// @Header 200 {string} ETag "New task version"
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 412 {object} DTOs.TaskResponse "Task was restored concurrently; the body is its current version"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{id}/restore [post]
+func (h *TasksHandler) RestoreTask(c *gin.Context) {
+	taskID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	task, err := h.tasksService.RestoreTask(middleware.CurrentUserID(c), taskID)
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	setTaskETag(c, task)
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
+
+// Версия задачи передаётся клиенту в ETag и возвращается им в If-Match
+func setTaskETag(c *gin.Context, task *models.Task) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(task.Version)))
+}
+
+// If-Match обязателен при изменении задачи: это ETag версии, которую видел клиент, или "*" — без проверки
+func parseIfMatch(c *gin.Context) (*int, bool) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" {
+		c.Error(errors.ApplicationError{
+			StatusCode: 428,
+			Code:       "PreconditionRequired",
+			Errors:     map[string]string{"If-Match": "If-Match header with the task ETag is required"},
+		})
+		return nil, false
+	}
+
+	if value == "*" {
+		return nil, true
+	}
+
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		if version, err := strconv.Atoi(unquoted); err == nil {
+			return &version, true
+		}
+	}
+
+	c.Error(errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "InvalidRequest",
+		Errors:     map[string]string{"If-Match": "If-Match must be * or a single task ETag"},
+	})
+	return nil, false
+}
+
+// Конфликт версий — это 412 с текущим состоянием задачи, остальные ошибки передаются обработчику ошибок
+func respondTaskError(c *gin.Context, err error) {
+	var conflict errors.VersionConflictError
+	if defaultErrors.As(err, &conflict) {
+		setTaskETag(c, conflict.Current)
+		c.JSON(http.StatusPreconditionFailed, toTaskResponse(conflict.Current))
+		return
+	}
+
+	c.Error(err)
+}
+
+func toMacroResponses(macroList []macros.Macro) []DTOs.MacroResponse {
+	response := make([]DTOs.MacroResponse, len(macroList))
+	for i, macro := range macroList {
+		response[i] = DTOs.MacroResponse{Kind: string(macro.Kind), Text: macro.Text, Reason: macro.Reason}
+	}
+
+	return response
+}
+
+func toTaskResponse(task *models.Task) DTOs.TaskResponse {
+	return DTOs.TaskResponse{
 		ID:          task.ID,
 		CreatedAt:   task.CreatedAt,
 		ChangedAt:   task.ChangedAt,
@@ -252,5 +1046,47 @@ func (h *TasksHandler) ToggleTaskStatus(c *gin.Context) {
 		Deadline:    task.Deadline,
 		Status:      task.Status,
 		Priority:    task.Priority,
-	})
+		ProjectID:   task.ProjectID,
+		TimeZone:    task.TimeZone,
+
+		Recurrence:       task.Recurrence,
+		NextOccurrenceID: task.NextOccurrenceID,
+		DeletedAt:        task.DeletedAt,
+		Version:          task.Version,
+
+		Progress: DTOs.ChecklistProgressResponse{
+			Done:  task.Checklist.Done,
+			Total: task.Checklist.Total,
+		},
+		Tags: toTagResponses(task.Tags),
+	}
+}
+
+func toTaskResponses(tasks []*models.Task) []DTOs.TaskResponse {
+	response := make([]DTOs.TaskResponse, len(tasks))
+	for i, task := range tasks {
+		response[i] = toTaskResponse(task)
+	}
+
+	return response
+}
+
+func toTaskEventResponses(events []*models.TaskEvent) []DTOs.TaskEventResponse {
+	response := make([]DTOs.TaskEventResponse, len(events))
+	for i, event := range events {
+		changes := make([]DTOs.FieldChangeResponse, len(event.Changes))
+		for j, change := range event.Changes {
+			changes[j] = DTOs.FieldChangeResponse{Field: change.Field, Old: change.Old, New: change.New}
+		}
+
+		response[i] = DTOs.TaskEventResponse{
+			ID:        event.ID,
+			CreatedAt: event.CreatedAt,
+			ActorID:   event.ActorID,
+			Type:      event.Type,
+			Changes:   changes,
+		}
+	}
+
+	return response
 }
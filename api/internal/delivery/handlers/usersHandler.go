@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/delivery/middleware"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type UsersHandler struct {
+	usersService interfaces.UsersService
+}
+
+func NewUsersHandler(usersService interfaces.UsersService) *UsersHandler {
+	return &UsersHandler{usersService: usersService}
+}
+
+// GetProfile
+// @Summary Get current user
+// @Description Get the profile of the authenticated user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Success 200 {object} DTOs.UserResponse
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /users/me [get]
+func (h *UsersHandler) GetProfile(c *gin.Context) {
+	user, err := h.usersService.GetProfile(middleware.CurrentUserID(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserResponse(user))
+}
+
+// UpdateProfile
+// @Summary Update current user
+// @Description Set the IANA time zone (e.g. Asia/Novosibirsk) used for task deadlines when the request
+// @Description has no X-Time-Zone header. Null resets it to UTC.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param profile body DTOs.UpdateProfileRequest true "Profile"
+// @Success 200 {object} DTOs.UserResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /users/me [put]
+func (h *UsersHandler) UpdateProfile(c *gin.Context) {
+	var request DTOs.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	user, err := h.usersService.UpdateProfile(middleware.CurrentUserID(c), request.TimeZone)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserResponse(user))
+}
+
+func toUserResponse(user *models.User) DTOs.UserResponse {
+	return DTOs.UserResponse{
+		ID:        user.ID,
+		CreatedAt: user.CreatedAt,
+		Email:     user.Email,
+		TimeZone:  user.TimeZone,
+	}
+}
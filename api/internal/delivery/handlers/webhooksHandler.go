@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/delivery/middleware"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type WebhooksHandler struct {
+	webhooksService interfaces.WebhooksService
+}
+
+func NewWebhooksHandler(webhooksService interfaces.WebhooksService) *WebhooksHandler {
+	return &WebhooksHandler{webhooksService: webhooksService}
+}
+
+// GetWebhooks
+// @Summary Get webhooks
+// @Description Get webhook subscriptions of the current user by creation date
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} []DTOs.WebhookResponse
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks [get]
+func (h *WebhooksHandler) GetWebhooks(c *gin.Context) {
+	webhooks, err := h.webhooksService.GetWebhooks(middleware.CurrentUserID(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := make([]DTOs.WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		response[i] = toWebhookResponse(webhook, false)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateWebhook
+// @Summary Create a webhook
+// @Description Subscribe a URL to changes of the current user's tasks, optionally filtered by change type
+// @Description and task priority. Each change is sent as a POST request signed with the secret:
+// @Description X-Webhook-Signature is "sha256=" + hex HMAC-SHA256 of "<X-Webhook-Timestamp>.<body>".
+// @Description Failed requests are retried with exponential backoff. The secret is returned only here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body DTOs.WebhookRequest true "Webhook"
+// @Success 201 {object} DTOs.WebhookResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks [post]
+func (h *WebhooksHandler) CreateWebhook(c *gin.Context) {
+	var request DTOs.WebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	webhook, err := h.webhooksService.CreateWebhook(middleware.CurrentUserID(c), *request.URL, request.Secret,
+		request.EventTypes, request.Priorities)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toWebhookResponse(webhook, true))
+}
+
+// GetWebhook
+// @Summary Get a webhook
+// @Description Get webhook subscription by ID
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook id"
+// @Success 200 {object} DTOs.WebhookResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks/{id} [get]
+func (h *WebhooksHandler) GetWebhook(c *gin.Context) {
+	webhookID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhooksService.GetWebhook(middleware.CurrentUserID(c), webhookID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWebhookResponse(webhook, false))
+}
+
+// UpdateWebhook
+// @Summary Update a webhook
+// @Description Change URL and filters of the webhook. Without a secret the previous one is kept.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook id"
+// @Param webhook body DTOs.WebhookRequest true "Webhook"
+// @Success 200 {object} DTOs.WebhookResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks/{id} [put]
+func (h *WebhooksHandler) UpdateWebhook(c *gin.Context) {
+	webhookID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request DTOs.WebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errors.ApplicationError{
+			StatusCode: 400,
+			Code:       "InvalidRequest",
+			Errors:     map[string]string{"message": err.Error()},
+		})
+		return
+	}
+
+	webhook, err := h.webhooksService.UpdateWebhook(middleware.CurrentUserID(c), webhookID, *request.URL,
+		request.Secret, request.EventTypes, request.Priorities)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWebhookResponse(webhook, false))
+}
+
+// DeleteWebhook
+// @Summary Delete a webhook
+// @Description Delete the webhook together with its pending and failed deliveries
+// @Tags webhooks
+// @Param id path string true "Webhook id"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks/{id} [delete]
+func (h *WebhooksHandler) DeleteWebhook(c *gin.Context) {
+	webhookID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.webhooksService.DeleteWebhook(middleware.CurrentUserID(c), webhookID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetDeadLetters
+// @Summary Get failed deliveries
+// @Description Get deliveries of the current user's webhooks that ran out of attempts, newest first
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} []DTOs.WebhookDeliveryResponse
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks/dead-letters [get]
+func (h *WebhooksHandler) GetDeadLetters(c *gin.Context) {
+	deliveries, err := h.webhooksService.GetDeadLetters(middleware.CurrentUserID(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := make([]DTOs.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		response[i] = toWebhookDeliveryResponse(delivery)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RetryDelivery
+// @Summary Retry a failed delivery
+// @Description Put the failed delivery back into the queue with a fresh set of attempts
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Delivery id"
+// @Success 200 {object} DTOs.WebhookDeliveryResponse
+// @Failure 400 {object} errors.ApplicationError "Bad request"
+// @Failure 401 {object} errors.ApplicationError "Unauthorized"
+// @Failure 404 {object} errors.ApplicationError "Not found"
+// @Failure 500 "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks/dead-letters/{id}/retry [post]
+func (h *WebhooksHandler) RetryDelivery(c *gin.Context) {
+	deliveryID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhooksService.RetryDelivery(middleware.CurrentUserID(c), deliveryID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWebhookDeliveryResponse(delivery))
+}
+
+func toWebhookResponse(webhook *models.Webhook, withSecret bool) DTOs.WebhookResponse {
+	response := DTOs.WebhookResponse{
+		ID:         webhook.ID,
+		CreatedAt:  webhook.CreatedAt,
+		URL:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		Priorities: webhook.Priorities,
+	}
+	if response.EventTypes == nil {
+		response.EventTypes = []enums.TaskChangeType{}
+	}
+	if response.Priorities == nil {
+		response.Priorities = []enums.Priority{}
+	}
+	if withSecret {
+		response.Secret = &webhook.Secret
+	}
+
+	return response
+}
+
+func toWebhookDeliveryResponse(delivery *models.WebhookDelivery) DTOs.WebhookDeliveryResponse {
+	return DTOs.WebhookDeliveryResponse{
+		ID:            delivery.ID,
+		WebhookID:     delivery.WebhookID,
+		CreatedAt:     delivery.CreatedAt,
+		EventType:     delivery.EventType,
+		Status:        delivery.Status,
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LastError:     delivery.LastError,
+		DeliveredAt:   delivery.DeliveredAt,
+		Payload:       json.RawMessage(delivery.Payload),
+	}
+}
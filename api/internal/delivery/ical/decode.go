@@ -0,0 +1,242 @@
+package ical
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	localDateTimeFormat = "20060102T150405"
+	dateFormat          = "20060102"
+)
+
+// Entry — задача из записи VTODO или ошибка разбора записи
+type Entry struct {
+	Task models.TaskImport
+	Err  error
+}
+
+// Decode читает записи VTODO календаря iCalendar (RFC 5545), остальные компоненты пропускаются.
+// Время без часового пояса понимается в location (nil — UTC), срок-дата — как конец этого дня.
+// Ошибка возвращается, только если data не календарь
+func Decode(data []byte, location *time.Location) ([]Entry, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	lines := unfold(string(data))
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, invalidCalendar("File is not an iCalendar calendar")
+	}
+
+	var entries []Entry
+	var current *Entry
+	var invalid map[string]string
+	// Вложенные в VTODO компоненты (например, VALARM) пропускаются
+	nested := 0
+
+	for _, line := range lines {
+		name, params, value, ok := parseLine(line)
+		if !ok {
+			if current != nil && nested == 0 {
+				invalid["line"] = fmt.Sprintf("Malformed line %q", line)
+			}
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, string(ToDo)):
+			if current == nil {
+				current, invalid = &Entry{}, map[string]string{}
+			} else {
+				nested++
+			}
+		case current == nil:
+		case name == "BEGIN":
+			nested++
+		case name == "END" && nested > 0:
+			nested--
+		case name == "END":
+			if len(invalid) > 0 {
+				current.Err = errors.ApplicationError{StatusCode: 400, Code: "InvalidRequest", Errors: invalid}
+			}
+			entries = append(entries, *current)
+			current = nil
+		case nested == 0:
+			if field, message := setProperty(&current.Task, name, params, value, location); field != "" {
+				invalid[field] = message
+			}
+		}
+	}
+
+	if current != nil {
+		return nil, invalidCalendar("VTODO is not terminated")
+	}
+
+	return entries, nil
+}
+
+// Возвращает поле задачи и сообщение, если значение свойства некорректно
+func setProperty(task *models.TaskImport, name string, params map[string]string, value string,
+	location *time.Location) (string, string) {
+	switch name {
+	case "SUMMARY":
+		task.Name = unescapeText(value)
+	case "DESCRIPTION":
+		if description := unescapeText(value); description != "" {
+			task.Description = &description
+		}
+	case "DUE":
+		deadline, ok := parseDateTime(value, params, location)
+		if !ok {
+			return "deadline", fmt.Sprintf("Invalid due date %q", value)
+		}
+		task.Deadline = &deadline
+	case "PRIORITY":
+		level, err := strconv.Atoi(value)
+		if err != nil || level < 0 || level > 9 {
+			return "priority", fmt.Sprintf("Invalid priority %q", value)
+		}
+		task.Priority = fromPriority(level)
+	case "CATEGORIES":
+		for _, category := range splitList(value) {
+			if category = strings.TrimSpace(unescapeText(category)); category != "" {
+				task.Tags = append(task.Tags, category)
+			}
+		}
+	case "RRULE":
+		task.Recurrence = &value
+	case "STATUS":
+		// Отменённую задачу нельзя создать ни активной, ни выполненной, поэтому она не импортируется
+		if strings.EqualFold(value, "CANCELLED") {
+			return "status", "Cancelled tasks are not imported"
+		}
+		task.Done = strings.EqualFold(value, "COMPLETED")
+	case "COMPLETED":
+		task.Done = true
+	}
+
+	return "", ""
+}
+
+func invalidCalendar(message string) errors.ApplicationError {
+	return errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "InvalidRequest",
+		Errors:     map[string]string{"file": message},
+	}
+}
+
+// Строки-продолжения (RFC 5545, 3.1) присоединяются к предыдущей строке, пустые строки пропускаются
+func unfold(data string) []string {
+	data = strings.TrimPrefix(data, "\ufeff")
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\n ", "")
+	data = strings.ReplaceAll(data, "\n\t", "")
+
+	var lines []string
+	for _, line := range strings.Split(data, "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// Строка вида NAME;PARAM=value;PARAM="value":VALUE; двоеточие в кавычках значение не начинает
+func parseLine(line string) (name string, params map[string]string, value string, ok bool) {
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params = map[string]string{}
+	for _, param := range parts[1:] {
+		key, paramValue, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = strings.Trim(paramValue, `"`)
+	}
+
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+// Время в UTC (с суффиксом Z), с параметром TZID или без часового пояса либо дата (VALUE=DATE)
+func parseDateTime(value string, params map[string]string, location *time.Location) (time.Time, bool) {
+	if params["VALUE"] == "DATE" || len(value) == len(dateFormat) {
+		date, err := time.ParseInLocation(dateFormat, value, location)
+		return time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, location), err == nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeFormat, value)
+		return t, err == nil
+	}
+
+	if zone, ok := params["TZID"]; ok {
+		zoneLocation, err := time.LoadLocation(zone)
+		if err != nil {
+			return time.Time{}, false
+		}
+		location = zoneLocation
+	}
+	t, err := time.ParseInLocation(localDateTimeFormat, value, location)
+
+	return t, err == nil
+}
+
+// Запятые, экранированные обратной косой чертой, элементы списка не разделяют
+func splitList(value string) []string {
+	var items []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			items = append(items, value[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(items, value[start:])
+}
+
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func unescapeText(text string) string {
+	return textUnescaper.Replace(text)
+}
+
+// Обратное priority: 1–4 — высокий приоритет (1 — наивысший), 5 — средний, 6–9 — низкий, 0 — не задан
+func fromPriority(level int) *enums.Priority {
+	var priority enums.Priority
+	switch {
+	case level == 0:
+		return nil
+	case level == 1:
+		priority = enums.Critical
+	case level < 5:
+		priority = enums.High
+	case level == 5:
+		priority = enums.Medium
+	default:
+		priority = enums.Low
+	}
+
+	return &priority
+}
@@ -0,0 +1,122 @@
+package ical
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Тест чтения задач из календаря RFC 5545
+func TestDecode(t *testing.T) {
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	calendar := func(lines ...string) []byte {
+		return []byte(strings.Join(append(append([]string{"BEGIN:VCALENDAR", "VERSION:2.0"}, lines...),
+			"END:VCALENDAR"), "\r\n"))
+	}
+
+	t.Run("Календарь экспорта читается обратно", func(t *testing.T) {
+		deadline := time.Date(2030, 2, 15, 11, 0, 0, 0, time.UTC)
+		task := models.NewTask("Отчёт; квартал, итоги", utils.Ptr("Собрать цифры\nи отправить"), &deadline,
+			utils.Ptr(enums.Late), utils.Ptr(enums.High))
+		task.Tags = []models.Tag{{Name: "work"}, {Name: "a,b"}}
+		long := models.NewTask(strings.Repeat("Длинное название ", 10), nil, nil, nil, nil)
+
+		entries, err := Decode(Encode("Задачи", []*models.Task{task, long}, ToDo), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Entry{
+			{Task: models.TaskImport{
+				Name:        "Отчёт; квартал, итоги",
+				Description: utils.Ptr("Собрать цифры\nи отправить"),
+				Deadline:    &deadline,
+				Priority:    utils.Ptr(enums.High),
+				Tags:        []string{"work", "a,b"},
+				Done:        true,
+			}},
+			{Task: models.TaskImport{Name: strings.Repeat("Длинное название ", 10), Priority: utils.Ptr(enums.Medium)}},
+		}, entries)
+	})
+
+	t.Run("Время и приоритет", func(t *testing.T) {
+		entries, err := Decode(calendar(
+			"BEGIN:VTODO", "SUMMARY:Дата", "DUE;VALUE=DATE:20300215", "PRIORITY:2", "END:VTODO",
+			"BEGIN:VTODO", "SUMMARY:Пояс", "DUE;TZID=Europe/Moscow:20300215T090000", "PRIORITY:7", "END:VTODO",
+			"BEGIN:VTODO", "SUMMARY:Без пояса", "DUE:20300215T090000", "PRIORITY:0", "RRULE:FREQ=DAILY",
+			"END:VTODO",
+		), moscow)
+
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 3) {
+			assert.Equal(t, time.Date(2030, 2, 15, 23, 59, 59, 0, moscow), *entries[0].Task.Deadline)
+			assert.Equal(t, enums.High, *entries[0].Task.Priority)
+			assert.True(t, time.Date(2030, 2, 15, 6, 0, 0, 0, time.UTC).Equal(*entries[1].Task.Deadline))
+			assert.Equal(t, enums.Low, *entries[1].Task.Priority)
+			assert.True(t, time.Date(2030, 2, 15, 6, 0, 0, 0, time.UTC).Equal(*entries[2].Task.Deadline))
+			assert.Nil(t, entries[2].Task.Priority)
+			assert.Equal(t, "FREQ=DAILY", *entries[2].Task.Recurrence)
+		}
+	})
+
+	t.Run("Вложенные и другие компоненты пропускаются", func(t *testing.T) {
+		entries, err := Decode(calendar(
+			"BEGIN:VEVENT", "SUMMARY:Событие", "END:VEVENT",
+			"BEGIN:VTODO", "SUMMARY:Задача",
+			"BEGIN:VALARM", "ACTION:DISPLAY", "DESCRIPTION:Напоминание", "END:VALARM",
+			"COMPLETED:20300101T000000Z", "END:VTODO",
+		), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Entry{{Task: models.TaskImport{Name: "Задача", Done: true}}}, entries)
+	})
+
+	t.Run("Ошибка в записи", func(t *testing.T) {
+		entries, err := Decode(calendar(
+			"BEGIN:VTODO", "SUMMARY:Плохая", "DUE:завтра", "PRIORITY:10", "END:VTODO",
+			"BEGIN:VTODO", "SUMMARY:Хорошая", "END:VTODO",
+		), nil)
+
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, errors.ApplicationError{
+				StatusCode: 400,
+				Code:       "InvalidRequest",
+				Errors: map[string]string{
+					"deadline": `Invalid due date "завтра"`,
+					"priority": `Invalid priority "10"`,
+				},
+			}, entries[0].Err)
+			assert.NoError(t, entries[1].Err)
+		}
+	})
+
+	t.Run("Отменённая задача", func(t *testing.T) {
+		entries, err := Decode(calendar(
+			"BEGIN:VTODO", "SUMMARY:Отменена", "STATUS:CANCELLED", "END:VTODO",
+			"BEGIN:VTODO", "SUMMARY:Выполнена", "STATUS:COMPLETED", "END:VTODO",
+		), nil)
+
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, errors.ApplicationError{
+				StatusCode: 400,
+				Code:       "InvalidRequest",
+				Errors:     map[string]string{"status": "Cancelled tasks are not imported"},
+			}, entries[0].Err)
+			assert.NoError(t, entries[1].Err)
+			assert.True(t, entries[1].Task.Done)
+		}
+	})
+
+	t.Run("Не календарь", func(t *testing.T) {
+		unterminated := "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nSUMMARY:Без конца\r\n"
+		for _, data := range []string{"", "name,deadline\r\n", unterminated} {
+			_, err := Decode([]byte(data), nil)
+			assert.Error(t, err, data)
+		}
+	})
+}
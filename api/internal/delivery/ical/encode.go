@@ -0,0 +1,147 @@
+package ical
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Component — вид записей календаря
+type Component string
+
+const (
+	// ToDo — задача со сроком DUE; в календарь попадают все задачи
+	ToDo Component = "VTODO"
+	// Event — событие в момент дедлайна; задачи без дедлайна пропускаются
+	Event Component = "VEVENT"
+)
+
+const (
+	productID      = "-//HITS ToDoList//Tasks//RU"
+	dateTimeFormat = "20060102T150405Z"
+	// Длина строки без CRLF, после которой строка переносится (RFC 5545, 3.1)
+	maxLineOctets = 75
+)
+
+// Encode записывает задачи календарём iCalendar (RFC 5545) с названием name. Время записывается в UTC
+func Encode(name string, tasks []*models.Task, component Component) []byte {
+	encoder := &encoder{}
+	encoder.property("BEGIN", "VCALENDAR")
+	encoder.property("VERSION", "2.0")
+	encoder.property("PRODID", productID)
+	encoder.property("CALSCALE", "GREGORIAN")
+	encoder.property("X-WR-CALNAME", escapeText(name))
+
+	for _, task := range tasks {
+		if component == Event && task.Deadline == nil {
+			continue
+		}
+		encoder.task(task, component)
+	}
+
+	encoder.property("END", "VCALENDAR")
+
+	return encoder.buffer.Bytes()
+}
+
+type encoder struct {
+	buffer bytes.Buffer
+}
+
+func (encoder *encoder) task(task *models.Task, component Component) {
+	encoder.property("BEGIN", string(component))
+	encoder.property("UID", task.ID.String())
+
+	modified := task.CreatedAt
+	if task.ChangedAt != nil {
+		modified = *task.ChangedAt
+	}
+	encoder.property("DTSTAMP", formatDateTime(modified))
+	encoder.property("CREATED", formatDateTime(task.CreatedAt))
+	encoder.property("LAST-MODIFIED", formatDateTime(modified))
+	// Клиенты обновляют запись, только если номер её версии вырос
+	encoder.property("SEQUENCE", strconv.Itoa(max(task.Version-1, 0)))
+
+	encoder.property("SUMMARY", escapeText(task.Name))
+	if task.Description != nil && *task.Description != "" {
+		encoder.property("DESCRIPTION", escapeText(*task.Description))
+	}
+	encoder.property("PRIORITY", strconv.Itoa(priority(task.Priority)))
+	if len(task.Tags) > 0 {
+		categories := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			categories[i] = escapeText(tag.Name)
+		}
+		encoder.property("CATEGORIES", strings.Join(categories, ","))
+	}
+
+	if component == Event {
+		encoder.property("DTSTART", formatDateTime(*task.Deadline))
+		// Событие-дедлайн не занимает время в расписании
+		encoder.property("TRANSP", "TRANSPARENT")
+	} else {
+		if task.Deadline != nil {
+			encoder.property("DUE", formatDateTime(*task.Deadline))
+		}
+		if completed(task.Status) {
+			encoder.property("STATUS", "COMPLETED")
+			encoder.property("PERCENT-COMPLETE", "100")
+		} else {
+			encoder.property("STATUS", "NEEDS-ACTION")
+		}
+	}
+
+	encoder.property("END", string(component))
+}
+
+// Строка длиннее maxLineOctets байт переносится: продолжение начинается с пробела, символы UTF-8
+// не разрываются
+func (encoder *encoder) property(name string, value string) {
+	line := name + ":" + value
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		encoder.buffer.WriteString(line[:cut])
+		encoder.buffer.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+	encoder.buffer.WriteString(line)
+	encoder.buffer.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "")
+
+func escapeText(text string) string {
+	return textEscaper.Replace(text)
+}
+
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat)
+}
+
+// Приоритет iCalendar: 1 — наивысший, 9 — наинизший
+func priority(priority enums.Priority) int {
+	switch priority {
+	case enums.Critical:
+		return 1
+	case enums.High:
+		return 3
+	case enums.Low:
+		return 9
+	default:
+		return 5
+	}
+}
+
+// Задача, выполненная после дедлайна (Late), тоже выполнена
+func completed(status enums.Status) bool {
+	return status == enums.Completed || status == enums.Late
+}
@@ -0,0 +1,69 @@
+package ical
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// Тест записи задач календарём RFC 5545
+func TestEncode(t *testing.T) {
+	created := time.Date(2030, 2, 1, 9, 0, 0, 0, time.UTC)
+	deadline := time.Date(2030, 2, 15, 18, 0, 0, 0, time.FixedZone("UTC+7", 7*60*60))
+
+	report := models.NewTask("Отчёт; квартал, итоги", utils.Ptr("Собрать цифры\nи отправить"), &deadline, nil,
+		utils.Ptr(enums.Critical))
+	report.CreatedAt = created
+	report.ChangedAt = utils.Ptr(created.Add(time.Hour))
+	report.Version = 3
+	report.Tags = []models.Tag{{Name: "work"}, {Name: "q1"}}
+	done := models.NewTask("Сделано", nil, nil, utils.Ptr(enums.Late), utils.Ptr(enums.Low))
+	done.CreatedAt = created
+
+	t.Run("Задачи", func(t *testing.T) {
+		calendar := string(Encode("Задачи", []*models.Task{report, done}, ToDo))
+
+		assert.True(t, strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(calendar, "END:VCALENDAR\r\n"))
+		assert.Equal(t, 2, strings.Count(calendar, "BEGIN:VTODO\r\n"))
+		assert.Contains(t, calendar, "UID:"+report.ID.String()+"\r\n")
+		assert.Contains(t, calendar, `SUMMARY:Отчёт\; квартал\, итоги`+"\r\n")
+		assert.Contains(t, calendar, `DESCRIPTION:Собрать цифры\nи отправить`+"\r\n")
+		assert.Contains(t, calendar, "DUE:20300215T110000Z\r\n")
+		assert.Contains(t, calendar, "DTSTAMP:20300201T100000Z\r\n")
+		assert.Contains(t, calendar, "SEQUENCE:2\r\n")
+		assert.Contains(t, calendar, "PRIORITY:1\r\n")
+		assert.Contains(t, calendar, "PRIORITY:9\r\n")
+		assert.Contains(t, calendar, "CATEGORIES:work,q1\r\n")
+		assert.Contains(t, calendar, "STATUS:NEEDS-ACTION\r\n")
+		// Выполненная после дедлайна задача тоже выполнена
+		assert.Contains(t, calendar, "STATUS:COMPLETED\r\nPERCENT-COMPLETE:100\r\n")
+	})
+
+	t.Run("События", func(t *testing.T) {
+		calendar := string(Encode("Задачи", []*models.Task{report, done}, Event))
+
+		// Задача без дедлайна в календарь событий не попадает
+		assert.Equal(t, 1, strings.Count(calendar, "BEGIN:VEVENT\r\n"))
+		assert.Contains(t, calendar, "DTSTART:20300215T110000Z\r\n")
+		assert.NotContains(t, calendar, "DUE:")
+		assert.NotContains(t, calendar, "STATUS:")
+	})
+
+	t.Run("Перенос длинных строк", func(t *testing.T) {
+		long := models.NewTask(strings.Repeat("Длинное название ", 10), nil, nil, nil, nil)
+		calendar := string(Encode("Задачи", []*models.Task{long}, ToDo))
+
+		for _, line := range strings.Split(strings.TrimSuffix(calendar, "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(line), 75)
+			assert.True(t, utf8.ValidString(line))
+		}
+		unfolded := strings.ReplaceAll(calendar, "\r\n ", "")
+		assert.Contains(t, unfolded, "SUMMARY:"+strings.Repeat("Длинное название ", 10)+"\r\n")
+	})
+}
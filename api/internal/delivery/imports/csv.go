@@ -0,0 +1,192 @@
+package imports
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Поля задачи, которые можно взять из столбцов CSV
+var csvFields = []string{"name", "description", "deadline", "priority", "recurrence", "tags", "status"}
+
+// Форматы дедлайна; в форматах без часового пояса время понимается в часовом поясе пользователя
+var deadlineFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"02.01.2006 15:04",
+}
+
+// Форматы дедлайна-даты: дедлайн — конец дня
+var deadlineDateFormats = []string{"2006-01-02", "02.01.2006"}
+
+// Первая строка — заголовки. Столбец поля задаётся в columns, по умолчанию это столбец с названием поля
+// без учёта регистра. Разделитель — запятая или точка с запятой, если в заголовке нет запятых
+func decodeCSV(data []byte, columns map[string]string, location *time.Location) ([]Row, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if header, _, _ := bytes.Cut(data, []byte("\n")); !bytes.Contains(header, []byte(",")) &&
+		bytes.Contains(header, []byte(";")) {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, invalidFile("file", "CSV file is empty")
+	}
+	if err != nil {
+		return nil, invalidFile("file", "Invalid CSV: "+err.Error())
+	}
+
+	indexes, err := columnIndexes(header, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, invalidFile("file", "Invalid CSV: "+err.Error())
+		}
+
+		value := func(field string) string {
+			if index, ok := indexes[field]; ok && index < len(record) {
+				return strings.TrimSpace(record[index])
+			}
+			return ""
+		}
+		rows = append(rows, csvRow(value, location))
+	}
+
+	return rows, nil
+}
+
+// Индексы столбцов полей задачи; столбец названия обязателен
+func columnIndexes(header []string, columns map[string]string) (map[string]int, error) {
+	invalid := map[string]string{}
+	for field := range columns {
+		if !slices.Contains(csvFields, field) {
+			invalid["columns["+field+"]"] = fmt.Sprintf("Unknown field, expected one of %s",
+				strings.Join(csvFields, ", "))
+		}
+	}
+
+	indexes := map[string]int{}
+	for _, field := range csvFields {
+		column, mapped := columns[field]
+		if !mapped {
+			column = field
+		}
+
+		index := -1
+		for i, name := range header {
+			if strings.EqualFold(strings.TrimSpace(name), column) {
+				index = i
+				break
+			}
+		}
+
+		switch {
+		case index >= 0:
+			indexes[field] = index
+		case mapped:
+			invalid["columns["+field+"]"] = fmt.Sprintf("Column %q not found", column)
+		case field == "name":
+			invalid["columns[name]"] = "Column for task name not found"
+		}
+	}
+
+	if len(invalid) > 0 {
+		return nil, errors.ApplicationError{StatusCode: 400, Code: "InvalidRequest", Errors: invalid}
+	}
+
+	return indexes, nil
+}
+
+func csvRow(value func(field string) string, location *time.Location) Row {
+	var row Row
+	invalid := map[string]string{}
+	task := &row.Task
+
+	task.Name = value("name")
+	if description := value("description"); description != "" {
+		task.Description = &description
+	}
+	if recurrence := value("recurrence"); recurrence != "" {
+		task.Recurrence = &recurrence
+	}
+
+	if deadline := value("deadline"); deadline != "" {
+		if parsed, ok := parseDeadline(deadline, location); ok {
+			task.Deadline = &parsed
+		} else {
+			invalid["deadline"] = fmt.Sprintf("Invalid deadline %q", deadline)
+		}
+	}
+
+	if priority := value("priority"); priority != "" {
+		if parsed, ok := parseEnum(priority, enums.Low, enums.Medium, enums.High, enums.Critical); ok {
+			task.Priority = &parsed
+		} else {
+			invalid["priority"] = fmt.Sprintf("Unsupported priority: %v", priority)
+		}
+	}
+
+	if status := value("status"); status != "" {
+		if parsed, ok := parseEnum(status, enums.Active, enums.Completed, enums.Overdue, enums.Late); ok {
+			task.Done = parsed == enums.Completed || parsed == enums.Late
+		} else {
+			invalid["status"] = fmt.Sprintf("Unsupported status: %v", status)
+		}
+	}
+
+	for _, tag := range strings.Split(value("tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			task.Tags = append(task.Tags, tag)
+		}
+	}
+
+	row.Err = rowError(invalid)
+	return row
+}
+
+func parseDeadline(value string, location *time.Location) (time.Time, bool) {
+	for _, format := range deadlineFormats {
+		if deadline, err := time.ParseInLocation(format, value, location); err == nil {
+			return deadline, true
+		}
+	}
+	for _, format := range deadlineDateFormats {
+		if date, err := time.ParseInLocation(format, value, location); err == nil {
+			return time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, location), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// Значение перечисления без учёта регистра
+func parseEnum[T ~string](value string, values ...T) (T, bool) {
+	for _, candidate := range values {
+		if strings.EqualFold(value, string(candidate)) {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,69 @@
+package imports
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/delivery/ical"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"fmt"
+	"time"
+)
+
+// Format — формат импортируемого файла
+type Format string
+
+const (
+	CSV  Format = "csv"
+	ICal Format = "ical"
+	JSON Format = "json"
+)
+
+// Row — задача из строки файла или ошибка разбора строки
+type Row struct {
+	Task models.TaskImport
+	Err  error
+}
+
+// Decode читает задачи из файла. columns используется только для CSV: поле задачи → заголовок столбца.
+// Время без часового пояса понимается в location (nil — UTC). Ошибка возвращается, если файл нельзя
+// разобрать целиком; ошибки отдельных строк возвращаются в Row
+func Decode(format Format, data []byte, columns map[string]string, location *time.Location) ([]Row, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	switch format {
+	case CSV:
+		return decodeCSV(data, columns, location)
+	case JSON:
+		return decodeJSON(data)
+	case ICal:
+		entries, err := ical.Decode(data, location)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]Row, len(entries))
+		for i, entry := range entries {
+			rows[i] = Row{Task: entry.Task, Err: entry.Err}
+		}
+		return rows, nil
+	default:
+		return nil, invalidFile("format", fmt.Sprintf("Unsupported format %q", format))
+	}
+}
+
+func invalidFile(field string, message string) errors.ApplicationError {
+	return errors.ApplicationError{
+		StatusCode: 400,
+		Code:       "InvalidRequest",
+		Errors:     map[string]string{field: message},
+	}
+}
+
+// Ошибка строки со всеми некорректными полями или nil
+func rowError(invalid map[string]string) error {
+	if len(invalid) == 0 {
+		return nil
+	}
+
+	return errors.ApplicationError{StatusCode: 400, Code: "InvalidRequest", Errors: invalid}
+}
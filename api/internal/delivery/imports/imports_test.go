@@ -0,0 +1,142 @@
+package imports
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func invalidRow(invalid map[string]string) error {
+	return errors.ApplicationError{StatusCode: 400, Code: "InvalidRequest", Errors: invalid}
+}
+
+// Тест чтения задач из CSV
+func TestDecode_CSV(t *testing.T) {
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+
+	tests := []struct {
+		name     string
+		data     string
+		columns  map[string]string
+		wantRows []Row
+		wantErr  error
+	}{
+		{
+			name: "Столбцы по названиям полей",
+			data: "Name,Deadline,Priority,Tags,Status\r\n" +
+				"Отчёт,2030-02-15 18:00,high,\"work, q1\",Completed\r\n" +
+				"Дата,15.02.2030,,,\r\n" +
+				"Со смещением,2030-02-15T18:00:00Z,,,\r\n",
+			wantRows: []Row{
+				{Task: models.TaskImport{
+					Name:     "Отчёт",
+					Deadline: utils.Ptr(time.Date(2030, 2, 15, 18, 0, 0, 0, moscow)),
+					Priority: utils.Ptr(enums.High),
+					Tags:     []string{"work", "q1"},
+					Done:     true,
+				}},
+				{Task: models.TaskImport{
+					Name:     "Дата",
+					Deadline: utils.Ptr(time.Date(2030, 2, 15, 23, 59, 59, 0, moscow)),
+				}},
+				{Task: models.TaskImport{Name: "Со смещением", Deadline: utils.Ptr(time.Date(2030, 2, 15, 18, 0, 0, 0,
+					time.UTC))}},
+			},
+		},
+		{
+			name:    "Сопоставление столбцов и точка с запятой",
+			data:    "\ufeffЗадача;Описание;Повтор\nПолить цветы;На балконе;FREQ=WEEKLY\n",
+			columns: map[string]string{"name": "задача", "description": "Описание", "recurrence": "Повтор"},
+			wantRows: []Row{{Task: models.TaskImport{
+				Name:        "Полить цветы",
+				Description: utils.Ptr("На балконе"),
+				Recurrence:  utils.Ptr("FREQ=WEEKLY"),
+			}}},
+		},
+		{
+			name: "Ошибки в строке",
+			data: "name,deadline,priority,status\nПлохая,завтра,urgent,done\nХорошая\n",
+			wantRows: []Row{
+				{Task: models.TaskImport{Name: "Плохая"}, Err: invalidRow(map[string]string{
+					"deadline": `Invalid deadline "завтра"`,
+					"priority": "Unsupported priority: urgent",
+					"status":   "Unsupported status: done",
+				})},
+				{Task: models.TaskImport{Name: "Хорошая"}},
+			},
+		},
+		{
+			name:    "Неизвестные столбцы",
+			data:    "title,due\nЗадача,2030-01-01\n",
+			columns: map[string]string{"deadline": "Срок", "owner": "due"},
+			wantErr: invalidRow(map[string]string{
+				"columns[name]":     "Column for task name not found",
+				"columns[deadline]": `Column "Срок" not found`,
+				"columns[owner]": "Unknown field, expected one of " +
+					"name, description, deadline, priority, recurrence, tags, status",
+			}),
+		},
+		{
+			name:    "Пустой файл",
+			data:    "",
+			wantErr: invalidRow(map[string]string{"file": "CSV file is empty"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Decode(CSV, []byte(tt.data), tt.columns, moscow)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRows, rows)
+		})
+	}
+}
+
+// Тест чтения задач в формате ответа GET /tasks
+func TestDecode_JSON(t *testing.T) {
+	deadline := time.Date(2030, 2, 15, 18, 0, 0, 0, time.UTC)
+	task := `{"id":"6f1c1f4e-8d1a-4a9e-9a43-1f0b7f7a2c11","name":"Отчёт","description":"Цифры",` +
+		`"deadline":"2030-02-15T18:00:00Z","status":"Late","priority":"Critical","projectId":null,` +
+		`"recurrence":"FREQ=DAILY","version":4,"tags":[{"name":"work"}]}`
+	wantTask := models.TaskImport{
+		Name:        "Отчёт",
+		Description: utils.Ptr("Цифры"),
+		Deadline:    &deadline,
+		Priority:    utils.Ptr(enums.Critical),
+		Recurrence:  utils.Ptr("FREQ=DAILY"),
+		Tags:        []string{"work"},
+		Done:        true,
+	}
+
+	t.Run("Массив задач", func(t *testing.T) {
+		rows, err := Decode(JSON, []byte("["+task+`,{"name":"Вторая","priority":"Urgent"},{"name":5}]`), nil, nil)
+
+		assert.NoError(t, err)
+		if assert.Len(t, rows, 3) {
+			assert.Equal(t, Row{Task: wantTask}, rows[0])
+			assert.Equal(t, invalidRow(map[string]string{"priority": "Unsupported priority: Urgent"}), rows[1].Err)
+			assert.Error(t, rows[2].Err)
+		}
+	})
+
+	t.Run("Страница задач", func(t *testing.T) {
+		rows, err := Decode(JSON, []byte(`{"items":[`+task+`],"nextCursor":null}`), nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Row{{Task: wantTask}}, rows)
+	})
+
+	t.Run("Некорректный JSON", func(t *testing.T) {
+		_, err := Decode(JSON, []byte(`[{"name":`), nil, nil)
+		assert.Error(t, err)
+	})
+}
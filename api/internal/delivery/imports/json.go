@@ -0,0 +1,69 @@
+package imports
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"bytes"
+	"encoding/json"
+)
+
+// Задачи в формате ответа GET /tasks: массив задач или страница с задачами в items.
+// Поля, которые задаёт сервер (id, проект, версия и т. п.), не переносятся
+func decodeJSON(data []byte) ([]Row, error) {
+	var items []json.RawMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var page struct {
+			Items []json.RawMessage `json:"items"`
+		}
+		if err := json.Unmarshal(trimmed, &page); err != nil {
+			return nil, invalidFile("file", "Invalid JSON: "+err.Error())
+		}
+		items = page.Items
+	} else if err := json.Unmarshal(trimmed, &items); err != nil {
+		return nil, invalidFile("file", "Invalid JSON: "+err.Error())
+	}
+
+	rows := make([]Row, len(items))
+	for i, item := range items {
+		var task DTOs.TaskResponse
+		if err := json.Unmarshal(item, &task); err != nil {
+			rows[i].Err = rowError(map[string]string{"message": err.Error()})
+			continue
+		}
+		rows[i] = fromTaskResponse(task)
+	}
+
+	return rows, nil
+}
+
+func fromTaskResponse(task DTOs.TaskResponse) Row {
+	invalid := map[string]string{}
+	imported := models.TaskImport{
+		Name:        task.Name,
+		Description: task.Description,
+		Deadline:    task.Deadline,
+		Recurrence:  task.Recurrence,
+	}
+
+	if task.Priority != "" {
+		if err := enums.ValidatePriority(task.Priority); err != nil {
+			invalid["priority"] = err.Error()
+		} else {
+			imported.Priority = &task.Priority
+		}
+	}
+
+	if task.Status != "" {
+		if err := enums.ValidateStatus(task.Status); err != nil {
+			invalid["status"] = err.Error()
+		}
+		imported.Done = task.Status == enums.Completed || task.Status == enums.Late
+	}
+
+	for _, tag := range task.Tags {
+		imported.Tags = append(imported.Tags, tag.Name)
+	}
+
+	return Row{Task: imported, Err: rowError(invalid)}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"strings"
+)
+
+const userIDKey = "userID"
+
+func Auth(authService interfaces.AuthService) gin.HandlerFunc {
+	return authenticate(authService, false)
+}
+
+// StreamAuth принимает токен и в параметре запроса access_token: браузерный EventSource
+// не умеет передавать заголовки
+func StreamAuth(authService interfaces.AuthService) gin.HandlerFunc {
+	return authenticate(authService, true)
+}
+
+func authenticate(authService interfaces.AuthService, allowQueryToken bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if header == "" && allowQueryToken {
+			token = c.Query("access_token")
+			found = true
+		}
+		if !found || token == "" {
+			c.Error(errors.ApplicationError{
+				StatusCode: 401,
+				Code:       "Unauthorized",
+				Errors:     map[string]string{"message": "Missing bearer token"},
+			})
+			c.Abort()
+			return
+		}
+
+		userID, err := authService.Authenticate(token)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Set(userIDKey, userID)
+		c.Next()
+	}
+}
+
+// CalendarFeedAuth определяет пользователя по секретному токену из адреса ленты календаря: календари
+// подписываются на ленту по ссылке и не умеют передавать заголовки
+func CalendarFeedAuth(usersService interfaces.UsersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := usersService.AuthenticateCalendarFeed(c.Param("token"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Set(userIDKey, userID)
+		c.Next()
+	}
+}
+
+// CurrentUserID возвращает ID пользователя, установленный middleware Auth
+func CurrentUserID(c *gin.Context) uuid.UUID {
+	return c.MustGet(userIDKey).(uuid.UUID)
+}
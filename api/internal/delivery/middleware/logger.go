@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/url"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// Logger — журнал запросов в формате gin.Logger, из которого убраны токены в адресе: access_token
+// потока изменений и токен ленты календаря
+func Logger(out io.Writer) gin.HandlerFunc {
+	return gin.LoggerWithConfig(gin.LoggerConfig{
+		Output: out,
+		Formatter: func(param gin.LogFormatterParams) string {
+			return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v\n%s",
+				param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+				param.StatusCode,
+				param.Latency,
+				param.ClientIP,
+				param.Method,
+				redactPath(param.Path),
+				param.ErrorMessage,
+			)
+		},
+	})
+}
+
+func redactPath(path string) string {
+	path, rawQuery, hasQuery := strings.Cut(path, "?")
+
+	if feed, found := strings.CutPrefix(path, "/calendar/"); found {
+		if _, rest, found := strings.Cut(feed, "/"); found {
+			path = "/calendar/" + redacted + "/" + rest
+		}
+	}
+
+	if !hasQuery {
+		return path
+	}
+
+	// Нераспознанную строку запроса нельзя проверить на токен, поэтому она не пишется целиком
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return path + "?" + redacted
+	}
+	if query.Has("access_token") {
+		query.Set("access_token", redacted)
+		rawQuery = query.Encode()
+	}
+
+	return path + "?" + rawQuery
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Тест журнала запросов: токены из адреса в него не попадают
+func TestLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var out bytes.Buffer
+	router := gin.New()
+	router.Use(Logger(&out))
+	router.GET("/tasks/events", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/calendar/:token/tasks.ics", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, target := range []string{
+		"/tasks/events?access_token=secret-jwt&limit=5",
+		"/tasks/events?access_token=secret-jwt;",
+		"/calendar/secret-feed/tasks.ics",
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	log := out.String()
+	assert.NotContains(t, log, "secret-jwt")
+	assert.NotContains(t, log, "secret-feed")
+	assert.Contains(t, log, "/tasks/events?access_token=REDACTED&limit=5")
+	assert.Contains(t, log, "/calendar/REDACTED/tasks.ics")
+}
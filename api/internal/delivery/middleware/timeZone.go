@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"HITS_ToDoList_Tests/internal/application/errors"
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+const (
+	TimeZoneHeader = "X-Time-Zone"
+	locationKey    = "location"
+)
+
+// TimeZone определяет часовой пояс запроса: заголовок X-Time-Zone, иначе часовой пояс из профиля.
+// Должен выполняться после Auth
+func TimeZone(usersService interfaces.UsersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var location *time.Location
+		var err error
+
+		if header := c.GetHeader(TimeZoneHeader); header != "" {
+			location, err = models.LoadTimeZone(&header)
+			if err != nil {
+				c.Error(errors.ApplicationError{
+					StatusCode: 400,
+					Code:       "InvalidRequest",
+					Errors:     map[string]string{"message": "Unknown time zone in " + TimeZoneHeader + " header"},
+				})
+				c.Abort()
+				return
+			}
+		} else {
+			location, err = usersService.GetLocation(CurrentUserID(c))
+			if err != nil {
+				c.Error(err)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set(locationKey, location)
+		c.Next()
+	}
+}
+
+// CurrentLocation возвращает часовой пояс, установленный middleware TimeZone, или UTC
+func CurrentLocation(c *gin.Context) *time.Location {
+	if location, exists := c.Get(locationKey); exists {
+		return location.(*time.Location)
+	}
+
+	return time.UTC
+}
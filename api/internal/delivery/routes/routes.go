@@ -5,13 +5,84 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, tasksHandler *handlers.TasksHandler) {
-	tasks := router.Group("/tasks")
+// timeZoneMiddleware определяет часовой пояс запроса для задач и выполняется после authMiddleware;
+// streamAuthMiddleware проверяет токен потока событий, который может прийти и в параметре запроса;
+// calendarFeedMiddleware определяет пользователя по токену из адреса ленты календаря
+func SetupRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc, timeZoneMiddleware gin.HandlerFunc,
+	streamAuthMiddleware gin.HandlerFunc, calendarFeedMiddleware gin.HandlerFunc, authHandler *handlers.AuthHandler,
+	tasksHandler *handlers.TasksHandler, taskChangesHandler *handlers.TaskChangesHandler,
+	checklistHandler *handlers.ChecklistHandler, remindersHandler *handlers.RemindersHandler,
+	tagsHandler *handlers.TagsHandler, projectsHandler *handlers.ProjectsHandler, usersHandler *handlers.UsersHandler,
+	webhooksHandler *handlers.WebhooksHandler, calendarHandler *handlers.CalendarHandler) {
+	auth := router.Group("/auth")
+	{
+		auth.POST("/register", authHandler.Register)
+		auth.POST("/login", authHandler.Login)
+	}
+
+	users := router.Group("/users", authMiddleware)
+	{
+		users.GET("/me", usersHandler.GetProfile)
+		users.PUT("/me", usersHandler.UpdateProfile)
+		users.POST("/me/calendar-feed", calendarHandler.CreateFeed)
+		users.DELETE("/me/calendar-feed", calendarHandler.DeleteFeed)
+	}
+
+	router.GET("/tasks/events", streamAuthMiddleware, taskChangesHandler.StreamTaskChanges)
+	router.GET("/tasks.ics", authMiddleware, calendarHandler.ExportTasks)
+	router.GET("/calendar/:token/tasks.ics", calendarFeedMiddleware, calendarHandler.ExportFeed)
+
+	tasks := router.Group("/tasks", authMiddleware, timeZoneMiddleware)
 	{
 		tasks.POST("", tasksHandler.CreateTask)
 		tasks.GET("", tasksHandler.GetAllTasks)
+		tasks.POST("/parse", tasksHandler.ParseTask)
+		tasks.POST("/bulk", tasksHandler.BulkTasks)
+		tasks.POST("/import", tasksHandler.ImportTasks)
+		tasks.GET("/trash", tasksHandler.GetDeletedTasks)
+		tasks.GET("/:id", tasksHandler.GetTask)
 		tasks.DELETE("/:id", tasksHandler.DeleteTask)
 		tasks.PUT("/:id", tasksHandler.UpdateTask)
+		tasks.PATCH("/:id", tasksHandler.PatchTask)
 		tasks.PATCH("/:id/toggle", tasksHandler.ToggleTaskStatus)
+		tasks.GET("/:id/history", tasksHandler.GetTaskHistory)
+		tasks.POST("/:id/restore", tasksHandler.RestoreTask)
+
+		tasks.GET("/:id/items", checklistHandler.GetItems)
+		tasks.POST("/:id/items", checklistHandler.AddItem)
+		tasks.PUT("/:id/items/:itemId", checklistHandler.UpdateItem)
+		tasks.DELETE("/:id/items/:itemId", checklistHandler.DeleteItem)
+
+		tasks.GET("/:id/reminders", remindersHandler.GetReminders)
+		tasks.PUT("/:id/reminders", remindersHandler.SetReminders)
+	}
+
+	tags := router.Group("/tags", authMiddleware)
+	{
+		tags.GET("", tagsHandler.GetTags)
+		tags.POST("", tagsHandler.CreateTag)
+		tags.PUT("/:id", tagsHandler.UpdateTag)
+		tags.DELETE("/:id", tagsHandler.DeleteTag)
+	}
+
+	projects := router.Group("/projects", authMiddleware)
+	{
+		projects.GET("", projectsHandler.GetProjects)
+		projects.POST("", projectsHandler.CreateProject)
+		projects.GET("/:id", projectsHandler.GetProject)
+		projects.PUT("/:id", projectsHandler.UpdateProject)
+		projects.DELETE("/:id", projectsHandler.DeleteProject)
+		projects.GET("/:id/tasks", tasksHandler.GetProjectTasks)
+	}
+
+	webhooks := router.Group("/webhooks", authMiddleware)
+	{
+		webhooks.GET("", webhooksHandler.GetWebhooks)
+		webhooks.POST("", webhooksHandler.CreateWebhook)
+		webhooks.GET("/dead-letters", webhooksHandler.GetDeadLetters)
+		webhooks.POST("/dead-letters/:id/retry", webhooksHandler.RetryDelivery)
+		webhooks.GET("/:id", webhooksHandler.GetWebhook)
+		webhooks.PUT("/:id", webhooksHandler.UpdateWebhook)
+		webhooks.DELETE("/:id", webhooksHandler.DeleteWebhook)
 	}
 }
@@ -0,0 +1,25 @@
+package enums
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Frequency — значение FREQ правила повторения (RFC 5545)
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+func ValidateFrequency(f Frequency) error {
+	switch f {
+	case Daily, Weekly, Monthly, Yearly:
+		return nil
+	default:
+		return errors.New(fmt.Sprintf("Unsupported frequency: %v", f))
+	}
+}
@@ -1,5 +1,10 @@
 package enums
 
+import (
+	"errors"
+	"fmt"
+)
+
 type Status string
 
 const (
@@ -8,3 +13,12 @@ const (
 	Overdue   Status = "Overdue"
 	Late      Status = "Late"
 )
+
+func ValidateStatus(s Status) error {
+	switch s {
+	case Active, Completed, Overdue, Late:
+		return nil
+	default:
+		return errors.New(fmt.Sprintf("Unsupported status: %v", s))
+	}
+}
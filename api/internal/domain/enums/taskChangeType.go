@@ -0,0 +1,27 @@
+package enums
+
+import "fmt"
+
+// TaskChangeType — вид изменения задачи в потоке событий для клиентов. TaskChangeReminder получают
+// только вебхуки: это напоминание о приближении дедлайна, а не изменение задачи
+type TaskChangeType string
+
+const (
+	TaskChangeCreated TaskChangeType = "created"
+	TaskChangeUpdated TaskChangeType = "updated"
+	TaskChangeToggled TaskChangeType = "toggled"
+	TaskChangeDeleted TaskChangeType = "deleted"
+	TaskChangeOverdue TaskChangeType = "overdue"
+
+	TaskChangeReminder TaskChangeType = "reminder"
+)
+
+func ValidateTaskChangeType(changeType TaskChangeType) error {
+	switch changeType {
+	case TaskChangeCreated, TaskChangeUpdated, TaskChangeToggled, TaskChangeDeleted, TaskChangeOverdue,
+		TaskChangeReminder:
+		return nil
+	default:
+		return fmt.Errorf("invalid TaskChangeType: %q", changeType)
+	}
+}
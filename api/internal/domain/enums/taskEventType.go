@@ -0,0 +1,11 @@
+package enums
+
+type TaskEventType string
+
+const (
+	TaskCreated       TaskEventType = "Created"
+	TaskUpdated       TaskEventType = "Updated"
+	TaskStatusChanged TaskEventType = "StatusChanged"
+	TaskDeleted       TaskEventType = "Deleted"
+	TaskRestored      TaskEventType = "Restored"
+)
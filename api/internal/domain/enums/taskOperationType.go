@@ -0,0 +1,22 @@
+package enums
+
+import "fmt"
+
+// TaskOperationType — действие над задачей в пакетном запросе
+type TaskOperationType string
+
+const (
+	OperationCreate TaskOperationType = "create"
+	OperationUpdate TaskOperationType = "update"
+	OperationToggle TaskOperationType = "toggle"
+	OperationDelete TaskOperationType = "delete"
+)
+
+func ValidateTaskOperationType(operationType TaskOperationType) error {
+	switch operationType {
+	case OperationCreate, OperationUpdate, OperationToggle, OperationDelete:
+		return nil
+	default:
+		return fmt.Errorf("invalid TaskOperationType: %q", operationType)
+	}
+}
@@ -0,0 +1,10 @@
+package enums
+
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "Pending"
+	DeliveryDelivered WebhookDeliveryStatus = "Delivered"
+	// Попытки исчерпаны, отправка попадает в список неудавшихся
+	DeliveryFailed WebhookDeliveryStatus = "Failed"
+)
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type ChecklistItemsRepository interface {
+	Add(item models.ChecklistItem) error
+	GetByID(id uuid.UUID) (*models.ChecklistItem, error)
+	GetByTaskID(taskID uuid.UUID) ([]*models.ChecklistItem, error)
+	// GetProgress возвращает прогресс чек-листов задач; задачи без пунктов в результат не попадают
+	GetProgress(taskIDs []uuid.UUID) (map[uuid.UUID]models.ChecklistProgress, error)
+	Update(item models.ChecklistItem) error
+	// MarkAllDone отмечает выполненными все пункты чек-листа задачи
+	MarkAllDone(taskID uuid.UUID, now time.Time) error
+	DeleteByID(id uuid.UUID) error
+	DeleteByTaskID(taskID uuid.UUID) error
+}
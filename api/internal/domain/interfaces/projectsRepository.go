@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type ProjectsRepository interface {
+	Add(project models.Project) error
+	GetByID(id uuid.UUID) (*models.Project, error)
+	// GetByOwnerID возвращает проекты пользователя: Inbox первым, остальные по дате создания;
+	// archived == nil — и архивные, и активные
+	GetByOwnerID(ownerID uuid.UUID, archived *bool) ([]*models.Project, error)
+	// GetInbox возвращает Inbox пользователя или nil, если он ещё не создан
+	GetInbox(ownerID uuid.UUID) (*models.Project, error)
+	Update(project models.Project) error
+	DeleteByID(id uuid.UUID) error
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type TagsRepository interface {
+	Add(tag models.Tag) error
+	GetByID(id uuid.UUID) (*models.Tag, error)
+	GetByOwnerID(ownerID uuid.UUID) ([]*models.Tag, error)
+	GetByNames(ownerID uuid.UUID, names []string) ([]*models.Tag, error)
+	Update(tag models.Tag) error
+	// DeleteByID удаляет метку вместе с её связями с задачами
+	DeleteByID(id uuid.UUID) error
+	// GetByTaskIDs возвращает метки задач, упорядоченные по имени; задачи без меток в результат не попадают
+	GetByTaskIDs(taskIDs []uuid.UUID) (map[uuid.UUID][]models.Tag, error)
+	// GetTaskIDs возвращает задачи, у которых есть хотя бы одна из меток
+	GetTaskIDs(tagIDs []uuid.UUID) ([]uuid.UUID, error)
+	// SetTaskTags заменяет набор меток задачи; пустой tagIDs снимает все метки
+	SetTaskTags(taskID uuid.UUID, tagIDs []uuid.UUID) error
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type TaskEventsRepository interface {
+	Add(event models.TaskEvent) error
+	// GetPage возвращает события задачи в хронологическом порядке, начиная после after
+	GetPage(taskID uuid.UUID, after *models.TaskEventsCursor, limit int) (*models.TaskEventsPage, error)
+	// DeleteByTaskID удаляет журнал окончательно удалённой задачи
+	DeleteByTaskID(taskID uuid.UUID) error
+}
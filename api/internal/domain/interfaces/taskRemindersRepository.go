@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type TaskRemindersRepository interface {
+	// GetByTaskID возвращает напоминания задачи, самые ранние (с наибольшим MinutesBefore) первыми
+	GetByTaskID(taskID uuid.UUID) ([]*models.TaskReminder, error)
+	// GetPending возвращает неотправленные напоминания со временем отправки не позже until, по времени
+	GetPending(until time.Time) ([]*models.TaskReminder, error)
+	// ReplaceForTask заменяет все напоминания задачи на reminders
+	ReplaceForTask(taskID uuid.UUID, reminders []models.TaskReminder) error
+	// Update сохраняет напоминание; напоминание, удалённое заменой списка, не восстанавливается
+	Update(reminder models.TaskReminder) error
+}
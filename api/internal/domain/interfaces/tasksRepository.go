@@ -3,13 +3,36 @@ package interfaces
 import (
 	"HITS_ToDoList_Tests/internal/application/enums"
 	"HITS_ToDoList_Tests/internal/domain/models"
+	"errors"
 	"github.com/google/uuid"
+	"time"
 )
 
+// ErrTaskVersionConflict — задача в хранилище изменилась с момента чтения
+var ErrTaskVersionConflict = errors.New("task version conflict")
+
+// Задачи из корзины (DeletedAt != nil) выбираются только фильтром TasksFilter.Deleted,
+// GetByID и MarkOverdue их не видят. В корзину задачу переносит Update с заполненным DeletedAt
 type TasksRepository interface {
 	Add(task models.Task) error
-	GetAll(sorting *enums.Sorting) ([]*models.Task, error)
+	GetAll(filter *models.TasksFilter, sorting *enums.Sorting) ([]*models.Task, error)
+	GetPage(filter *models.TasksFilter, sorting *enums.Sorting, after *models.TasksCursor,
+		limit int) (*models.TasksPage, error)
 	GetByID(id uuid.UUID) (*models.Task, error)
+	// GetByIDForUpdate — GetByID, который в транзакции UnitOfWork блокирует задачу до её конца
+	// (SELECT ... FOR UPDATE), чтобы изменения одной задачи выполнялись по очереди
+	GetByIDForUpdate(id uuid.UUID) (*models.Task, error)
+	// GetDeleted — задача из корзины без блокировки; nil, если такой задачи в корзине нет
+	GetDeleted(id uuid.UUID) (*models.Task, error)
+	// GetDeletedForUpdate — задача из корзины, перенесённая туда раньше deletedBefore (nil — когда угодно),
+	// с той же блокировкой, что и GetByIDForUpdate; nil, если такой задачи в корзине нет
+	GetDeletedForUpdate(id uuid.UUID, deletedBefore *time.Time) (*models.Task, error)
+	// DeleteByID удаляет задачу окончательно
 	DeleteByID(taskID uuid.UUID) error
+	// Update сохраняет задачу, только если её версия в хранилище равна task.Version, и увеличивает
+	// версию на единицу; иначе возвращает ErrTaskVersionConflict
 	Update(task models.Task) error
+	// MarkOverdue одним запросом переводит активные задачи с дедлайном раньше now в Overdue
+	// и возвращает изменённые задачи с новой версией
+	MarkOverdue(now time.Time) ([]*models.Task, error)
 }
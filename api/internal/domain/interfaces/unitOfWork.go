@@ -0,0 +1,18 @@
+package interfaces
+
+// Repositories — репозитории, работающие в одной транзакции
+type Repositories struct {
+	Tasks          TasksRepository
+	ChecklistItems ChecklistItemsRepository
+	Tags           TagsRepository
+	Projects       ProjectsRepository
+	TaskEvents     TaskEventsRepository
+	// UnitOfWork открывает вложенную транзакцию: её откат не отменяет изменений внешней
+	UnitOfWork UnitOfWork
+}
+
+// UnitOfWork выполняет fn в транзакции: изменения, сделанные через repos, сохраняются вместе,
+// а ошибка или паника в fn откатывает их все
+type UnitOfWork interface {
+	WithinTx(fn func(repos Repositories) error) error
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"errors"
+	"github.com/google/uuid"
+)
+
+// ErrUserEmailTaken — пользователь с таким email уже есть
+var ErrUserEmailTaken = errors.New("user email already taken")
+
+type UsersRepository interface {
+	// Add возвращает ErrUserEmailTaken, если email уже занят, в том числе параллельной регистрацией
+	Add(user models.User) error
+	GetByID(id uuid.UUID) (*models.User, error)
+	GetByEmail(email string) (*models.User, error)
+	GetByCalendarTokenHash(hash string) (*models.User, error)
+	Update(user models.User) error
+}
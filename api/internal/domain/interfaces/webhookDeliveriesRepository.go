@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type WebhookDeliveriesRepository interface {
+	Add(delivery models.WebhookDelivery) error
+	GetByID(id uuid.UUID) (*models.WebhookDelivery, error)
+	// GetDue возвращает не больше limit ожидающих отправок, время попытки которых наступило к now,
+	// начиная с самых давних
+	GetDue(now time.Time, limit int) ([]*models.WebhookDelivery, error)
+	// GetByWebhookIDs возвращает отправки подписок в указанном статусе, последние созданные первыми
+	GetByWebhookIDs(webhookIDs []uuid.UUID, status enums.WebhookDeliveryStatus) ([]*models.WebhookDelivery, error)
+	Update(delivery models.WebhookDelivery) error
+	DeleteByWebhookID(webhookID uuid.UUID) error
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type WebhooksRepository interface {
+	Add(webhook models.Webhook) error
+	GetByID(id uuid.UUID) (*models.Webhook, error)
+	// GetByOwnerID возвращает подписки пользователя в порядке создания
+	GetByOwnerID(ownerID uuid.UUID) ([]*models.Webhook, error)
+	Update(webhook models.Webhook) error
+	DeleteByID(id uuid.UUID) error
+}
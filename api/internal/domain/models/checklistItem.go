@@ -0,0 +1,33 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ChecklistItem — пункт чек-листа внутри задачи
+type ChecklistItem struct {
+	ID        uuid.UUID
+	TaskID    uuid.UUID `gorm:"not null;index"`
+	CreatedAt time.Time `gorm:"not null"`
+	ChangedAt *time.Time
+	Name      string `gorm:"not null"`
+	IsDone    bool   `gorm:"not null"`
+	Position  int    `gorm:"not null"`
+}
+
+// ChecklistProgress — сколько пунктов чек-листа задачи выполнено из общего числа
+type ChecklistProgress struct {
+	Done  int
+	Total int
+}
+
+func NewChecklistItem(taskID uuid.UUID, name string, position int) *ChecklistItem {
+	return &ChecklistItem{
+		ID:        uuid.New(),
+		TaskID:    taskID,
+		CreatedAt: time.Now(),
+		Name:      name,
+		Position:  position,
+	}
+}
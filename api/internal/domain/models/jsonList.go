@@ -0,0 +1,38 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONList хранит список в одной колонке в виде JSON
+type JSONList[T any] []T
+
+func (JSONList[T]) GormDataType() string {
+	return "text"
+}
+
+func (list JSONList[T]) Value() (driver.Value, error) {
+	if list == nil {
+		list = JSONList[T]{}
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (list *JSONList[T]) Scan(value any) error {
+	switch data := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(data), list)
+	case []byte:
+		return json.Unmarshal(data, list)
+	default:
+		return fmt.Errorf("unsupported list value %T", value)
+	}
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// Notification — напоминание владельцу задачи: до дедлайна Task осталось Left
+type Notification struct {
+	Email string
+	Task  Task
+	Left  time.Duration
+}
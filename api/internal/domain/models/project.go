@@ -0,0 +1,36 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const InboxProjectName = "Inbox"
+
+// Project — список задач пользователя. Inbox создаётся автоматически и принимает задачи удалённых проектов
+type Project struct {
+	ID         uuid.UUID
+	OwnerID    uuid.UUID `gorm:"not null;index"`
+	CreatedAt  time.Time `gorm:"not null"`
+	ChangedAt  *time.Time
+	Name       string `gorm:"not null"`
+	Color      *string
+	IsArchived bool `gorm:"not null"`
+	IsInbox    bool `gorm:"not null"`
+}
+
+func NewProject(ownerID uuid.UUID, name string, color *string) *Project {
+	return &Project{
+		ID:        uuid.New(),
+		OwnerID:   ownerID,
+		CreatedAt: time.Now(),
+		Name:      name,
+		Color:     color,
+	}
+}
+
+func NewInboxProject(ownerID uuid.UUID) *Project {
+	project := NewProject(ownerID, InboxProjectName, nil)
+	project.IsInbox = true
+	return project
+}
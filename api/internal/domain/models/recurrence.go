@@ -0,0 +1,234 @@
+package models
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence — поддерживаемое подмножество RRULE из RFC 5545: FREQ=DAILY|WEEKLY|MONTHLY|YEARLY,
+// INTERVAL, BYDAY (только для WEEKLY), COUNT и UNTIL. COUNT хранит число оставшихся вхождений
+// серии, включая текущее, поэтому у каждой следующей задачи он уменьшается.
+type Recurrence struct {
+	Frequency enums.Frequency
+	Interval  int
+	ByDay     []time.Weekday
+	Count     *int
+	Until     *time.Time
+}
+
+const untilLayout = "20060102T150405Z"
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ParseRecurrence разбирает правило вида "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", префикс "RRULE:" необязателен
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.ToUpper(strings.TrimSpace(rule))
+	rule = strings.TrimPrefix(rule, "RRULE:")
+	if rule == "" {
+		return nil, errors.New("rule is empty")
+	}
+
+	recurrence := &Recurrence{Interval: 1}
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(rule, ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found || value == "" {
+			return nil, fmt.Errorf("malformed rule part %q", part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%s is set more than once", name)
+		}
+		seen[name] = true
+
+		switch name {
+		case "FREQ":
+			recurrence.Frequency = enums.Frequency(value)
+			if err := enums.ValidateFrequency(recurrence.Frequency); err != nil {
+				return nil, err
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, errors.New("INTERVAL must be a positive integer")
+			}
+			recurrence.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return nil, errors.New("COUNT must be a positive integer")
+			}
+			recurrence.Count = &count
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			recurrence.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := weekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("unsupported BYDAY value %q", day)
+				}
+				if !slices.Contains(recurrence.ByDay, weekday) {
+					recurrence.ByDay = append(recurrence.ByDay, weekday)
+				}
+			}
+			slices.SortFunc(recurrence.ByDay, func(a, b time.Weekday) int {
+				return isoWeekday(a) - isoWeekday(b)
+			})
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", name)
+		}
+	}
+
+	if recurrence.Frequency == "" {
+		return nil, errors.New("FREQ is required")
+	}
+	if len(recurrence.ByDay) > 0 && recurrence.Frequency != enums.Weekly {
+		return nil, errors.New("BYDAY is supported only with FREQ=WEEKLY")
+	}
+	if recurrence.Count != nil && recurrence.Until != nil {
+		return nil, errors.New("COUNT and UNTIL cannot be used together")
+	}
+
+	return recurrence, nil
+}
+
+// String возвращает правило в каноническом виде, в котором оно хранится у задачи
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, weekday := range r.ByDay {
+			days[i] = weekdayNames[weekday]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.Count != nil {
+		parts = append(parts, "COUNT="+strconv.Itoa(*r.Count))
+	}
+
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// NextAfter сдвигает дедлайн по правилу до первого вхождения позже now. Пропущенные вхождения
+// расходуют COUNT; вместе с дедлайном возвращается правило для следующей задачи серии.
+// ok == false, если серия закончилась.
+func (r Recurrence) NextAfter(deadline time.Time, now time.Time) (next time.Time, rest Recurrence, ok bool) {
+	rest = r
+	next = deadline
+
+	for {
+		if rest.Count != nil {
+			if *rest.Count <= 1 {
+				return time.Time{}, Recurrence{}, false
+			}
+			count := *rest.Count - 1
+			rest.Count = &count
+		}
+
+		if next, ok = rest.step(next); !ok {
+			return time.Time{}, Recurrence{}, false
+		}
+		if rest.Until != nil && next.After(*rest.Until) {
+			return time.Time{}, Recurrence{}, false
+		}
+		if next.After(now) {
+			return next, rest, true
+		}
+	}
+}
+
+const maxSkippedPeriods = 100
+
+func (r Recurrence) step(from time.Time) (time.Time, bool) {
+	switch r.Frequency {
+	case enums.Daily:
+		return from.AddDate(0, 0, r.Interval), true
+	case enums.Weekly:
+		if len(r.ByDay) == 0 {
+			return from.AddDate(0, 0, 7*r.Interval), true
+		}
+		return r.nextWeekday(from), true
+	case enums.Monthly:
+		return sameDayAfter(from, func(k int) time.Time { return from.AddDate(0, k*r.Interval, 0) })
+	case enums.Yearly:
+		return sameDayAfter(from, func(k int) time.Time { return from.AddDate(k*r.Interval, 0, 0) })
+	default:
+		return time.Time{}, false
+	}
+}
+
+// Неделя начинается с понедельника; подходят дни из BYDAY в неделях, кратных INTERVAL от недели from
+func (r Recurrence) nextWeekday(from time.Time) time.Time {
+	weekStart := from.AddDate(0, 0, -(isoWeekday(from.Weekday()) - 1))
+
+	for day := 1; ; day++ {
+		candidate := from.AddDate(0, 0, day)
+		weeks := daysBetween(weekStart, candidate) / 7
+		if weeks%r.Interval == 0 && slices.Contains(r.ByDay, candidate.Weekday()) {
+			return candidate
+		}
+	}
+}
+
+// Даты, которых нет в месяце (31 число, 29 февраля), пропускаются, как того требует RFC 5545
+func sameDayAfter(from time.Time, shift func(k int) time.Time) (time.Time, bool) {
+	for k := 1; k <= maxSkippedPeriods; k++ {
+		if candidate := shift(k); candidate.Day() == from.Day() {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if until, err := time.Parse(untilLayout, value); err == nil {
+		return until, nil
+	}
+	if until, err := time.Parse("20060102", value); err == nil {
+		// Дата без времени включает весь день
+		return until.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	return time.Time{}, errors.New("UNTIL must be in YYYYMMDD or YYYYMMDDTHHMMSSZ format")
+}
+
+func isoWeekday(weekday time.Weekday) int {
+	if weekday == time.Sunday {
+		return 7
+	}
+	return int(weekday)
+}
+
+func daysBetween(from time.Time, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
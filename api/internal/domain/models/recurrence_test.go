@@ -0,0 +1,200 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Тест разбора правила повторения
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      string
+		wantRule  string
+		wantError bool
+	}{
+		{name: "Ежедневно", rule: "FREQ=DAILY", wantRule: "FREQ=DAILY"},
+		{name: "Префикс и нижний регистр", rule: "rrule:freq=weekly;byday=th,mo", wantRule: "FREQ=WEEKLY;BYDAY=MO,TH"},
+		{name: "Интервал и количество", rule: "FREQ=MONTHLY;COUNT=3;INTERVAL=2",
+			wantRule: "FREQ=MONTHLY;INTERVAL=2;COUNT=3"},
+		{name: "Дата окончания без времени", rule: "FREQ=YEARLY;UNTIL=20300101",
+			wantRule: "FREQ=YEARLY;UNTIL=20300101T235959Z"},
+		{name: "Единичный интервал не сохраняется", rule: "FREQ=DAILY;INTERVAL=1", wantRule: "FREQ=DAILY"},
+		{name: "Пустое правило", rule: " ", wantError: true},
+		{name: "Без FREQ", rule: "INTERVAL=2", wantError: true},
+		{name: "Неизвестная частота", rule: "FREQ=HOURLY", wantError: true},
+		{name: "Нулевой интервал", rule: "FREQ=DAILY;INTERVAL=0", wantError: true},
+		{name: "BYDAY не для недели", rule: "FREQ=MONTHLY;BYDAY=MO", wantError: true},
+		{name: "Неизвестный день", rule: "FREQ=WEEKLY;BYDAY=1MO", wantError: true},
+		{name: "COUNT вместе с UNTIL", rule: "FREQ=DAILY;COUNT=2;UNTIL=20300101", wantError: true},
+		{name: "Повторяющаяся часть", rule: "FREQ=DAILY;FREQ=WEEKLY", wantError: true},
+		{name: "Неподдерживаемая часть", rule: "FREQ=DAILY;BYHOUR=9", wantError: true},
+		{name: "Часть без значения", rule: "FREQ=DAILY;COUNT", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurrence, err := ParseRecurrence(tt.rule)
+
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRule, recurrence.String())
+		})
+	}
+}
+
+// Тест вычисления следующего дедлайна серии
+func TestRecurrence_NextAfter(t *testing.T) {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 18, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		deadline time.Time
+		now      time.Time
+		wantNext time.Time
+		wantRule string
+		wantEnd  bool
+	}{
+		{
+			name:     "Каждые два дня",
+			rule:     "FREQ=DAILY;INTERVAL=2",
+			deadline: at(2024, time.March, 1),
+			now:      at(2024, time.February, 28),
+			wantNext: at(2024, time.March, 3),
+			wantRule: "FREQ=DAILY;INTERVAL=2",
+		},
+		{
+			name:     "Еженедельно без дней",
+			rule:     "FREQ=WEEKLY",
+			deadline: at(2024, time.March, 1),
+			now:      at(2024, time.February, 28),
+			wantNext: at(2024, time.March, 8),
+			wantRule: "FREQ=WEEKLY",
+		},
+		{
+			name:     "Дни недели в той же неделе",
+			rule:     "FREQ=WEEKLY;BYDAY=MO,TH",
+			deadline: at(2024, time.March, 4), // понедельник
+			now:      at(2024, time.March, 1),
+			wantNext: at(2024, time.March, 7),
+			wantRule: "FREQ=WEEKLY;BYDAY=MO,TH",
+		},
+		{
+			name:     "Раз в две недели по понедельникам и четвергам",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			deadline: at(2024, time.March, 7), // четверг
+			now:      at(2024, time.March, 1),
+			wantNext: at(2024, time.March, 18),
+			wantRule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+		},
+		{
+			name:     "Воскресенье завершает неделю",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,MO",
+			deadline: at(2024, time.March, 4), // понедельник
+			now:      at(2024, time.March, 1),
+			wantNext: at(2024, time.March, 10),
+			wantRule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU",
+		},
+		{
+			name:     "Ежемесячно пропускает короткие месяцы",
+			rule:     "FREQ=MONTHLY",
+			deadline: at(2024, time.January, 31),
+			now:      at(2024, time.January, 1),
+			wantNext: at(2024, time.March, 31),
+			wantRule: "FREQ=MONTHLY",
+		},
+		{
+			name:     "Ежегодно с 29 февраля",
+			rule:     "FREQ=YEARLY",
+			deadline: at(2024, time.February, 29),
+			now:      at(2024, time.January, 1),
+			wantNext: at(2028, time.February, 29),
+			wantRule: "FREQ=YEARLY",
+		},
+		{
+			name:     "Пропущенные вхождения не создаются",
+			rule:     "FREQ=DAILY",
+			deadline: at(2024, time.March, 1),
+			now:      at(2024, time.March, 4).Add(time.Hour),
+			wantNext: at(2024, time.March, 5),
+			wantRule: "FREQ=DAILY",
+		},
+		{
+			name:     "COUNT уменьшается",
+			rule:     "FREQ=DAILY;COUNT=3",
+			deadline: at(2024, time.March, 1),
+			now:      at(2024, time.February, 28),
+			wantNext: at(2024, time.March, 2),
+			wantRule: "FREQ=DAILY;COUNT=2",
+		},
+		{
+			name:     "Пропущенные вхождения расходуют COUNT",
+			rule:     "FREQ=DAILY;COUNT=3",
+			deadline: at(2024, time.March, 1),
+			now:      at(2024, time.March, 3).Add(time.Hour),
+			wantEnd:  true,
+		},
+		{
+			name:     "Последнее вхождение",
+			rule:     "FREQ=DAILY;COUNT=1",
+			deadline: at(2024, time.March, 1),
+			now:      at(2024, time.February, 28),
+			wantEnd:  true,
+		},
+		{
+			name:     "После UNTIL серия заканчивается",
+			rule:     "FREQ=WEEKLY;UNTIL=20240305",
+			deadline: at(2024, time.March, 1),
+			now:      at(2024, time.February, 28),
+			wantEnd:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurrence, err := ParseRecurrence(tt.rule)
+			assert.NoError(t, err)
+
+			original := recurrence.String()
+			next, rest, ok := recurrence.NextAfter(tt.deadline, tt.now)
+
+			// Правило исходной задачи не меняется
+			assert.Equal(t, original, recurrence.String())
+
+			if tt.wantEnd {
+				assert.False(t, ok)
+				return
+			}
+
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantNext, next)
+			assert.Equal(t, tt.wantRule, rest.String())
+		})
+	}
+}
+
+// Тест сохранения времени суток при переходе на летнее время
+func TestRecurrence_NextAfter_DST(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+
+	recurrence, err := ParseRecurrence("FREQ=DAILY")
+	assert.NoError(t, err)
+
+	deadline := time.Date(2024, time.March, 30, 9, 0, 0, 0, location)
+	next, _, ok := recurrence.NextAfter(deadline, deadline)
+
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, time.March, 31, 9, 0, 0, 0, location), next)
+	assert.Equal(t, 23*time.Hour, next.Sub(deadline))
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// Tag — метка пользователя; имя хранится в нижнем регистре и уникально в пределах владельца
+type Tag struct {
+	ID        uuid.UUID
+	OwnerID   uuid.UUID `gorm:"not null;uniqueIndex:idx_tags_owner_id_name"`
+	CreatedAt time.Time `gorm:"not null"`
+	Name      string    `gorm:"not null;uniqueIndex:idx_tags_owner_id_name"`
+}
+
+// TaskTag — связь задачи с меткой
+type TaskTag struct {
+	TaskID uuid.UUID `gorm:"primaryKey"`
+	TagID  uuid.UUID `gorm:"primaryKey;index"`
+}
+
+func NewTag(ownerID uuid.UUID, name string) *Tag {
+	return &Tag{
+		ID:        uuid.New(),
+		OwnerID:   ownerID,
+		CreatedAt: time.Now(),
+		Name:      name,
+	}
+}
+
+// NormalizeTagName приводит имя метки к виду, в котором оно хранится: "#Work " → "work"
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+}
@@ -8,6 +8,7 @@ import (
 
 type Task struct {
 	ID          uuid.UUID
+	OwnerID     uuid.UUID `gorm:"index"`
 	CreatedAt   time.Time `gorm:"not null"`
 	ChangedAt   *time.Time
 	Name        string `gorm:"not null"`
@@ -15,6 +16,26 @@ type Task struct {
 	Deadline    *time.Time
 	Status      enums.Status   `gorm:"not null"`
 	Priority    enums.Priority `gorm:"not null"`
+	ProjectID   *uuid.UUID     `gorm:"index"`
+
+	// Часовой пояс, в котором задан дедлайн: по нему считаются следующие повторения; nil — UTC
+	TimeZone *string
+
+	// Правило повторения в формате RRULE; следующая задача серии создаётся один раз,
+	// и её ID сохраняется в NextOccurrenceID выполненной или просроченной задачи
+	Recurrence       *string
+	NextOccurrenceID *uuid.UUID
+
+	// Время перемещения в корзину; nil — задача не удалена. Задачи из корзины не попадают в выборки
+	// и удаляются окончательно по истечении срока хранения
+	DeletedAt *time.Time `gorm:"index"`
+
+	// Версия задачи увеличивается при каждом сохранении; по ней обнаруживаются одновременные изменения
+	Version int `gorm:"not null;default:1"`
+
+	// Прогресс чек-листа и метки не хранятся в таблице задач, их заполняет сервис
+	Checklist ChecklistProgress `gorm:"-"`
+	Tags      []Tag             `gorm:"-"`
 }
 
 func NewTask(name string, description *string, deadline *time.Time, status *enums.Status,
@@ -25,6 +46,7 @@ func NewTask(name string, description *string, deadline *time.Time, status *enum
 		Name:        name,
 		Description: description,
 		Deadline:    deadline,
+		Version:     1,
 	}
 
 	if status == nil {
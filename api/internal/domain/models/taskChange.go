@@ -0,0 +1,25 @@
+package models
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"time"
+)
+
+// TaskChange — событие для подписанных клиентов: состояние задачи после изменения.
+// ID назначает шина событий при публикации, идентификаторы растут по порядку публикации
+type TaskChange struct {
+	ID         uint64
+	Type       enums.TaskChangeType
+	Task       Task
+	OccurredAt time.Time
+}
+
+// TaskChangeSubscription — подписка на изменения задач пользователя. Missed — изменения, пропущенные
+// с момента переподключения; Reset — часть пропущенных изменений уже не хранится, и клиенту
+// нужно заново загрузить задачи. Changes закрывается, когда подписка завершена шиной или через Close
+type TaskChangeSubscription struct {
+	Missed  []TaskChange
+	Reset   bool
+	Changes <-chan TaskChange
+	Close   func()
+}
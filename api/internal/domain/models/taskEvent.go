@@ -0,0 +1,177 @@
+package models
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TaskEvent — запись журнала изменений задачи. Журнал только пополняется и хранится
+// после удаления задачи
+type TaskEvent struct {
+	ID        uuid.UUID
+	TaskID    uuid.UUID `gorm:"not null;index:idx_task_events_task_id_created_at,priority:1"`
+	CreatedAt time.Time `gorm:"not null;index:idx_task_events_task_id_created_at,priority:2"`
+	// Кто изменил задачу; nil — планировщик
+	ActorID *uuid.UUID
+	Type    enums.TaskEventType `gorm:"not null"`
+	Changes FieldChanges        `gorm:"not null"`
+}
+
+// FieldChange — старое и новое значение поля задачи в текстовом виде; nil — значение не задано
+type FieldChange struct {
+	Field string  `json:"field"`
+	Old   *string `json:"old"`
+	New   *string `json:"new"`
+}
+
+// FieldChanges хранится в одной колонке в виде JSON
+type FieldChanges []FieldChange
+
+func (FieldChanges) GormDataType() string {
+	return "text"
+}
+
+func (changes FieldChanges) Value() (driver.Value, error) {
+	if changes == nil {
+		changes = FieldChanges{}
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (changes *FieldChanges) Scan(value any) error {
+	switch data := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(data), changes)
+	case []byte:
+		return json.Unmarshal(data, changes)
+	default:
+		return fmt.Errorf("unsupported field changes value %T", value)
+	}
+}
+
+func NewTaskEvent(taskID uuid.UUID, actorID *uuid.UUID, eventType enums.TaskEventType,
+	changes FieldChanges) *TaskEvent {
+	return &TaskEvent{
+		ID:        uuid.New(),
+		TaskID:    taskID,
+		CreatedAt: time.Now(),
+		ActorID:   actorID,
+		Type:      eventType,
+		Changes:   changes,
+	}
+}
+
+// DiffTasks сравнивает поля задачи до и после изменения. before == nil — задача создана,
+// after == nil — удалена. Метки берутся из поля Tags, поэтому у обеих версий они должны быть загружены
+func DiffTasks(before *Task, after *Task) FieldChanges {
+	var oldValues, newValues []fieldValue
+	if before != nil {
+		oldValues = taskFieldValues(before)
+	}
+	if after != nil {
+		newValues = taskFieldValues(after)
+	}
+
+	changes := FieldChanges{}
+	for i := range max(len(oldValues), len(newValues)) {
+		var change FieldChange
+		if oldValues != nil {
+			change.Field, change.Old = oldValues[i].field, oldValues[i].value
+		}
+		if newValues != nil {
+			change.Field, change.New = newValues[i].field, newValues[i].value
+		}
+
+		if !equalValues(change.Old, change.New) {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+type fieldValue struct {
+	field string
+	value *string
+}
+
+func taskFieldValues(task *Task) []fieldValue {
+	return []fieldValue{
+		{"name", &task.Name},
+		{"description", task.Description},
+		{"deadline", formatTime(task.Deadline)},
+		{"status", stringValue(string(task.Status))},
+		{"priority", stringValue(string(task.Priority))},
+		{"projectId", formatUUID(task.ProjectID)},
+		{"timeZone", task.TimeZone},
+		{"recurrence", task.Recurrence},
+		{"nextOccurrenceId", formatUUID(task.NextOccurrenceID)},
+		{"tags", formatTags(task.Tags)},
+	}
+}
+
+func equalValues(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func stringValue(value string) *string {
+	return &value
+}
+
+func formatTime(value *time.Time) *string {
+	if value == nil {
+		return nil
+	}
+
+	return stringValue(value.UTC().Format(time.RFC3339))
+}
+
+func formatUUID(value *uuid.UUID) *string {
+	if value == nil {
+		return nil
+	}
+
+	return stringValue(value.String())
+}
+
+// Метки — отсортированные имена через запятую; пустой набор не отличается от отсутствующего
+func formatTags(tags []Tag) *string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	slices.Sort(names)
+
+	return stringValue(strings.Join(names, ", "))
+}
+
+// TaskEventsCursor — ключ последнего выданного события, после которого продолжается выборка
+type TaskEventsCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+type TaskEventsPage struct {
+	Items   []*TaskEvent
+	HasMore bool
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Тест сравнения версий задачи для журнала изменений
+func TestDiffTasks(t *testing.T) {
+	deadline := time.Date(2030, 2, 15, 18, 0, 0, 0, time.FixedZone("UTC+7", 7*60*60))
+	description := "Описание"
+	task := NewTask("Отчёт", &description, &deadline, nil, nil)
+	task.Tags = []Tag{{Name: "work"}, {Name: "home"}}
+
+	t.Run("Создание", func(t *testing.T) {
+		changes := DiffTasks(nil, task)
+
+		assert.Equal(t, FieldChange{Field: "name", New: utils.Ptr("Отчёт")}, changes[0])
+		assert.Contains(t, changes, FieldChange{Field: "deadline", New: utils.Ptr("2030-02-15T11:00:00Z")})
+		assert.Contains(t, changes, FieldChange{Field: "tags", New: utils.Ptr("home, work")})
+		// Пустые поля не попадают в журнал
+		for _, change := range changes {
+			assert.NotEqual(t, "projectId", change.Field)
+		}
+	})
+
+	t.Run("Изменение", func(t *testing.T) {
+		after := *task
+		after.Name = "Годовой отчёт"
+		after.Description = nil
+		after.Status = enums.Completed
+		// Тот же момент в другом часовом поясе изменением не считается
+		after.Deadline = utils.Ptr(deadline.UTC())
+		after.Tags = []Tag{{Name: "home"}, {Name: "work"}}
+
+		assert.Equal(t, FieldChanges{
+			{Field: "name", Old: utils.Ptr("Отчёт"), New: utils.Ptr("Годовой отчёт")},
+			{Field: "description", Old: utils.Ptr("Описание")},
+			{Field: "status", Old: utils.Ptr("Active"), New: utils.Ptr("Completed")},
+		}, DiffTasks(task, &after))
+	})
+
+	t.Run("Без изменений", func(t *testing.T) {
+		same := *task
+		assert.Empty(t, DiffTasks(task, &same))
+	})
+
+	t.Run("Удаление", func(t *testing.T) {
+		projectID := uuid.New()
+		deleted := *task
+		deleted.ProjectID = &projectID
+
+		changes := DiffTasks(&deleted, nil)
+		assert.Contains(t, changes, FieldChange{Field: "projectId", Old: utils.Ptr(projectID.String())})
+		for _, change := range changes {
+			assert.Nil(t, change.New)
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"time"
+)
+
+// TaskImport — задача из импортируемого файла. Done — задача в файле уже выполнена
+type TaskImport struct {
+	Name        string
+	Description *string
+	Deadline    *time.Time
+	Priority    *enums.Priority
+	Recurrence  *string
+	Tags        []string
+	Done        bool
+}
+
+// TaskImportResult — итог импорта задачи: созданная задача, пропуск задачи с прошедшим дедлайном или ошибка
+type TaskImportResult struct {
+	Task    *Task
+	Skipped bool
+	Err     error
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"github.com/google/uuid"
+	"time"
+)
+
+// TaskOperation — действие пакетного запроса. Поля задачи используются в create и update, IsDone
+// и CompleteItems — в toggle. Version — ожидаемая версия задачи; nil — без проверки
+type TaskOperation struct {
+	Type    enums.TaskOperationType
+	TaskID  uuid.UUID
+	Version *int
+
+	Name        string
+	Description *string
+	Deadline    *time.Time
+	Priority    *enums.Priority
+	Recurrence  *string
+	Tags        []string
+	ProjectID   *uuid.UUID
+
+	IsDone        bool
+	CompleteItems bool
+}
+
+// TaskOperationResult — итог действия: задача после него (для delete — nil) или ошибка
+type TaskOperationResult struct {
+	Task *Task
+	Err  error
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"github.com/google/uuid"
+	"time"
+)
+
+// TaskPatch — частичное изменение задачи: nil оставляет поле без изменений,
+// указатель на nil сбрасывает его (приоритет — в Medium, метки и проект — убираются)
+type TaskPatch struct {
+	Name        *string
+	Description **string
+	Deadline    **time.Time
+	Priority    **enums.Priority
+	Recurrence  **string
+	Tags        *[]string
+	ProjectID   **uuid.UUID
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"github.com/google/uuid"
+	"time"
+)
+
+// TaskReminder — напоминание за MinutesBefore минут до дедлайна задачи. RemindAt — время отправки
+// для текущего дедлайна, nil — задача не активна или без дедлайна. SentAt сохраняется до отправки,
+// поэтому после перезапуска напоминание не повторяется; при переносе дедлайна оно сбрасывается.
+// Напоминание, время которого прошло уже при планировании, сразу отмечается отправленным
+type TaskReminder struct {
+	ID            uuid.UUID
+	TaskID        uuid.UUID  `gorm:"not null;index"`
+	MinutesBefore int        `gorm:"not null"`
+	RemindAt      *time.Time `gorm:"index"`
+	SentAt        *time.Time
+}
+
+func NewTaskReminder(taskID uuid.UUID, minutesBefore int) *TaskReminder {
+	return &TaskReminder{
+		ID:            uuid.New(),
+		TaskID:        taskID,
+		MinutesBefore: minutesBefore,
+	}
+}
+
+// Schedule пересчитывает время отправки по задаче в момент now и сообщает, изменилось ли оно
+func (reminder *TaskReminder) Schedule(task *Task, now time.Time) bool {
+	var remindAt *time.Time
+	if task.Status == enums.Active && task.Deadline != nil && task.DeletedAt == nil {
+		at := task.Deadline.Add(-time.Duration(reminder.MinutesBefore) * time.Minute)
+		remindAt = &at
+	}
+
+	if remindAt == nil && reminder.RemindAt == nil ||
+		remindAt != nil && reminder.RemindAt != nil && remindAt.Equal(*reminder.RemindAt) {
+		return false
+	}
+
+	reminder.RemindAt = remindAt
+	reminder.SentAt = nil
+	if remindAt != nil && remindAt.Before(now) {
+		reminder.SentAt = &now
+	}
+	return true
+}
+
+// Pending — напоминание запланировано и ещё не отправлено
+func (reminder *TaskReminder) Pending() bool {
+	return reminder.RemindAt != nil && reminder.SentAt == nil
+}
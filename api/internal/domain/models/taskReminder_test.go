@@ -0,0 +1,59 @@
+package models
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Тест пересчёта времени напоминания при изменении задачи
+func TestTaskReminder_Schedule(t *testing.T) {
+	now := time.Date(2030, 2, 15, 12, 0, 0, 0, time.UTC)
+	deadline := now.Add(3 * time.Hour)
+	task := NewTask("Отчёт", nil, &deadline, nil, nil)
+	reminder := NewTaskReminder(task.ID, 60)
+
+	assert.True(t, reminder.Schedule(task, now))
+	assert.Equal(t, deadline.Add(-time.Hour), *reminder.RemindAt)
+	assert.True(t, reminder.Pending())
+
+	t.Run("Время не изменилось", func(t *testing.T) {
+		reminder.SentAt = utils.Ptr(now)
+		assert.False(t, reminder.Schedule(task, now.Add(time.Hour)))
+		assert.NotNil(t, reminder.SentAt)
+		reminder.SentAt = nil
+	})
+
+	t.Run("Перенос дедлайна снова включает напоминание", func(t *testing.T) {
+		reminder.SentAt = utils.Ptr(now)
+		moved := *task
+		moved.Deadline = utils.Ptr(deadline.Add(24 * time.Hour))
+
+		assert.True(t, reminder.Schedule(&moved, now))
+		assert.Equal(t, moved.Deadline.Add(-time.Hour), *reminder.RemindAt)
+		assert.True(t, reminder.Pending())
+	})
+
+	t.Run("Выполненная задача и задача без дедлайна", func(t *testing.T) {
+		completed := *task
+		completed.Status = enums.Completed
+		assert.True(t, reminder.Schedule(&completed, now))
+		assert.Nil(t, reminder.RemindAt)
+		assert.False(t, reminder.Pending())
+
+		withoutDeadline := *task
+		withoutDeadline.Deadline = nil
+		assert.False(t, reminder.Schedule(&withoutDeadline, now))
+	})
+
+	t.Run("Время напоминания уже прошло", func(t *testing.T) {
+		soon := *task
+		soon.Deadline = utils.Ptr(now.Add(30 * time.Minute))
+
+		assert.True(t, reminder.Schedule(&soon, now))
+		assert.Equal(t, now, *reminder.SentAt)
+		assert.False(t, reminder.Pending())
+	})
+}
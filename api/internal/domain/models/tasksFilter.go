@@ -0,0 +1,26 @@
+package models
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"github.com/google/uuid"
+	"time"
+)
+
+type TasksFilter struct {
+	OwnerID      *uuid.UUID
+	Statuses     []enums.Status
+	Priorities   []enums.Priority
+	DeadlineFrom *time.Time
+	DeadlineTo   *time.Time
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	Query        *string
+	ProjectID    *uuid.UUID
+	// Имена меток; сервис заменяет их на IDs задач с любой из этих меток
+	Tags []string
+	// IDs ограничивает выборку перечисленными задачами; nil — без ограничения
+	IDs []uuid.UUID
+	// Deleted выбирает задачи из корзины вместо обычных; DeletedBefore — удалённые раньше этого момента
+	Deleted       bool
+	DeletedBefore *time.Time
+}
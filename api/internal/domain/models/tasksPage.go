@@ -0,0 +1,29 @@
+package models
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"github.com/google/uuid"
+	"time"
+)
+
+// TasksCursor — ключ последней выданной задачи, после которой продолжается выборка
+type TasksCursor struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	Deadline  *time.Time
+	Priority  enums.Priority
+}
+
+type TasksPage struct {
+	Items   []*Task
+	HasMore bool
+}
+
+func NewTasksCursor(task *Task) *TasksCursor {
+	return &TasksCursor{
+		ID:        task.ID,
+		CreatedAt: task.CreatedAt,
+		Deadline:  task.Deadline,
+		Priority:  task.Priority,
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"errors"
+	"time"
+	_ "time/tzdata"
+)
+
+// LoadTimeZone возвращает часовой пояс по IANA-названию (Asia/Novosibirsk); nil — UTC.
+// База часовых поясов встроена в бинарник, поэтому не зависит от системной
+func LoadTimeZone(name *string) (*time.Location, error) {
+	if name == nil {
+		return time.UTC, nil
+	}
+
+	// time.LoadLocation трактует "" как UTC, а "Local" — как пояс сервера
+	if *name == "" || *name == "Local" {
+		return nil, errors.New("unknown time zone")
+	}
+
+	return time.LoadLocation(*name)
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type User struct {
+	ID           uuid.UUID
+	CreatedAt    time.Time `gorm:"not null"`
+	Email        string    `gorm:"not null;uniqueIndex"`
+	PasswordHash string    `gorm:"not null"`
+	// IANA-название часового пояса из профиля; nil — UTC
+	TimeZone *string
+	// SHA-256 секретного токена ленты календаря; nil — лента отключена. Сам токен не хранится
+	CalendarTokenHash *string `gorm:"uniqueIndex"`
+}
+
+func NewUser(email string, passwordHash string) *User {
+	return &User{
+		ID:           uuid.New(),
+		CreatedAt:    time.Now(),
+		Email:        email,
+		PasswordHash: passwordHash,
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// Webhook — подписка пользователя на изменения его задач. Пустые EventTypes и Priorities — без отбора
+// по виду изменения и приоритету задачи
+type Webhook struct {
+	ID        uuid.UUID
+	OwnerID   uuid.UUID `gorm:"not null;index"`
+	CreatedAt time.Time `gorm:"not null"`
+	URL       string    `gorm:"not null"`
+	// Ключ подписи HMAC-SHA256 тела запроса
+	Secret     string                         `gorm:"not null"`
+	EventTypes JSONList[enums.TaskChangeType] `gorm:"not null"`
+	Priorities JSONList[enums.Priority]       `gorm:"not null"`
+}
+
+func NewWebhook(ownerID uuid.UUID, url string, secret string, eventTypes []enums.TaskChangeType,
+	priorities []enums.Priority) *Webhook {
+	return &Webhook{
+		ID:         uuid.New(),
+		OwnerID:    ownerID,
+		CreatedAt:  time.Now(),
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Priorities: priorities,
+	}
+}
+
+// Matches проверяет, что изменение задачи владельца подписки проходит её отбор
+func (webhook *Webhook) Matches(change TaskChange) bool {
+	if change.Task.OwnerID != webhook.OwnerID {
+		return false
+	}
+
+	if len(webhook.EventTypes) > 0 && !slices.Contains(webhook.EventTypes, change.Type) {
+		return false
+	}
+
+	return len(webhook.Priorities) == 0 || slices.Contains(webhook.Priorities, change.Task.Priority)
+}
+
+// WebhookDelivery — отправка одного изменения задачи по подписке. Отправки хранятся вместе с подписками,
+// поэтому повторы переживают перезапуск; исчерпав попытки, отправка остаётся со статусом Failed
+type WebhookDelivery struct {
+	ID        uuid.UUID
+	WebhookID uuid.UUID            `gorm:"not null;index"`
+	CreatedAt time.Time            `gorm:"not null"`
+	EventType enums.TaskChangeType `gorm:"not null"`
+	// Тело запроса в JSON
+	Payload string                      `gorm:"type:text;not null"`
+	Status  enums.WebhookDeliveryStatus `gorm:"not null;index:idx_webhook_deliveries_status_next_attempt_at,priority:1"`
+	// Сколько попыток уже сделано
+	Attempts      int       `gorm:"not null"`
+	NextAttemptAt time.Time `gorm:"not null;index:idx_webhook_deliveries_status_next_attempt_at,priority:2"`
+	LastError     *string
+	DeliveredAt   *time.Time
+}
+
+func NewWebhookDelivery(webhookID uuid.UUID, eventType enums.TaskChangeType, payload string) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:            uuid.New(),
+		WebhookID:     webhookID,
+		CreatedAt:     now,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        enums.DeliveryPending,
+		NextAttemptAt: now,
+	}
+}
@@ -1,13 +1,126 @@
 package db
 
 import (
-	"fmt"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"github.com/glebarez/go-sqlite"
+	sqliteDialector "github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"net"
+	"net/url"
+	"strings"
+	"time"
 )
 
+const sqliteUTCDriverName = "sqlite_utc"
+
+func init() {
+	// Встроенная в SQLite LOWER приводит к нижнему регистру только ASCII, из-за чего поиск по
+	// кириллице вёл бы себя иначе, чем в Postgres. Подменяем её Unicode-версией для всех соединений.
+	sqlite.MustRegisterDeterministicScalarFunction("lower", 1,
+		func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			switch value := args[0].(type) {
+			case string:
+				return strings.ToLower(value), nil
+			case []byte:
+				return strings.ToLower(string(value)), nil
+			default:
+				return value, nil
+			}
+		})
+
+	base, err := sql.Open(sqliteDialector.DriverName, "")
+	if err != nil {
+		panic(err)
+	}
+	sql.Register(sqliteUTCDriverName, utcDriver{base.Driver()})
+}
+
 func NewPostgresConnection(host string, user string, password string, dbName string, port string) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		host, user, password, dbName, port)
-	return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	return gorm.Open(postgres.Open(postgresDSN(host, user, password, dbName, port)), &gorm.Config{})
+}
+
+// Строка подключения собирается URL-адресом: в формате key=value пробел, кавычка или обратная косая черта
+// в пароле ломали бы разбор или подменяли другие параметры
+func postgresDSN(host string, user string, password string, dbName string, port string) string {
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(user, password),
+		Host:     net.JoinHostPort(host, port),
+		Path:     "/" + dbName,
+		RawQuery: "sslmode=disable",
+	}
+	return dsn.String()
+}
+
+func NewSQLiteConnection(path string) (*gorm.DB, error) {
+	db, err := gorm.Open(&sqliteDialector.Dialector{
+		DriverName: sqliteUTCDriverName,
+		DSN:        path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)",
+	}, &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Каждое соединение с ":memory:" открывает отдельную пустую базу, поэтому пул ограничивается одним
+	if path == ":memory:" {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	return db, nil
+}
+
+// SQLite хранит время строкой вместе со смещением и сравнивает такие строки лексикографически, поэтому
+// deadline < ? работает только для значений в одной зоне. Драйвер-обёртка приводит все параметры
+// time.Time к UTC, как это фактически делает timestamptz в Postgres.
+type utcDriver struct {
+	driver.Driver
+}
+
+func (d utcDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &utcConn{conn}, nil
+}
+
+type utcConn struct {
+	driver.Conn
+}
+
+func (c *utcConn) CheckNamedValue(value *driver.NamedValue) error {
+	switch t := value.Value.(type) {
+	case time.Time:
+		value.Value = t.UTC()
+		return nil
+	case *time.Time:
+		if t != nil {
+			value.Value = t.UTC()
+			return nil
+		}
+	}
+	return driver.ErrSkip
+}
+
+func (c *utcConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c *utcConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+}
+
+func (c *utcConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+func (c *utcConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
 }
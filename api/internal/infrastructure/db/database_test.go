@@ -0,0 +1,26 @@
+package db
+
+import (
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// Тест строки подключения к Postgres: спецсимволы в значениях не меняют параметры
+func TestPostgresDSN(t *testing.T) {
+	passwords := []string{"secret", "with space", `it's`, `back\slash`, "p@ss:w/rd?x=1 sslmode=require"}
+
+	for _, password := range passwords {
+		t.Run(password, func(t *testing.T) {
+			config, err := pgconn.ParseConfig(postgresDSN("db.local", "todo user", password, "todo db", "6543"))
+
+			assert.NoError(t, err)
+			assert.Equal(t, "db.local", config.Host)
+			assert.Equal(t, uint16(6543), config.Port)
+			assert.Equal(t, "todo user", config.User)
+			assert.Equal(t, password, config.Password)
+			assert.Equal(t, "todo db", config.Database)
+			assert.Nil(t, config.TLSConfig)
+		})
+	}
+}
@@ -1,10 +1,177 @@
 package db
 
 import (
-	"HITS_ToDoList_Tests/internal/domain/models"
+	"errors"
+	"fmt"
 	"gorm.io/gorm"
+	"log"
+	"time"
 )
 
+// Migration — версионированное изменение схемы. Up и Down выполняются в транзакции вместе с записью
+// в schema_migrations, поэтому миграция либо применяется целиком, либо не применяется вовсе.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// MigrationState — миграция и время её применения (nil, если миграция ещё не применена)
+type MigrationState struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+type schemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrate применяет все ещё не применённые миграции по возрастанию версии
 func Migrate(db *gorm.DB) error {
-	return db.AutoMigrate(&models.Task{})
+	return migrateUp(db, migrations)
+}
+
+// Rollback откатывает последние steps применённых миграций
+func Rollback(db *gorm.DB, steps int) error {
+	return migrateDown(db, migrations, steps)
+}
+
+// MigrationsStatus возвращает состояние всех известных миграций
+func MigrationsStatus(db *gorm.DB) ([]MigrationState, error) {
+	return migrationsStatus(db, migrations)
+}
+
+func migrateUp(db *gorm.DB, list []Migration) error {
+	applied, err := loadAppliedMigrations(db, list)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range list {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		log.Printf("Applied migration %d_%s", migration.Version, migration.Name)
+	}
+
+	return nil
+}
+
+func migrateDown(db *gorm.DB, list []Migration, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("rollback steps must be positive, got %d", steps)
+	}
+
+	applied, err := loadAppliedMigrations(db, list)
+	if err != nil {
+		return err
+	}
+
+	for i := len(list) - 1; i >= 0 && steps > 0; i-- {
+		migration := list[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return fmt.Errorf("roll back migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		log.Printf("Rolled back migration %d_%s", migration.Version, migration.Name)
+		steps--
+	}
+
+	return nil
+}
+
+func migrationsStatus(db *gorm.DB, list []Migration) ([]MigrationState, error) {
+	applied, err := loadAppliedMigrations(db, list)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]MigrationState, 0, len(list))
+	for _, migration := range list {
+		state := MigrationState{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			state.AppliedAt = &record.AppliedAt
+		}
+		states = append(states, state)
+	}
+
+	return states, nil
+}
+
+// loadAppliedMigrations создаёт schema_migrations при необходимости и возвращает применённые миграции.
+// Версия, неизвестная текущей сборке, означает, что схему обновил более новый бинарник.
+func loadAppliedMigrations(db *gorm.DB, list []Migration) (map[int64]schemaMigration, error) {
+	if err := validateMigrations(list); err != nil {
+		return nil, err
+	}
+
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	var records []schemaMigration
+	if err := db.Order("version").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+
+	known := make(map[int64]bool, len(list))
+	for _, migration := range list {
+		known[migration.Version] = true
+	}
+
+	applied := make(map[int64]schemaMigration, len(records))
+	for _, record := range records {
+		if !known[record.Version] {
+			return nil, fmt.Errorf("database has unknown migration %d_%s, the binary is older than the schema",
+				record.Version, record.Name)
+		}
+		applied[record.Version] = record
+	}
+
+	return applied, nil
+}
+
+func validateMigrations(list []Migration) error {
+	for i, migration := range list {
+		if migration.Up == nil || migration.Down == nil {
+			return fmt.Errorf("migration %d_%s must define both Up and Down", migration.Version, migration.Name)
+		}
+		if i > 0 && migration.Version <= list[i-1].Version {
+			return errors.New("migration versions must be unique and strictly increasing")
+		}
+	}
+	return nil
 }
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := NewSQLiteConnection(filepath.Join(t.TempDir(), "todo.db"))
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	return db
+}
+
+func allVersions() []int64 {
+	versions := make([]int64, len(migrations))
+	for i, migration := range migrations {
+		versions[i] = migration.Version
+	}
+	return versions
+}
+
+func appliedVersions(t *testing.T, db *gorm.DB) []int64 {
+	var versions []int64
+	assert.NoError(t, db.Model(&schemaMigration{}).Order("version").Pluck("version", &versions).Error)
+	return versions
+}
+
+// Тест применения всех миграций на пустой базе
+func TestMigrate(t *testing.T) {
+	db := newTestDB(t)
+
+	assert.NoError(t, Migrate(db))
+	assert.Equal(t, allVersions(), appliedVersions(t, db))
+	assert.True(t, db.Migrator().HasTable(&models.Task{}))
+	assert.True(t, db.Migrator().HasTable(&models.User{}))
+	assert.True(t, db.Migrator().HasTable(&models.ChecklistItem{}))
+	assert.True(t, db.Migrator().HasTable(&models.Tag{}))
+	assert.True(t, db.Migrator().HasTable(&models.TaskTag{}))
+	assert.True(t, db.Migrator().HasTable(&models.Project{}))
+	assert.True(t, db.Migrator().HasTable(&models.TaskEvent{}))
+	assert.True(t, db.Migrator().HasTable(&models.Webhook{}))
+	assert.True(t, db.Migrator().HasTable(&models.WebhookDelivery{}))
+	assert.True(t, db.Migrator().HasTable(&models.TaskReminder{}))
+	assert.True(t, db.Migrator().HasColumn(&models.Task{}, "ProjectID"))
+	assert.True(t, db.Migrator().HasColumn(&models.Task{}, "OwnerID"))
+	assert.True(t, db.Migrator().HasColumn(&models.Task{}, "TimeZone"))
+	assert.True(t, db.Migrator().HasColumn(&models.Task{}, "DeletedAt"))
+	assert.True(t, db.Migrator().HasColumn(&models.Task{}, "Version"))
+	assert.True(t, db.Migrator().HasColumn(&models.User{}, "TimeZone"))
+	assert.True(t, db.Migrator().HasIndex(&models.User{}, "idx_users_calendar_token_hash"))
+	assert.True(t, db.Migrator().HasIndex(&models.Task{}, "idx_tasks_status_deadline"))
+
+	// Повторный запуск ничего не меняет
+	assert.NoError(t, Migrate(db))
+	assert.Equal(t, allVersions(), appliedVersions(t, db))
+
+	// Схема совпадает с моделями: запись и чтение работают
+	user := models.NewUser("user@example.com", "hash")
+	assert.NoError(t, db.Create(user).Error)
+	task := models.NewTask("Задача", nil, nil, nil, nil)
+	task.OwnerID = user.ID
+	assert.NoError(t, db.Create(task).Error)
+
+	var stored models.Task
+	assert.NoError(t, db.First(&stored, "owner_id = ?", user.ID).Error)
+	assert.Equal(t, task.ID, stored.ID)
+}
+
+// Тест перехода базы, созданной прежним AutoMigrate, на версионированную схему
+func TestMigrate_AdoptsLegacySchema(t *testing.T) {
+	db := newTestDB(t)
+	assert.NoError(t, db.Migrator().CreateTable(&taskV1{}))
+	task := taskV1{Name: "Старая задача", Status: "Active", Priority: "Medium"}
+	assert.NoError(t, db.Create(&task).Error)
+
+	assert.NoError(t, Migrate(db))
+
+	assert.Equal(t, allVersions(), appliedVersions(t, db))
+	assert.True(t, db.Migrator().HasColumn(&models.Task{}, "OwnerID"))
+
+	var count int64
+	assert.NoError(t, db.Model(&models.Task{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+// Тест отката миграций
+func TestRollback(t *testing.T) {
+	db := newTestDB(t)
+	assert.NoError(t, Migrate(db))
+
+	assert.NoError(t, Rollback(db, len(migrations)-2))
+	assert.Equal(t, []int64{1, 2}, appliedVersions(t, db))
+	assert.False(t, db.Migrator().HasColumn(&models.Task{}, "OwnerID"))
+	assert.False(t, db.Migrator().HasIndex(&models.Task{}, "idx_tasks_status_deadline"))
+
+	assert.NoError(t, Rollback(db, 10))
+	assert.Empty(t, appliedVersions(t, db))
+	assert.False(t, db.Migrator().HasTable(&models.Task{}))
+
+	assert.Error(t, Rollback(db, 0))
+
+	// После полного отката схема восстанавливается заново
+	assert.NoError(t, Migrate(db))
+	assert.Equal(t, allVersions(), appliedVersions(t, db))
+}
+
+// Тест состояния миграций
+func TestMigrationsStatus(t *testing.T) {
+	db := newTestDB(t)
+	assert.NoError(t, Migrate(db))
+	assert.NoError(t, Rollback(db, 1))
+
+	states, err := MigrationsStatus(db)
+
+	assert.NoError(t, err)
+	assert.Len(t, states, len(migrations))
+	assert.NotNil(t, states[0].AppliedAt)
+	last := states[len(states)-1]
+	assert.Equal(t, migrations[len(migrations)-1].Name, last.Name)
+	assert.Nil(t, last.AppliedAt)
+}
+
+// Тест неудачной миграции и некорректного списка миграций
+func TestMigrate_Errors(t *testing.T) {
+	db := newTestDB(t)
+	failing := []Migration{
+		{
+			Version: 1,
+			Name:    "create_table",
+			Up:      func(tx *gorm.DB) error { return tx.Exec("CREATE TABLE things (id INTEGER)").Error },
+			Down:    func(tx *gorm.DB) error { return tx.Exec("DROP TABLE things").Error },
+		},
+		{
+			Version: 2,
+			Name:    "broken",
+			Up:      func(tx *gorm.DB) error { return tx.Exec("ALTER TABLE missing ADD COLUMN x INTEGER").Error },
+			Down:    func(tx *gorm.DB) error { return nil },
+		},
+	}
+
+	assert.Error(t, migrateUp(db, failing))
+	assert.Equal(t, []int64{1}, appliedVersions(t, db))
+
+	// Бинарник не знает о применённой миграции
+	assert.ErrorContains(t, migrateUp(db, failing[1:]), "unknown migration 1_create_table")
+
+	// Версии не по возрастанию
+	assert.Error(t, migrateUp(db, []Migration{failing[1], failing[0]}))
+}
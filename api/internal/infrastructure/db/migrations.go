@@ -0,0 +1,448 @@
+package db
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Список миграций схемы. Уже выпущенные миграции не меняются: новое изменение схемы добавляется
+// в конец списка со следующей версией. Структуры ниже — снимки моделей на момент миграции,
+// чтобы последующие изменения models не влияли на уже применённые шаги.
+//
+// Первые миграции проверяют наличие таблиц и колонок, поэтому базы, созданные прежним AutoMigrate,
+// переходят на версионированную схему без потери данных.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "create_tasks",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&taskV1{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&taskV1{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&taskV1{})
+		},
+	},
+	{
+		Version: 2,
+		Name:    "create_users",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&userV2{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&userV2{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&userV2{})
+		},
+	},
+	{
+		Version: 3,
+		Name:    "add_tasks_owner_id",
+		Up: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&taskV3{}, "OwnerID") {
+				if err := tx.Migrator().AddColumn(&taskV3{}, "OwnerID"); err != nil {
+					return err
+				}
+			}
+			if tx.Migrator().HasIndex(&taskV3{}, "OwnerID") {
+				return nil
+			}
+			return tx.Migrator().CreateIndex(&taskV3{}, "OwnerID")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropIndex(&taskV3{}, "OwnerID"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&taskV3{}, "OwnerID")
+		},
+	},
+	{
+		Version: 4,
+		Name:    "add_tasks_list_indexes",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_tasks_owner_id_created_at " +
+				"ON tasks (owner_id, created_at, id)").Error; err != nil {
+				return err
+			}
+			return tx.Exec("CREATE INDEX IF NOT EXISTS idx_tasks_status_deadline ON tasks (status, deadline)").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_tasks_status_deadline").Error; err != nil {
+				return err
+			}
+			return tx.Exec("DROP INDEX IF EXISTS idx_tasks_owner_id_created_at").Error
+		},
+	},
+	{
+		Version: 5,
+		Name:    "create_checklist_items",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&checklistItemV5{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&checklistItemV5{})
+		},
+	},
+	{
+		Version: 6,
+		Name:    "add_tasks_recurrence",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().AddColumn(&taskV6{}, "Recurrence"); err != nil {
+				return err
+			}
+			return tx.Migrator().AddColumn(&taskV6{}, "NextOccurrenceID")
+		},
+		// Migrator().DropColumn в SQLite пересоздаёт таблицу и теряет индексы, поэтому колонки удаляются
+		// через ALTER TABLE, который поддерживают обе СУБД
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Exec("ALTER TABLE tasks DROP COLUMN next_occurrence_id").Error; err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE tasks DROP COLUMN recurrence").Error
+		},
+	},
+	{
+		Version: 7,
+		Name:    "create_tags",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().CreateTable(&tagV7{}); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateTable(&taskTagV7{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&taskTagV7{}); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&tagV7{})
+		},
+	},
+	{
+		Version: 8,
+		Name:    "create_projects",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().CreateTable(&projectV8{}); err != nil {
+				return err
+			}
+			// Inbox у пользователя один; частичные индексы поддерживают и PostgreSQL, и SQLite
+			if err := tx.Exec("CREATE UNIQUE INDEX idx_projects_owner_id_inbox " +
+				"ON projects (owner_id) WHERE is_inbox = true").Error; err != nil {
+				return err
+			}
+			if err := tx.Migrator().AddColumn(&taskV8{}, "ProjectID"); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&taskV8{}, "ProjectID")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_tasks_project_id").Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("ALTER TABLE tasks DROP COLUMN project_id").Error; err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&projectV8{})
+		},
+	},
+	{
+		Version: 9,
+		Name:    "add_time_zones",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().AddColumn(&userV9{}, "TimeZone"); err != nil {
+				return err
+			}
+			return tx.Migrator().AddColumn(&taskV9{}, "TimeZone")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Exec("ALTER TABLE tasks DROP COLUMN time_zone").Error; err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE users DROP COLUMN time_zone").Error
+		},
+	},
+	{
+		Version: 10,
+		Name:    "create_task_events",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&taskEventV10{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&taskEventV10{})
+		},
+	},
+	{
+		Version: 11,
+		Name:    "add_task_deleted_at",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().AddColumn(&taskV11{}, "DeletedAt"); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&taskV11{}, "DeletedAt")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_tasks_deleted_at").Error; err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE tasks DROP COLUMN deleted_at").Error
+		},
+	},
+	{
+		Version: 12,
+		Name:    "add_task_version",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().AddColumn(&taskV12{}, "Version")
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE tasks DROP COLUMN version").Error
+		},
+	},
+	{
+		Version: 13,
+		Name:    "create_webhooks",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&webhookV13{}, &webhookDeliveryV13{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&webhookDeliveryV13{}, &webhookV13{})
+		},
+	},
+	{
+		Version: 14,
+		Name:    "create_task_reminders",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&taskReminderV14{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&taskReminderV14{})
+		},
+	},
+	{
+		Version: 15,
+		Name:    "add_users_calendar_token",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().AddColumn(&userV15{}, "CalendarTokenHash"); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&userV15{}, "CalendarTokenHash")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_users_calendar_token_hash").Error; err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE users DROP COLUMN calendar_token_hash").Error
+		},
+	},
+}
+
+type taskV1 struct {
+	ID          uuid.UUID
+	CreatedAt   time.Time `gorm:"not null"`
+	ChangedAt   *time.Time
+	Name        string `gorm:"not null"`
+	Description *string
+	Deadline    *time.Time
+	Status      string `gorm:"not null"`
+	Priority    string `gorm:"not null"`
+}
+
+func (taskV1) TableName() string {
+	return "tasks"
+}
+
+type userV2 struct {
+	ID           uuid.UUID
+	CreatedAt    time.Time `gorm:"not null"`
+	Email        string    `gorm:"not null;uniqueIndex"`
+	PasswordHash string    `gorm:"not null"`
+}
+
+func (userV2) TableName() string {
+	return "users"
+}
+
+type taskV3 struct {
+	ID      uuid.UUID
+	OwnerID uuid.UUID `gorm:"index"`
+}
+
+func (taskV3) TableName() string {
+	return "tasks"
+}
+
+type checklistItemV5 struct {
+	ID        uuid.UUID
+	TaskID    uuid.UUID `gorm:"not null;index"`
+	Task      taskV1    `gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time `gorm:"not null"`
+	ChangedAt *time.Time
+	Name      string `gorm:"not null"`
+	IsDone    bool   `gorm:"not null"`
+	Position  int    `gorm:"not null"`
+}
+
+func (checklistItemV5) TableName() string {
+	return "checklist_items"
+}
+
+type taskV6 struct {
+	ID               uuid.UUID
+	Recurrence       *string
+	NextOccurrenceID *uuid.UUID
+}
+
+func (taskV6) TableName() string {
+	return "tasks"
+}
+
+type tagV7 struct {
+	ID        uuid.UUID
+	OwnerID   uuid.UUID `gorm:"not null;uniqueIndex:idx_tags_owner_id_name"`
+	CreatedAt time.Time `gorm:"not null"`
+	Name      string    `gorm:"not null;uniqueIndex:idx_tags_owner_id_name"`
+}
+
+func (tagV7) TableName() string {
+	return "tags"
+}
+
+type taskTagV7 struct {
+	TaskID uuid.UUID `gorm:"primaryKey"`
+	Task   taskV1    `gorm:"constraint:OnDelete:CASCADE"`
+	TagID  uuid.UUID `gorm:"primaryKey;index"`
+	Tag    tagV7     `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+func (taskTagV7) TableName() string {
+	return "task_tags"
+}
+
+type projectV8 struct {
+	ID         uuid.UUID
+	OwnerID    uuid.UUID `gorm:"not null;index"`
+	CreatedAt  time.Time `gorm:"not null"`
+	ChangedAt  *time.Time
+	Name       string `gorm:"not null"`
+	Color      *string
+	IsArchived bool `gorm:"not null"`
+	IsInbox    bool `gorm:"not null"`
+}
+
+func (projectV8) TableName() string {
+	return "projects"
+}
+
+type taskV8 struct {
+	ID        uuid.UUID
+	ProjectID *uuid.UUID `gorm:"index"`
+}
+
+func (taskV8) TableName() string {
+	return "tasks"
+}
+
+type userV9 struct {
+	ID       uuid.UUID
+	TimeZone *string
+}
+
+func (userV9) TableName() string {
+	return "users"
+}
+
+type taskV9 struct {
+	ID       uuid.UUID
+	TimeZone *string
+}
+
+func (taskV9) TableName() string {
+	return "tasks"
+}
+
+type taskEventV10 struct {
+	ID        uuid.UUID
+	TaskID    uuid.UUID `gorm:"not null;index:idx_task_events_task_id_created_at,priority:1"`
+	CreatedAt time.Time `gorm:"not null;index:idx_task_events_task_id_created_at,priority:2"`
+	ActorID   *uuid.UUID
+	Type      string `gorm:"not null"`
+	Changes   string `gorm:"type:text;not null"`
+}
+
+func (taskEventV10) TableName() string {
+	return "task_events"
+}
+
+type taskV11 struct {
+	ID        uuid.UUID
+	DeletedAt *time.Time `gorm:"index"`
+}
+
+func (taskV11) TableName() string {
+	return "tasks"
+}
+
+type taskV12 struct {
+	ID      uuid.UUID
+	Version int `gorm:"not null;default:1"`
+}
+
+func (taskV12) TableName() string {
+	return "tasks"
+}
+
+type webhookV13 struct {
+	ID         uuid.UUID
+	OwnerID    uuid.UUID `gorm:"not null;index"`
+	CreatedAt  time.Time `gorm:"not null"`
+	URL        string    `gorm:"not null"`
+	Secret     string    `gorm:"not null"`
+	EventTypes string    `gorm:"type:text;not null"`
+	Priorities string    `gorm:"type:text;not null"`
+}
+
+func (webhookV13) TableName() string {
+	return "webhooks"
+}
+
+type webhookDeliveryV13 struct {
+	ID            uuid.UUID
+	WebhookID     uuid.UUID  `gorm:"not null;index"`
+	Webhook       webhookV13 `gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt     time.Time  `gorm:"not null"`
+	EventType     string     `gorm:"not null"`
+	Payload       string     `gorm:"type:text;not null"`
+	Status        string     `gorm:"not null;index:idx_webhook_deliveries_status_next_attempt_at,priority:1"`
+	Attempts      int        `gorm:"not null"`
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_webhook_deliveries_status_next_attempt_at,priority:2"`
+	LastError     *string
+	DeliveredAt   *time.Time
+}
+
+func (webhookDeliveryV13) TableName() string {
+	return "webhook_deliveries"
+}
+
+type taskReminderV14 struct {
+	ID            uuid.UUID
+	TaskID        uuid.UUID  `gorm:"not null;index"`
+	Task          taskV1     `gorm:"constraint:OnDelete:CASCADE"`
+	MinutesBefore int        `gorm:"not null"`
+	RemindAt      *time.Time `gorm:"index"`
+	SentAt        *time.Time
+}
+
+func (taskReminderV14) TableName() string {
+	return "task_reminders"
+}
+
+type userV15 struct {
+	ID                uuid.UUID
+	CalendarTokenHash *string `gorm:"uniqueIndex"`
+}
+
+func (userV15) TableName() string {
+	return "users"
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Сколько событий подписчик может не прочитать, прежде чем шина его отключит
+const subscriberBuffer = 64
+
+// TaskChangeBus рассылает изменения задач подписчикам их владельца и хранит последние события для
+// переподключившихся клиентов. Подписчик, не успевающий читать, отключается: переподключившись
+// с Last-Event-ID, он получит пропущенное из истории
+type TaskChangeBus struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []models.TaskChange
+	historySize int
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+type subscriber struct {
+	userID  uuid.UUID
+	changes chan models.TaskChange
+}
+
+// historySize — сколько последних событий хранится для переподключения
+func NewTaskChangeBus(historySize int) *TaskChangeBus {
+	return &TaskChangeBus{
+		// ID продолжают расти и после перезапуска: ID событий прошлого запуска меньше первого ID
+		// нового, поэтому клиенту с таким Last-Event-ID шина ответит Reset
+		lastID:      uint64(time.Now().UnixMicro()),
+		history:     make([]models.TaskChange, 0, historySize),
+		historySize: historySize,
+		subscribers: map[*subscriber]struct{}{},
+	}
+}
+
+var _ interfaces.TaskChangeStream = (*TaskChangeBus)(nil)
+
+func (b *TaskChangeBus) Publish(change models.TaskChange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.lastID++
+	change.ID = b.lastID
+
+	if b.historySize > 0 {
+		if len(b.history) == b.historySize {
+			copy(b.history, b.history[1:])
+			b.history = b.history[:len(b.history)-1]
+		}
+		b.history = append(b.history, change)
+	}
+
+	for sub := range b.subscribers {
+		if sub.userID != change.Task.OwnerID {
+			continue
+		}
+
+		select {
+		case sub.changes <- change:
+		default:
+			b.remove(sub)
+		}
+	}
+}
+
+func (b *TaskChangeBus) Subscribe(userID uuid.UUID, lastEventID *uint64) *models.TaskChangeSubscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &subscriber{userID: userID, changes: make(chan models.TaskChange, subscriberBuffer)}
+	subscription := &models.TaskChangeSubscription{
+		Changes: sub.changes,
+		Close: func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			b.remove(sub)
+		},
+	}
+
+	if lastEventID != nil {
+		subscription.Missed, subscription.Reset = b.missedSince(userID, *lastEventID)
+	}
+
+	if b.closed {
+		close(sub.changes)
+	} else {
+		b.subscribers[sub] = struct{}{}
+	}
+
+	return subscription
+}
+
+// Close завершает все подписки; новые подписки сразу получают закрытый канал
+func (b *TaskChangeBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subscribers {
+		b.remove(sub)
+	}
+}
+
+// События получают ID подряд, поэтому история покрывает lastEventID, только если следующее
+// за ним событие ещё хранится. Незнакомый ID (больше последнего выданного) тоже требует Reset
+func (b *TaskChangeBus) missedSince(userID uuid.UUID, lastEventID uint64) ([]models.TaskChange, bool) {
+	firstKept := b.lastID - uint64(len(b.history)) + 1
+	if lastEventID > b.lastID || lastEventID+1 < firstKept {
+		return nil, true
+	}
+
+	var missed []models.TaskChange
+	for _, change := range b.history[lastEventID+1-firstKept:] {
+		if change.Task.OwnerID == userID {
+			missed = append(missed, change)
+		}
+	}
+
+	return missed, false
+}
+
+func (b *TaskChangeBus) remove(sub *subscriber) {
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(b.subscribers, sub)
+	close(sub.changes)
+}
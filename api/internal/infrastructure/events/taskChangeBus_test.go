@@ -0,0 +1,129 @@
+package events
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func taskChange(ownerID uuid.UUID, changeType enums.TaskChangeType) models.TaskChange {
+	return models.TaskChange{Type: changeType, Task: models.Task{ID: uuid.New(), OwnerID: ownerID}}
+}
+
+// Тест рассылки изменений подписчикам владельца задачи
+func TestTaskChangeBus_Publish(t *testing.T) {
+	userID, otherUserID := uuid.New(), uuid.New()
+	bus := NewTaskChangeBus(10)
+	subscription := bus.Subscribe(userID, nil)
+	defer subscription.Close()
+
+	bus.Publish(taskChange(otherUserID, enums.TaskChangeCreated))
+	bus.Publish(taskChange(userID, enums.TaskChangeCreated))
+	bus.Publish(taskChange(userID, enums.TaskChangeOverdue))
+
+	assert.Empty(t, subscription.Missed)
+	assert.False(t, subscription.Reset)
+	first, second := <-subscription.Changes, <-subscription.Changes
+	assert.Equal(t, enums.TaskChangeCreated, first.Type)
+	assert.Equal(t, enums.TaskChangeOverdue, second.Type)
+	assert.Equal(t, first.ID+1, second.ID)
+	assert.Empty(t, subscription.Changes)
+}
+
+// Тест восстановления пропущенных изменений по Last-Event-ID
+func TestTaskChangeBus_Resume(t *testing.T) {
+	userID, otherUserID := uuid.New(), uuid.New()
+	bus := NewTaskChangeBus(3)
+
+	first := bus.Subscribe(userID, nil)
+	bus.Publish(taskChange(userID, enums.TaskChangeCreated))
+	received := <-first.Changes
+	first.Close()
+
+	bus.Publish(taskChange(userID, enums.TaskChangeUpdated))
+	bus.Publish(taskChange(otherUserID, enums.TaskChangeCreated))
+	bus.Publish(taskChange(userID, enums.TaskChangeDeleted))
+
+	tests := []struct {
+		name        string
+		lastEventID uint64
+		wantMissed  []enums.TaskChangeType
+		wantReset   bool
+	}{
+		{
+			name:        "Пропущенные изменения хранятся",
+			lastEventID: received.ID + 1,
+			wantMissed:  []enums.TaskChangeType{enums.TaskChangeDeleted},
+		},
+		{
+			name:        "Все изменения получены",
+			lastEventID: received.ID + 3,
+		},
+		{
+			name:        "Часть пропущенного уже не хранится",
+			lastEventID: received.ID - 1,
+			wantReset:   true,
+		},
+		{
+			name:        "ID события из прошлого запуска",
+			lastEventID: 42,
+			wantReset:   true,
+		},
+		{
+			name:        "Незнакомый ID",
+			lastEventID: received.ID + 100,
+			wantReset:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := bus.Subscribe(userID, &tt.lastEventID)
+			defer subscription.Close()
+
+			var missed []enums.TaskChangeType
+			for _, change := range subscription.Missed {
+				assert.Greater(t, change.ID, tt.lastEventID)
+				missed = append(missed, change.Type)
+			}
+			assert.Equal(t, tt.wantMissed, missed)
+			assert.Equal(t, tt.wantReset, subscription.Reset)
+		})
+	}
+}
+
+// Тест отключения подписчика, который не успевает читать
+func TestTaskChangeBus_SlowSubscriber(t *testing.T) {
+	userID := uuid.New()
+	bus := NewTaskChangeBus(10)
+	subscription := bus.Subscribe(userID, nil)
+
+	for range subscriberBuffer + 1 {
+		bus.Publish(taskChange(userID, enums.TaskChangeUpdated))
+	}
+
+	received := 0
+	for range subscription.Changes {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+	subscription.Close()
+}
+
+// Тест завершения подписок при остановке
+func TestTaskChangeBus_Close(t *testing.T) {
+	userID := uuid.New()
+	bus := NewTaskChangeBus(10)
+	subscription := bus.Subscribe(userID, nil)
+
+	bus.Close()
+	bus.Publish(taskChange(userID, enums.TaskChangeCreated))
+
+	_, ok := <-subscription.Changes
+	assert.False(t, ok)
+	_, ok = <-bus.Subscribe(userID, nil).Changes
+	assert.False(t, ok)
+	subscription.Close()
+}
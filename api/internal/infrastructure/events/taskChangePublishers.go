@@ -0,0 +1,15 @@
+package events
+
+import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+)
+
+// TaskChangePublishers передаёт каждое изменение всем публикаторам по порядку
+type TaskChangePublishers []interfaces.TaskChangePublisher
+
+func (publishers TaskChangePublishers) Publish(change models.TaskChange) {
+	for _, publisher := range publishers {
+		publisher.Publish(change)
+	}
+}
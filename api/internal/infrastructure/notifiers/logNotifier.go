@@ -0,0 +1,22 @@
+package notifiers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"log"
+)
+
+// LogNotifier пишет напоминания в журнал сервера
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+var _ interfaces.Notifier = (*LogNotifier)(nil)
+
+func (notifier *LogNotifier) Notify(notification models.Notification) error {
+	_, body := reminderText(notification)
+	log.Printf("Reminder for %s about task %s: %s", notification.Email, notification.Task.ID, body)
+	return nil
+}
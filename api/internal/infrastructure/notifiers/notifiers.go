@@ -0,0 +1,62 @@
+package notifiers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Notifiers отправляет напоминание по всем каналам; неудача одного канала не мешает остальным
+type Notifiers []interfaces.Notifier
+
+func (notifiers Notifiers) Notify(notification models.Notification) error {
+	var errs []error
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Тема и текст напоминания; дедлайн показывается в часовом поясе, в котором он задан
+func reminderText(notification models.Notification) (string, string) {
+	task := notification.Task
+	location, err := models.LoadTimeZone(task.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	subject := fmt.Sprintf("Напоминание: «%s»", task.Name)
+	body := fmt.Sprintf("До дедлайна задачи «%s» осталось %s: %s.", task.Name, formatLeft(notification.Left),
+		task.Deadline.In(location).Format("02.01.2006 15:04 MST"))
+	if task.Description != nil && *task.Description != "" {
+		body += "\n\n" + *task.Description
+	}
+
+	return subject, body
+}
+
+func formatLeft(left time.Duration) string {
+	minutes := int(left.Round(time.Minute) / time.Minute)
+	if minutes < 1 {
+		return "меньше минуты"
+	}
+
+	var parts []string
+	if days := minutes / (24 * 60); days > 0 {
+		parts = append(parts, fmt.Sprintf("%d д", days))
+	}
+	if hours := minutes % (24 * 60) / 60; hours > 0 {
+		parts = append(parts, fmt.Sprintf("%d ч", hours))
+	}
+	if rest := minutes % 60; rest > 0 {
+		parts = append(parts, fmt.Sprintf("%d мин", rest))
+	}
+
+	return strings.Join(parts, " ")
+}
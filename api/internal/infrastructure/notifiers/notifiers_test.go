@@ -0,0 +1,53 @@
+package notifiers
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Канал уведомлений, который запоминает напоминания и возвращает err
+type recordingNotifier struct {
+	err           error
+	notifications []models.Notification
+}
+
+func (notifier *recordingNotifier) Notify(notification models.Notification) error {
+	notifier.notifications = append(notifier.notifications, notification)
+	return notifier.err
+}
+
+// Тест отправки напоминания по всем каналам
+func TestNotifiers_Notify(t *testing.T) {
+	failing := &recordingNotifier{err: errors.New("connection refused")}
+	working := &recordingNotifier{}
+	notification := models.Notification{Email: "user@example.com", Left: time.Hour}
+
+	err := Notifiers{failing, working}.Notify(notification)
+
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, []models.Notification{notification}, failing.notifications)
+	assert.Equal(t, []models.Notification{notification}, working.notifications)
+	assert.NoError(t, Notifiers{}.Notify(notification))
+}
+
+// Тест записи оставшегося до дедлайна времени
+func TestFormatLeft(t *testing.T) {
+	tests := []struct {
+		left time.Duration
+		want string
+	}{
+		{left: 20 * time.Second, want: "меньше минуты"},
+		{left: 59*time.Minute + 40*time.Second, want: "1 ч"},
+		{left: 90 * time.Minute, want: "1 ч 30 мин"},
+		{left: 2*24*time.Hour + 5*time.Minute, want: "2 д 5 мин"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatLeft(tt.left))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package notifiers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/config"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPNotifier отправляет напоминание письмом на адрес владельца задачи
+type SMTPNotifier struct {
+	cfg config.SMTPConfig
+}
+
+func NewSMTPNotifier(cfg config.SMTPConfig) *SMTPNotifier {
+	return &SMTPNotifier{cfg: cfg}
+}
+
+var _ interfaces.Notifier = (*SMTPNotifier)(nil)
+
+// То же, что smtp.SendMail, но с таймаутом на весь разговор с сервером
+func (notifier *SMTPNotifier) Notify(notification models.Notification) error {
+	address := net.JoinHostPort(notifier.cfg.Host, strconv.Itoa(notifier.cfg.Port))
+	conn, err := net.DialTimeout("tcp", address, notifier.cfg.Timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(notifier.cfg.Timeout)); err != nil {
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, notifier.cfg.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: notifier.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if notifier.cfg.Username != "" {
+		auth := smtp.PlainAuth("", notifier.cfg.Username, notifier.cfg.Password, notifier.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(notifier.cfg.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(notification.Email); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(notifier.message(notification)); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (notifier *SMTPNotifier) message(notification models.Notification) []byte {
+	subject, body := reminderText(notification)
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", notifier.cfg.From)
+	fmt.Fprintf(&message, "To: %s\r\n", notification.Email)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	message.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	message.WriteString(body)
+	message.WriteString("\r\n")
+
+	return message.Bytes()
+}
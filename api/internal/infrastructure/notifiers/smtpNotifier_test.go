@@ -0,0 +1,186 @@
+package notifiers
+
+import (
+	"HITS_ToDoList_Tests/internal/config"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Письмо, принятое тестовым SMTP-сервером
+type receivedMail struct {
+	Auth string
+	From string
+	To   []string
+	Data string
+}
+
+// Тестовый SMTP-сервер: принимает письма без шифрования и отклоняет получателя rejectRcpt
+type fakeSMTPServer struct {
+	listener   net.Listener
+	rejectRcpt string
+	mu         sync.Mutex
+	mails      []receivedMail
+}
+
+func newFakeSMTPServer(t *testing.T, rejectRcpt string) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	server := &fakeSMTPServer{listener: listener, rejectRcpt: rejectRcpt}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(textproto.NewConn(conn))
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+
+	return server
+}
+
+func (server *fakeSMTPServer) serve(conn *textproto.Conn) {
+	defer conn.Close()
+
+	var current receivedMail
+	conn.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		command, argument, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(command) {
+		case "EHLO":
+			conn.PrintfLine("250-localhost\r\n250-8BITMIME\r\n250 AUTH PLAIN")
+		case "AUTH":
+			current.Auth = argument
+			conn.PrintfLine("235 Authenticated")
+		case "MAIL":
+			current.From = smtpPath(argument)
+			conn.PrintfLine("250 OK")
+		case "RCPT":
+			to := smtpPath(argument)
+			if to == server.rejectRcpt {
+				conn.PrintfLine("550 No such user")
+				continue
+			}
+			current.To = append(current.To, to)
+			conn.PrintfLine("250 OK")
+		case "DATA":
+			conn.PrintfLine("354 Go ahead")
+			data, err := conn.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.Data = string(data)
+			server.mu.Lock()
+			server.mails = append(server.mails, current)
+			server.mu.Unlock()
+			current = receivedMail{}
+			conn.PrintfLine("250 Queued")
+		case "QUIT":
+			conn.PrintfLine("221 Bye")
+			return
+		default:
+			conn.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+// Адрес из «FROM:<address> BODY=8BITMIME»
+func smtpPath(argument string) string {
+	_, path, _ := strings.Cut(argument, "<")
+	path, _, _ = strings.Cut(path, ">")
+	return path
+}
+
+func (server *fakeSMTPServer) config() config.SMTPConfig {
+	host, port, _ := net.SplitHostPort(server.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return config.SMTPConfig{Host: host, Port: portNumber, From: "todo@example.com", Timeout: 5 * time.Second}
+}
+
+func (server *fakeSMTPServer) received() []receivedMail {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return append([]receivedMail(nil), server.mails...)
+}
+
+// Тест отправки напоминания письмом через SMTP-сервер
+func TestSMTPNotifier_Notify(t *testing.T) {
+	deadline := time.Date(2030, 2, 15, 11, 0, 0, 0, time.UTC)
+	task := models.NewTask("Отчёт", utils.Ptr("Собрать цифры за квартал"), &deadline, nil, nil)
+	task.TimeZone = utils.Ptr("Asia/Novosibirsk")
+	notification := models.Notification{Email: "user@example.com", Task: *task, Left: 90 * time.Minute}
+
+	t.Run("Письмо владельцу задачи", func(t *testing.T) {
+		server := newFakeSMTPServer(t, "")
+
+		assert.NoError(t, NewSMTPNotifier(server.config()).Notify(notification))
+
+		mails := server.received()
+		if !assert.Len(t, mails, 1) {
+			return
+		}
+		assert.Empty(t, mails[0].Auth)
+		assert.Equal(t, "todo@example.com", mails[0].From)
+		assert.Equal(t, []string{"user@example.com"}, mails[0].To)
+
+		message, err := mail.ReadMessage(strings.NewReader(mails[0].Data))
+		assert.NoError(t, err)
+		subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+		assert.NoError(t, err)
+		assert.Equal(t, "Напоминание: «Отчёт»", subject)
+		assert.Equal(t, "user@example.com", message.Header.Get("To"))
+		assert.Equal(t, "text/plain; charset=utf-8", message.Header.Get("Content-Type"))
+		assert.Contains(t, mails[0].Data, "До дедлайна задачи «Отчёт» осталось 1 ч 30 мин: 15.02.2030 18:00 +07.")
+		assert.Contains(t, mails[0].Data, "Собрать цифры за квартал")
+	})
+
+	t.Run("Вход на сервер", func(t *testing.T) {
+		server := newFakeSMTPServer(t, "")
+		cfg := server.config()
+		cfg.Username = "todo"
+		cfg.Password = "password"
+
+		assert.NoError(t, NewSMTPNotifier(cfg).Notify(notification))
+
+		mails := server.received()
+		if assert.Len(t, mails, 1) {
+			credentials := base64.StdEncoding.EncodeToString([]byte("\x00todo\x00password"))
+			assert.Equal(t, "PLAIN "+credentials, mails[0].Auth)
+		}
+	})
+
+	t.Run("Сервер отклонил получателя", func(t *testing.T) {
+		server := newFakeSMTPServer(t, "user@example.com")
+
+		err := NewSMTPNotifier(server.config()).Notify(notification)
+
+		assert.ErrorContains(t, err, "No such user")
+		assert.Empty(t, server.received())
+	})
+
+	t.Run("Сервер недоступен", func(t *testing.T) {
+		server := newFakeSMTPServer(t, "")
+		cfg := server.config()
+		server.listener.Close()
+
+		assert.Error(t, NewSMTPNotifier(cfg).Notify(notification))
+	})
+}
@@ -0,0 +1,29 @@
+package notifiers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"time"
+)
+
+// WebhookNotifier отправляет напоминание вебхукам владельца задачи событием reminder; подпись
+// и повторы — как у остальных событий вебхуков
+type WebhookNotifier struct {
+	publisher interfaces.TaskChangePublisher
+}
+
+func NewWebhookNotifier(publisher interfaces.TaskChangePublisher) *WebhookNotifier {
+	return &WebhookNotifier{publisher: publisher}
+}
+
+var _ interfaces.Notifier = (*WebhookNotifier)(nil)
+
+func (notifier *WebhookNotifier) Notify(notification models.Notification) error {
+	notifier.publisher.Publish(models.TaskChange{
+		Type:       enums.TaskChangeReminder,
+		Task:       notification.Task,
+		OccurredAt: time.Now(),
+	})
+	return nil
+}
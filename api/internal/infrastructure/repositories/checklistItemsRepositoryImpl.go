@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ChecklistItemsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewChecklistItemsRepository(db *gorm.DB) interfaces.ChecklistItemsRepository {
+	return &ChecklistItemsRepositoryImpl{db: db}
+}
+
+func (repo *ChecklistItemsRepositoryImpl) Add(item models.ChecklistItem) error {
+	return repo.db.Create(&item).Error
+}
+
+func (repo *ChecklistItemsRepositoryImpl) GetByID(id uuid.UUID) (*models.ChecklistItem, error) {
+	var item models.ChecklistItem
+
+	err := repo.db.Where("id = ?", id).First(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (repo *ChecklistItemsRepositoryImpl) GetByTaskID(taskID uuid.UUID) ([]*models.ChecklistItem, error) {
+	var items []*models.ChecklistItem
+
+	err := repo.db.Where("task_id = ?", taskID).Order("position").Order("created_at").Order("id").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (repo *ChecklistItemsRepositoryImpl) GetProgress(taskIDs []uuid.UUID) (map[uuid.UUID]models.ChecklistProgress,
+	error) {
+	progress := map[uuid.UUID]models.ChecklistProgress{}
+	if len(taskIDs) == 0 {
+		return progress, nil
+	}
+
+	var rows []struct {
+		TaskID uuid.UUID
+		Done   int
+		Total  int
+	}
+
+	err := repo.db.Model(&models.ChecklistItem{}).
+		Select("task_id, SUM(CASE WHEN is_done THEN 1 ELSE 0 END) AS done, COUNT(*) AS total").
+		Where("task_id IN ?", taskIDs).
+		Group("task_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		progress[row.TaskID] = models.ChecklistProgress{Done: row.Done, Total: row.Total}
+	}
+
+	return progress, nil
+}
+
+func (repo *ChecklistItemsRepositoryImpl) Update(item models.ChecklistItem) error {
+	return repo.db.Save(&item).Error
+}
+
+func (repo *ChecklistItemsRepositoryImpl) MarkAllDone(taskID uuid.UUID, now time.Time) error {
+	return repo.db.Model(&models.ChecklistItem{}).
+		Where("task_id = ? AND is_done = ?", taskID, false).
+		Updates(map[string]any{"is_done": true, "changed_at": now}).Error
+}
+
+func (repo *ChecklistItemsRepositoryImpl) DeleteByID(id uuid.UUID) error {
+	return repo.db.Where("id = ?", id).Delete(&models.ChecklistItem{}).Error
+}
+
+func (repo *ChecklistItemsRepositoryImpl) DeleteByTaskID(taskID uuid.UUID) error {
+	return repo.db.Where("task_id = ?", taskID).Delete(&models.ChecklistItem{}).Error
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	dbConn "HITS_ToDoList_Tests/internal/infrastructure/db"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// Тест подсчёта прогресса чек-листов одним запросом
+func TestChecklistItemsRepositoryImpl_GetProgress(t *testing.T) {
+	db, mock := newMockDb(t)
+	repo := NewChecklistItemsRepository(db)
+
+	firstTaskID, secondTaskID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT task_id, SUM(CASE WHEN is_done THEN 1 ELSE 0 END) AS done, COUNT(*) AS total 
+		FROM "checklist_items" WHERE task_id IN ($1,$2) GROUP BY "task_id"`,
+	)).
+		WithArgs(firstTaskID, secondTaskID).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "done", "total"}).AddRow(firstTaskID, 3, 5))
+
+	progress, err := repo.GetProgress([]uuid.UUID{firstTaskID, secondTaskID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]models.ChecklistProgress{firstTaskID: {Done: 3, Total: 5}}, progress)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Тест одинакового поведения in-memory и SQL-репозиториев пунктов чек-листа
+func TestChecklistItemsRepositories(t *testing.T) {
+	db, err := dbConn.NewSQLiteConnection(":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, dbConn.Migrate(db))
+
+	repos := map[string]interfaces.ChecklistItemsRepository{
+		"memory": NewMemoryChecklistItemsRepository(),
+		"sqlite": NewChecklistItemsRepository(db),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			task := models.NewTask("task", nil, nil, nil, nil)
+			assert.NoError(t, NewTasksRepository(db).Add(*task))
+			otherTaskID := uuid.New()
+
+			second := models.NewChecklistItem(task.ID, "second", 1)
+			first := models.NewChecklistItem(task.ID, "first", 0)
+			first.IsDone = true
+			assert.NoError(t, repo.Add(*second))
+			assert.NoError(t, repo.Add(*first))
+			assert.NoError(t, repo.Add(*models.NewChecklistItem(task.ID, "third", 2)))
+
+			items, err := repo.GetByTaskID(task.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"first", "second", "third"}, []string{items[0].Name, items[1].Name, items[2].Name})
+
+			progress, err := repo.GetProgress([]uuid.UUID{task.ID, otherTaskID})
+			assert.NoError(t, err)
+			assert.Equal(t, map[uuid.UUID]models.ChecklistProgress{task.ID: {Done: 1, Total: 3}}, progress)
+
+			assert.NoError(t, repo.MarkAllDone(task.ID, time.Now()))
+			progress, _ = repo.GetProgress([]uuid.UUID{task.ID})
+			assert.Equal(t, models.ChecklistProgress{Done: 3, Total: 3}, progress[task.ID])
+
+			assert.NoError(t, repo.DeleteByID(second.ID))
+			stored, err := repo.GetByID(second.ID)
+			assert.NoError(t, err)
+			assert.Nil(t, stored)
+
+			assert.NoError(t, repo.DeleteByTaskID(task.ID))
+			items, _ = repo.GetByTaskID(task.ID)
+			assert.Empty(t, items)
+		})
+	}
+}
+
+// Тест каскадного удаления пунктов вместе с задачей на уровне схемы
+func TestChecklistItemsRepositoryImpl_CascadeDelete(t *testing.T) {
+	db, err := dbConn.NewSQLiteConnection(":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, dbConn.Migrate(db))
+
+	tasksRepo := NewTasksRepository(db)
+	itemsRepo := NewChecklistItemsRepository(db)
+
+	task := models.NewTask("task", nil, nil, nil, nil)
+	assert.NoError(t, tasksRepo.Add(*task))
+	assert.NoError(t, itemsRepo.Add(*models.NewChecklistItem(task.ID, "item", 0)))
+
+	// Пункт не может ссылаться на несуществующую задачу
+	assert.Error(t, itemsRepo.Add(*models.NewChecklistItem(uuid.New(), "orphan", 0)))
+
+	assert.NoError(t, tasksRepo.DeleteByID(task.ID))
+
+	items, err := itemsRepo.GetByTaskID(task.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+}
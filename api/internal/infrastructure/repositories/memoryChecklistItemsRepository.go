@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"fmt"
+	"github.com/google/uuid"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type MemoryChecklistItemsRepository struct {
+	mu    sync.RWMutex
+	items map[uuid.UUID]models.ChecklistItem
+}
+
+func NewMemoryChecklistItemsRepository() interfaces.ChecklistItemsRepository {
+	return &MemoryChecklistItemsRepository{items: map[uuid.UUID]models.ChecklistItem{}}
+}
+
+func (repo *MemoryChecklistItemsRepository) snapshot() func() {
+	repo.mu.RLock()
+	items := maps.Clone(repo.items)
+	repo.mu.RUnlock()
+
+	return func() {
+		repo.mu.Lock()
+		repo.items = items
+		repo.mu.Unlock()
+	}
+}
+
+func (repo *MemoryChecklistItemsRepository) Add(item models.ChecklistItem) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, exists := repo.items[item.ID]; exists {
+		return fmt.Errorf("checklist item %s already exists", item.ID)
+	}
+
+	repo.items[item.ID] = item
+	return nil
+}
+
+func (repo *MemoryChecklistItemsRepository) GetByID(id uuid.UUID) (*models.ChecklistItem, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	item, exists := repo.items[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &item, nil
+}
+
+func (repo *MemoryChecklistItemsRepository) GetByTaskID(taskID uuid.UUID) ([]*models.ChecklistItem, error) {
+	repo.mu.RLock()
+	items := make([]*models.ChecklistItem, 0)
+	for _, item := range repo.items {
+		if item.TaskID == taskID {
+			items = append(items, &item)
+		}
+	}
+	repo.mu.RUnlock()
+
+	slices.SortFunc(items, func(a, b *models.ChecklistItem) int {
+		if a.Position != b.Position {
+			return a.Position - b.Position
+		}
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return items, nil
+}
+
+func (repo *MemoryChecklistItemsRepository) GetProgress(
+	taskIDs []uuid.UUID) (map[uuid.UUID]models.ChecklistProgress, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	progress := map[uuid.UUID]models.ChecklistProgress{}
+	for _, item := range repo.items {
+		if !slices.Contains(taskIDs, item.TaskID) {
+			continue
+		}
+
+		taskProgress := progress[item.TaskID]
+		taskProgress.Total++
+		if item.IsDone {
+			taskProgress.Done++
+		}
+		progress[item.TaskID] = taskProgress
+	}
+
+	return progress, nil
+}
+
+func (repo *MemoryChecklistItemsRepository) Update(item models.ChecklistItem) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.items[item.ID] = item
+	return nil
+}
+
+func (repo *MemoryChecklistItemsRepository) MarkAllDone(taskID uuid.UUID, now time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, item := range repo.items {
+		if item.TaskID == taskID && !item.IsDone {
+			item.IsDone = true
+			item.ChangedAt = &now
+			repo.items[id] = item
+		}
+	}
+
+	return nil
+}
+
+func (repo *MemoryChecklistItemsRepository) DeleteByID(id uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.items, id)
+	return nil
+}
+
+func (repo *MemoryChecklistItemsRepository) DeleteByTaskID(taskID uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, item := range repo.items {
+		if item.TaskID == taskID {
+			delete(repo.items, id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"fmt"
+	"github.com/google/uuid"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type MemoryProjectsRepository struct {
+	mu       sync.RWMutex
+	projects map[uuid.UUID]models.Project
+}
+
+func NewMemoryProjectsRepository() interfaces.ProjectsRepository {
+	return &MemoryProjectsRepository{projects: map[uuid.UUID]models.Project{}}
+}
+
+func (repo *MemoryProjectsRepository) snapshot() func() {
+	repo.mu.RLock()
+	projects := maps.Clone(repo.projects)
+	repo.mu.RUnlock()
+
+	return func() {
+		repo.mu.Lock()
+		repo.projects = projects
+		repo.mu.Unlock()
+	}
+}
+
+func (repo *MemoryProjectsRepository) Add(project models.Project) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, exists := repo.projects[project.ID]; exists {
+		return fmt.Errorf("project %s already exists", project.ID)
+	}
+	if err := repo.checkSingleInbox(project); err != nil {
+		return err
+	}
+
+	repo.projects[project.ID] = project
+	return nil
+}
+
+func (repo *MemoryProjectsRepository) GetByID(id uuid.UUID) (*models.Project, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	project, exists := repo.projects[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &project, nil
+}
+
+func (repo *MemoryProjectsRepository) GetByOwnerID(ownerID uuid.UUID, archived *bool) ([]*models.Project, error) {
+	repo.mu.RLock()
+	projects := make([]*models.Project, 0)
+	for _, project := range repo.projects {
+		if project.OwnerID == ownerID && (archived == nil || project.IsArchived == *archived) {
+			projects = append(projects, &project)
+		}
+	}
+	repo.mu.RUnlock()
+
+	// Тот же порядок, что и в SQL-реализации: Inbox, затем по дате создания и id
+	slices.SortFunc(projects, func(a, b *models.Project) int {
+		if a.IsInbox != b.IsInbox {
+			if a.IsInbox {
+				return -1
+			}
+			return 1
+		}
+		if result := a.CreatedAt.Compare(b.CreatedAt); result != 0 {
+			return result
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return projects, nil
+}
+
+func (repo *MemoryProjectsRepository) GetInbox(ownerID uuid.UUID) (*models.Project, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	for _, project := range repo.projects {
+		if project.OwnerID == ownerID && project.IsInbox {
+			return &project, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (repo *MemoryProjectsRepository) Update(project models.Project) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if err := repo.checkSingleInbox(project); err != nil {
+		return err
+	}
+
+	repo.projects[project.ID] = project
+	return nil
+}
+
+func (repo *MemoryProjectsRepository) DeleteByID(id uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.projects, id)
+	return nil
+}
+
+// Повторяет частичный уникальный индекс idx_projects_owner_id_inbox SQL-схемы
+func (repo *MemoryProjectsRepository) checkSingleInbox(project models.Project) error {
+	if !project.IsInbox {
+		return nil
+	}
+
+	for _, existing := range repo.projects {
+		if existing.ID != project.ID && existing.OwnerID == project.OwnerID && existing.IsInbox {
+			return fmt.Errorf("inbox of user %s already exists", project.OwnerID)
+		}
+	}
+	return nil
+}
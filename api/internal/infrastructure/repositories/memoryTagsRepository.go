@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"fmt"
+	"github.com/google/uuid"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type MemoryTagsRepository struct {
+	mu       sync.RWMutex
+	tags     map[uuid.UUID]models.Tag
+	taskTags map[uuid.UUID][]uuid.UUID
+}
+
+func NewMemoryTagsRepository() interfaces.TagsRepository {
+	return &MemoryTagsRepository{
+		tags:     map[uuid.UUID]models.Tag{},
+		taskTags: map[uuid.UUID][]uuid.UUID{},
+	}
+}
+
+func (repo *MemoryTagsRepository) snapshot() func() {
+	repo.mu.RLock()
+	tags := maps.Clone(repo.tags)
+	// Списки меток задачи меняются на месте, поэтому копируются целиком
+	taskTags := make(map[uuid.UUID][]uuid.UUID, len(repo.taskTags))
+	for taskID, tagIDs := range repo.taskTags {
+		taskTags[taskID] = slices.Clone(tagIDs)
+	}
+	repo.mu.RUnlock()
+
+	return func() {
+		repo.mu.Lock()
+		repo.tags = tags
+		repo.taskTags = taskTags
+		repo.mu.Unlock()
+	}
+}
+
+func (repo *MemoryTagsRepository) Add(tag models.Tag) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, exists := repo.tags[tag.ID]; exists {
+		return fmt.Errorf("tag %s already exists", tag.ID)
+	}
+	if err := repo.checkUniqueName(tag); err != nil {
+		return err
+	}
+
+	repo.tags[tag.ID] = tag
+	return nil
+}
+
+func (repo *MemoryTagsRepository) GetByID(id uuid.UUID) (*models.Tag, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	tag, exists := repo.tags[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &tag, nil
+}
+
+func (repo *MemoryTagsRepository) GetByOwnerID(ownerID uuid.UUID) ([]*models.Tag, error) {
+	return repo.find(func(tag models.Tag) bool {
+		return tag.OwnerID == ownerID
+	}), nil
+}
+
+func (repo *MemoryTagsRepository) GetByNames(ownerID uuid.UUID, names []string) ([]*models.Tag, error) {
+	return repo.find(func(tag models.Tag) bool {
+		return tag.OwnerID == ownerID && slices.Contains(names, tag.Name)
+	}), nil
+}
+
+func (repo *MemoryTagsRepository) Update(tag models.Tag) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if err := repo.checkUniqueName(tag); err != nil {
+		return err
+	}
+
+	repo.tags[tag.ID] = tag
+	return nil
+}
+
+func (repo *MemoryTagsRepository) DeleteByID(id uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.tags, id)
+	for taskID, tagIDs := range repo.taskTags {
+		repo.taskTags[taskID] = slices.DeleteFunc(tagIDs, func(tagID uuid.UUID) bool {
+			return tagID == id
+		})
+	}
+
+	return nil
+}
+
+func (repo *MemoryTagsRepository) GetByTaskIDs(taskIDs []uuid.UUID) (map[uuid.UUID][]models.Tag, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	tags := map[uuid.UUID][]models.Tag{}
+	for _, taskID := range taskIDs {
+		for _, tagID := range repo.taskTags[taskID] {
+			tags[taskID] = append(tags[taskID], repo.tags[tagID])
+		}
+		slices.SortFunc(tags[taskID], func(a, b models.Tag) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+
+	return tags, nil
+}
+
+func (repo *MemoryTagsRepository) GetTaskIDs(tagIDs []uuid.UUID) ([]uuid.UUID, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	taskIDs := make([]uuid.UUID, 0)
+	for taskID, taskTagIDs := range repo.taskTags {
+		if slices.ContainsFunc(taskTagIDs, func(tagID uuid.UUID) bool {
+			return slices.Contains(tagIDs, tagID)
+		}) {
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+
+	return taskIDs, nil
+}
+
+func (repo *MemoryTagsRepository) SetTaskTags(taskID uuid.UUID, tagIDs []uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if len(tagIDs) == 0 {
+		delete(repo.taskTags, taskID)
+		return nil
+	}
+
+	for _, tagID := range tagIDs {
+		if _, exists := repo.tags[tagID]; !exists {
+			return fmt.Errorf("tag %s does not exist", tagID)
+		}
+	}
+
+	repo.taskTags[taskID] = slices.Clone(tagIDs)
+	return nil
+}
+
+func (repo *MemoryTagsRepository) find(matches func(tag models.Tag) bool) []*models.Tag {
+	repo.mu.RLock()
+	tags := make([]*models.Tag, 0)
+	for _, tag := range repo.tags {
+		if matches(tag) {
+			tags = append(tags, &tag)
+		}
+	}
+	repo.mu.RUnlock()
+
+	slices.SortFunc(tags, func(a, b *models.Tag) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return tags
+}
+
+// Повторяет уникальный индекс (owner_id, name) SQL-схемы
+func (repo *MemoryTagsRepository) checkUniqueName(tag models.Tag) error {
+	for _, existing := range repo.tags {
+		if existing.ID != tag.ID && existing.OwnerID == tag.OwnerID && existing.Name == tag.Name {
+			return fmt.Errorf("tag %q already exists", tag.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"bytes"
+	"github.com/google/uuid"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+type MemoryTaskEventsRepository struct {
+	mu     sync.RWMutex
+	events map[uuid.UUID][]models.TaskEvent
+}
+
+func NewMemoryTaskEventsRepository() interfaces.TaskEventsRepository {
+	return &MemoryTaskEventsRepository{events: map[uuid.UUID][]models.TaskEvent{}}
+}
+
+func (repo *MemoryTaskEventsRepository) snapshot() func() {
+	repo.mu.RLock()
+	events := maps.Clone(repo.events)
+	repo.mu.RUnlock()
+
+	return func() {
+		repo.mu.Lock()
+		repo.events = events
+		repo.mu.Unlock()
+	}
+}
+
+func (repo *MemoryTaskEventsRepository) Add(event models.TaskEvent) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	event.Changes = slices.Clone(event.Changes)
+	repo.events[event.TaskID] = append(repo.events[event.TaskID], event)
+	return nil
+}
+
+func (repo *MemoryTaskEventsRepository) DeleteByTaskID(taskID uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.events, taskID)
+	return nil
+}
+
+func (repo *MemoryTaskEventsRepository) GetPage(taskID uuid.UUID, after *models.TaskEventsCursor,
+	limit int) (*models.TaskEventsPage, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	events := make([]*models.TaskEvent, 0)
+	for _, event := range repo.events[taskID] {
+		if after != nil && compareTaskEvents(&event, after.CreatedAt, after.ID) <= 0 {
+			continue
+		}
+		event.Changes = slices.Clone(event.Changes)
+		events = append(events, &event)
+	}
+
+	slices.SortFunc(events, func(a, b *models.TaskEvent) int {
+		return compareTaskEvents(a, b.CreatedAt, b.ID)
+	})
+
+	page := &models.TaskEventsPage{Items: events}
+	if len(events) > limit {
+		page.Items = events[:limit]
+		page.HasMore = true
+	}
+
+	return page, nil
+}
+
+func compareTaskEvents(event *models.TaskEvent, createdAt time.Time, id uuid.UUID) int {
+	if c := event.CreatedAt.Compare(createdAt); c != 0 {
+		return c
+	}
+
+	return bytes.Compare(event.ID[:], id[:])
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type MemoryTaskRemindersRepository struct {
+	mu        sync.RWMutex
+	reminders map[uuid.UUID]models.TaskReminder
+}
+
+func NewMemoryTaskRemindersRepository() interfaces.TaskRemindersRepository {
+	return &MemoryTaskRemindersRepository{reminders: map[uuid.UUID]models.TaskReminder{}}
+}
+
+func (repo *MemoryTaskRemindersRepository) GetByTaskID(taskID uuid.UUID) ([]*models.TaskReminder, error) {
+	reminders := repo.find(func(reminder models.TaskReminder) bool {
+		return reminder.TaskID == taskID
+	})
+
+	slices.SortFunc(reminders, func(a, b *models.TaskReminder) int {
+		return b.MinutesBefore - a.MinutesBefore
+	})
+
+	return reminders, nil
+}
+
+func (repo *MemoryTaskRemindersRepository) GetPending(until time.Time) ([]*models.TaskReminder, error) {
+	reminders := repo.find(func(reminder models.TaskReminder) bool {
+		return reminder.Pending() && !reminder.RemindAt.After(until)
+	})
+
+	slices.SortFunc(reminders, func(a, b *models.TaskReminder) int {
+		if c := a.RemindAt.Compare(*b.RemindAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return reminders, nil
+}
+
+func (repo *MemoryTaskRemindersRepository) ReplaceForTask(taskID uuid.UUID, reminders []models.TaskReminder) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, reminder := range repo.reminders {
+		if reminder.TaskID == taskID {
+			delete(repo.reminders, id)
+		}
+	}
+
+	for _, reminder := range reminders {
+		repo.reminders[reminder.ID] = reminder
+	}
+
+	return nil
+}
+
+func (repo *MemoryTaskRemindersRepository) Update(reminder models.TaskReminder) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, exists := repo.reminders[reminder.ID]; exists {
+		repo.reminders[reminder.ID] = reminder
+	}
+
+	return nil
+}
+
+func (repo *MemoryTaskRemindersRepository) find(
+	match func(reminder models.TaskReminder) bool) []*models.TaskReminder {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	reminders := make([]*models.TaskReminder, 0)
+	for _, reminder := range repo.reminders {
+		if match(reminder) {
+			reminders = append(reminders, &reminder)
+		}
+	}
+
+	return reminders
+}
@@ -0,0 +1,289 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/application/enums"
+	domainEnums "HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryTasksRepository хранит задачи в памяти процесса; данные теряются при перезапуске
+type MemoryTasksRepository struct {
+	mu    sync.RWMutex
+	tasks map[uuid.UUID]models.Task
+}
+
+func NewMemoryTasksRepository() interfaces.TasksRepository {
+	return &MemoryTasksRepository{tasks: map[uuid.UUID]models.Task{}}
+}
+
+func (repo *MemoryTasksRepository) snapshot() func() {
+	repo.mu.RLock()
+	tasks := maps.Clone(repo.tasks)
+	repo.mu.RUnlock()
+
+	return func() {
+		repo.mu.Lock()
+		repo.tasks = tasks
+		repo.mu.Unlock()
+	}
+}
+
+func (repo *MemoryTasksRepository) Add(task models.Task) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, exists := repo.tasks[task.ID]; exists {
+		return fmt.Errorf("task %s already exists", task.ID)
+	}
+
+	repo.tasks[task.ID] = task
+	return nil
+}
+
+func (repo *MemoryTasksRepository) GetAll(filter *models.TasksFilter, sorting *enums.Sorting) ([]*models.Task,
+	error) {
+	return repo.find(filter, sorting, nil, -1)
+}
+
+func (repo *MemoryTasksRepository) GetPage(filter *models.TasksFilter, sorting *enums.Sorting,
+	after *models.TasksCursor, limit int) (*models.TasksPage, error) {
+	tasks, err := repo.find(filter, sorting, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.TasksPage{Items: tasks}
+	if len(tasks) > limit {
+		page.Items = tasks[:limit]
+		page.HasMore = true
+	}
+
+	return page, nil
+}
+
+func (repo *MemoryTasksRepository) GetByID(id uuid.UUID) (*models.Task, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	task, exists := repo.tasks[id]
+	if !exists || task.DeletedAt != nil {
+		return nil, nil
+	}
+
+	return &task, nil
+}
+
+// Транзакции MemoryUnitOfWork выполняются по одной, поэтому отдельная блокировка задачи не нужна
+func (repo *MemoryTasksRepository) GetByIDForUpdate(id uuid.UUID) (*models.Task, error) {
+	return repo.GetByID(id)
+}
+
+func (repo *MemoryTasksRepository) GetDeleted(id uuid.UUID) (*models.Task, error) {
+	return repo.GetDeletedForUpdate(id, nil)
+}
+
+func (repo *MemoryTasksRepository) GetDeletedForUpdate(id uuid.UUID, deletedBefore *time.Time) (*models.Task,
+	error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	task, exists := repo.tasks[id]
+	if !exists || task.DeletedAt == nil || (deletedBefore != nil && !task.DeletedAt.Before(*deletedBefore)) {
+		return nil, nil
+	}
+
+	return &task, nil
+}
+
+func (repo *MemoryTasksRepository) DeleteByID(taskID uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.tasks, taskID)
+	return nil
+}
+
+func (repo *MemoryTasksRepository) Update(task models.Task) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if stored, exists := repo.tasks[task.ID]; !exists || stored.Version != task.Version {
+		return interfaces.ErrTaskVersionConflict
+	}
+
+	task.Version++
+	repo.tasks[task.ID] = task
+	return nil
+}
+
+func (repo *MemoryTasksRepository) MarkOverdue(now time.Time) ([]*models.Task, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var tasks []*models.Task
+	for id, task := range repo.tasks {
+		if task.Status == domainEnums.Active && task.DeletedAt == nil && task.Deadline != nil &&
+			task.Deadline.Before(now) {
+			task.Status = domainEnums.Overdue
+			task.ChangedAt = &now
+			task.Version++
+			repo.tasks[id] = task
+			tasks = append(tasks, &task)
+		}
+	}
+
+	return tasks, nil
+}
+
+// Выборка с теми же правилами фильтрации и порядка, что и SQL-реализация; limit < 0 — без ограничения
+func (repo *MemoryTasksRepository) find(filter *models.TasksFilter, sorting *enums.Sorting,
+	after *models.TasksCursor, limit int) ([]*models.Task, error) {
+	compare, err := tasksComparator(sorting)
+	if err != nil {
+		return nil, err
+	}
+
+	repo.mu.RLock()
+	tasks := make([]*models.Task, 0, len(repo.tasks))
+	for _, task := range repo.tasks {
+		if matchesTasksFilter(&task, filter) {
+			tasks = append(tasks, &task)
+		}
+	}
+	repo.mu.RUnlock()
+
+	slices.SortFunc(tasks, compare)
+
+	if after != nil {
+		cursorTask := &models.Task{
+			ID:        after.ID,
+			CreatedAt: after.CreatedAt,
+			Deadline:  after.Deadline,
+			Priority:  after.Priority,
+		}
+		start, _ := slices.BinarySearchFunc(tasks, cursorTask, compare)
+		for start < len(tasks) && compare(tasks[start], cursorTask) <= 0 {
+			start++
+		}
+		tasks = tasks[start:]
+	}
+
+	if limit >= 0 && len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+
+	return tasks, nil
+}
+
+func matchesTasksFilter(task *models.Task, filter *models.TasksFilter) bool {
+	if (task.DeletedAt != nil) != (filter != nil && filter.Deleted) {
+		return false
+	}
+	if filter == nil {
+		return true
+	}
+
+	if filter.OwnerID != nil && task.OwnerID != *filter.OwnerID {
+		return false
+	}
+	if filter.IDs != nil && !slices.Contains(filter.IDs, task.ID) {
+		return false
+	}
+	if filter.ProjectID != nil && (task.ProjectID == nil || *task.ProjectID != *filter.ProjectID) {
+		return false
+	}
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, task.Status) {
+		return false
+	}
+	if len(filter.Priorities) > 0 && !slices.Contains(filter.Priorities, task.Priority) {
+		return false
+	}
+	if filter.DeadlineFrom != nil && (task.Deadline == nil || task.Deadline.Before(*filter.DeadlineFrom)) {
+		return false
+	}
+	if filter.DeadlineTo != nil && (task.Deadline == nil || task.Deadline.After(*filter.DeadlineTo)) {
+		return false
+	}
+	if filter.CreatedFrom != nil && task.CreatedAt.Before(*filter.CreatedFrom) {
+		return false
+	}
+	if filter.CreatedTo != nil && task.CreatedAt.After(*filter.CreatedTo) {
+		return false
+	}
+	if filter.Query != nil && *filter.Query != "" {
+		query := strings.ToLower(*filter.Query)
+		inName := strings.Contains(strings.ToLower(task.Name), query)
+		inDescription := task.Description != nil && strings.Contains(strings.ToLower(*task.Description), query)
+		if !inName && !inDescription {
+			return false
+		}
+	}
+	if filter.DeletedBefore != nil && (task.DeletedAt == nil || !task.DeletedAt.Before(*filter.DeletedBefore)) {
+		return false
+	}
+
+	return true
+}
+
+// Функция сравнения, повторяющая порядок applyTasksOrder
+func tasksComparator(sorting *enums.Sorting) (func(a, b *models.Task) int, error) {
+	byID := func(a, b *models.Task) int {
+		return strings.Compare(a.ID.String(), b.ID.String())
+	}
+
+	if sorting == nil {
+		return byID, nil
+	}
+
+	var byKey func(a, b *models.Task) int
+	descending := false
+
+	switch *sorting {
+	case enums.CreateAsc, enums.CreateDesc:
+		byKey = func(a, b *models.Task) int {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}
+		descending = *sorting == enums.CreateDesc
+	case enums.DeadlineAsc, enums.DeadlineDesc:
+		byKey = func(a, b *models.Task) int {
+			switch {
+			case a.Deadline == nil && b.Deadline == nil:
+				return 0
+			case a.Deadline == nil:
+				return -1
+			case b.Deadline == nil:
+				return 1
+			default:
+				return a.Deadline.Compare(*b.Deadline)
+			}
+		}
+		descending = *sorting == enums.DeadlineDesc
+	case enums.PriorityAsc, enums.PriorityDesc:
+		byKey = func(a, b *models.Task) int {
+			return priorityRanks[a.Priority] - priorityRanks[b.Priority]
+		}
+		descending = *sorting == enums.PriorityDesc
+	default:
+		return nil, errors.New(fmt.Sprintf("Invalid sorting: %v", *sorting))
+	}
+
+	return func(a, b *models.Task) int {
+		result := byKey(a, b)
+		if result == 0 {
+			result = byID(a, b)
+		}
+		if descending {
+			return -result
+		}
+		return result
+	}, nil
+}
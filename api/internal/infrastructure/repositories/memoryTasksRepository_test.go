@@ -0,0 +1,265 @@
+package repositories
+
+import (
+	appEnums "HITS_ToDoList_Tests/internal/application/enums"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	dbConn "HITS_ToDoList_Tests/internal/infrastructure/db"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Набор задач с совпадающими ключами сортировки и пустыми дедлайнами; часть задач входит в проект,
+// две лежат в корзине
+func seedTasks(t *testing.T, repos ...interfaces.TasksRepository) (uuid.UUID, uuid.UUID) {
+	ownerID := uuid.New()
+	projectID := uuid.New()
+	now := time.Now().Truncate(time.Microsecond)
+	priorities := []enums.Priority{enums.Low, enums.Medium, enums.High, enums.Critical}
+
+	for i := 0; i < 13; i++ {
+		task := models.NewTask(fmt.Sprintf("task %d", i), nil, nil, nil, utils.Ptr(priorities[i%4]))
+		task.OwnerID = ownerID
+		task.CreatedAt = now.Add(-time.Duration(i/3) * time.Minute)
+		if i%4 != 0 {
+			task.Deadline = utils.Ptr(now.Add(time.Duration(i%3) * time.Hour))
+		}
+		if i == 5 {
+			task.Description = utils.Ptr("Особая задача")
+		}
+		if i%5 == 0 {
+			task.Status = enums.Completed
+		}
+		if i%3 == 0 {
+			task.ProjectID = &projectID
+		}
+		switch i {
+		case 7:
+			task.DeletedAt = utils.Ptr(now.Add(-time.Hour))
+		case 11:
+			task.DeletedAt = utils.Ptr(now)
+		}
+
+		for _, repo := range repos {
+			assert.NoError(t, repo.Add(*task))
+		}
+	}
+
+	return ownerID, projectID
+}
+
+func newSQLiteTasksRepository(t *testing.T) interfaces.TasksRepository {
+	db, err := dbConn.NewSQLiteConnection(":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&models.Task{}))
+
+	return NewTasksRepository(db)
+}
+
+func taskIDs(tasks []*models.Task) []uuid.UUID {
+	ids := make([]uuid.UUID, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+// Тест совпадения выборок in-memory репозитория и SQL-реализации
+func TestMemoryTasksRepository_MatchesSQL(t *testing.T) {
+	memoryRepo := NewMemoryTasksRepository()
+	sqlRepo := newSQLiteTasksRepository(t)
+	ownerID, projectID := seedTasks(t, memoryRepo, sqlRepo)
+
+	sortings := []*appEnums.Sorting{nil}
+	for _, sorting := range []string{appEnums.CreateAsc, appEnums.CreateDesc, appEnums.DeadlineAsc,
+		appEnums.DeadlineDesc, appEnums.PriorityAsc, appEnums.PriorityDesc} {
+		sortings = append(sortings, (*appEnums.Sorting)(utils.Ptr(sorting)))
+	}
+
+	filters := map[string]*models.TasksFilter{
+		"без фильтра":      nil,
+		"по владельцу":     {OwnerID: &ownerID},
+		"чужой владелец":   {OwnerID: utils.Ptr(uuid.New())},
+		"по статусу":       {Statuses: []enums.Status{enums.Completed}},
+		"по приоритету":    {Priorities: []enums.Priority{enums.High, enums.Low}},
+		"по тексту":        {Query: utils.Ptr("особая")},
+		"по дедлайну":      {DeadlineFrom: utils.Ptr(time.Now().Add(30 * time.Minute))},
+		"по дате создания": {CreatedTo: utils.Ptr(time.Now().Add(-90 * time.Second))},
+		"по проекту":       {ProjectID: &projectID},
+		"в корзине":        {Deleted: true},
+		"давно в корзине":  {Deleted: true, DeletedBefore: utils.Ptr(time.Now().Add(-30 * time.Minute))},
+	}
+
+	for filterName, filter := range filters {
+		for _, sorting := range sortings {
+			name := filterName
+			if sorting != nil {
+				name += ", " + string(*sorting)
+			}
+
+			t.Run(name, func(t *testing.T) {
+				expected, err := sqlRepo.GetAll(filter, sorting)
+				assert.NoError(t, err)
+				actual, err := memoryRepo.GetAll(filter, sorting)
+				assert.NoError(t, err)
+				if sorting != nil {
+					assert.Equal(t, taskIDs(expected), taskIDs(actual))
+				} else {
+					assert.ElementsMatch(t, taskIDs(expected), taskIDs(actual))
+				}
+
+				var after *models.TasksCursor
+				for {
+					expectedPage, err := sqlRepo.GetPage(filter, sorting, after, 4)
+					assert.NoError(t, err)
+					actualPage, err := memoryRepo.GetPage(filter, sorting, after, 4)
+					assert.NoError(t, err)
+
+					assert.Equal(t, taskIDs(expectedPage.Items), taskIDs(actualPage.Items))
+					assert.Equal(t, expectedPage.HasMore, actualPage.HasMore)
+
+					if !expectedPage.HasMore || !actualPage.HasMore {
+						break
+					}
+					after = models.NewTasksCursor(expectedPage.Items[len(expectedPage.Items)-1])
+				}
+			})
+		}
+	}
+}
+
+// Тест изменения и удаления задач в in-memory репозитории
+func TestMemoryTasksRepository_CRUD(t *testing.T) {
+	repo := NewMemoryTasksRepository()
+	task := models.NewTask("task", nil, nil, nil, nil)
+
+	assert.NoError(t, repo.Add(*task))
+	assert.Error(t, repo.Add(*task))
+
+	task.Name = "updated"
+	assert.NoError(t, repo.Update(*task))
+
+	stored, err := repo.GetByID(task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", stored.Name)
+
+	// Изменение полученной копии не влияет на хранилище
+	stored.Name = "changed outside"
+	stored, _ = repo.GetByID(task.ID)
+	assert.Equal(t, "updated", stored.Name)
+
+	// Задача не в корзине не находится среди удалённых
+	stored, err = repo.GetDeletedForUpdate(task.ID, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+
+	// Задача из корзины не находится по ID
+	task.Version++
+	task.DeletedAt = utils.Ptr(time.Now())
+	assert.NoError(t, repo.Update(*task))
+	stored, err = repo.GetByID(task.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+
+	stored, err = repo.GetDeleted(task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, task.ID, stored.ID)
+
+	// Среди удалённых она находится, только если удалена раньше deletedBefore
+	stored, err = repo.GetDeletedForUpdate(task.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, task.ID, stored.ID)
+	stored, err = repo.GetDeletedForUpdate(task.ID, task.DeletedAt)
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+
+	assert.NoError(t, repo.DeleteByID(task.ID))
+	trashed, err := repo.GetAll(&models.TasksFilter{Deleted: true}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, trashed)
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+// Тест перевода просроченных задач в Overdue в in-memory репозитории и SQLite
+func TestMemoryTasksRepository_MarkOverdue(t *testing.T) {
+	memoryRepo := NewMemoryTasksRepository()
+	sqliteRepo := newSQLiteTasksRepository(t)
+
+	now := time.Now()
+	novosibirsk := time.FixedZone("UTC+7", 7*60*60)
+	deadlines := []*time.Time{
+		nil,
+		utils.Ptr(now.Add(-time.Minute)),
+		utils.Ptr(now.Add(time.Minute)),
+		// Смещение зоны не должно влиять на сравнение со временем в UTC
+		utils.Ptr(now.Add(-time.Minute).In(novosibirsk)),
+		utils.Ptr(now.Add(time.Minute).In(novosibirsk)),
+	}
+
+	var expected []uuid.UUID
+	for i, deadline := range deadlines {
+		task := models.NewTask(fmt.Sprintf("task %d", i), nil, deadline, nil, nil)
+		if deadline != nil && deadline.Before(now) {
+			expected = append(expected, task.ID)
+		}
+		assert.NoError(t, memoryRepo.Add(*task))
+		assert.NoError(t, sqliteRepo.Add(*task))
+	}
+	completed := models.NewTask("completed", nil, utils.Ptr(now.Add(-time.Hour)), utils.Ptr(enums.Completed), nil)
+	trashed := models.NewTask("trashed", nil, utils.Ptr(now.Add(-time.Hour)), nil, nil)
+	trashed.DeletedAt = &now
+	for _, task := range []*models.Task{completed, trashed} {
+		assert.NoError(t, memoryRepo.Add(*task))
+		assert.NoError(t, sqliteRepo.Add(*task))
+	}
+
+	for name, repo := range map[string]interfaces.TasksRepository{"memory": memoryRepo, "sqlite": sqliteRepo} {
+		t.Run(name, func(t *testing.T) {
+			tasks, err := repo.MarkOverdue(now.UTC())
+
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, expected, taskIDs(tasks))
+			for _, task := range tasks {
+				assert.Equal(t, enums.Overdue, task.Status)
+				assert.Equal(t, 2, task.Version)
+			}
+
+			overdue, err := repo.GetAll(&models.TasksFilter{Statuses: []enums.Status{enums.Overdue}}, nil)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, expected, taskIDs(overdue))
+		})
+	}
+}
+
+// Тест сохранения с проверкой версии в in-memory репозитории и SQLite
+func TestMemoryTasksRepository_UpdateVersion(t *testing.T) {
+	for name, repo := range map[string]interfaces.TasksRepository{
+		"memory": NewMemoryTasksRepository(),
+		"sqlite": newSQLiteTasksRepository(t),
+	} {
+		t.Run(name, func(t *testing.T) {
+			task := models.NewTask("task", nil, nil, nil, nil)
+			assert.NoError(t, repo.Add(*task))
+
+			first, second := *task, *task
+			first.Name = "first"
+			second.Name = "second"
+
+			assert.NoError(t, repo.Update(first))
+			assert.ErrorIs(t, repo.Update(second), interfaces.ErrTaskVersionConflict)
+
+			stored, err := repo.GetByID(task.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, "first", stored.Name)
+			assert.Equal(t, 2, stored.Version)
+
+			assert.ErrorIs(t, repo.Update(models.Task{ID: uuid.New(), Version: 1}), interfaces.ErrTaskVersionConflict)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"sync"
+)
+
+// Репозиторий в памяти, который умеет откатываться: snapshot запоминает данные, а возвращённая
+// функция восстанавливает их
+type memorySnapshotter interface {
+	snapshot() (restore func())
+}
+
+// MemoryUnitOfWork выполняет транзакции по одной. Откат восстанавливает снимок данных, сделанный в начале
+// транзакции, поэтому изменения в обход WithinTx, сделанные во время транзакции, при откате теряются
+type MemoryUnitOfWork struct {
+	mu     *sync.Mutex
+	repos  interfaces.Repositories
+	nested bool
+}
+
+func NewMemoryUnitOfWork(repos interfaces.Repositories) interfaces.UnitOfWork {
+	return &MemoryUnitOfWork{mu: &sync.Mutex{}, repos: repos}
+}
+
+func (uow *MemoryUnitOfWork) WithinTx(fn func(repos interfaces.Repositories) error) error {
+	if !uow.nested {
+		uow.mu.Lock()
+		defer uow.mu.Unlock()
+	}
+
+	var restores []func()
+	for _, repo := range []any{uow.repos.Tasks, uow.repos.ChecklistItems, uow.repos.Tags, uow.repos.Projects,
+		uow.repos.TaskEvents} {
+		if snapshotter, ok := repo.(memorySnapshotter); ok {
+			restores = append(restores, snapshotter.snapshot())
+		}
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			for _, restore := range restores {
+				restore()
+			}
+		}
+	}()
+
+	repos := uow.repos
+	repos.UnitOfWork = &MemoryUnitOfWork{mu: uow.mu, repos: uow.repos, nested: true}
+	if err := fn(repos); err != nil {
+		return err
+	}
+
+	committed = true
+	return nil
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"fmt"
+	"github.com/google/uuid"
+	"sync"
+)
+
+type MemoryUsersRepository struct {
+	mu    sync.RWMutex
+	users map[uuid.UUID]models.User
+}
+
+func NewMemoryUsersRepository() interfaces.UsersRepository {
+	return &MemoryUsersRepository{users: map[uuid.UUID]models.User{}}
+}
+
+func (repo *MemoryUsersRepository) Add(user models.User) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, existing := range repo.users {
+		if existing.Email == user.Email {
+			return interfaces.ErrUserEmailTaken
+		}
+		if existing.ID == user.ID {
+			return fmt.Errorf("user %s already exists", user.Email)
+		}
+	}
+
+	repo.users[user.ID] = user
+	return nil
+}
+
+func (repo *MemoryUsersRepository) GetByID(id uuid.UUID) (*models.User, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	user, exists := repo.users[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &user, nil
+}
+
+func (repo *MemoryUsersRepository) GetByEmail(email string) (*models.User, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	for _, user := range repo.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (repo *MemoryUsersRepository) GetByCalendarTokenHash(hash string) (*models.User, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	for _, user := range repo.users {
+		if user.CalendarTokenHash != nil && *user.CalendarTokenHash == hash {
+			return &user, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (repo *MemoryUsersRepository) Update(user models.User) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.users[user.ID] = user
+	return nil
+}
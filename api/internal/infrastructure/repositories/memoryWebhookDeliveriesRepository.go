@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type MemoryWebhookDeliveriesRepository struct {
+	mu         sync.RWMutex
+	deliveries map[uuid.UUID]models.WebhookDelivery
+}
+
+func NewMemoryWebhookDeliveriesRepository() interfaces.WebhookDeliveriesRepository {
+	return &MemoryWebhookDeliveriesRepository{deliveries: map[uuid.UUID]models.WebhookDelivery{}}
+}
+
+func (repo *MemoryWebhookDeliveriesRepository) Add(delivery models.WebhookDelivery) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, exists := repo.deliveries[delivery.ID]; exists {
+		return fmt.Errorf("webhook delivery %s already exists", delivery.ID)
+	}
+
+	repo.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (repo *MemoryWebhookDeliveriesRepository) GetByID(id uuid.UUID) (*models.WebhookDelivery, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	delivery, exists := repo.deliveries[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &delivery, nil
+}
+
+func (repo *MemoryWebhookDeliveriesRepository) GetDue(now time.Time,
+	limit int) ([]*models.WebhookDelivery, error) {
+	deliveries := repo.find(func(delivery models.WebhookDelivery) bool {
+		return delivery.Status == enums.DeliveryPending && !delivery.NextAttemptAt.After(now)
+	})
+
+	slices.SortFunc(deliveries, func(a, b *models.WebhookDelivery) int {
+		if c := a.NextAttemptAt.Compare(b.NextAttemptAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+func (repo *MemoryWebhookDeliveriesRepository) GetByWebhookIDs(webhookIDs []uuid.UUID,
+	status enums.WebhookDeliveryStatus) ([]*models.WebhookDelivery, error) {
+	deliveries := repo.find(func(delivery models.WebhookDelivery) bool {
+		return delivery.Status == status && slices.Contains(webhookIDs, delivery.WebhookID)
+	})
+
+	slices.SortFunc(deliveries, func(a, b *models.WebhookDelivery) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return deliveries, nil
+}
+
+func (repo *MemoryWebhookDeliveriesRepository) Update(delivery models.WebhookDelivery) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, exists := repo.deliveries[delivery.ID]; !exists {
+		return fmt.Errorf("webhook delivery %s not found", delivery.ID)
+	}
+
+	repo.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (repo *MemoryWebhookDeliveriesRepository) DeleteByWebhookID(webhookID uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, delivery := range repo.deliveries {
+		if delivery.WebhookID == webhookID {
+			delete(repo.deliveries, id)
+		}
+	}
+
+	return nil
+}
+
+func (repo *MemoryWebhookDeliveriesRepository) find(
+	match func(delivery models.WebhookDelivery) bool) []*models.WebhookDelivery {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	deliveries := make([]*models.WebhookDelivery, 0)
+	for _, delivery := range repo.deliveries {
+		if match(delivery) {
+			deliveries = append(deliveries, &delivery)
+		}
+	}
+
+	return deliveries
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type MemoryWebhooksRepository struct {
+	mu       sync.RWMutex
+	webhooks map[uuid.UUID]models.Webhook
+}
+
+func NewMemoryWebhooksRepository() interfaces.WebhooksRepository {
+	return &MemoryWebhooksRepository{webhooks: map[uuid.UUID]models.Webhook{}}
+}
+
+func (repo *MemoryWebhooksRepository) Add(webhook models.Webhook) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, exists := repo.webhooks[webhook.ID]; exists {
+		return fmt.Errorf("webhook %s already exists", webhook.ID)
+	}
+
+	repo.webhooks[webhook.ID] = cloneWebhook(webhook)
+	return nil
+}
+
+func (repo *MemoryWebhooksRepository) GetByID(id uuid.UUID) (*models.Webhook, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	webhook, exists := repo.webhooks[id]
+	if !exists {
+		return nil, nil
+	}
+
+	webhook = cloneWebhook(webhook)
+	return &webhook, nil
+}
+
+func (repo *MemoryWebhooksRepository) GetByOwnerID(ownerID uuid.UUID) ([]*models.Webhook, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	webhooks := make([]*models.Webhook, 0)
+	for _, webhook := range repo.webhooks {
+		if webhook.OwnerID == ownerID {
+			webhook = cloneWebhook(webhook)
+			webhooks = append(webhooks, &webhook)
+		}
+	}
+
+	slices.SortFunc(webhooks, func(a, b *models.Webhook) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return webhooks, nil
+}
+
+func (repo *MemoryWebhooksRepository) Update(webhook models.Webhook) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, exists := repo.webhooks[webhook.ID]; !exists {
+		return fmt.Errorf("webhook %s not found", webhook.ID)
+	}
+
+	repo.webhooks[webhook.ID] = cloneWebhook(webhook)
+	return nil
+}
+
+func (repo *MemoryWebhooksRepository) DeleteByID(id uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.webhooks, id)
+	return nil
+}
+
+// Списки отбора не должны разделяться с вызывающим кодом
+func cloneWebhook(webhook models.Webhook) models.Webhook {
+	webhook.EventTypes = slices.Clone(webhook.EventTypes)
+	webhook.Priorities = slices.Clone(webhook.Priorities)
+	return webhook
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProjectsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewProjectsRepository(db *gorm.DB) interfaces.ProjectsRepository {
+	return &ProjectsRepositoryImpl{db: db}
+}
+
+func (repo *ProjectsRepositoryImpl) Add(project models.Project) error {
+	return repo.db.Create(&project).Error
+}
+
+func (repo *ProjectsRepositoryImpl) GetByID(id uuid.UUID) (*models.Project, error) {
+	return repo.first(repo.db.Where("id = ?", id))
+}
+
+func (repo *ProjectsRepositoryImpl) GetByOwnerID(ownerID uuid.UUID, archived *bool) ([]*models.Project, error) {
+	var projects []*models.Project
+
+	query := repo.db.Where("owner_id = ?", ownerID)
+	if archived != nil {
+		query = query.Where("is_archived = ?", *archived)
+	}
+
+	if err := query.Order("is_inbox DESC").Order("created_at").Order("id").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (repo *ProjectsRepositoryImpl) GetInbox(ownerID uuid.UUID) (*models.Project, error) {
+	return repo.first(repo.db.Where("owner_id = ? AND is_inbox = ?", ownerID, true))
+}
+
+func (repo *ProjectsRepositoryImpl) Update(project models.Project) error {
+	return repo.db.Save(&project).Error
+}
+
+func (repo *ProjectsRepositoryImpl) DeleteByID(id uuid.UUID) error {
+	return repo.db.Where("id = ?", id).Delete(&models.Project{}).Error
+}
+
+func (repo *ProjectsRepositoryImpl) first(query *gorm.DB) (*models.Project, error) {
+	var project models.Project
+
+	if err := query.First(&project).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &project, nil
+}
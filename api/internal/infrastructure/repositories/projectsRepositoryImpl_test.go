@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	dbConn "HITS_ToDoList_Tests/internal/infrastructure/db"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Тест одинакового поведения in-memory и SQL-репозиториев проектов
+func TestProjectsRepositories(t *testing.T) {
+	db, err := dbConn.NewSQLiteConnection(":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, dbConn.Migrate(db))
+
+	repos := map[string]interfaces.ProjectsRepository{
+		"memory": NewMemoryProjectsRepository(),
+		"sqlite": NewProjectsRepository(db),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			ownerID := uuid.New()
+
+			inbox, err := repo.GetInbox(ownerID)
+			assert.NoError(t, err)
+			assert.Nil(t, inbox)
+
+			work := models.NewProject(ownerID, "Работа", utils.Ptr("#1e88e5"))
+			home := models.NewProject(ownerID, "Дом", nil)
+			home.CreatedAt = work.CreatedAt.Add(time.Minute)
+			inbox = models.NewInboxProject(ownerID)
+			inbox.CreatedAt = work.CreatedAt.Add(time.Hour)
+			for _, project := range []*models.Project{work, home, inbox, models.NewInboxProject(uuid.New())} {
+				assert.NoError(t, repo.Add(*project))
+			}
+
+			// Inbox у пользователя один
+			assert.Error(t, repo.Add(*models.NewInboxProject(ownerID)))
+
+			stored, err := repo.GetInbox(ownerID)
+			assert.NoError(t, err)
+			assert.Equal(t, inbox.ID, stored.ID)
+
+			projects, err := repo.GetByOwnerID(ownerID, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"Inbox", "Работа", "Дом"},
+				[]string{projects[0].Name, projects[1].Name, projects[2].Name})
+
+			work.IsArchived = true
+			assert.NoError(t, repo.Update(*work))
+
+			projects, err = repo.GetByOwnerID(ownerID, utils.Ptr(true))
+			assert.NoError(t, err)
+			assert.Len(t, projects, 1)
+			assert.Equal(t, work.ID, projects[0].ID)
+			assert.Equal(t, "#1e88e5", *projects[0].Color)
+
+			projects, err = repo.GetByOwnerID(ownerID, utils.Ptr(false))
+			assert.NoError(t, err)
+			assert.Len(t, projects, 2)
+
+			assert.NoError(t, repo.DeleteByID(home.ID))
+			stored, err = repo.GetByID(home.ID)
+			assert.NoError(t, err)
+			assert.Nil(t, stored)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TagsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTagsRepository(db *gorm.DB) interfaces.TagsRepository {
+	return &TagsRepositoryImpl{db: db}
+}
+
+func (repo *TagsRepositoryImpl) Add(tag models.Tag) error {
+	return repo.db.Create(&tag).Error
+}
+
+func (repo *TagsRepositoryImpl) GetByID(id uuid.UUID) (*models.Tag, error) {
+	var tag models.Tag
+
+	err := repo.db.Where("id = ?", id).First(&tag).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (repo *TagsRepositoryImpl) GetByOwnerID(ownerID uuid.UUID) ([]*models.Tag, error) {
+	var tags []*models.Tag
+
+	if err := repo.db.Where("owner_id = ?", ownerID).Order("name").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (repo *TagsRepositoryImpl) GetByNames(ownerID uuid.UUID, names []string) ([]*models.Tag, error) {
+	tags := make([]*models.Tag, 0)
+	if len(names) == 0 {
+		return tags, nil
+	}
+
+	if err := repo.db.Where("owner_id = ? AND name IN ?", ownerID, names).Order("name").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (repo *TagsRepositoryImpl) Update(tag models.Tag) error {
+	return repo.db.Save(&tag).Error
+}
+
+func (repo *TagsRepositoryImpl) DeleteByID(id uuid.UUID) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tag_id = ?", id).Delete(&models.TaskTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.Tag{}).Error
+	})
+}
+
+func (repo *TagsRepositoryImpl) GetByTaskIDs(taskIDs []uuid.UUID) (map[uuid.UUID][]models.Tag, error) {
+	tags := map[uuid.UUID][]models.Tag{}
+	if len(taskIDs) == 0 {
+		return tags, nil
+	}
+
+	var rows []struct {
+		models.Tag
+		TaskID uuid.UUID
+	}
+
+	err := repo.db.Model(&models.Tag{}).
+		Select("tags.*, task_tags.task_id").
+		Joins("JOIN task_tags ON task_tags.tag_id = tags.id").
+		Where("task_tags.task_id IN ?", taskIDs).
+		Order("tags.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		tags[row.TaskID] = append(tags[row.TaskID], row.Tag)
+	}
+
+	return tags, nil
+}
+
+func (repo *TagsRepositoryImpl) GetTaskIDs(tagIDs []uuid.UUID) ([]uuid.UUID, error) {
+	taskIDs := make([]uuid.UUID, 0)
+	if len(tagIDs) == 0 {
+		return taskIDs, nil
+	}
+
+	err := repo.db.Model(&models.TaskTag{}).Distinct("task_id").Where("tag_id IN ?", tagIDs).
+		Pluck("task_id", &taskIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return taskIDs, nil
+}
+
+func (repo *TagsRepositoryImpl) SetTaskTags(taskID uuid.UUID, tagIDs []uuid.UUID) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).Delete(&models.TaskTag{}).Error; err != nil {
+			return err
+		}
+
+		if len(tagIDs) == 0 {
+			return nil
+		}
+
+		links := make([]models.TaskTag, len(tagIDs))
+		for i, tagID := range tagIDs {
+			links[i] = models.TaskTag{TaskID: taskID, TagID: tagID}
+		}
+
+		return tx.Create(&links).Error
+	})
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	dbConn "HITS_ToDoList_Tests/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// Тест одинакового поведения in-memory и SQL-репозиториев меток
+func TestTagsRepositories(t *testing.T) {
+	db, err := dbConn.NewSQLiteConnection(":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, dbConn.Migrate(db))
+
+	repos := map[string]interfaces.TagsRepository{
+		"memory": NewMemoryTagsRepository(),
+		"sqlite": NewTagsRepository(db),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			ownerID := uuid.New()
+			firstTask := models.NewTask("first", nil, nil, nil, nil)
+			secondTask := models.NewTask("second", nil, nil, nil, nil)
+			assert.NoError(t, NewTasksRepository(db).Add(*firstTask))
+			assert.NoError(t, NewTasksRepository(db).Add(*secondTask))
+
+			work := models.NewTag(ownerID, "work")
+			home := models.NewTag(ownerID, "home")
+			foreign := models.NewTag(uuid.New(), "work")
+			for _, tag := range []*models.Tag{work, home, foreign} {
+				assert.NoError(t, repo.Add(*tag))
+			}
+
+			// Имя уникально в пределах владельца
+			assert.Error(t, repo.Add(*models.NewTag(ownerID, "work")))
+
+			tags, err := repo.GetByOwnerID(ownerID)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"home", "work"}, []string{tags[0].Name, tags[1].Name})
+
+			tags, err = repo.GetByNames(ownerID, []string{"work", "missing"})
+			assert.NoError(t, err)
+			assert.Len(t, tags, 1)
+			assert.Equal(t, work.ID, tags[0].ID)
+
+			assert.NoError(t, repo.SetTaskTags(firstTask.ID, []uuid.UUID{work.ID, home.ID}))
+			assert.NoError(t, repo.SetTaskTags(secondTask.ID, []uuid.UUID{work.ID}))
+			assert.NoError(t, repo.SetTaskTags(secondTask.ID, []uuid.UUID{home.ID}))
+
+			taskTags, err := repo.GetByTaskIDs([]uuid.UUID{firstTask.ID, secondTask.ID, uuid.New()})
+			assert.NoError(t, err)
+			assert.Len(t, taskTags, 2)
+			assert.Equal(t, []string{"home", "work"}, []string{taskTags[firstTask.ID][0].Name,
+				taskTags[firstTask.ID][1].Name})
+			assert.Equal(t, home.ID, taskTags[secondTask.ID][0].ID)
+
+			taskIDs, err := repo.GetTaskIDs([]uuid.UUID{work.ID})
+			assert.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{firstTask.ID}, taskIDs)
+			taskIDs, err = repo.GetTaskIDs([]uuid.UUID{work.ID, home.ID})
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []uuid.UUID{firstTask.ID, secondTask.ID}, taskIDs)
+
+			work.Name = "job"
+			assert.NoError(t, repo.Update(*work))
+			stored, err := repo.GetByID(work.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, "job", stored.Name)
+
+			// Удаление метки снимает её с задач
+			assert.NoError(t, repo.DeleteByID(home.ID))
+			taskTags, err = repo.GetByTaskIDs([]uuid.UUID{firstTask.ID, secondTask.ID})
+			assert.NoError(t, err)
+			assert.Len(t, taskTags[firstTask.ID], 1)
+			assert.Empty(t, taskTags[secondTask.ID])
+
+			assert.NoError(t, repo.SetTaskTags(firstTask.ID, nil))
+			taskIDs, err = repo.GetTaskIDs([]uuid.UUID{work.ID})
+			assert.NoError(t, err)
+			assert.Empty(t, taskIDs)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TaskEventsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTaskEventsRepository(db *gorm.DB) interfaces.TaskEventsRepository {
+	return &TaskEventsRepositoryImpl{db: db}
+}
+
+func (repo *TaskEventsRepositoryImpl) Add(event models.TaskEvent) error {
+	return repo.db.Create(&event).Error
+}
+
+func (repo *TaskEventsRepositoryImpl) DeleteByTaskID(taskID uuid.UUID) error {
+	return repo.db.Where("task_id = ?", taskID).Delete(&models.TaskEvent{}).Error
+}
+
+func (repo *TaskEventsRepositoryImpl) GetPage(taskID uuid.UUID, after *models.TaskEventsCursor,
+	limit int) (*models.TaskEventsPage, error) {
+	var events []*models.TaskEvent
+
+	query := repo.db.Where("task_id = ?", taskID)
+	if after != nil {
+		query = query.Where("created_at > ? OR (created_at = ? AND id > ?)", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+
+	if err := query.Order("created_at").Order("id").Limit(limit + 1).Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	page := &models.TaskEventsPage{Items: events}
+	if len(events) > limit {
+		page.Items = events[:limit]
+		page.HasMore = true
+	}
+
+	return page, nil
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	dbConn "HITS_ToDoList_Tests/internal/infrastructure/db"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Тест одинакового поведения in-memory и SQL-репозиториев журнала изменений
+func TestTaskEventsRepositories(t *testing.T) {
+	db, err := dbConn.NewSQLiteConnection(":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, dbConn.Migrate(db))
+
+	repos := map[string]interfaces.TaskEventsRepository{
+		"memory": NewMemoryTaskEventsRepository(),
+		"sqlite": NewTaskEventsRepository(db),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			taskID := uuid.New()
+			actorID := uuid.New()
+			start := time.Now().UTC().Truncate(time.Second)
+
+			events := make([]*models.TaskEvent, 5)
+			for i := range events {
+				events[i] = models.NewTaskEvent(taskID, &actorID, enums.TaskUpdated, models.FieldChanges{
+					{Field: "name", Old: utils.Ptr("Было"), New: utils.Ptr("Стало")},
+				})
+				events[i].CreatedAt = start.Add(time.Duration(i) * time.Minute)
+			}
+			events[4].ActorID = nil
+			events[4].Type = enums.TaskStatusChanged
+			events[4].Changes = models.FieldChanges{
+				{Field: "status", Old: utils.Ptr("Active"), New: utils.Ptr("Overdue")},
+			}
+
+			// Порядок добавления не влияет на порядок выдачи
+			for _, i := range []int{3, 0, 4, 1, 2} {
+				assert.NoError(t, repo.Add(*events[i]))
+			}
+			assert.NoError(t, repo.Add(*models.NewTaskEvent(uuid.New(), nil, enums.TaskCreated, nil)))
+
+			page, err := repo.GetPage(taskID, nil, 2)
+			assert.NoError(t, err)
+			assert.True(t, page.HasMore)
+			assert.Equal(t, []uuid.UUID{events[0].ID, events[1].ID}, []uuid.UUID{page.Items[0].ID, page.Items[1].ID})
+			assert.Equal(t, events[0].Changes, page.Items[0].Changes)
+			assert.Equal(t, actorID, *page.Items[0].ActorID)
+
+			last := page.Items[1]
+			page, err = repo.GetPage(taskID, &models.TaskEventsCursor{CreatedAt: last.CreatedAt, ID: last.ID}, 10)
+			assert.NoError(t, err)
+			assert.False(t, page.HasMore)
+			assert.Len(t, page.Items, 3)
+			assert.Equal(t, events[4].ID, page.Items[2].ID)
+			assert.Nil(t, page.Items[2].ActorID)
+			assert.Equal(t, enums.TaskStatusChanged, page.Items[2].Type)
+			assert.Equal(t, "Overdue", *page.Items[2].Changes[0].New)
+
+			assert.NoError(t, repo.DeleteByTaskID(taskID))
+			page, err = repo.GetPage(taskID, nil, 10)
+			assert.NoError(t, err)
+			assert.Empty(t, page.Items)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type TaskRemindersRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTaskRemindersRepository(db *gorm.DB) interfaces.TaskRemindersRepository {
+	return &TaskRemindersRepositoryImpl{db: db}
+}
+
+func (repo *TaskRemindersRepositoryImpl) GetByTaskID(taskID uuid.UUID) ([]*models.TaskReminder, error) {
+	reminders := make([]*models.TaskReminder, 0)
+
+	err := repo.db.Where("task_id = ?", taskID).Order("minutes_before DESC").Find(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (repo *TaskRemindersRepositoryImpl) GetPending(until time.Time) ([]*models.TaskReminder, error) {
+	reminders := make([]*models.TaskReminder, 0)
+
+	err := repo.db.Where("remind_at <= ? AND sent_at IS NULL", until).Order("remind_at, id").Find(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (repo *TaskRemindersRepositoryImpl) ReplaceForTask(taskID uuid.UUID, reminders []models.TaskReminder) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).Delete(&models.TaskReminder{}).Error; err != nil {
+			return err
+		}
+		if len(reminders) == 0 {
+			return nil
+		}
+		return tx.Create(&reminders).Error
+	})
+}
+
+func (repo *TaskRemindersRepositoryImpl) Update(reminder models.TaskReminder) error {
+	return repo.db.Model(&reminder).Select("*").Updates(&reminder).Error
+}
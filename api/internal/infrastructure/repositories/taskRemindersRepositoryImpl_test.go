@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	dbConn "HITS_ToDoList_Tests/internal/infrastructure/db"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Тест одинакового поведения in-memory и SQL-репозиториев напоминаний
+func TestTaskRemindersRepositories(t *testing.T) {
+	db, err := dbConn.NewSQLiteConnection(":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, dbConn.Migrate(db))
+
+	repos := map[string]interfaces.TaskRemindersRepository{
+		"memory": NewMemoryTaskRemindersRepository(),
+		"sqlite": NewTaskRemindersRepository(db),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now().UTC().Truncate(time.Second)
+			deadline := now.Add(24 * time.Hour)
+			task := models.NewTask("task", nil, &deadline, nil, nil)
+			other := models.NewTask("other", nil, &deadline, nil, nil)
+			for _, task := range []*models.Task{task, other} {
+				assert.NoError(t, NewTasksRepository(db).Add(*task))
+			}
+
+			reminders := make([]models.TaskReminder, 3)
+			for i, minutes := range []int{60, 24 * 60, 10} {
+				reminders[i] = *models.NewTaskReminder(task.ID, minutes)
+				reminders[i].Schedule(task, now)
+			}
+			assert.NoError(t, repo.ReplaceForTask(task.ID, reminders))
+			otherReminder := models.NewTaskReminder(other.ID, 30)
+			otherReminder.Schedule(other, now)
+			assert.NoError(t, repo.ReplaceForTask(other.ID, []models.TaskReminder{*otherReminder}))
+
+			stored, err := repo.GetByTaskID(task.ID)
+			assert.NoError(t, err)
+			if assert.Len(t, stored, 3) {
+				assert.Equal(t, []int{24 * 60, 60, 10},
+					[]int{stored[0].MinutesBefore, stored[1].MinutesBefore, stored[2].MinutesBefore})
+				assert.True(t, deadline.Add(-time.Hour).Equal(*stored[1].RemindAt))
+			}
+
+			pending, err := repo.GetPending(deadline.Add(-30 * time.Minute))
+			assert.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{reminders[1].ID, reminders[0].ID, otherReminder.ID},
+				reminderIDs(pending))
+
+			reminders[1].SentAt = utils.Ptr(now)
+			assert.NoError(t, repo.Update(reminders[1]))
+			pending, err = repo.GetPending(deadline)
+			assert.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{reminders[0].ID, otherReminder.ID, reminders[2].ID}, reminderIDs(pending))
+
+			// Напоминание, удалённое заменой списка, не восстанавливается сохранением
+			removed := models.NewTaskReminder(task.ID, 5)
+			assert.NoError(t, repo.Update(*removed))
+			stored, err = repo.GetByTaskID(task.ID)
+			assert.NoError(t, err)
+			assert.Len(t, stored, 3)
+
+			assert.NoError(t, repo.ReplaceForTask(task.ID, nil))
+			stored, err = repo.GetByTaskID(task.ID)
+			assert.NoError(t, err)
+			assert.Empty(t, stored)
+			stored, err = repo.GetByTaskID(other.ID)
+			assert.NoError(t, err)
+			assert.Len(t, stored, 1)
+		})
+	}
+}
+
+func reminderIDs(reminders []*models.TaskReminder) []uuid.UUID {
+	ids := make([]uuid.UUID, len(reminders))
+	for i, reminder := range reminders {
+		ids[i] = reminder.ID
+	}
+	return ids
+}
@@ -2,12 +2,16 @@ package repositories
 
 import (
 	"HITS_ToDoList_Tests/internal/application/enums"
+	domainEnums "HITS_ToDoList_Tests/internal/domain/enums"
 	"HITS_ToDoList_Tests/internal/domain/interfaces"
 	"HITS_ToDoList_Tests/internal/domain/models"
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
 )
 
 type TasksRepositoryImpl struct {
@@ -22,53 +26,88 @@ func (repo *TasksRepositoryImpl) Add(task models.Task) error {
 	return repo.db.Create(task).Error
 }
 
-func (repo *TasksRepositoryImpl) GetAll(sorting *enums.Sorting) ([]*models.Task, error) {
+func (repo *TasksRepositoryImpl) GetAll(filter *models.TasksFilter, sorting *enums.Sorting) ([]*models.Task, error) {
 	var tasks []*models.Task
-	var err error
+
+	query := applyTasksFilter(repo.db, filter)
 
 	if sorting != nil {
-		switch {
-		case *sorting == enums.CreateAsc:
-			err = repo.db.Order("created_at").Find(&tasks).Error
-		case *sorting == enums.CreateDesc:
-			err = repo.db.Order("created_at DESC").Find(&tasks).Error
-		case *sorting == enums.DeadlineAsc:
-			err = repo.db.Order("deadline NULLS FIRST").Find(&tasks).Error
-		case *sorting == enums.DeadlineDesc:
-			err = repo.db.Order("deadline DESC NULLS LAST").Find(&tasks).Error
-		case *sorting == enums.PriorityAsc:
-			err = repo.db.Order(`
-		CASE priority
-            WHEN 'Low' THEN 1
-            WHEN 'Medium' THEN 2 
-            WHEN 'High' THEN 3
-            WHEN 'Critical' THEN 4
-        END`).Find(&tasks).Error
-		case *sorting == enums.PriorityDesc:
-			err = repo.db.Order(`
-		CASE priority
-            WHEN 'Low' THEN 1
-            WHEN 'Medium' THEN 2 
-            WHEN 'High' THEN 3
-            WHEN 'Critical' THEN 4
-        END DESC`).Find(&tasks).Error
-		default:
-			return nil, errors.New(fmt.Sprintf("Invalid sorting: %v", sorting))
+		var err error
+		if query, err = applyTasksOrder(query, sorting, nil); err != nil {
+			return nil, err
 		}
-	} else {
-		err = repo.db.Find(&tasks).Error
 	}
 
-	if err != nil {
+	if err := query.Find(&tasks).Error; err != nil {
 		return nil, err
 	}
 	return tasks, nil
 }
 
+func (repo *TasksRepositoryImpl) GetPage(filter *models.TasksFilter, sorting *enums.Sorting,
+	after *models.TasksCursor, limit int) (*models.TasksPage, error) {
+	var tasks []*models.Task
+
+	query := applyTasksFilter(repo.db, filter)
+
+	query, err := applyTasksOrder(query, sorting, after)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Limit(limit + 1).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	page := &models.TasksPage{Items: tasks}
+	if len(tasks) > limit {
+		page.Items = tasks[:limit]
+		page.HasMore = true
+	}
+
+	return page, nil
+}
+
 func (repo *TasksRepositoryImpl) GetByID(id uuid.UUID) (*models.Task, error) {
+	return repo.getByID(repo.db, id)
+}
+
+// SQLite не поддерживает блокировку строк и пропускает FOR UPDATE: там транзакции и так идут по одной
+func (repo *TasksRepositoryImpl) GetByIDForUpdate(id uuid.UUID) (*models.Task, error) {
+	return repo.getByID(repo.db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), id)
+}
+
+func (repo *TasksRepositoryImpl) GetDeleted(id uuid.UUID) (*models.Task, error) {
+	return repo.getDeleted(repo.db, id, nil)
+}
+
+func (repo *TasksRepositoryImpl) GetDeletedForUpdate(id uuid.UUID, deletedBefore *time.Time) (*models.Task, error) {
+	return repo.getDeleted(repo.db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), id, deletedBefore)
+}
+
+func (repo *TasksRepositoryImpl) getDeleted(db *gorm.DB, id uuid.UUID, deletedBefore *time.Time) (*models.Task,
+	error) {
+	var task models.Task
+
+	query := db.Where("id = ? AND deleted_at IS NOT NULL", id)
+	if deletedBefore != nil {
+		query = query.Where("deleted_at < ?", *deletedBefore)
+	}
+
+	if err := query.First(&task).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+func (repo *TasksRepositoryImpl) getByID(db *gorm.DB, id uuid.UUID) (*models.Task, error) {
 	var task models.Task
 
-	err := repo.db.Where("id = ?", id).First(&task).Error
+	err := db.Where("id = ? AND deleted_at IS NULL", id).First(&task).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -88,6 +127,168 @@ func (repo *TasksRepositoryImpl) DeleteByID(taskID uuid.UUID) error {
 	return nil
 }
 
+// Все поля записываются одним UPDATE с условием на версию, поэтому параллельное сохранение
+// той же версии не затирает чужие изменения
 func (repo *TasksRepositoryImpl) Update(task models.Task) error {
-	return repo.db.Save(&task).Error
+	version := task.Version
+	task.Version++
+
+	result := repo.db.Model(&task).Where("version = ?", version).Select("*").Updates(&task)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrTaskVersionConflict
+	}
+
+	return nil
+}
+
+func (repo *TasksRepositoryImpl) MarkOverdue(now time.Time) ([]*models.Task, error) {
+	var tasks []*models.Task
+
+	err := repo.db.Model(&tasks).
+		Clauses(clause.Returning{}).
+		Where("status = ? AND deadline < ? AND deleted_at IS NULL", domainEnums.Active, now).
+		Updates(map[string]any{
+			"status":     domainEnums.Overdue,
+			"changed_at": now,
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// Задачи из корзины выбираются только по фильтру Deleted
+func applyTasksFilter(query *gorm.DB, filter *models.TasksFilter) *gorm.DB {
+	if filter == nil || !filter.Deleted {
+		query = query.Where("deleted_at IS NULL")
+	} else {
+		query = query.Where("deleted_at IS NOT NULL")
+	}
+
+	if filter == nil {
+		return query
+	}
+
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.IDs != nil {
+		query = query.Where("id IN ?", filter.IDs)
+	}
+	if filter.ProjectID != nil {
+		query = query.Where("project_id = ?", *filter.ProjectID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if len(filter.Priorities) > 0 {
+		query = query.Where("priority IN ?", filter.Priorities)
+	}
+	if filter.DeadlineFrom != nil {
+		query = query.Where("deadline >= ?", *filter.DeadlineFrom)
+	}
+	if filter.DeadlineTo != nil {
+		query = query.Where("deadline <= ?", *filter.DeadlineTo)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *filter.CreatedTo)
+	}
+	if filter.Query != nil && *filter.Query != "" {
+		pattern := "%" + escapeLikePattern(strings.ToLower(*filter.Query)) + "%"
+		query = query.Where(`(LOWER(name) LIKE ? ESCAPE '\' OR LOWER(description) LIKE ? ESCAPE '\')`,
+			pattern, pattern)
+	}
+	if filter.DeletedBefore != nil {
+		query = query.Where("deleted_at < ?", *filter.DeletedBefore)
+	}
+
+	return query
+}
+
+// Экранирование спецсимволов LIKE, чтобы поисковая строка искалась буквально
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+const (
+	priorityRankExpr  = "CASE priority WHEN 'Low' THEN 1 WHEN 'Medium' THEN 2 WHEN 'High' THEN 3 WHEN 'Critical' THEN 4 END"
+	deadlineIsSetExpr = "CASE WHEN deadline IS NULL THEN 0 ELSE 1 END"
+)
+
+var priorityRanks = map[domainEnums.Priority]int{
+	domainEnums.Low:      1,
+	domainEnums.Medium:   2,
+	domainEnums.High:     3,
+	domainEnums.Critical: 4,
+}
+
+// Порядок выборки и, если задан курсор, условие продолжения после него.
+// Последним ключом всегда идёт id, чтобы порядок был полным и одинаковым во всех СУБД
+// (NULL-дедлайны упорядочиваются явным выражением, а не NULLS FIRST/LAST).
+func applyTasksOrder(query *gorm.DB, sorting *enums.Sorting, after *models.TasksCursor) (*gorm.DB, error) {
+	if sorting == nil {
+		if after != nil {
+			query = query.Where("id > ?", after.ID)
+		}
+		return query.Order("id"), nil
+	}
+
+	switch *sorting {
+	case enums.CreateAsc:
+		if after != nil {
+			query = query.Where("created_at > ? OR (created_at = ? AND id > ?)",
+				after.CreatedAt, after.CreatedAt, after.ID)
+		}
+		return query.Order("created_at").Order("id"), nil
+	case enums.CreateDesc:
+		if after != nil {
+			query = query.Where("created_at < ? OR (created_at = ? AND id < ?)",
+				after.CreatedAt, after.CreatedAt, after.ID)
+		}
+		return query.Order("created_at DESC").Order("id DESC"), nil
+	case enums.DeadlineAsc:
+		if after != nil {
+			if after.Deadline == nil {
+				query = query.Where("deadline IS NOT NULL OR id > ?", after.ID)
+			} else {
+				query = query.Where("deadline > ? OR (deadline = ? AND id > ?)",
+					*after.Deadline, *after.Deadline, after.ID)
+			}
+		}
+		return query.Order(deadlineIsSetExpr).Order("deadline").Order("id"), nil
+	case enums.DeadlineDesc:
+		if after != nil {
+			if after.Deadline == nil {
+				query = query.Where("deadline IS NULL AND id < ?", after.ID)
+			} else {
+				query = query.Where("deadline < ? OR (deadline = ? AND id < ?) OR deadline IS NULL",
+					*after.Deadline, *after.Deadline, after.ID)
+			}
+		}
+		return query.Order(deadlineIsSetExpr + " DESC").Order("deadline DESC").Order("id DESC"), nil
+	case enums.PriorityAsc:
+		if after != nil {
+			rank := priorityRanks[after.Priority]
+			query = query.Where(priorityRankExpr+" > ? OR ("+priorityRankExpr+" = ? AND id > ?)",
+				rank, rank, after.ID)
+		}
+		return query.Order(priorityRankExpr).Order("id"), nil
+	case enums.PriorityDesc:
+		if after != nil {
+			rank := priorityRanks[after.Priority]
+			query = query.Where(priorityRankExpr+" < ? OR ("+priorityRankExpr+" = ? AND id < ?)",
+				rank, rank, after.ID)
+		}
+		return query.Order(priorityRankExpr + " DESC").Order("id DESC"), nil
+	default:
+		return nil, errors.New(fmt.Sprintf("Invalid sorting: %v", *sorting))
+	}
 }
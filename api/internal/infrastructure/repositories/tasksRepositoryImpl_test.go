@@ -3,8 +3,10 @@ package repositories
 import (
 	appEnums "HITS_ToDoList_Tests/internal/application/enums"
 	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
 	"HITS_ToDoList_Tests/internal/domain/models"
 	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"database/sql/driver"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -40,18 +42,26 @@ func TestTasksRepositoryImpl_Add(t *testing.T) {
 	repo := NewTasksRepository(db)
 
 	task := models.NewTask("name", nil, nil, nil, nil)
+	task.OwnerID = uuid.New()
 
 	mock.ExpectBegin()
 	mock.ExpectExec(`INSERT INTO "tasks"`).
 		WithArgs(
 			task.ID,
+			task.OwnerID,
 			task.CreatedAt,
 			task.ChangedAt,
 			task.Name,
 			task.Description,
 			task.Deadline,
 			task.Status,
-			task.Priority).
+			task.Priority,
+			task.ProjectID,
+			task.TimeZone,
+			task.Recurrence,
+			task.NextOccurrenceID,
+			task.DeletedAt,
+			task.Version).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
@@ -73,7 +83,7 @@ func TestTasksRepositoryImpl_GetAll(t *testing.T) {
 		{
 			name:          "Получение задач без сортировки",
 			sorting:       nil,
-			expectedQuery: `SELECT * FROM "tasks"`,
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL`,
 			tasks: []*models.Task{
 				models.NewTask("task1", nil, nil, nil, nil),
 				models.NewTask("task2", nil, nil, nil, nil),
@@ -82,7 +92,7 @@ func TestTasksRepositoryImpl_GetAll(t *testing.T) {
 		{
 			name:          "Получение задач с сортировкой по дате создания (по возрастанию)",
 			sorting:       (*appEnums.Sorting)(utils.Ptr(appEnums.CreateAsc)),
-			expectedQuery: `SELECT * FROM "tasks" ORDER BY created_at`,
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL ORDER BY created_at,id`,
 			tasks: []*models.Task{
 				{
 					ID:          uuid.New(),
@@ -109,7 +119,7 @@ func TestTasksRepositoryImpl_GetAll(t *testing.T) {
 		{
 			name:          "Получение задач с сортировкой по дате создания (по убыванию)",
 			sorting:       (*appEnums.Sorting)(utils.Ptr(appEnums.CreateDesc)),
-			expectedQuery: `SELECT * FROM "tasks" ORDER BY created_at DESC`,
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL ORDER BY created_at DESC,id DESC`,
 			tasks: []*models.Task{
 				{
 					ID:          uuid.New(),
@@ -134,18 +144,20 @@ func TestTasksRepositoryImpl_GetAll(t *testing.T) {
 			},
 		},
 		{
-			name:          "Получение задач с сортировкой по дедлайну (по возрастанию)",
-			sorting:       (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineAsc)),
-			expectedQuery: `SELECT * FROM "tasks" ORDER BY deadline NULLS FIRST`,
+			name:    "Получение задач с сортировкой по дедлайну (по возрастанию)",
+			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineAsc)),
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL ` +
+				`ORDER BY CASE WHEN deadline IS NULL THEN 0 ELSE 1 END,deadline,id`,
 			tasks: []*models.Task{
 				models.NewTask("task1", nil, utils.Ptr(time.Now().Add(time.Hour)), nil, nil),
 				models.NewTask("task2", nil, utils.Ptr(time.Now().Add(2*time.Hour)), nil, nil),
 			},
 		},
 		{
-			name:          "Получение задач с сортировкой по дедлайну (по убыванию)",
-			sorting:       (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineDesc)),
-			expectedQuery: `SELECT * FROM "tasks" ORDER BY deadline DESC NULLS LAST`,
+			name:    "Получение задач с сортировкой по дедлайну (по убыванию)",
+			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineDesc)),
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL ` +
+				`ORDER BY CASE WHEN deadline IS NULL THEN 0 ELSE 1 END DESC,deadline DESC,id DESC`,
 			tasks: []*models.Task{
 				models.NewTask("task1", nil, utils.Ptr(time.Now().Add(2*time.Hour)), nil, nil),
 				models.NewTask("task2", nil, utils.Ptr(time.Now().Add(time.Hour)), nil, nil),
@@ -154,13 +166,13 @@ func TestTasksRepositoryImpl_GetAll(t *testing.T) {
 		{
 			name:    "Получение задач с сортировкой по приоритету (по возрастанию)",
 			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.PriorityAsc)),
-			expectedQuery: `SELECT * FROM "tasks" 
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL
          		ORDER BY CASE priority 
          		WHEN 'Low' THEN 1
          		WHEN 'Medium' THEN 2 
          		WHEN 'High' THEN 3 
          		WHEN 'Critical' THEN 4 
-         		END`,
+         		END,id`,
 			tasks: []*models.Task{
 				models.NewTask("mediumTask", nil, nil, nil, utils.Ptr(enums.Medium)),
 				models.NewTask("criticalTask", nil, nil, nil, utils.Ptr(enums.Critical)),
@@ -169,13 +181,13 @@ func TestTasksRepositoryImpl_GetAll(t *testing.T) {
 		{
 			name:    "Получение задач с сортировкой по приоритету (по убыванию)",
 			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.PriorityDesc)),
-			expectedQuery: `SELECT * FROM "tasks" 
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL
          		ORDER BY CASE priority 
          		WHEN 'Low' THEN 1 
          		WHEN 'Medium' THEN 2 
          		WHEN 'High' THEN 3 
          		WHEN 'Critical' THEN 4 
-         		END DESC`,
+         		END DESC,id DESC`,
 			tasks: []*models.Task{
 				models.NewTask("criticalTask", nil, nil, nil, utils.Ptr(enums.Critical)),
 				models.NewTask("mediumTask", nil, nil, nil, utils.Ptr(enums.Medium)),
@@ -205,7 +217,7 @@ func TestTasksRepositoryImpl_GetAll(t *testing.T) {
 
 			mock.ExpectQuery(regexp.QuoteMeta(tc.expectedQuery)).WillReturnRows(rows)
 
-			_, err := repo.GetAll(tc.sorting)
+			_, err := repo.GetAll(nil, tc.sorting)
 
 			assert.NoError(t, err)
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -213,6 +225,166 @@ func TestTasksRepositoryImpl_GetAll(t *testing.T) {
 	}
 }
 
+// Тест получения задач с фильтрацией и поиском
+func TestTasksRepositoryImpl_GetAll_Filter(t *testing.T) {
+	deadlineFrom := time.Now()
+	deadlineTo := deadlineFrom.AddDate(0, 0, 7)
+	ownerID := uuid.New()
+	projectID := uuid.New()
+
+	type testCase struct {
+		name          string
+		filter        *models.TasksFilter
+		expectedQuery string
+		expectedArgs  []driver.Value
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Пустой фильтр",
+			filter:        &models.TasksFilter{},
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL`,
+		},
+		{
+			name:          "Фильтрация по владельцу",
+			filter:        &models.TasksFilter{OwnerID: &ownerID},
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL AND owner_id = $1`,
+			expectedArgs:  []driver.Value{ownerID},
+		},
+		{
+			name:          "Фильтрация по проекту",
+			filter:        &models.TasksFilter{OwnerID: &ownerID, ProjectID: &projectID},
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL AND owner_id = $1 AND project_id = $2`,
+			expectedArgs:  []driver.Value{ownerID, projectID},
+		},
+		{
+			name: "Фильтрация по статусу и приоритету",
+			filter: &models.TasksFilter{
+				Statuses:   []enums.Status{enums.Active, enums.Overdue},
+				Priorities: []enums.Priority{enums.High},
+			},
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL AND status IN ($1,$2) AND priority IN ($3)`,
+			expectedArgs:  []driver.Value{enums.Active, enums.Overdue, enums.High},
+		},
+		{
+			name: "Фильтрация по диапазону дедлайна",
+			filter: &models.TasksFilter{
+				DeadlineFrom: &deadlineFrom,
+				DeadlineTo:   &deadlineTo,
+			},
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL AND deadline >= $1 AND deadline <= $2`,
+			expectedArgs:  []driver.Value{deadlineFrom, deadlineTo},
+		},
+		{
+			name: "Поиск по тексту со спецсимволами",
+			filter: &models.TasksFilter{
+				Query: utils.Ptr("Отчёт 100%"),
+			},
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL AND ` +
+				`((LOWER(name) LIKE $1 ESCAPE '\' OR LOWER(description) LIKE $2 ESCAPE '\'))`,
+			expectedArgs: []driver.Value{`%отчёт 100\%%`, `%отчёт 100\%%`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDb(t)
+			repo := NewTasksRepository(db)
+
+			rows := sqlmock.NewRows([]string{"id", "created_at", "changed_at", "name", "description", "deadline",
+				"status", "priority"})
+
+			expectation := mock.ExpectQuery(regexp.QuoteMeta(tc.expectedQuery))
+			if tc.expectedArgs != nil {
+				expectation = expectation.WithArgs(tc.expectedArgs...)
+			}
+			expectation.WillReturnRows(rows)
+
+			_, err := repo.GetAll(tc.filter, nil)
+
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// Тест постраничного получения задач
+func TestTasksRepositoryImpl_GetPage(t *testing.T) {
+	cursorTask := models.NewTask("cursor", nil, utils.Ptr(time.Now().Add(time.Hour)), nil, utils.Ptr(enums.High))
+
+	type testCase struct {
+		name          string
+		sorting       *appEnums.Sorting
+		after         *models.TasksCursor
+		expectedQuery string
+		expectedArgs  []driver.Value
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Первая страница без сортировки",
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL ORDER BY id LIMIT $1`,
+			expectedArgs:  []driver.Value{3},
+		},
+		{
+			name:          "Следующая страница без сортировки",
+			after:         models.NewTasksCursor(cursorTask),
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL AND id > $1 ORDER BY id LIMIT $2`,
+			expectedArgs:  []driver.Value{cursorTask.ID, 3},
+		},
+		{
+			name:    "Следующая страница с сортировкой по дате создания (по убыванию)",
+			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.CreateDesc)),
+			after:   models.NewTasksCursor(cursorTask),
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL AND ` +
+				`(created_at < $1 OR (created_at = $2 AND id < $3)) ORDER BY created_at DESC,id DESC LIMIT $4`,
+			expectedArgs: []driver.Value{cursorTask.CreatedAt, cursorTask.CreatedAt, cursorTask.ID, 3},
+		},
+		{
+			name:    "Следующая страница с сортировкой по дедлайну (по возрастанию)",
+			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.DeadlineAsc)),
+			after:   models.NewTasksCursor(cursorTask),
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL AND ` +
+				`(deadline > $1 OR (deadline = $2 AND id > $3)) ORDER BY CASE WHEN deadline IS NULL THEN 0 ELSE 1 END,deadline,id LIMIT $4`,
+			expectedArgs: []driver.Value{*cursorTask.Deadline, *cursorTask.Deadline, cursorTask.ID, 3},
+		},
+		{
+			name:    "Следующая страница с сортировкой по приоритету (по убыванию)",
+			sorting: (*appEnums.Sorting)(utils.Ptr(appEnums.PriorityDesc)),
+			after:   models.NewTasksCursor(cursorTask),
+			expectedQuery: `SELECT * FROM "tasks" WHERE deleted_at IS NULL AND (` + priorityRankExpr + ` < $1 OR (` +
+				priorityRankExpr + ` = $2 AND id < $3)) ORDER BY ` + priorityRankExpr + ` DESC,id DESC LIMIT $4`,
+			expectedArgs: []driver.Value{3, 3, cursorTask.ID, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDb(t)
+			repo := NewTasksRepository(db)
+
+			rows := sqlmock.NewRows([]string{"id", "created_at", "changed_at", "name", "description", "deadline",
+				"status", "priority"})
+			for i := 0; i < 3; i++ {
+				task := models.NewTask("task", nil, nil, nil, nil)
+				rows.AddRow(task.ID, task.CreatedAt, task.ChangedAt, task.Name, task.Description, task.Deadline,
+					task.Status, task.Priority)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(tc.expectedQuery)).
+				WithArgs(tc.expectedArgs...).
+				WillReturnRows(rows)
+
+			page, err := repo.GetPage(nil, tc.sorting, tc.after, 2)
+
+			assert.NoError(t, err)
+			assert.Len(t, page.Items, 2)
+			assert.True(t, page.HasMore)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 // Тест получения задачи по ID
 func TestTasksRepositoryImpl_GetByID(t *testing.T) {
 	type testCase struct {
@@ -258,13 +430,13 @@ func TestTasksRepositoryImpl_GetByID(t *testing.T) {
 				}
 
 				mock.ExpectQuery(regexp.QuoteMeta(
-					`SELECT * FROM "tasks" WHERE id = $1 ORDER BY "tasks"."id" LIMIT $2`,
+					`SELECT * FROM "tasks" WHERE id = $1 AND deleted_at IS NULL ORDER BY "tasks"."id" LIMIT $2`,
 				)).
 					WithArgs(tc.taskID, 1).
 					WillReturnRows(rows)
 			} else {
 				mock.ExpectQuery(regexp.QuoteMeta(
-					`SELECT * FROM "tasks" WHERE id = $1 ORDER BY "tasks"."id" LIMIT $2`,
+					`SELECT * FROM "tasks" WHERE id = $1 AND deleted_at IS NULL ORDER BY "tasks"."id" LIMIT $2`,
 				)).
 					WithArgs(tc.taskID, 1).
 					WillReturnError(tc.expectedError)
@@ -289,6 +461,65 @@ func TestTasksRepositoryImpl_GetByID(t *testing.T) {
 	}
 }
 
+// Тест чтения задачи с блокировкой строки
+func TestTasksRepositoryImpl_GetByIDForUpdate(t *testing.T) {
+	db, mock := newMockDb(t)
+	repo := NewTasksRepository(db)
+	task := models.NewTask("targetTask", nil, nil, nil, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT * FROM "tasks" WHERE id = $1 AND deleted_at IS NULL ORDER BY "tasks"."id" LIMIT $2 FOR UPDATE`,
+	)).
+		WithArgs(task.ID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(task.ID, task.Name))
+
+	result, err := repo.GetByIDForUpdate(task.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, task.ID, result.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Тест чтения задачи из корзины с блокировкой строки
+func TestTasksRepositoryImpl_GetDeletedForUpdate(t *testing.T) {
+	db, mock := newMockDb(t)
+	repo := NewTasksRepository(db)
+	task := models.NewTask("targetTask", nil, nil, nil, nil)
+	before := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT * FROM "tasks" WHERE (id = $1 AND deleted_at IS NOT NULL) AND deleted_at < $2 `+
+			`ORDER BY "tasks"."id" LIMIT $3 FOR UPDATE`,
+	)).
+		WithArgs(task.ID, before, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(task.ID, task.Name))
+
+	result, err := repo.GetDeletedForUpdate(task.ID, &before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, task.ID, result.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Тест чтения задачи из корзины без блокировки
+func TestTasksRepositoryImpl_GetDeleted(t *testing.T) {
+	db, mock := newMockDb(t)
+	repo := NewTasksRepository(db)
+	task := models.NewTask("targetTask", nil, nil, nil, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT * FROM "tasks" WHERE id = $1 AND deleted_at IS NOT NULL ORDER BY "tasks"."id" LIMIT $2`,
+	)).
+		WithArgs(task.ID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	result, err := repo.GetDeleted(task.ID)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 // Тест удаления задачи из БД по ID
 func TestTasksRepositoryImpl_DeleteByID(t *testing.T) {
 	db, mock := newMockDb(t)
@@ -311,26 +542,76 @@ func TestTasksRepositoryImpl_DeleteByID(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-// Тест обновления задачи из БД по ID
+// Тест обновления задачи из БД по ID с проверкой версии
 func TestTasksRepositoryImpl_Update(t *testing.T) {
+	task := models.NewTask("task", nil, nil, nil, nil)
+	query := regexp.QuoteMeta(
+		`UPDATE "tasks" 
+		SET "owner_id"=$1,"created_at"=$2,"changed_at"=$3,"name"=$4,"description"=$5,"deadline"=$6,"status"=$7,` +
+			`"priority"=$8,"project_id"=$9,"time_zone"=$10,"recurrence"=$11,"next_occurrence_id"=$12,` +
+			`"deleted_at"=$13,"version"=$14 
+		WHERE version = $15 AND "id" = $16`,
+	)
+
+	testCases := []struct {
+		name         string
+		rowsAffected int64
+		expectedErr  error
+	}{
+		{
+			name:         "Версия совпадает",
+			rowsAffected: 1,
+		},
+		{
+			name:         "Задачу изменили раньше",
+			rowsAffected: 0,
+			expectedErr:  interfaces.ErrTaskVersionConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDb(t)
+			repo := NewTasksRepository(db)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(query).
+				WithArgs(task.OwnerID, task.CreatedAt, task.ChangedAt, task.Name, task.Description, task.Deadline,
+					task.Status, task.Priority, task.ProjectID, task.TimeZone, task.Recurrence, task.NextOccurrenceID,
+					task.DeletedAt, task.Version+1, task.Version, task.ID).
+				WillReturnResult(sqlmock.NewResult(0, tc.rowsAffected))
+			mock.ExpectCommit()
+
+			err := repo.Update(*task)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// Тест массового перевода просроченных задач в Overdue
+func TestTasksRepositoryImpl_MarkOverdue(t *testing.T) {
 	db, mock := newMockDb(t)
 	repo := NewTasksRepository(db)
 
-	task := models.NewTask("task", nil, nil, nil, nil)
+	now := time.Now()
+	taskID := uuid.New()
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta(
-		`UPDATE "tasks" 
-		SET "created_at"=$1,"changed_at"=$2,"name"=$3,"description"=$4,"deadline"=$5,"status"=$6,"priority"=$7 
-		WHERE "id" = $8`,
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`UPDATE "tasks" SET "changed_at"=$1,"status"=$2,"version"=version + 1 `+
+			`WHERE status = $3 AND deadline < $4 AND deleted_at IS NULL RETURNING *`,
 	)).
-		WithArgs(task.CreatedAt, task.ChangedAt, task.Name, task.Description, task.Deadline, task.Status,
-			task.Priority, task.ID).
-		WillReturnResult(sqlmock.NewResult(0, 1))
+		WithArgs(now, enums.Overdue, enums.Active, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(taskID, enums.Overdue))
 	mock.ExpectCommit()
 
-	err := repo.Update(*task)
+	tasks, err := repo.MarkOverdue(now)
 
 	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, taskID, tasks[0].ID)
+	assert.Equal(t, enums.Overdue, tasks[0].Status)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"gorm.io/gorm"
+)
+
+// UnitOfWorkImpl выдаёт репозитории, привязанные к транзакции gorm; вложенные транзакции
+// становятся точками сохранения (SAVEPOINT)
+type UnitOfWorkImpl struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) interfaces.UnitOfWork {
+	return &UnitOfWorkImpl{db: db}
+}
+
+func (uow *UnitOfWorkImpl) WithinTx(fn func(repos interfaces.Repositories) error) error {
+	return uow.db.Transaction(func(tx *gorm.DB) error {
+		return fn(interfaces.Repositories{
+			Tasks:          NewTasksRepository(tx),
+			ChecklistItems: NewChecklistItemsRepository(tx),
+			Tags:           NewTagsRepository(tx),
+			Projects:       NewProjectsRepository(tx),
+			TaskEvents:     NewTaskEventsRepository(tx),
+			UnitOfWork:     &UnitOfWorkImpl{db: tx},
+		})
+	})
+}
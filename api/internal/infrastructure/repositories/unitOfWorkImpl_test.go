@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	dbConn "HITS_ToDoList_Tests/internal/infrastructure/db"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// Тест фиксации и отката транзакций в SQLite и в памяти
+func TestUnitOfWork(t *testing.T) {
+	newMemory := func(t *testing.T) (interfaces.UnitOfWork, interfaces.Repositories, uuid.UUID) {
+		repos := interfaces.Repositories{
+			Tasks:          NewMemoryTasksRepository(),
+			ChecklistItems: NewMemoryChecklistItemsRepository(),
+			Tags:           NewMemoryTagsRepository(),
+			Projects:       NewMemoryProjectsRepository(),
+			TaskEvents:     NewMemoryTaskEventsRepository(),
+		}
+		return NewMemoryUnitOfWork(repos), repos, uuid.New()
+	}
+	newSQLite := func(t *testing.T) (interfaces.UnitOfWork, interfaces.Repositories, uuid.UUID) {
+		db, err := dbConn.NewSQLiteConnection(":memory:")
+		assert.NoError(t, err)
+		assert.NoError(t, dbConn.Migrate(db))
+		user := models.NewUser("user@example.com", "hash")
+		assert.NoError(t, NewUsersRepository(db).Add(*user))
+		return NewUnitOfWork(db), interfaces.Repositories{
+			Tasks:          NewTasksRepository(db),
+			ChecklistItems: NewChecklistItemsRepository(db),
+			Tags:           NewTagsRepository(db),
+			Projects:       NewProjectsRepository(db),
+			TaskEvents:     NewTaskEventsRepository(db),
+		}, user.ID
+	}
+	errFailed := errors.New("failed")
+
+	for name, open := range map[string]func(t *testing.T) (interfaces.UnitOfWork, interfaces.Repositories, uuid.UUID){
+		"В памяти": newMemory,
+		"SQLite":   newSQLite,
+	} {
+		t.Run(name, func(t *testing.T) {
+			uow, repos, ownerID := open(t)
+			newTask := func(name string) *models.Task {
+				task := models.NewTask(name, nil, nil, nil, nil)
+				task.OwnerID = ownerID
+				return task
+			}
+			stored := func(task *models.Task) bool {
+				found, err := repos.Tasks.GetByID(task.ID)
+				assert.NoError(t, err)
+				return found != nil
+			}
+
+			committed := newTask("Сохранённая")
+			err := uow.WithinTx(func(tx interfaces.Repositories) error {
+				return tx.Tasks.Add(*committed)
+			})
+			assert.NoError(t, err)
+			assert.True(t, stored(committed))
+
+			// Откат отменяет изменения во всех репозиториях транзакции
+			rolledBack := newTask("Отменённая")
+			tag := models.NewTag(ownerID, "work")
+			err = uow.WithinTx(func(tx interfaces.Repositories) error {
+				assert.NoError(t, tx.Tasks.Add(*rolledBack))
+				assert.NoError(t, tx.Tags.Add(*tag))
+				assert.NoError(t, tx.Tags.SetTaskTags(committed.ID, []uuid.UUID{tag.ID}))
+				return errFailed
+			})
+			assert.ErrorIs(t, err, errFailed)
+			assert.False(t, stored(rolledBack))
+			tags, err := repos.Tags.GetByTaskIDs([]uuid.UUID{committed.ID})
+			assert.NoError(t, err)
+			assert.Empty(t, tags[committed.ID])
+
+			// Откат вложенной транзакции не отменяет изменений внешней
+			outer := newTask("Внешняя")
+			inner := newTask("Вложенная")
+			err = uow.WithinTx(func(tx interfaces.Repositories) error {
+				if err := tx.Tasks.Add(*outer); err != nil {
+					return err
+				}
+				assert.ErrorIs(t, tx.UnitOfWork.WithinTx(func(nested interfaces.Repositories) error {
+					assert.NoError(t, nested.Tasks.Add(*inner))
+					return errFailed
+				}), errFailed)
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.True(t, stored(outer))
+			assert.False(t, stored(inner))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UsersRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUsersRepository(db *gorm.DB) interfaces.UsersRepository {
+	return &UsersRepositoryImpl{db: db}
+}
+
+// Нарушение уникального индекса по email переводится в ErrUserEmailTaken; ошибку переводит диалект
+// БД, так как у Postgres и SQLite она разная
+func (repo *UsersRepositoryImpl) Add(user models.User) error {
+	err := repo.db.Create(&user).Error
+	if translator, ok := repo.db.Dialector.(gorm.ErrorTranslator); ok &&
+		errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey) {
+		return interfaces.ErrUserEmailTaken
+	}
+	return err
+}
+
+func (repo *UsersRepositoryImpl) GetByID(id uuid.UUID) (*models.User, error) {
+	var user models.User
+
+	err := repo.db.Where("id = ?", id).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (repo *UsersRepositoryImpl) GetByEmail(email string) (*models.User, error) {
+	var user models.User
+
+	err := repo.db.Where("email = ?", email).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (repo *UsersRepositoryImpl) GetByCalendarTokenHash(hash string) (*models.User, error) {
+	var user models.User
+
+	err := repo.db.Where("calendar_token_hash = ?", hash).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (repo *UsersRepositoryImpl) Update(user models.User) error {
+	return repo.db.Save(&user).Error
+}
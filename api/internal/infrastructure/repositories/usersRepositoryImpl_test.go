@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+// Тест добавления пользователя в БД
+func TestUsersRepositoryImpl_Add(t *testing.T) {
+	db, mock := newMockDb(t)
+	repo := NewUsersRepository(db)
+
+	user := models.NewUser("user@example.com", "hash")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "users"`).
+		WithArgs(user.ID, user.CreatedAt, user.Email, user.PasswordHash, user.TimeZone, user.CalendarTokenHash).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Add(*user)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Тест добавления пользователя с уже занятым email
+func TestUsersRepositoryImpl_Add_EmailTaken(t *testing.T) {
+	db, mock := newMockDb(t)
+	repo := NewUsersRepository(db)
+
+	user := models.NewUser("user@example.com", "hash")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "users"`).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email"})
+	mock.ExpectRollback()
+
+	err := repo.Add(*user)
+	assert.ErrorIs(t, err, interfaces.ErrUserEmailTaken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Тест получения пользователя по email
+func TestUsersRepositoryImpl_GetByEmail(t *testing.T) {
+	type testCase struct {
+		name string
+		user *models.User
+	}
+
+	testCases := []testCase{
+		{
+			name: "Получение существующего пользователя",
+			user: models.NewUser("user@example.com", "hash"),
+		},
+		{
+			name: "Получение несуществующего пользователя",
+			user: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDb(t)
+			repo := NewUsersRepository(db)
+
+			rows := sqlmock.NewRows([]string{"id", "created_at", "email", "password_hash"})
+			if tc.user != nil {
+				rows.AddRow(tc.user.ID, tc.user.CreatedAt, tc.user.Email, tc.user.PasswordHash)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(
+				`SELECT * FROM "users" WHERE email = $1 ORDER BY "users"."id" LIMIT $2`,
+			)).
+				WithArgs("user@example.com", 1).
+				WillReturnRows(rows)
+
+			result, err := repo.GetByEmail("user@example.com")
+
+			assert.NoError(t, err)
+			if tc.user != nil {
+				assert.NotNil(t, result)
+				assert.Equal(t, tc.user.Email, result.Email)
+			} else {
+				assert.Nil(t, result)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// Тест сохранения профиля пользователя
+func TestUsersRepositoryImpl_Update(t *testing.T) {
+	db, mock := newMockDb(t)
+	repo := NewUsersRepository(db)
+
+	user := models.NewUser("user@example.com", "hash")
+	user.TimeZone = utils.Ptr("Asia/Novosibirsk")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE "users" SET "created_at"=$1,"email"=$2,"password_hash"=$3,"time_zone"=$4,"calendar_token_hash"=$5 `+
+			`WHERE "id" = $6`,
+	)).
+		WithArgs(user.CreatedAt, user.Email, user.PasswordHash, user.TimeZone, user.CalendarTokenHash, user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Update(*user)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Тест поиска владельца ленты календаря по хешу токена
+func TestUsersRepositoryImpl_GetByCalendarTokenHash(t *testing.T) {
+	db, mock := newMockDb(t)
+	repo := NewUsersRepository(db)
+
+	user := models.NewUser("user@example.com", "hash")
+	user.CalendarTokenHash = utils.Ptr("token-hash")
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT * FROM "users" WHERE calendar_token_hash = $1 ORDER BY "users"."id" LIMIT $2`,
+	)).
+		WithArgs("token-hash", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "calendar_token_hash"}).
+			AddRow(user.ID, user.Email, *user.CalendarTokenHash))
+	mock.ExpectQuery(`SELECT \* FROM "users" WHERE calendar_token_hash`).
+		WithArgs("unknown", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	result, err := repo.GetByCalendarTokenHash("token-hash")
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, result.ID)
+
+	result, err = repo.GetByCalendarTokenHash("unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type WebhookDeliveriesRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveriesRepository(db *gorm.DB) interfaces.WebhookDeliveriesRepository {
+	return &WebhookDeliveriesRepositoryImpl{db: db}
+}
+
+func (repo *WebhookDeliveriesRepositoryImpl) Add(delivery models.WebhookDelivery) error {
+	return repo.db.Create(&delivery).Error
+}
+
+func (repo *WebhookDeliveriesRepositoryImpl) GetByID(id uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+
+	err := repo.db.Where("id = ?", id).First(&delivery).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (repo *WebhookDeliveriesRepositoryImpl) GetDue(now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+
+	err := repo.db.Where("status = ? AND next_attempt_at <= ?", enums.DeliveryPending, now).
+		Order("next_attempt_at, id").Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (repo *WebhookDeliveriesRepositoryImpl) GetByWebhookIDs(webhookIDs []uuid.UUID,
+	status enums.WebhookDeliveryStatus) ([]*models.WebhookDelivery, error) {
+	deliveries := make([]*models.WebhookDelivery, 0)
+	if len(webhookIDs) == 0 {
+		return deliveries, nil
+	}
+
+	err := repo.db.Where("webhook_id IN ? AND status = ?", webhookIDs, status).
+		Order("created_at DESC, id").Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (repo *WebhookDeliveriesRepositoryImpl) Update(delivery models.WebhookDelivery) error {
+	return repo.db.Save(&delivery).Error
+}
+
+func (repo *WebhookDeliveriesRepositoryImpl) DeleteByWebhookID(webhookID uuid.UUID) error {
+	return repo.db.Where("webhook_id = ?", webhookID).Delete(&models.WebhookDelivery{}).Error
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WebhooksRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWebhooksRepository(db *gorm.DB) interfaces.WebhooksRepository {
+	return &WebhooksRepositoryImpl{db: db}
+}
+
+func (repo *WebhooksRepositoryImpl) Add(webhook models.Webhook) error {
+	return repo.db.Create(&webhook).Error
+}
+
+func (repo *WebhooksRepositoryImpl) GetByID(id uuid.UUID) (*models.Webhook, error) {
+	var webhook models.Webhook
+
+	err := repo.db.Where("id = ?", id).First(&webhook).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (repo *WebhooksRepositoryImpl) GetByOwnerID(ownerID uuid.UUID) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+
+	if err := repo.db.Where("owner_id = ?", ownerID).Order("created_at, id").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (repo *WebhooksRepositoryImpl) Update(webhook models.Webhook) error {
+	return repo.db.Save(&webhook).Error
+}
+
+func (repo *WebhooksRepositoryImpl) DeleteByID(id uuid.UUID) error {
+	return repo.db.Where("id = ?", id).Delete(&models.Webhook{}).Error
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	dbConn "HITS_ToDoList_Tests/internal/infrastructure/db"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Тест одинакового поведения in-memory и SQL-репозиториев подписок и их отправок
+func TestWebhookRepositories(t *testing.T) {
+	db, err := dbConn.NewSQLiteConnection(":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, dbConn.Migrate(db))
+
+	repos := map[string]struct {
+		webhooks   interfaces.WebhooksRepository
+		deliveries interfaces.WebhookDeliveriesRepository
+	}{
+		"memory": {NewMemoryWebhooksRepository(), NewMemoryWebhookDeliveriesRepository()},
+		"sqlite": {NewWebhooksRepository(db), NewWebhookDeliveriesRepository(db)},
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			ownerID := uuid.New()
+			start := time.Now().UTC().Truncate(time.Second)
+
+			first := models.NewWebhook(ownerID, "https://example.com/first", "0123456789abcdef",
+				[]enums.TaskChangeType{enums.TaskChangeOverdue}, []enums.Priority{enums.Critical})
+			first.CreatedAt = start
+			second := models.NewWebhook(ownerID, "https://example.com/second", "0123456789abcdef", nil, nil)
+			second.CreatedAt = start.Add(time.Minute)
+			for _, webhook := range []*models.Webhook{second, first} {
+				assert.NoError(t, repo.webhooks.Add(*webhook))
+			}
+			foreign := models.NewWebhook(uuid.New(), "https://example.com", "0123456789abcdef", nil, nil)
+			assert.NoError(t, repo.webhooks.Add(*foreign))
+
+			webhooks, err := repo.webhooks.GetByOwnerID(ownerID)
+			assert.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{first.ID, second.ID}, []uuid.UUID{webhooks[0].ID, webhooks[1].ID})
+			assert.Equal(t, first.EventTypes, webhooks[0].EventTypes)
+			assert.Equal(t, first.Priorities, webhooks[0].Priorities)
+			assert.Empty(t, webhooks[1].EventTypes)
+
+			second.URL = "https://example.com/changed"
+			second.Priorities = []enums.Priority{enums.High, enums.Critical}
+			assert.NoError(t, repo.webhooks.Update(*second))
+			stored, err := repo.webhooks.GetByID(second.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, "https://example.com/changed", stored.URL)
+			assert.Equal(t, second.Priorities, stored.Priorities)
+
+			deliveries := make([]*models.WebhookDelivery, 3)
+			for i := range deliveries {
+				deliveries[i] = models.NewWebhookDelivery(first.ID, enums.TaskChangeOverdue, `{"event":"overdue"}`)
+				deliveries[i].CreatedAt = start.Add(time.Duration(i) * time.Minute)
+				deliveries[i].NextAttemptAt = start.Add(time.Duration(i) * time.Minute)
+				assert.NoError(t, repo.deliveries.Add(*deliveries[i]))
+			}
+			other := models.NewWebhookDelivery(second.ID, enums.TaskChangeCreated, `{}`)
+			other.NextAttemptAt = start.Add(time.Hour)
+			assert.NoError(t, repo.deliveries.Add(*other))
+
+			due, err := repo.deliveries.GetDue(start.Add(time.Minute), 10)
+			assert.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{deliveries[0].ID, deliveries[1].ID}, []uuid.UUID{due[0].ID, due[1].ID})
+			due, err = repo.deliveries.GetDue(start.Add(time.Hour), 1)
+			assert.NoError(t, err)
+			assert.Len(t, due, 1)
+
+			for _, delivery := range deliveries[:2] {
+				delivery.Status = enums.DeliveryFailed
+				delivery.Attempts = 8
+				delivery.LastError = utils.Ptr("webhook responded with status 500")
+				assert.NoError(t, repo.deliveries.Update(*delivery))
+			}
+
+			failed, err := repo.deliveries.GetByWebhookIDs([]uuid.UUID{first.ID, second.ID}, enums.DeliveryFailed)
+			assert.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{deliveries[1].ID, deliveries[0].ID}, []uuid.UUID{failed[0].ID, failed[1].ID})
+			assert.Equal(t, 8, failed[0].Attempts)
+			assert.Equal(t, `{"event":"overdue"}`, failed[0].Payload)
+			failed, err = repo.deliveries.GetByWebhookIDs(nil, enums.DeliveryFailed)
+			assert.NoError(t, err)
+			assert.Empty(t, failed)
+
+			assert.NoError(t, repo.deliveries.DeleteByWebhookID(first.ID))
+			assert.NoError(t, repo.webhooks.DeleteByID(first.ID))
+			deleted, err := repo.deliveries.GetByID(deliveries[0].ID)
+			assert.NoError(t, err)
+			assert.Nil(t, deleted)
+			kept, err := repo.deliveries.GetByID(other.ID)
+			assert.NoError(t, err)
+			assert.NotNil(t, kept)
+			webhooks, err = repo.webhooks.GetByOwnerID(ownerID)
+			assert.NoError(t, err)
+			assert.Len(t, webhooks, 1)
+		})
+	}
+}
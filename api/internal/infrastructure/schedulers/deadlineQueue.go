@@ -0,0 +1,143 @@
+package schedulers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"container/heap"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// DeadlineQueue — min-heap дедлайнов активных задач. Планировщик спит до вершины кучи,
+// а изменение вершины будит его через канал Changed.
+//
+// Очередь хранит только дедлайны ближе horizon: более далёкие планировщик добирает из хранилища
+// при периодической синхронизации, поэтому horizon не должен быть меньше её интервала.
+type DeadlineQueue struct {
+	mu      sync.Mutex
+	heap    deadlineHeap
+	entries map[uuid.UUID]*deadlineEntry
+	changed chan struct{}
+	horizon time.Duration
+}
+
+type deadlineEntry struct {
+	taskID   uuid.UUID
+	deadline time.Time
+	index    int
+}
+
+func NewDeadlineQueue(horizon time.Duration) *DeadlineQueue {
+	return &DeadlineQueue{
+		entries: map[uuid.UUID]*deadlineEntry{},
+		changed: make(chan struct{}, 1),
+		horizon: horizon,
+	}
+}
+
+var _ interfaces.DeadlineTracker = (*DeadlineQueue)(nil)
+
+// Дедлайн дальше горизонта снимает задачу с отслеживания: раньше следующей синхронизации он не наступит
+func (q *DeadlineQueue) Track(taskID uuid.UUID, deadline time.Time) {
+	if deadline.After(time.Now().Add(q.horizon)) {
+		q.Untrack(taskID)
+		return
+	}
+
+	q.mu.Lock()
+	if entry, ok := q.entries[taskID]; ok {
+		entry.deadline = deadline
+		heap.Fix(&q.heap, entry.index)
+	} else {
+		entry = &deadlineEntry{taskID: taskID, deadline: deadline}
+		q.entries[taskID] = entry
+		heap.Push(&q.heap, entry)
+	}
+	isFirst := q.heap[0].taskID == taskID
+	q.mu.Unlock()
+
+	if isFirst {
+		q.notify()
+	}
+}
+
+func (q *DeadlineQueue) Untrack(taskID uuid.UUID) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if entry, ok := q.entries[taskID]; ok {
+		heap.Remove(&q.heap, entry.index)
+		delete(q.entries, taskID)
+	}
+}
+
+// Next возвращает ближайший отслеживаемый дедлайн
+func (q *DeadlineQueue) Next() (time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.heap) == 0 {
+		return time.Time{}, false
+	}
+	return q.heap[0].deadline, true
+}
+
+// RemoveDue снимает с отслеживания задачи с дедлайном раньше now
+func (q *DeadlineQueue) RemoveDue(now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.heap) > 0 && q.heap[0].deadline.Before(now) {
+		entry := heap.Pop(&q.heap).(*deadlineEntry)
+		delete(q.entries, entry.taskID)
+	}
+}
+
+func (q *DeadlineQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.heap)
+}
+
+// Changed сигнализирует, что ближайший дедлайн стал раньше
+func (q *DeadlineQueue) Changed() <-chan struct{} {
+	return q.changed
+}
+
+func (q *DeadlineQueue) notify() {
+	select {
+	case q.changed <- struct{}{}:
+	default:
+	}
+}
+
+type deadlineHeap []*deadlineEntry
+
+func (h deadlineHeap) Len() int {
+	return len(h)
+}
+
+func (h deadlineHeap) Less(i, j int) bool {
+	return h[i].deadline.Before(h[j].deadline)
+}
+
+func (h deadlineHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *deadlineHeap) Push(x any) {
+	entry := x.(*deadlineEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *deadlineHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
@@ -0,0 +1,77 @@
+package schedulers
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Тест порядка дедлайнов в очереди
+func TestDeadlineQueue(t *testing.T) {
+	queue := NewDeadlineQueue(24 * time.Hour)
+	now := time.Now()
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+	_, ok := queue.Next()
+	assert.False(t, ok)
+
+	queue.Track(second, now.Add(2*time.Hour))
+	queue.Track(third, now.Add(3*time.Hour))
+	queue.Track(first, now.Add(time.Hour))
+
+	next, ok := queue.Next()
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(time.Hour), next)
+
+	// Повторное отслеживание переносит дедлайн, а не добавляет новый
+	queue.Track(third, now.Add(time.Minute))
+	next, _ = queue.Next()
+	assert.Equal(t, now.Add(time.Minute), next)
+	assert.Equal(t, 3, queue.Len())
+
+	queue.Untrack(third)
+	queue.Untrack(uuid.New())
+	next, _ = queue.Next()
+	assert.Equal(t, now.Add(time.Hour), next)
+
+	queue.RemoveDue(now.Add(90 * time.Minute))
+	next, _ = queue.Next()
+	assert.Equal(t, now.Add(2*time.Hour), next)
+	assert.Equal(t, 1, queue.Len())
+}
+
+// Тест горизонта очереди: далёкие дедлайны не отслеживаются
+func TestDeadlineQueue_Horizon(t *testing.T) {
+	queue := NewDeadlineQueue(time.Hour)
+	now := time.Now()
+	near, far := uuid.New(), uuid.New()
+
+	queue.Track(near, now.Add(time.Minute))
+	queue.Track(far, now.AddDate(1, 0, 0))
+	assert.Equal(t, 1, queue.Len())
+
+	// Перенос дедлайна за горизонт снимает задачу с отслеживания
+	queue.Track(near, now.Add(2*time.Hour))
+	assert.Equal(t, 0, queue.Len())
+	_, ok := queue.Next()
+	assert.False(t, ok)
+}
+
+// Тест сигнала о новом ближайшем дедлайне
+func TestDeadlineQueue_Changed(t *testing.T) {
+	queue := NewDeadlineQueue(time.Hour)
+	now := time.Now()
+
+	queue.Track(uuid.New(), now.Add(time.Hour))
+	assert.Len(t, queue.Changed(), 1)
+	<-queue.Changed()
+
+	// Более поздний дедлайн не меняет вершину и не будит планировщик
+	queue.Track(uuid.New(), now.Add(2*time.Hour))
+	assert.Len(t, queue.Changed(), 0)
+
+	queue.Track(uuid.New(), now.Add(time.Minute))
+	queue.Track(uuid.New(), now.Add(time.Second))
+	assert.Len(t, queue.Changed(), 1)
+}
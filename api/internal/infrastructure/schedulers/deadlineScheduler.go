@@ -2,14 +2,91 @@ package schedulers
 
 import (
 	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"context"
 	"time"
 )
 
-func StartTasksDeadlineScheduling(service interfaces.TasksService, interval time.Duration) {
-	ticker := time.NewTicker(interval)
+// StartTasksDeadlineScheduling запускает планировщик, который просыпается к ближайшему дедлайну из queue
+// и одним запросом переводит просроченные задачи в Overdue. Раз в resyncInterval очередь пополняется
+// дедлайнами из хранилища, наступающими до следующей синхронизации: так учитываются задачи, изменённые
+// в обход сервиса, а очередь с горизонтом не меньше resyncInterval не разрастается дедлайнами
+// из далёкого будущего.
+//
+// Так же планировщик просыпается к ближайшему напоминанию из reminderQueue и отправляет наступившие
+// напоминания через reminders. reminders может быть nil — тогда напоминания не отправляются.
+//
+// Планировщик работает до отмены ctx или вызова возвращённой функции остановки. Функция остановки
+// дожидается завершения текущего прохода, её можно вызывать повторно.
+func StartTasksDeadlineScheduling(ctx context.Context, service interfaces.TasksService, queue *DeadlineQueue,
+	reminders interfaces.RemindersService, reminderQueue *DeadlineQueue, resyncInterval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	if reminders == nil {
+		reminderQueue = NewDeadlineQueue(resyncInterval)
+	}
+
+	resync := func() {
+		service.UpdateTaskStatuses()
+		service.TrackActiveDeadlines(time.Now().Add(resyncInterval))
+		if reminders != nil {
+			// Напоминания, время которых наступило, пока сервер не работал, отправляются сразу
+			reminders.SendDueReminders(time.Now())
+			reminders.TrackReminders(time.Now().Add(resyncInterval))
+		}
+	}
+
 	go func() {
-		for range ticker.C {
-			service.UpdateTaskStatuses()
+		defer close(done)
+
+		resync()
+
+		resyncTicker := time.NewTicker(resyncInterval)
+		defer resyncTicker.Stop()
+		timer := time.NewTimer(resyncInterval)
+		timer.Stop()
+
+		for {
+			if next, ok := nextWakeUp(queue, reminderQueue); ok {
+				timer.Reset(time.Until(next))
+			} else {
+				timer.Stop()
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				// Время фиксируется до запроса: всё, что раньше него, запрос гарантированно обработает
+				now := time.Now()
+				if next, ok := queue.Next(); ok && !next.After(now) {
+					service.UpdateTaskStatuses()
+					queue.RemoveDue(now)
+				}
+				if next, ok := reminderQueue.Next(); ok && !next.After(now) {
+					// Следующие напоминания задач сервис поставит в очередь заново при отправке
+					reminderQueue.RemoveDue(now)
+					reminders.SendDueReminders(now)
+				}
+			case <-queue.Changed():
+			case <-reminderQueue.Changed():
+			case <-resyncTicker.C:
+				resync()
+			}
 		}
 	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func nextWakeUp(queue *DeadlineQueue, reminderQueue *DeadlineQueue) (time.Time, bool) {
+	next, ok := queue.Next()
+	if nextReminder, hasReminder := reminderQueue.Next(); hasReminder && (!ok || nextReminder.Before(next)) {
+		return nextReminder, true
+	}
+
+	return next, ok
 }
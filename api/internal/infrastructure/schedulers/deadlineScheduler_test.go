@@ -0,0 +1,188 @@
+package schedulers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/services"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/infrastructure/repositories"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Тест перевода задачи в Overdue в момент дедлайна, без ожидания периодической синхронизации
+func TestStartTasksDeadlineScheduling(t *testing.T) {
+	repo := repositories.NewMemoryTasksRepository()
+	queue := NewDeadlineQueue(time.Hour)
+	service := services.NewTasksService(repo, repositories.NewMemoryChecklistItemsRepository(),
+		repositories.NewMemoryTagsRepository(), repositories.NewMemoryProjectsRepository(),
+		repositories.NewMemoryTaskEventsRepository(), nil, queue, nil)
+	userID := uuid.New()
+
+	// Задача, просроченная до запуска планировщика
+	stale := models.NewTask("stale", nil, utils.Ptr(time.Now().Add(-time.Hour)), nil, nil)
+	assert.NoError(t, repo.Add(*stale))
+
+	stop := StartTasksDeadlineScheduling(context.Background(), service, queue, nil, nil, time.Hour)
+	defer stop()
+
+	assert.Eventually(t, func() bool {
+		task, _ := repo.GetByID(stale.ID)
+		return task.Status == enums.Overdue
+	}, time.Second, 10*time.Millisecond)
+
+	soon, err := service.CreateTask(userID, "Скоро дедлайн", nil, utils.Ptr(time.Now().Add(200*time.Millisecond)), nil,
+		nil, nil, nil, nil)
+	assert.NoError(t, err)
+	later, err := service.CreateTask(userID, "Дедлайн позже", nil, utils.Ptr(time.Now().Add(time.Hour)), nil, nil,
+		nil, nil, nil)
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		task, _ := repo.GetByID(soon.ID)
+		return task.Status == enums.Overdue
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// Дедлайн дальше горизонта в очередь не попадает
+	_, err = service.CreateTask(userID, "Дедлайн через неделю", nil, utils.Ptr(time.Now().AddDate(0, 0, 7)), nil,
+		nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	task, _ := repo.GetByID(later.ID)
+	assert.Equal(t, enums.Active, task.Status)
+	assert.Equal(t, 1, queue.Len())
+}
+
+// Тест остановки планировщика
+func TestStartTasksDeadlineScheduling_Stop(t *testing.T) {
+	repo := repositories.NewMemoryTasksRepository()
+	queue := NewDeadlineQueue(time.Hour)
+	service := services.NewTasksService(repo, repositories.NewMemoryChecklistItemsRepository(),
+		repositories.NewMemoryTagsRepository(), repositories.NewMemoryProjectsRepository(),
+		repositories.NewMemoryTaskEventsRepository(), nil, queue, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stop := StartTasksDeadlineScheduling(ctx, service, queue, nil, nil, time.Hour)
+	cancel()
+	stop()
+	// Повторная остановка не блокируется
+	stop()
+
+	task, err := service.CreateTask(uuid.New(), "После остановки", nil, utils.Ptr(time.Now().Add(50*time.Millisecond)),
+		nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	time.Sleep(200 * time.Millisecond)
+	stored, _ := repo.GetByID(task.ID)
+	assert.Equal(t, enums.Active, stored.Status)
+}
+
+// Тест создания следующей задачи серии, когда планировщик просрочил повторяющуюся задачу
+func TestStartTasksDeadlineScheduling_Recurring(t *testing.T) {
+	repo := repositories.NewMemoryTasksRepository()
+	queue := NewDeadlineQueue(time.Hour)
+	service := services.NewTasksService(repo, repositories.NewMemoryChecklistItemsRepository(),
+		repositories.NewMemoryTagsRepository(), repositories.NewMemoryProjectsRepository(),
+		repositories.NewMemoryTaskEventsRepository(), nil, queue, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stop := StartTasksDeadlineScheduling(ctx, service, queue, nil, nil, time.Hour)
+	defer stop()
+
+	deadline := time.Now().Add(100 * time.Millisecond)
+	task, err := service.CreateTask(uuid.New(), "Вынести мусор", nil, &deadline, nil, utils.Ptr("FREQ=DAILY"), nil,
+		nil, nil)
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		stored, _ := repo.GetByID(task.ID)
+		return stored.Status == enums.Overdue && stored.NextOccurrenceID != nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	stored, _ := repo.GetByID(task.ID)
+	next, _ := repo.GetByID(*stored.NextOccurrenceID)
+	assert.Equal(t, enums.Active, next.Status)
+	assert.True(t, deadline.AddDate(0, 0, 1).Equal(*next.Deadline))
+	assert.Equal(t, "FREQ=DAILY", *next.Recurrence)
+	// Дедлайн следующей задачи через сутки, дальше горизонта очереди: его добавит синхронизация
+	assert.Equal(t, 0, queue.Len())
+}
+
+// Канал уведомлений, который запоминает напоминания планировщика
+type recordingNotifier struct {
+	mu            sync.Mutex
+	notifications []models.Notification
+}
+
+func (notifier *recordingNotifier) Notify(notification models.Notification) error {
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	notifier.notifications = append(notifier.notifications, notification)
+	return nil
+}
+
+func (notifier *recordingNotifier) received() []models.Notification {
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	return append([]models.Notification(nil), notifier.notifications...)
+}
+
+// Тест отправки напоминаний в их время и без повторов после перезапуска планировщика
+func TestStartTasksDeadlineScheduling_Reminders(t *testing.T) {
+	tasksRepo := repositories.NewMemoryTasksRepository()
+	usersRepo := repositories.NewMemoryUsersRepository()
+	remindersRepo := repositories.NewMemoryTaskRemindersRepository()
+	queue := NewDeadlineQueue(time.Hour)
+	reminderQueue := NewDeadlineQueue(time.Hour)
+	notifier := &recordingNotifier{}
+	reminders := services.NewRemindersService(tasksRepo, usersRepo, remindersRepo, notifier, reminderQueue)
+	service := services.NewTasksService(tasksRepo, repositories.NewMemoryChecklistItemsRepository(),
+		repositories.NewMemoryTagsRepository(), repositories.NewMemoryProjectsRepository(),
+		repositories.NewMemoryTaskEventsRepository(), nil, queue, reminders)
+
+	user := models.NewUser("user@example.com", "hash")
+	assert.NoError(t, usersRepo.Add(*user))
+
+	// Напоминание, время которого наступило, пока планировщик не работал
+	missed := models.NewTask("Пропущенное", nil, utils.Ptr(time.Now().Add(time.Hour)), nil, nil)
+	missed.OwnerID = user.ID
+	assert.NoError(t, tasksRepo.Add(*missed))
+	missedReminder := models.NewTaskReminder(missed.ID, 90)
+	missedReminder.Schedule(missed, time.Now().Add(-time.Hour))
+	assert.NoError(t, remindersRepo.ReplaceForTask(missed.ID, []models.TaskReminder{*missedReminder}))
+
+	stop := StartTasksDeadlineScheduling(context.Background(), service, queue, reminders, reminderQueue, time.Hour)
+
+	assert.Eventually(t, func() bool {
+		return len(notifier.received()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, missed.ID, notifier.received()[0].Task.ID)
+	assert.Equal(t, user.Email, notifier.received()[0].Email)
+
+	deadline := time.Now().Add(time.Minute + 200*time.Millisecond)
+	task, err := service.CreateTask(user.ID, "Скоро напоминание", nil, &deadline, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	_, err = reminders.SetReminders(user.ID, task.ID, []int{60, 1})
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return len(notifier.received()) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	notification := notifier.received()[1]
+	assert.Equal(t, task.ID, notification.Task.ID)
+	assert.InDelta(t, time.Minute, notification.Left, float64(100*time.Millisecond))
+
+	// После перезапуска отправленные напоминания не повторяются
+	stop()
+	stop = StartTasksDeadlineScheduling(context.Background(), service, queue, reminders, reminderQueue, time.Hour)
+	defer stop()
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Len(t, notifier.received(), 2)
+	assert.Equal(t, 0, reminderQueue.Len())
+}
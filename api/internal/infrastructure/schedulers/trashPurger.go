@@ -0,0 +1,42 @@
+package schedulers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"context"
+	"time"
+)
+
+// StartTrashPurging запускает фоновое удаление задач, пролежавших в корзине дольше retention:
+// сразу при запуске и затем раз в interval. Остановка — как у StartTasksDeadlineScheduling.
+func StartTrashPurging(ctx context.Context, service interfaces.TasksService, retention time.Duration,
+	interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	purge := func() {
+		service.PurgeDeletedTasks(time.Now().Add(-retention))
+	}
+
+	go func() {
+		defer close(done)
+
+		purge()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				purge()
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
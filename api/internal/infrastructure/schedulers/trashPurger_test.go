@@ -0,0 +1,90 @@
+package schedulers
+
+import (
+	appEnums "HITS_ToDoList_Tests/internal/application/enums"
+	"HITS_ToDoList_Tests/internal/application/services"
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/infrastructure/repositories"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Тест окончательного удаления задач, пролежавших в корзине дольше срока хранения
+func TestStartTrashPurging(t *testing.T) {
+	repo := repositories.NewMemoryTasksRepository()
+	service := services.NewTasksService(repo, repositories.NewMemoryChecklistItemsRepository(),
+		repositories.NewMemoryTagsRepository(), repositories.NewMemoryProjectsRepository(),
+		repositories.NewMemoryTaskEventsRepository(), nil, NewDeadlineQueue(time.Hour), nil)
+
+	expired := models.NewTask("Давно удалена", nil, nil, nil, nil)
+	expired.DeletedAt = utils.Ptr(time.Now().Add(-2 * time.Hour))
+	recent := models.NewTask("Удалена недавно", nil, nil, nil, nil)
+	recent.DeletedAt = utils.Ptr(time.Now().Add(-time.Minute))
+	active := models.NewTask("Не удалена", nil, nil, nil, nil)
+	for _, task := range []*models.Task{expired, recent, active} {
+		assert.NoError(t, repo.Add(*task))
+	}
+
+	stop := StartTrashPurging(context.Background(), service, time.Hour, time.Hour)
+	defer stop()
+
+	assert.Eventually(t, func() bool {
+		trash, _ := repo.GetAll(&models.TasksFilter{Deleted: true}, nil)
+		return len(trash) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	trash, _ := repo.GetAll(&models.TasksFilter{Deleted: true}, nil)
+	assert.Equal(t, recent.ID, trash[0].ID)
+	stored, _ := repo.GetByID(active.ID)
+	assert.NotNil(t, stored)
+}
+
+// Репозиторий, вызывающий afterList сразу после выборки задач корзины на удаление
+type listHookTasksRepository struct {
+	interfaces.TasksRepository
+	afterList func()
+}
+
+func (repo *listHookTasksRepository) GetAll(filter *models.TasksFilter, sorting *appEnums.Sorting) ([]*models.Task,
+	error) {
+	tasks, err := repo.TasksRepository.GetAll(filter, sorting)
+	if filter != nil && filter.DeletedBefore != nil && repo.afterList != nil {
+		repo.afterList()
+		repo.afterList = nil
+	}
+	return tasks, err
+}
+
+// Тест восстановления задачи между выборкой корзины и её очисткой: задача остаётся вместе с чек-листом
+func TestPurgeDeletedTasks_RestoredAfterListing(t *testing.T) {
+	repo := &listHookTasksRepository{TasksRepository: repositories.NewMemoryTasksRepository()}
+	itemsRepo := repositories.NewMemoryChecklistItemsRepository()
+	service := services.NewTasksService(repo, itemsRepo, repositories.NewMemoryTagsRepository(),
+		repositories.NewMemoryProjectsRepository(), repositories.NewMemoryTaskEventsRepository(), nil, nil, nil)
+
+	userID := uuid.New()
+	task := models.NewTask("Давно удалена", nil, nil, nil, nil)
+	task.OwnerID = userID
+	task.DeletedAt = utils.Ptr(time.Now().Add(-2 * time.Hour))
+	assert.NoError(t, repo.Add(*task))
+	item := models.NewChecklistItem(task.ID, "Пункт", 0)
+	assert.NoError(t, itemsRepo.Add(*item))
+
+	repo.afterList = func() {
+		_, err := service.RestoreTask(userID, task.ID)
+		assert.NoError(t, err)
+	}
+	service.PurgeDeletedTasks(time.Now().Add(-time.Hour))
+
+	stored, err := repo.GetByID(task.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, stored)
+	items, err := itemsRepo.GetByTaskID(task.ID)
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+}
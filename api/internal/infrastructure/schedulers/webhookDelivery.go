@@ -0,0 +1,38 @@
+package schedulers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"context"
+	"time"
+)
+
+// StartWebhookDelivery запускает фоновую отправку изменений задач подписчикам: очередь проверяется
+// сразу при запуске и затем раз в interval. Остановка — как у StartTasksDeadlineScheduling.
+func StartWebhookDelivery(ctx context.Context, service interfaces.WebhooksService,
+	interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		service.DeliverPending(time.Now())
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				service.DeliverPending(time.Now())
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
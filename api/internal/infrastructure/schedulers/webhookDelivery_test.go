@@ -0,0 +1,53 @@
+package schedulers
+
+import (
+	"HITS_ToDoList_Tests/internal/application/services"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/infrastructure/repositories"
+	"HITS_ToDoList_Tests/internal/infrastructure/webhooks"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Тест фоновой отправки: первая попытка получает ошибку, повтор доходит до получателя
+func TestStartWebhookDelivery(t *testing.T) {
+	var requests atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer receiver.Close()
+
+	webhooksRepo := repositories.NewMemoryWebhooksRepository()
+	deliveriesRepo := repositories.NewMemoryWebhookDeliveriesRepository()
+	service := services.NewWebhooksService(webhooksRepo, deliveriesRepo, webhooks.NewHTTPWebhookSender(time.Second, true),
+		3, 10*time.Millisecond, true)
+
+	userID := uuid.New()
+	_, err := service.CreateWebhook(userID, receiver.URL, nil, nil, nil)
+	assert.NoError(t, err)
+	task := models.NewTask("Отчёт", nil, nil, nil, nil)
+	task.OwnerID = userID
+	service.Publish(models.TaskChange{Type: enums.TaskChangeCreated, Task: *task, OccurredAt: time.Now()})
+
+	stop := StartWebhookDelivery(context.Background(), service, 10*time.Millisecond)
+	defer stop()
+
+	assert.Eventually(t, func() bool {
+		return requests.Load() == 2
+	}, time.Second, 10*time.Millisecond)
+	stop()
+
+	due, _ := deliveriesRepo.GetDue(time.Now().Add(time.Hour), 10)
+	assert.Empty(t, due)
+	dead, _ := service.GetDeadLetters(userID)
+	assert.Empty(t, dead)
+}
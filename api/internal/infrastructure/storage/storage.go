@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"HITS_ToDoList_Tests/internal/config"
+	"HITS_ToDoList_Tests/internal/domain/interfaces"
+	"HITS_ToDoList_Tests/internal/infrastructure/db"
+	"HITS_ToDoList_Tests/internal/infrastructure/repositories"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// Storage — набор репозиториев выбранного при запуске хранилища
+type Storage struct {
+	Users          interfaces.UsersRepository
+	Tasks          interfaces.TasksRepository
+	ChecklistItems interfaces.ChecklistItemsRepository
+	Tags           interfaces.TagsRepository
+	Projects       interfaces.ProjectsRepository
+	TaskEvents     interfaces.TaskEventsRepository
+	// Подписки, их отправки и напоминания не входят в транзакции задач: они обновляются по изменениям
+	// задач после фиксации
+	Webhooks          interfaces.WebhooksRepository
+	WebhookDeliveries interfaces.WebhookDeliveriesRepository
+	TaskReminders     interfaces.TaskRemindersRepository
+	// UnitOfWork выполняет изменения задач и связанных с ними данных в одной транзакции
+	UnitOfWork interfaces.UnitOfWork
+
+	db *gorm.DB
+}
+
+// Open подключает хранилище. Для SQL-драйверов миграции применяются при cfg.AutoMigrate,
+// иначе схему нужно обновить заранее командой migrate.
+func Open(cfg config.DatabaseConfig) (*Storage, error) {
+	if cfg.Driver == config.DriverMemory {
+		repos := interfaces.Repositories{
+			Tasks:          repositories.NewMemoryTasksRepository(),
+			ChecklistItems: repositories.NewMemoryChecklistItemsRepository(),
+			Tags:           repositories.NewMemoryTagsRepository(),
+			Projects:       repositories.NewMemoryProjectsRepository(),
+			TaskEvents:     repositories.NewMemoryTaskEventsRepository(),
+		}
+		return &Storage{
+			Users:             repositories.NewMemoryUsersRepository(),
+			Tasks:             repos.Tasks,
+			ChecklistItems:    repos.ChecklistItems,
+			Tags:              repos.Tags,
+			Projects:          repos.Projects,
+			TaskEvents:        repos.TaskEvents,
+			Webhooks:          repositories.NewMemoryWebhooksRepository(),
+			WebhookDeliveries: repositories.NewMemoryWebhookDeliveriesRepository(),
+			TaskReminders:     repositories.NewMemoryTaskRemindersRepository(),
+			UnitOfWork:        repositories.NewMemoryUnitOfWork(repos),
+		}, nil
+	}
+
+	dbConn, err := Connect(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.AutoMigrate {
+		if err := db.Migrate(dbConn); err != nil {
+			closeDB(dbConn)
+			return nil, fmt.Errorf("migrate db: %w", err)
+		}
+	}
+
+	return &Storage{
+		Users:             repositories.NewUsersRepository(dbConn),
+		Tasks:             repositories.NewTasksRepository(dbConn),
+		ChecklistItems:    repositories.NewChecklistItemsRepository(dbConn),
+		Tags:              repositories.NewTagsRepository(dbConn),
+		Projects:          repositories.NewProjectsRepository(dbConn),
+		TaskEvents:        repositories.NewTaskEventsRepository(dbConn),
+		Webhooks:          repositories.NewWebhooksRepository(dbConn),
+		WebhookDeliveries: repositories.NewWebhookDeliveriesRepository(dbConn),
+		TaskReminders:     repositories.NewTaskRemindersRepository(dbConn),
+		UnitOfWork:        repositories.NewUnitOfWork(dbConn),
+		db:                dbConn,
+	}, nil
+}
+
+// Connect открывает соединение с SQL-хранилищем без применения миграций
+func Connect(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	switch cfg.Driver {
+	case config.DriverPostgres:
+		dbConn, err := db.NewPostgresConnection(cfg.Host, cfg.User, cfg.Password, cfg.Name, cfg.Port)
+		if err != nil {
+			return nil, fmt.Errorf("connect to postgres: %w", err)
+		}
+		return dbConn, nil
+	case config.DriverSQLite:
+		dbConn, err := db.NewSQLiteConnection(cfg.Path)
+		if err != nil {
+			return nil, fmt.Errorf("open sqlite database %s: %w", cfg.Path, err)
+		}
+		return dbConn, nil
+	case config.DriverMemory:
+		return nil, fmt.Errorf("storage driver %q has no SQL database", cfg.Driver)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", cfg.Driver)
+	}
+}
+
+func (s *Storage) Close() error {
+	if s.db == nil {
+		return nil
+	}
+
+	return closeDB(s.db)
+}
+
+func closeDB(dbConn *gorm.DB) error {
+	sqlDB, err := dbConn.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"HITS_ToDoList_Tests/internal/config"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+// Тест открытия хранилищ, не требующих сервера БД
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.DatabaseConfig
+	}{
+		{
+			name: "In-memory хранилище",
+			cfg:  config.DatabaseConfig{Driver: config.DriverMemory},
+		},
+		{
+			name: "SQLite-файл",
+			cfg: config.DatabaseConfig{
+				Driver:      config.DriverSQLite,
+				Path:        filepath.Join(t.TempDir(), "todo.db"),
+				AutoMigrate: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := Open(tt.cfg)
+			assert.NoError(t, err)
+			defer store.Close()
+
+			user := models.NewUser("user@example.com", "hash")
+			assert.NoError(t, store.Users.Add(*user))
+
+			task := models.NewTask("Задача", nil, nil, nil, nil)
+			task.OwnerID = user.ID
+			assert.NoError(t, store.Tasks.Add(*task))
+
+			tasks, err := store.Tasks.GetAll(&models.TasksFilter{OwnerID: &user.ID}, nil)
+			assert.NoError(t, err)
+			assert.Len(t, tasks, 1)
+		})
+	}
+}
+
+// Тест сохранения данных SQLite между открытиями
+func TestOpen_SQLitePersistence(t *testing.T) {
+	cfg := config.DatabaseConfig{
+		Driver:      config.DriverSQLite,
+		Path:        filepath.Join(t.TempDir(), "todo.db"),
+		AutoMigrate: true,
+	}
+
+	store, err := Open(cfg)
+	assert.NoError(t, err)
+	user := models.NewUser("user@example.com", "hash")
+	assert.NoError(t, store.Users.Add(*user))
+	assert.NoError(t, store.Close())
+
+	store, err = Open(cfg)
+	assert.NoError(t, err)
+	defer store.Close()
+
+	stored, err := store.Users.GetByEmail("user@example.com")
+	assert.NoError(t, err)
+	assert.NotNil(t, stored)
+}
+
+// Тест неизвестного драйвера
+func TestOpen_UnsupportedDriver(t *testing.T) {
+	store, err := Open(config.DatabaseConfig{Driver: "mongodb"})
+
+	assert.Nil(t, store)
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package webhooks
+
+import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
+	"HITS_ToDoList_Tests/internal/application/validators"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	deliveryIDHeader = "X-Webhook-Id"
+	eventHeader      = "X-Webhook-Event"
+	timestampHeader  = "X-Webhook-Timestamp"
+	signatureHeader  = "X-Webhook-Signature"
+)
+
+// HTTPWebhookSender отправляет тело отправки POST-запросом. Получатель проверяет подпись
+// X-Webhook-Signature: "sha256=" и HMAC-SHA256 строки "<X-Webhook-Timestamp>.<тело>" в hex
+// с ключом подписки; X-Webhook-Id одинаков у всех попыток одной отправки
+type HTTPWebhookSender struct {
+	client *http.Client
+}
+
+var errPrivateAddress = errors.New("webhook address is loopback, private, link-local or unspecified")
+
+// Без allowPrivateNetworks адрес проверяется в момент соединения, уже после разрешения имени,
+// поэтому подменой DNS-записи после создания подписки запрос во внутреннюю сеть не отправить.
+// Перенаправления не выполняются: ответ 3xx считается неудачной попыткой
+func NewHTTPWebhookSender(timeout time.Duration, allowPrivateNetworks bool) *HTTPWebhookSender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateNetworks {
+		dialer.Control = rejectPrivateAddress
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Через прокси проверялся бы адрес прокси, а не получателя
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &HTTPWebhookSender{client: &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+var _ interfaces.WebhookSender = (*HTTPWebhookSender)(nil)
+
+func (sender *HTTPWebhookSender) Send(webhook *models.Webhook, delivery *models.WebhookDelivery) error {
+	request, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(deliveryIDHeader, delivery.ID.String())
+	request.Header.Set(eventHeader, string(delivery.EventType))
+	request.Header.Set(timestampHeader, timestamp)
+	request.Header.Set(signatureHeader, Sign(webhook.Secret, timestamp, []byte(delivery.Payload)))
+
+	response, err := sender.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	// Тело ответа дочитывается, чтобы соединение вернулось в пул
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+func rejectPrivateAddress(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || validators.IsPrivateIP(ip) {
+		return fmt.Errorf("%w: %s", errPrivateAddress, host)
+	}
+
+	return nil
+}
+
+// Sign возвращает значение заголовка X-Webhook-Signature для тела body, отправленного в момент timestamp
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
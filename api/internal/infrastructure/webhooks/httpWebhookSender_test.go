@@ -0,0 +1,133 @@
+package webhooks
+
+import (
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Тест подписи запроса, которую получатель проверяет своим ключом
+func TestHTTPWebhookSender_Send(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name:       "Получатель принял запрос",
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:       "Получатель ответил ошибкой",
+			statusCode: http.StatusServiceUnavailable,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := models.NewWebhook(uuid.New(), "", "0123456789abcdef", nil, nil)
+			delivery := models.NewWebhookDelivery(webhook.ID, enums.TaskChangeOverdue, `{"event":"overdue"}`)
+
+			var received *http.Request
+			var body []byte
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer receiver.Close()
+			webhook.URL = receiver.URL
+
+			err := NewHTTPWebhookSender(time.Second, true).Send(webhook, delivery)
+
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "503")
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, http.MethodPost, received.Method)
+			assert.Equal(t, `{"event":"overdue"}`, string(body))
+			assert.Equal(t, delivery.ID.String(), received.Header.Get("X-Webhook-Id"))
+			assert.Equal(t, "overdue", received.Header.Get("X-Webhook-Event"))
+			timestamp := received.Header.Get("X-Webhook-Timestamp")
+			assert.Equal(t, Sign("0123456789abcdef", timestamp, body), received.Header.Get("X-Webhook-Signature"))
+			assert.NotEqual(t, Sign("another-secret-key", timestamp, body), received.Header.Get("X-Webhook-Signature"))
+		})
+	}
+}
+
+// Тест недоступного получателя
+func TestHTTPWebhookSender_Unreachable(t *testing.T) {
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	webhook := models.NewWebhook(uuid.New(), receiver.URL, "0123456789abcdef", nil, nil)
+	receiver.Close()
+
+	err := NewHTTPWebhookSender(time.Second, true).Send(webhook, models.NewWebhookDelivery(webhook.ID,
+		enums.TaskChangeCreated, `{}`))
+
+	assert.Error(t, err)
+}
+
+// Тест проверки адреса в момент соединения: имя, разрешившееся во внутренний адрес, не пропускается
+func TestHTTPWebhookSender_PrivateAddress(t *testing.T) {
+	var requests atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer receiver.Close()
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{
+			name: "Loopback-адрес",
+			url:  receiver.URL,
+		},
+		{
+			name: "Имя, разрешающееся в loopback-адрес",
+			url:  strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := models.NewWebhook(uuid.New(), tt.url, "0123456789abcdef", nil, nil)
+
+			err := NewHTTPWebhookSender(time.Second, false).Send(webhook, models.NewWebhookDelivery(webhook.ID,
+				enums.TaskChangeCreated, `{}`))
+
+			assert.ErrorIs(t, err, errPrivateAddress)
+			assert.Zero(t, requests.Load())
+		})
+	}
+}
+
+// Тест перенаправления: запрос не повторяется по адресу из Location
+func TestHTTPWebhookSender_Redirect(t *testing.T) {
+	var redirected atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected.Add(1)
+	}))
+	defer target.Close()
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer receiver.Close()
+	webhook := models.NewWebhook(uuid.New(), receiver.URL, "0123456789abcdef", nil, nil)
+
+	err := NewHTTPWebhookSender(time.Second, true).Send(webhook, models.NewWebhookDelivery(webhook.ID,
+		enums.TaskChangeCreated, `{}`))
+
+	assert.ErrorContains(t, err, "307")
+	assert.Zero(t, redirected.Load())
+}
@@ -1,55 +1,160 @@
 package tests
 
 import (
+	"HITS_ToDoList_Tests/internal/application/interfaces"
 	"HITS_ToDoList_Tests/internal/application/services"
 	"HITS_ToDoList_Tests/internal/delivery/DTOs"
 	"HITS_ToDoList_Tests/internal/delivery/handlers"
 	"HITS_ToDoList_Tests/internal/delivery/middleware"
 	"HITS_ToDoList_Tests/internal/delivery/routes"
 	"HITS_ToDoList_Tests/internal/domain/enums"
+	domainInterfaces "HITS_ToDoList_Tests/internal/domain/interfaces"
 	"HITS_ToDoList_Tests/internal/domain/models"
+	dbConn "HITS_ToDoList_Tests/internal/infrastructure/db"
+	"HITS_ToDoList_Tests/internal/infrastructure/events"
+	"HITS_ToDoList_Tests/internal/infrastructure/notifiers"
 	"HITS_ToDoList_Tests/internal/infrastructure/repositories"
+	"HITS_ToDoList_Tests/internal/infrastructure/webhooks"
 	"HITS_ToDoList_Tests/internal/pkg/utils"
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"github.com/gin-gonic/gin"
-	"github.com/glebarez/sqlite"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 )
 
 func setupTestDB(t *testing.T) *gorm.DB {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db, err := dbConn.NewSQLiteConnection(":memory:")
 	assert.NoError(t, err)
 
-	err = db.AutoMigrate(&models.Task{})
+	err = dbConn.Migrate(db)
 	assert.NoError(t, err)
 
 	return db
 }
 
 func setupTestRouter(db *gorm.DB) *gin.Engine {
+	router, _ := setupTestRouterWithWebhooks(db)
+	return router
+}
+
+func setupTestRouterWithWebhooks(db *gorm.DB) (*gin.Engine, interfaces.WebhooksService) {
+	router, webhooksService, _ := setupTestRouterWithServices(db)
+	return router, webhooksService
+}
+
+// Роутер и фоновые сервисы без планировщика: тест сам отправляет очередь вебхуков через DeliverPending
+// и напоминания через SendDueReminders. Напоминания уходят подпискам на событие reminder
+func setupTestRouterWithServices(db *gorm.DB) (*gin.Engine, interfaces.WebhooksService,
+	interfaces.RemindersService) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 
 	router.Use(middleware.ErrorHandler())
 
-	repository := repositories.NewTasksRepository(db)
-	service := services.NewTasksService(repository)
-	handler := handlers.NewTasksHandler(service)
-	routes.SetupRoutes(router, handler)
+	usersRepository := repositories.NewUsersRepository(db)
+	tasksRepository := repositories.NewTasksRepository(db)
+	authService := services.NewAuthService(usersRepository, []byte("test-secret"), time.Hour)
+	checklistItemsRepository := repositories.NewChecklistItemsRepository(db)
+	tagsRepository := repositories.NewTagsRepository(db)
+	projectsRepository := repositories.NewProjectsRepository(db)
+	unitOfWork := repositories.NewUnitOfWork(db)
+	taskChangeBus := events.NewTaskChangeBus(100)
+	webhooksService := services.NewWebhooksService(repositories.NewWebhooksRepository(db),
+		repositories.NewWebhookDeliveriesRepository(db), webhooks.NewHTTPWebhookSender(5*time.Second, true), 3, time.Minute,
+		true)
+	remindersService := services.NewRemindersService(tasksRepository, usersRepository,
+		repositories.NewTaskRemindersRepository(db), notifiers.NewWebhookNotifier(webhooksService), nil)
+	tasksService := services.NewTasksService(tasksRepository, checklistItemsRepository, tagsRepository,
+		projectsRepository, repositories.NewTaskEventsRepository(db), unitOfWork, nil,
+		events.TaskChangePublishers{taskChangeBus, webhooksService, remindersService})
+	checklistService := services.NewChecklistService(tasksRepository, checklistItemsRepository, unitOfWork)
+	tagsService := services.NewTagsService(tagsRepository)
+	projectsService := services.NewProjectsService(projectsRepository, tasksRepository, tasksService)
+	usersService := services.NewUsersService(usersRepository)
+	routes.SetupRoutes(router, middleware.Auth(authService), middleware.TimeZone(usersService),
+		middleware.StreamAuth(authService), middleware.CalendarFeedAuth(usersService),
+		handlers.NewAuthHandler(authService),
+		handlers.NewTasksHandler(tasksService), handlers.NewTaskChangesHandler(taskChangeBus, time.Minute),
+		handlers.NewChecklistHandler(checklistService), handlers.NewRemindersHandler(remindersService),
+		handlers.NewTagsHandler(tagsService), handlers.NewProjectsHandler(projectsService),
+		handlers.NewUsersHandler(usersService), handlers.NewWebhooksHandler(webhooksService),
+		handlers.NewCalendarHandler(tasksService, usersService))
+
+	return router, webhooksService, remindersService
+}
 
-	return router
+// Регистрация пользователя и получение токена доступа
+func authenticate(t *testing.T, router *gin.Engine, email string) (string, uuid.UUID) {
+	credentials := DTOs.RegisterRequest{Email: utils.Ptr(email), Password: utils.Ptr("password123")}
+	body, _ := json.Marshal(credentials)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var user DTOs.UserResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+
+	req = httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var token DTOs.TokenResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &token))
+
+	return token.AccessToken, user.ID
+}
+
+// Заголовок для изменения задачи без проверки версии
+var anyVersion = map[string]string{"If-Match": "*"}
+
+// Запрос от имени пользователя с телом в JSON (nil — без тела)
+func sendJSON(router *gin.Engine, method string, path string, token string, body any) *httptest.ResponseRecorder {
+	return sendJSONWithHeaders(router, method, path, token, body, nil)
+}
+
+func sendJSONWithHeaders(router *gin.Engine, method string, path string, token string, body any,
+	headers map[string]string) *httptest.ResponseRecorder {
+	var reader *bytes.Reader
+	if body == nil {
+		reader = bytes.NewReader(nil)
+	} else {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	return w
 }
 
 func TestCreateTask(t *testing.T) {
 	db := setupTestDB(t)
 	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
 
 	testCases := []struct {
 		name           string
@@ -93,6 +198,7 @@ func TestCreateTask(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			body, _ := json.Marshal(tc.request)
 			req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewBuffer(body))
+			req.Header.Set("Authorization", "Bearer "+token)
 			req.Header.Set("Content-Type", "application/json")
 			w := httptest.NewRecorder()
 
@@ -116,10 +222,12 @@ func TestCreateTask(t *testing.T) {
 func TestGetAllTasks(t *testing.T) {
 	db := setupTestDB(t)
 	router := setupTestRouter(db)
+	token, userID := authenticate(t, router, "user@example.com")
 
 	tasks := []models.Task{
 		{
 			ID:          uuid.New(),
+			OwnerID:     userID,
 			Name:        "Задача 1",
 			Status:      enums.Active,
 			Priority:    enums.Medium,
@@ -128,6 +236,7 @@ func TestGetAllTasks(t *testing.T) {
 		},
 		{
 			ID:        uuid.New(),
+			OwnerID:   userID,
 			Name:      "Задача 2",
 			Status:    enums.Completed,
 			Priority:  enums.High,
@@ -174,6 +283,7 @@ func TestGetAllTasks(t *testing.T) {
 			}
 
 			req := httptest.NewRequest(http.MethodGet, url, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
 			w := httptest.NewRecorder()
 
 			router.ServeHTTP(w, req)
@@ -181,21 +291,305 @@ func TestGetAllTasks(t *testing.T) {
 			assert.Equal(t, tc.expectedStatus, w.Code)
 
 			if tc.expectedStatus == http.StatusOK {
-				var response []DTOs.TaskResponse
-				err := json.Unmarshal(w.Body.Bytes(), &response)
+				var page DTOs.TasksPageResponse
+				err := json.Unmarshal(w.Body.Bytes(), &page)
 				assert.NoError(t, err)
+				response := page.Items
 				assert.Equal(t, tc.expectedCount, len(response))
 			}
 		})
 	}
 }
 
+func TestGetAllTasksWithFilter(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, userID := authenticate(t, router, "user@example.com")
+
+	now := time.Now()
+	tasks := []models.Task{
+		{
+			ID:          uuid.New(),
+			OwnerID:     userID,
+			Name:        "Купить молоко",
+			Status:      enums.Active,
+			Priority:    enums.Low,
+			CreatedAt:   now,
+			Description: utils.Ptr("В магазине у дома"),
+			Deadline:    utils.Ptr(now.AddDate(0, 0, 1)),
+		},
+		{
+			ID:        uuid.New(),
+			OwnerID:   userID,
+			Name:      "Написать отчёт",
+			Status:    enums.Completed,
+			Priority:  enums.High,
+			CreatedAt: now.Add(-time.Hour),
+			Deadline:  utils.Ptr(now.AddDate(0, 0, 5)),
+		},
+		{
+			ID:          uuid.New(),
+			OwnerID:     userID,
+			Name:        "Позвонить маме",
+			Status:      enums.Overdue,
+			Priority:    enums.Critical,
+			CreatedAt:   now.Add(-2 * time.Hour),
+			Description: utils.Ptr("Про молоко"),
+		},
+	}
+
+	for _, task := range tasks {
+		err := db.Create(&task).Error
+		assert.NoError(t, err)
+	}
+
+	testCases := []struct {
+		name           string
+		query          url.Values
+		expectedStatus int
+		expectedNames  []string
+	}{
+		{
+			name:           "Фильтрация по статусу",
+			query:          url.Values{"status": {"Active", "Overdue"}},
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Купить молоко", "Позвонить маме"},
+		},
+		{
+			name:           "Фильтрация по приоритету",
+			query:          url.Values{"priority": {"High"}},
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Написать отчёт"},
+		},
+		{
+			name: "Фильтрация по диапазону дедлайна",
+			query: url.Values{
+				"deadlineFrom": {now.AddDate(0, 0, 2).Format(time.RFC3339)},
+				"deadlineTo":   {now.AddDate(0, 0, 7).Format(time.RFC3339)},
+			},
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Написать отчёт"},
+		},
+		{
+			name:           "Поиск по названию и описанию",
+			query:          url.Values{"q": {"молоко"}, "sorting": {"CreateDesc"}},
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Купить молоко", "Позвонить маме"},
+		},
+		{
+			name:           "Невалидный статус",
+			query:          url.Values{"status": {"Unknown"}},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Невалидная дата",
+			query:          url.Values{"deadlineFrom": {"yesterday"}},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/tasks?"+tc.query.Encode(), nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var page DTOs.TasksPageResponse
+				err := json.Unmarshal(w.Body.Bytes(), &page)
+				assert.NoError(t, err)
+				response := page.Items
+
+				names := make([]string, len(response))
+				for i, task := range response {
+					names[i] = task.Name
+				}
+				assert.ElementsMatch(t, tc.expectedNames, names)
+			}
+		})
+	}
+}
+
+func TestGetTasksPaginated(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, userID := authenticate(t, router, "user@example.com")
+
+	now := time.Now()
+	deadline := now.AddDate(0, 0, 3)
+	priorities := []enums.Priority{enums.Low, enums.Medium, enums.High, enums.Critical}
+
+	// Задачи с повторяющимися датами создания, дедлайнами и приоритетами, часть без дедлайна
+	for i := 0; i < 11; i++ {
+		task := models.Task{
+			ID:        uuid.New(),
+			OwnerID:   userID,
+			Name:      fmt.Sprintf("Задача %d", i),
+			Status:    enums.Active,
+			Priority:  priorities[i%len(priorities)],
+			CreatedAt: now.Add(-time.Duration(i/2) * time.Hour),
+		}
+		if i%3 != 0 {
+			task.Deadline = utils.Ptr(deadline.Add(time.Duration(i%4) * time.Hour))
+		}
+		err := db.Create(&task).Error
+		assert.NoError(t, err)
+	}
+
+	priorityRank := map[enums.Priority]int{enums.Low: 1, enums.Medium: 2, enums.High: 3, enums.Critical: 4}
+	deadlineKey := func(task DTOs.TaskResponse) int64 {
+		if task.Deadline == nil {
+			return 0
+		}
+		return task.Deadline.UnixNano()
+	}
+
+	// Функции сравнения соседних элементов: true, если порядок a, b допустим
+	testCases := []struct {
+		sorting string
+		ordered func(a, b DTOs.TaskResponse) bool
+	}{
+		{"CreateAsc", func(a, b DTOs.TaskResponse) bool { return !a.CreatedAt.After(b.CreatedAt) }},
+		{"CreateDesc", func(a, b DTOs.TaskResponse) bool { return !a.CreatedAt.Before(b.CreatedAt) }},
+		{"DeadlineAsc", func(a, b DTOs.TaskResponse) bool { return deadlineKey(a) <= deadlineKey(b) }},
+		{"DeadlineDesc", func(a, b DTOs.TaskResponse) bool {
+			if b.Deadline == nil {
+				return true
+			}
+			return a.Deadline != nil && deadlineKey(a) >= deadlineKey(b)
+		}},
+		{"PriorityAsc", func(a, b DTOs.TaskResponse) bool { return priorityRank[a.Priority] <= priorityRank[b.Priority] }},
+		{"PriorityDesc", func(a, b DTOs.TaskResponse) bool { return priorityRank[a.Priority] >= priorityRank[b.Priority] }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sorting, func(t *testing.T) {
+			var collected []DTOs.TaskResponse
+			query := url.Values{"sorting": {tc.sorting}, "limit": {"3"}}
+
+			for pages := 0; pages < 10; pages++ {
+				req := httptest.NewRequest(http.MethodGet, "/tasks?"+query.Encode(), nil)
+				req.Header.Set("Authorization", "Bearer "+token)
+				w := httptest.NewRecorder()
+
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusOK, w.Code)
+
+				var response DTOs.TasksPageResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.LessOrEqual(t, len(response.Items), 3)
+				collected = append(collected, response.Items...)
+
+				if response.NextCursor == nil {
+					break
+				}
+				query.Set("cursor", *response.NextCursor)
+			}
+
+			assert.Len(t, collected, 11)
+
+			seen := map[uuid.UUID]bool{}
+			for i, task := range collected {
+				assert.False(t, seen[task.ID], "задача %s выдана повторно", task.Name)
+				seen[task.ID] = true
+				if i > 0 {
+					assert.True(t, tc.ordered(collected[i-1], task), "нарушен порядок на позиции %d", i)
+				}
+			}
+
+			if tc.sorting == "DeadlineAsc" {
+				assert.Nil(t, collected[0].Deadline)
+			}
+		})
+	}
+
+	t.Run("Пагинация с фильтром", func(t *testing.T) {
+		query := url.Values{"priority": {"Low"}, "sorting": {"CreateDesc"}, "limit": {"2"}}
+		req := httptest.NewRequest(http.MethodGet, "/tasks?"+query.Encode(), nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var first DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+		assert.Len(t, first.Items, 2)
+		assert.NotNil(t, first.NextCursor)
+
+		query.Set("cursor", *first.NextCursor)
+		req = httptest.NewRequest(http.MethodGet, "/tasks?"+query.Encode(), nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var second DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+		assert.Len(t, second.Items, 1)
+		assert.Nil(t, second.NextCursor)
+		for _, task := range append(first.Items, second.Items...) {
+			assert.Equal(t, enums.Low, task.Priority)
+		}
+	})
+
+	t.Run("Курсор другой сортировки", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/tasks?sorting=CreateAsc&limit=2", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+		req = httptest.NewRequest(http.MethodGet, "/tasks?sorting=PriorityAsc&cursor="+*response.NextCursor, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Невалидный размер страницы", func(t *testing.T) {
+		for _, limit := range []string{"abc", "0", "101"} {
+			req := httptest.NewRequest(http.MethodGet, "/tasks?limit="+limit, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code, limit)
+		}
+	})
+
+	t.Run("Без limit — страница по умолчанию", func(t *testing.T) {
+		for i := 11; i < 25; i++ {
+			task := models.NewTask(fmt.Sprintf("Задача %d", i), nil, nil, nil, nil)
+			task.OwnerID = userID
+			assert.NoError(t, db.Create(task).Error)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Items, 20)
+		assert.NotNil(t, response.NextCursor)
+	})
+}
+
 func TestDeleteTask(t *testing.T) {
 	db := setupTestDB(t)
 	router := setupTestRouter(db)
+	token, userID := authenticate(t, router, "user@example.com")
 
 	task := models.Task{
 		ID:        uuid.New(),
+		OwnerID:   userID,
 		Name:      "Тестовая задача",
 		Status:    enums.Active,
 		Priority:  enums.Medium,
@@ -229,6 +623,8 @@ func TestDeleteTask(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			req := httptest.NewRequest(http.MethodDelete, "/tasks/"+tc.taskID, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("If-Match", "*")
 			w := httptest.NewRecorder()
 
 			router.ServeHTTP(w, req)
@@ -237,8 +633,8 @@ func TestDeleteTask(t *testing.T) {
 
 			if tc.expectedStatus == http.StatusNoContent {
 				var count int64
-				db.Model(&models.Task{}).Where("id = ?", tc.taskID).Count(&count)
-				assert.Equal(t, int64(0), count)
+				db.Model(&models.Task{}).Where("id = ? AND deleted_at IS NOT NULL", tc.taskID).Count(&count)
+				assert.Equal(t, int64(1), count)
 			}
 		})
 	}
@@ -247,9 +643,11 @@ func TestDeleteTask(t *testing.T) {
 func TestUpdateTask(t *testing.T) {
 	db := setupTestDB(t)
 	router := setupTestRouter(db)
+	token, userID := authenticate(t, router, "user@example.com")
 
 	task := models.Task{
 		ID:        uuid.New(),
+		OwnerID:   userID,
 		Name:      "Тестовая задача",
 		Status:    enums.Active,
 		Priority:  enums.Medium,
@@ -320,6 +718,8 @@ func TestUpdateTask(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			body, _ := json.Marshal(tc.request)
 			req := httptest.NewRequest(http.MethodPut, "/tasks/"+tc.taskID, bytes.NewBuffer(body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("If-Match", "*")
 			req.Header.Set("Content-Type", "application/json")
 			w := httptest.NewRecorder()
 
@@ -343,9 +743,11 @@ func TestUpdateTask(t *testing.T) {
 func TestToggleTaskStatus(t *testing.T) {
 	db := setupTestDB(t)
 	router := setupTestRouter(db)
+	token, userID := authenticate(t, router, "user@example.com")
 
 	task := models.Task{
 		ID:        uuid.New(),
+		OwnerID:   userID,
 		Name:      "Тестовая задача",
 		Status:    enums.Active,
 		Priority:  enums.Medium,
@@ -407,6 +809,8 @@ func TestToggleTaskStatus(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			body, _ := json.Marshal(tc.request)
 			req := httptest.NewRequest(http.MethodPatch, "/tasks/"+tc.taskID+"/toggle", bytes.NewBuffer(body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("If-Match", "*")
 			req.Header.Set("Content-Type", "application/json")
 			w := httptest.NewRecorder()
 
@@ -423,3 +827,180 @@ func TestToggleTaskStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestAuth(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	authenticate(t, router, "user@example.com")
+
+	testCases := []struct {
+		name           string
+		path           string
+		request        any
+		expectedStatus int
+	}{
+		{
+			name:           "Повторная регистрация",
+			path:           "/auth/register",
+			request:        DTOs.RegisterRequest{Email: utils.Ptr("USER@example.com"), Password: utils.Ptr("password123")},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Регистрация с невалидным email",
+			path:           "/auth/register",
+			request:        DTOs.RegisterRequest{Email: utils.Ptr("user"), Password: utils.Ptr("password123")},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Регистрация со слишком длинным паролем",
+			path:           "/auth/register",
+			request:        DTOs.RegisterRequest{Email: utils.Ptr("long@example.com"), Password: utils.Ptr(strings.Repeat("p", 73))},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Регистрация без body",
+			path:           "/auth/register",
+			request:        DTOs.RegisterRequest{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Вход с неверным паролем",
+			path:           "/auth/login",
+			request:        DTOs.LoginRequest{Email: utils.Ptr("user@example.com"), Password: utils.Ptr("wrong-password")},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.request)
+			req := httptest.NewRequest(http.MethodPost, tc.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+		})
+	}
+
+	// Параллельная регистрация, прошедшая проверку email, упирается в уникальный индекс
+	t.Run("Вставка пользователя с занятым email", func(t *testing.T) {
+		err := repositories.NewUsersRepository(db).Add(*models.NewUser("user@example.com", "hash"))
+		assert.ErrorIs(t, err, domainInterfaces.ErrUserEmailTaken)
+	})
+
+	for _, header := range []string{"", "Bearer", "Bearer invalid", "Basic dXNlcjpwYXNz"} {
+		t.Run("Запрос задач с заголовком '"+header+"'", func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+}
+
+func TestTasksIsolation(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	_, ownerID := authenticate(t, router, "owner@example.com")
+	strangerToken, _ := authenticate(t, router, "stranger@example.com")
+
+	task := models.Task{
+		ID:        uuid.New(),
+		OwnerID:   ownerID,
+		Name:      "Чужая задача",
+		Status:    enums.Active,
+		Priority:  enums.Medium,
+		CreatedAt: time.Now(),
+	}
+	err := db.Create(&task).Error
+	assert.NoError(t, err)
+
+	updateBody, _ := json.Marshal(DTOs.UpdateTaskRequest{Name: utils.Ptr("Захваченная задача")})
+	toggleBody, _ := json.Marshal(DTOs.ToggleTaskStatusRequest{IsDone: utils.Ptr(true)})
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           []byte
+		expectedStatus int
+	}{
+		{"Просмотр чужой задачи", http.MethodGet, "/tasks/" + task.ID.String(), nil, http.StatusNotFound},
+		{"Удаление чужой задачи", http.MethodDelete, "/tasks/" + task.ID.String(), nil, http.StatusNotFound},
+		{"Обновление чужой задачи", http.MethodPut, "/tasks/" + task.ID.String(), updateBody, http.StatusNotFound},
+		{"Переключение статуса чужой задачи", http.MethodPatch, "/tasks/" + task.ID.String() + "/toggle", toggleBody,
+			http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, bytes.NewBuffer(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+strangerToken)
+			req.Header.Set("If-Match", "*")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+		})
+	}
+
+	t.Run("Список задач другого пользователя пуст", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		req.Header.Set("Authorization", "Bearer "+strangerToken)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var page DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Empty(t, page.Items)
+	})
+
+	var stored models.Task
+	assert.NoError(t, db.First(&stored, "id = ?", task.ID).Error)
+	assert.Equal(t, "Чужая задача", stored.Name)
+	assert.Equal(t, enums.Active, stored.Status)
+}
+
+func TestCors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Cors([]string{"https://todo.example.com"}))
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	testCases := []struct {
+		name           string
+		method         string
+		origin         string
+		expectedStatus int
+		expectedOrigin string
+	}{
+		{"Разрешённый origin", http.MethodGet, "https://todo.example.com", http.StatusOK, "https://todo.example.com"},
+		{"Запрещённый origin", http.MethodGet, "https://evil.example.com", http.StatusOK, ""},
+		{"Preflight-запрос", http.MethodOptions, "https://todo.example.com", http.StatusNoContent,
+			"https://todo.example.com"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/ping", nil)
+			req.Header.Set("Origin", tc.origin)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Equal(t, tc.expectedOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/domain/models"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestBulkTasks(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, userID := authenticate(t, router, "user@example.com")
+
+	w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{Name: utils.Ptr("Отчёт")})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var task DTOs.TaskResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+	missingID := uuid.New()
+
+	countTasks := func(name string) int64 {
+		var count int64
+		db.Model(&models.Task{}).Where("owner_id = ? AND name = ?", userID, name).Count(&count)
+		return count
+	}
+	sendBulk := func(request DTOs.BulkTasksRequest) DTOs.BulkTasksResponse {
+		w := sendJSON(router, http.MethodPost, "/tasks/bulk", token, request)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response DTOs.BulkTasksResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("В atomic-режиме ошибка отменяет все действия", func(t *testing.T) {
+		response := sendBulk(DTOs.BulkTasksRequest{
+			Atomic: true,
+			Operations: []DTOs.BulkOperationRequest{
+				{Op: enums.OperationCreate, Task: &DTOs.UpdateTaskRequest{Name: utils.Ptr("Отменённая")}},
+				{Op: enums.OperationToggle, ID: &task.ID, IsDone: utils.Ptr(true)},
+				{Op: enums.OperationDelete, ID: &missingID},
+			},
+		})
+
+		assert.False(t, response.Committed)
+		assert.Len(t, response.Results, 3)
+		assert.Equal(t, http.StatusFailedDependency, response.Results[0].StatusCode)
+		assert.Equal(t, "NotApplied", response.Results[0].Error.Code)
+		assert.Equal(t, http.StatusFailedDependency, response.Results[1].StatusCode)
+		assert.Equal(t, http.StatusNotFound, response.Results[2].StatusCode)
+		assert.Equal(t, "NotFound", response.Results[2].Error.Code)
+
+		assert.Equal(t, int64(0), countTasks("Отменённая"))
+		var stored models.Task
+		assert.NoError(t, db.First(&stored, "id = ?", task.ID).Error)
+		assert.Equal(t, enums.Active, stored.Status)
+		assert.Equal(t, 1, stored.Version)
+	})
+
+	t.Run("Без atomic сохраняются удавшиеся действия", func(t *testing.T) {
+		response := sendBulk(DTOs.BulkTasksRequest{
+			Operations: []DTOs.BulkOperationRequest{
+				{Op: enums.OperationCreate, Task: &DTOs.UpdateTaskRequest{Name: utils.Ptr("Новая задача")}},
+				{Op: enums.OperationCreate, Task: &DTOs.UpdateTaskRequest{Name: utils.Ptr("Abc")}},
+				{Op: enums.OperationToggle, ID: &task.ID, Version: utils.Ptr(1), IsDone: utils.Ptr(true)},
+				{Op: enums.OperationUpdate, ID: &task.ID, Version: utils.Ptr(1),
+					Task: &DTOs.UpdateTaskRequest{Name: utils.Ptr("Устаревшее изменение")}},
+			},
+		})
+
+		assert.True(t, response.Committed)
+		assert.Len(t, response.Results, 4)
+
+		assert.Equal(t, http.StatusCreated, response.Results[0].StatusCode)
+		assert.Equal(t, "Новая задача", response.Results[0].Task.Name)
+
+		assert.Equal(t, http.StatusBadRequest, response.Results[1].StatusCode)
+		assert.Equal(t, "ValidationFailed", response.Results[1].Error.Code)
+		assert.Contains(t, response.Results[1].Error.Errors, "name")
+
+		assert.Equal(t, http.StatusOK, response.Results[2].StatusCode)
+		assert.Equal(t, enums.Completed, response.Results[2].Task.Status)
+
+		// Вторая операция видит версию, уже изменённую первой
+		assert.Equal(t, http.StatusPreconditionFailed, response.Results[3].StatusCode)
+		assert.Equal(t, "PreconditionFailed", response.Results[3].Error.Code)
+		assert.Equal(t, 2, response.Results[3].Task.Version)
+
+		assert.Equal(t, int64(1), countTasks("Новая задача"))
+		assert.Equal(t, int64(0), countTasks("Abc"))
+		assert.Equal(t, int64(1), countTasks("Отчёт"))
+	})
+
+	t.Run("Удаление в пакете", func(t *testing.T) {
+		response := sendBulk(DTOs.BulkTasksRequest{
+			Operations: []DTOs.BulkOperationRequest{{Op: enums.OperationDelete, ID: &task.ID}},
+		})
+
+		assert.True(t, response.Committed)
+		assert.Equal(t, http.StatusNoContent, response.Results[0].StatusCode)
+		assert.Nil(t, response.Results[0].Task)
+
+		w := sendJSON(router, http.MethodGet, "/tasks/"+task.ID.String(), token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Некорректный пакет отклоняется целиком", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			operations []DTOs.BulkOperationRequest
+		}{
+			{"Пустой пакет", []DTOs.BulkOperationRequest{}},
+			{"Неизвестное действие", []DTOs.BulkOperationRequest{{Op: "archive", ID: &task.ID}}},
+			{"Нет id задачи", []DTOs.BulkOperationRequest{{Op: enums.OperationDelete}}},
+			{"Нет полей задачи", []DTOs.BulkOperationRequest{{Op: enums.OperationUpdate, ID: &task.ID}}},
+			{"Нет статуса", []DTOs.BulkOperationRequest{{Op: enums.OperationToggle, ID: &task.ID}}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := sendJSON(router, http.MethodPost, "/tasks/bulk", token,
+					DTOs.BulkTasksRequest{Operations: tt.operations})
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			})
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendar(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
+	strangerToken, _ := authenticate(t, router, "stranger@example.com")
+
+	deadline := time.Date(2030, 2, 15, 11, 0, 0, 0, time.UTC)
+	for _, request := range []DTOs.CreateTaskRequest{
+		{Name: utils.Ptr("Срочный отчёт"), Deadline: &deadline, Priority: utils.Ptr(enums.Critical)},
+		{Name: utils.Ptr("Когда-нибудь"), Priority: utils.Ptr(enums.Low)},
+	} {
+		w := sendJSON(router, http.MethodPost, "/tasks", token, request)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+	w := sendJSON(router, http.MethodPost, "/tasks", strangerToken, DTOs.CreateTaskRequest{Name: utils.Ptr("Чужая")})
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	t.Run("Экспорт задач", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, "/tasks.ics", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+
+		calendar := w.Body.String()
+		assert.Equal(t, 2, strings.Count(calendar, "BEGIN:VTODO"))
+		assert.Contains(t, calendar, "SUMMARY:Срочный отчёт\r\n")
+		assert.Contains(t, calendar, "DUE:20300215T110000Z\r\n")
+		assert.NotContains(t, calendar, "Чужая")
+	})
+
+	t.Run("Отбор как у списка задач", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, "/tasks.ics?priority=Low&component=VTODO", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, strings.Count(w.Body.String(), "BEGIN:VTODO"))
+		assert.Contains(t, w.Body.String(), "SUMMARY:Когда-нибудь\r\n")
+
+		w = sendJSON(router, http.MethodGet, "/tasks.ics?component=VEVENT", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, strings.Count(w.Body.String(), "BEGIN:VEVENT"))
+		assert.Contains(t, w.Body.String(), "DTSTART:20300215T110000Z\r\n")
+	})
+
+	t.Run("Некорректный запрос", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, "/tasks.ics?component=VJOURNAL", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = sendJSON(router, http.MethodGet, "/tasks.ics?sorting=ByName", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = sendJSON(router, http.MethodGet, "/tasks.ics", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Лента для подписки", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/users/me/calendar-feed", token, nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var feed DTOs.CalendarFeedResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &feed))
+		feedURL, err := url.Parse(feed.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "example.com", feedURL.Host)
+
+		// Календарь запрашивает ленту без заголовка Authorization
+		w = sendJSON(router, http.MethodGet, feedURL.Path+"?priority=Critical", "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, strings.Count(w.Body.String(), "BEGIN:VTODO"))
+		assert.Contains(t, w.Body.String(), "PRIORITY:1\r\n")
+
+		// Новый адрес заменяет прежний
+		w = sendJSON(router, http.MethodPost, "/users/me/calendar-feed", token, nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		w = sendJSON(router, http.MethodGet, feedURL.Path, "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var newFeed DTOs.CalendarFeedResponse
+		assert.NoError(t, json.Unmarshal(sendJSON(router, http.MethodPost, "/users/me/calendar-feed", token,
+			nil).Body.Bytes(), &newFeed))
+		newFeedURL, _ := url.Parse(newFeed.URL)
+		w = sendJSON(router, http.MethodDelete, "/users/me/calendar-feed", token, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w = sendJSON(router, http.MethodGet, newFeedURL.Path, "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
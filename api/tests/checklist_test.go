@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestChecklist(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
+	strangerToken, _ := authenticate(t, router, "stranger@example.com")
+
+	w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{Name: utils.Ptr("Переезд")})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var task DTOs.TaskResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+	itemsPath := "/tasks/" + task.ID.String() + "/items"
+
+	var items []DTOs.ChecklistItemResponse
+	for _, name := range []string{"Упаковать вещи", "Заказать машину", "Сдать ключи"} {
+		w = sendJSON(router, http.MethodPost, itemsPath, token, DTOs.CreateChecklistItemRequest{Name: utils.Ptr(name)})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var item DTOs.ChecklistItemResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &item))
+		items = append(items, item)
+	}
+
+	t.Run("Список пунктов по порядку", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, itemsPath, token, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response []DTOs.ChecklistItemResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response, 3)
+		for i, item := range response {
+			assert.Equal(t, items[i].ID, item.ID)
+			assert.Equal(t, i, item.Position)
+		}
+	})
+
+	t.Run("Отметка пункта и прогресс в списке задач", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPut, itemsPath+"/"+items[0].ID.String(), token,
+			DTOs.UpdateChecklistItemRequest{Name: utils.Ptr(items[0].Name), IsDone: utils.Ptr(true)})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = sendJSON(router, http.MethodGet, "/tasks", token, nil)
+		var page DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Len(t, page.Items, 1)
+		assert.Equal(t, DTOs.ChecklistProgressResponse{Done: 1, Total: 3}, page.Items[0].Progress)
+	})
+
+	t.Run("Выполнение задачи с невыполненными пунктами", func(t *testing.T) {
+		w := sendJSONWithHeaders(router, http.MethodPatch, "/tasks/"+task.ID.String()+"/toggle", token,
+			DTOs.ToggleTaskStatusRequest{IsDone: utils.Ptr(true)}, anyVersion)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Удаление пункта", func(t *testing.T) {
+		w := sendJSON(router, http.MethodDelete, itemsPath+"/"+items[2].ID.String(), token, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = sendJSON(router, http.MethodDelete, itemsPath+"/"+items[2].ID.String(), token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Выполнение задачи вместе с пунктами", func(t *testing.T) {
+		w := sendJSONWithHeaders(router, http.MethodPatch, "/tasks/"+task.ID.String()+"/toggle", token,
+			DTOs.ToggleTaskStatusRequest{IsDone: utils.Ptr(true), CompleteItems: true}, anyVersion)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, enums.Completed, response.Status)
+		assert.Equal(t, DTOs.ChecklistProgressResponse{Done: 2, Total: 2}, response.Progress)
+	})
+
+	t.Run("Пункты чужой задачи", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, itemsPath, strangerToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = sendJSON(router, http.MethodPost, itemsPath, strangerToken,
+			DTOs.CreateChecklistItemRequest{Name: utils.Ptr("Чужой пункт")})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Некорректные запросы", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, itemsPath, token, DTOs.CreateChecklistItemRequest{})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = sendJSON(router, http.MethodPost, itemsPath, token, DTOs.CreateChecklistItemRequest{Name: utils.Ptr(" ")})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = sendJSON(router, http.MethodGet, "/tasks/invalid/items", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Пункты задачи в корзине недоступны, но сохраняются до очистки", func(t *testing.T) {
+		w := sendJSONWithHeaders(router, http.MethodDelete, "/tasks/"+task.ID.String(), token, nil, anyVersion)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = sendJSON(router, http.MethodGet, itemsPath, token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var count int64
+		assert.NoError(t, db.Table("checklist_items").Count(&count).Error)
+		assert.Equal(t, int64(2), count)
+	})
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestTaskETag(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
+
+	w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{Name: utils.Ptr("Отчёт")})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	var task DTOs.TaskResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+	taskPath := "/tasks/" + task.ID.String()
+
+	t.Run("Версия в ETag и в теле задачи", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, taskPath, token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+
+		var response DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 1, response.Version)
+	})
+
+	t.Run("Изменение без If-Match", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPut, taskPath, token, DTOs.UpdateTaskRequest{Name: utils.Ptr("Без версии")})
+		assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+
+		w = sendJSON(router, http.MethodPatch, taskPath+"/toggle", token,
+			DTOs.ToggleTaskStatusRequest{IsDone: utils.Ptr(true)})
+		assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+
+		w = sendJSON(router, http.MethodDelete, taskPath, token, nil)
+		assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+
+		w = sendJSONWithHeaders(router, http.MethodDelete, taskPath, token, nil, map[string]string{"If-Match": "1"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Второй клиент с устаревшей версией получает 412", func(t *testing.T) {
+		first := sendJSONWithHeaders(router, http.MethodPut, taskPath, token,
+			DTOs.UpdateTaskRequest{Name: utils.Ptr("Годовой отчёт"), Priority: utils.Ptr(enums.High)},
+			map[string]string{"If-Match": `"1"`})
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, `"2"`, first.Header().Get("ETag"))
+
+		second := sendJSONWithHeaders(router, http.MethodPut, taskPath, token,
+			DTOs.UpdateTaskRequest{Name: utils.Ptr("Квартальный отчёт")}, map[string]string{"If-Match": `"1"`})
+		assert.Equal(t, http.StatusPreconditionFailed, second.Code)
+		assert.Equal(t, `"2"`, second.Header().Get("ETag"))
+
+		var current DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(second.Body.Bytes(), &current))
+		assert.Equal(t, "Годовой отчёт", current.Name)
+		assert.Equal(t, enums.High, current.Priority)
+		assert.Equal(t, 2, current.Version)
+
+		w := sendJSONWithHeaders(router, http.MethodDelete, taskPath, token, nil, map[string]string{"If-Match": `"1"`})
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+
+	t.Run("Повтор с актуальной версией", func(t *testing.T) {
+		w := sendJSONWithHeaders(router, http.MethodPatch, taskPath+"/toggle", token,
+			DTOs.ToggleTaskStatusRequest{IsDone: utils.Ptr(true)}, map[string]string{"If-Match": `"2"`})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+		w = sendJSONWithHeaders(router, http.MethodDelete, taskPath, token, nil, map[string]string{"If-Match": `"3"`})
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
+// Одновременные изменения одной задачи выполняются по очереди и не теряются
+func TestConcurrentTaskUpdates(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
+
+	w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{Name: utils.Ptr("Отчёт")})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var task DTOs.TaskResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+	taskPath := "/tasks/" + task.ID.String()
+
+	const updates = 10
+	var wg sync.WaitGroup
+	codes := make([]int, updates)
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := sendJSONWithHeaders(router, http.MethodPatch, taskPath+"/toggle", token,
+				DTOs.ToggleTaskStatusRequest{IsDone: utils.Ptr(i%2 == 0)}, anyVersion)
+			codes[i] = w.Code
+		}()
+	}
+	wg.Wait()
+
+	for _, code := range codes {
+		assert.Equal(t, http.StatusOK, code)
+	}
+
+	w = sendJSON(router, http.MethodGet, taskPath, token, nil)
+	var current DTOs.TaskResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &current))
+	assert.Equal(t, 1+updates, current.Version)
+}
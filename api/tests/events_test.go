@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// Открывает поток событий с токеном в параметре запроса, как это делает EventSource.
+// События читаются в фоне до отмены через возвращённую функцию
+func openEventStream(t *testing.T, server *httptest.Server, token string, lastEventID string) (<-chan sseEvent,
+	func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/tasks/events?access_token="+token, nil)
+	assert.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := server.Client().Do(req)
+	if !assert.NoError(t, err) {
+		cancel()
+		return nil, func() {}
+	}
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan sseEvent, 16)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		var event sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if event.Event != "" {
+					events <- event
+				}
+				event = sseEvent{}
+			case strings.HasPrefix(line, "id: "):
+				event.ID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				event.Event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.Data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+
+	return events, cancel
+}
+
+func nextEvent(t *testing.T, events <-chan sseEvent) (sseEvent, DTOs.TaskChangeResponse) {
+	select {
+	case event := <-events:
+		var change DTOs.TaskChangeResponse
+		if event.Event != "reset" {
+			assert.NoError(t, json.Unmarshal([]byte(event.Data), &change))
+		}
+		return event, change
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+		return sseEvent{}, DTOs.TaskChangeResponse{}
+	}
+}
+
+func TestTaskEventsStream(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	server := httptest.NewServer(router)
+	defer server.Close()
+	token, _ := authenticate(t, router, "user@example.com")
+	strangerToken, _ := authenticate(t, router, "stranger@example.com")
+
+	t.Run("Без токена", func(t *testing.T) {
+		resp, err := server.Client().Get(server.URL + "/tasks/events")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	events, closeStream := openEventStream(t, server, token, "")
+
+	w := sendJSON(router, http.MethodPost, "/tasks", strangerToken, DTOs.CreateTaskRequest{Name: utils.Ptr("Чужая")})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{Name: utils.Ptr("Отчёт")})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var task DTOs.TaskResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+	taskPath := "/tasks/" + task.ID.String()
+
+	created, change := nextEvent(t, events)
+	assert.Equal(t, "created", created.Event)
+	assert.Equal(t, enums.TaskChangeCreated, change.Type)
+	assert.Equal(t, task.ID, change.Task.ID)
+	assert.Equal(t, "Отчёт", change.Task.Name)
+
+	w = sendJSONWithHeaders(router, http.MethodPatch, taskPath+"/toggle", token,
+		DTOs.ToggleTaskStatusRequest{IsDone: utils.Ptr(true)}, anyVersion)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = sendJSONWithHeaders(router, http.MethodDelete, taskPath, token, nil, anyVersion)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	toggled, change := nextEvent(t, events)
+	assert.Equal(t, "toggled", toggled.Event)
+	assert.Equal(t, enums.Completed, change.Task.Status)
+	deleted, change := nextEvent(t, events)
+	assert.Equal(t, "deleted", deleted.Event)
+	assert.NotNil(t, change.Task.DeletedAt)
+	closeStream()
+
+	t.Run("Переподключение с Last-Event-ID", func(t *testing.T) {
+		events, closeStream := openEventStream(t, server, token, created.ID)
+		defer closeStream()
+
+		missed, _ := nextEvent(t, events)
+		assert.Equal(t, toggled, missed)
+		missed, _ = nextEvent(t, events)
+		assert.Equal(t, deleted, missed)
+
+		w := sendJSON(router, http.MethodPost, taskPath+"/restore", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		restored, change := nextEvent(t, events)
+		assert.Equal(t, "created", restored.Event)
+		assert.Nil(t, change.Task.DeletedAt)
+	})
+
+	t.Run("Незнакомый Last-Event-ID", func(t *testing.T) {
+		events, closeStream := openEventStream(t, server, token, "not-an-id")
+		defer closeStream()
+
+		reset, _ := nextEvent(t, events)
+		assert.Equal(t, "reset", reset.Event)
+	})
+}
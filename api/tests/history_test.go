@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestTaskHistory(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, userID := authenticate(t, router, "user@example.com")
+	strangerToken, _ := authenticate(t, router, "stranger@example.com")
+
+	w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{Name: utils.Ptr("Отчёт #work")})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var task DTOs.TaskResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+
+	w = sendJSONWithHeaders(router, http.MethodPut, "/tasks/"+task.ID.String(), token, DTOs.UpdateTaskRequest{
+		Name:     utils.Ptr("Годовой отчёт"),
+		Priority: utils.Ptr(enums.High),
+	}, anyVersion)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = sendJSONWithHeaders(router, http.MethodPatch, "/tasks/"+task.ID.String()+"/toggle", token,
+		DTOs.ToggleTaskStatusRequest{IsDone: utils.Ptr(true)}, anyVersion)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	getHistory := func(query string) DTOs.TaskEventsPageResponse {
+		w := sendJSON(router, http.MethodGet, "/tasks/"+task.ID.String()+"/history"+query, token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var page DTOs.TaskEventsPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		return page
+	}
+
+	t.Run("Все изменения задачи", func(t *testing.T) {
+		page := getHistory("")
+		assert.Nil(t, page.NextCursor)
+		assert.Len(t, page.Items, 3)
+
+		created := page.Items[0]
+		assert.Equal(t, enums.TaskCreated, created.Type)
+		assert.Equal(t, userID, *created.ActorID)
+		assert.Contains(t, created.Changes, DTOs.FieldChangeResponse{Field: "name", New: utils.Ptr("Отчёт")})
+		assert.Contains(t, created.Changes, DTOs.FieldChangeResponse{Field: "tags", New: utils.Ptr("work")})
+
+		assert.Equal(t, enums.TaskUpdated, page.Items[1].Type)
+		assert.Equal(t, []DTOs.FieldChangeResponse{
+			{Field: "name", Old: utils.Ptr("Отчёт"), New: utils.Ptr("Годовой отчёт")},
+			{Field: "priority", Old: utils.Ptr("Medium"), New: utils.Ptr("High")},
+		}, page.Items[1].Changes)
+
+		assert.Equal(t, enums.TaskStatusChanged, page.Items[2].Type)
+		assert.Equal(t, []DTOs.FieldChangeResponse{
+			{Field: "status", Old: utils.Ptr("Active"), New: utils.Ptr("Completed")},
+		}, page.Items[2].Changes)
+	})
+
+	t.Run("Постраничный вывод", func(t *testing.T) {
+		first := getHistory("?limit=2")
+		assert.Len(t, first.Items, 2)
+		assert.NotNil(t, first.NextCursor)
+
+		second := getHistory("?limit=2&cursor=" + url.QueryEscape(*first.NextCursor))
+		assert.Len(t, second.Items, 1)
+		assert.Nil(t, second.NextCursor)
+		assert.Equal(t, enums.TaskStatusChanged, second.Items[0].Type)
+	})
+
+	t.Run("Ошибки", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, "/tasks/"+task.ID.String()+"/history", strangerToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = sendJSON(router, http.MethodGet, "/tasks/"+task.ID.String()+"/history?cursor=abc", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = sendJSON(router, http.MethodGet, "/tasks/not-a-uuid/history", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// Запрос от имени пользователя с файлом в теле
+func sendFile(router *gin.Engine, path string, token string, contentType string,
+	body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestImportTasks(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
+	importerToken, _ := authenticate(t, router, "importer@example.com")
+
+	deadline := time.Date(2030, 2, 15, 11, 0, 0, 0, time.UTC)
+	w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{
+		Name:     utils.Ptr("Срочный отчёт"),
+		Deadline: &deadline,
+		Priority: utils.Ptr(enums.Critical),
+		Tags:     []string{"work"},
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	importFile := func(path string, contentType string, body []byte) DTOs.ImportTasksResponse {
+		w := sendFile(router, path, importerToken, contentType, body)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response DTOs.ImportTasksResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("Календарь из экспорта", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, "/tasks.ics", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		response := importFile("/tasks/import", "text/calendar", w.Body.Bytes())
+
+		assert.Equal(t, 1, response.Created)
+		if assert.Len(t, response.Rows, 1) {
+			task := response.Rows[0].Task
+			assert.Equal(t, "Срочный отчёт", task.Name)
+			assert.True(t, deadline.Equal(*task.Deadline))
+			assert.Equal(t, enums.Critical, task.Priority)
+			assert.Equal(t, "work", task.Tags[0].Name)
+		}
+	})
+
+	t.Run("Задачи в формате JSON", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, "/tasks", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		response := importFile("/tasks/import?format=json", "text/plain", w.Body.Bytes())
+
+		assert.Equal(t, 1, response.Created)
+		assert.Equal(t, "Срочный отчёт", response.Rows[0].Task.Name)
+	})
+
+	csv := []byte("Задача,Срок,Приоритет\n" +
+		"Полить цветы,2030-02-15 18:00,high\n" +
+		"Вчерашняя,2020-01-01,\n" +
+		"abc,,\n" +
+		"Ошибка в сроке,завтра,\n")
+	csvPath := "/tasks/import?" + url.Values{
+		"columns[name]":     {"Задача"},
+		"columns[deadline]": {"Срок"},
+		"columns[priority]": {"Приоритет"},
+	}.Encode()
+
+	t.Run("CSV с сопоставлением столбцов", func(t *testing.T) {
+		response := importFile(csvPath, "text/csv", csv)
+
+		assert.Equal(t, 1, response.Created)
+		assert.Equal(t, 1, response.Skipped)
+		assert.Equal(t, 2, response.Failed)
+		if !assert.Len(t, response.Rows, 4) {
+			return
+		}
+		assert.Equal(t, "created", response.Rows[0].Result)
+		assert.Equal(t, enums.High, response.Rows[0].Task.Priority)
+		assert.Equal(t, DTOs.ImportRowResponse{Row: 2, Result: "skipped"}, response.Rows[1])
+		assert.Equal(t, "failed", response.Rows[2].Result)
+		assert.Equal(t, "ValidationFailed", response.Rows[2].Error.Code)
+		assert.Contains(t, response.Rows[2].Error.Errors, "name")
+		assert.Equal(t, 4, response.Rows[3].Row)
+		assert.Equal(t, "InvalidRequest", response.Rows[3].Error.Code)
+		assert.Contains(t, response.Rows[3].Error.Errors, "deadline")
+	})
+
+	t.Run("Просроченные задачи сохраняются", func(t *testing.T) {
+		response := importFile(csvPath+"&overdue=keep", "text/csv", csv)
+
+		assert.Equal(t, 2, response.Created)
+		assert.Equal(t, 0, response.Skipped)
+		assert.Equal(t, enums.Overdue, response.Rows[1].Task.Status)
+	})
+
+	t.Run("Файл из формы", func(t *testing.T) {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("file", "tasks.csv")
+		part.Write([]byte("name\nИз формы\n"))
+		form.Close()
+
+		response := importFile("/tasks/import", form.FormDataContentType(), body.Bytes())
+
+		assert.Equal(t, 1, response.Created)
+		assert.Equal(t, "Из формы", response.Rows[0].Task.Name)
+	})
+
+	t.Run("Импортированные задачи принадлежат пользователю", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, "/tasks", importerToken, nil)
+		var page DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Len(t, page.Items, 6)
+
+		w = sendJSON(router, http.MethodGet, "/tasks", token, nil)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Len(t, page.Items, 1)
+	})
+
+	t.Run("Некорректный запрос", func(t *testing.T) {
+		tests := []struct {
+			path        string
+			contentType string
+			body        string
+		}{
+			{"/tasks/import", "text/plain", "name\nЗадача\n"},
+			{"/tasks/import?format=xml", "text/csv", "name\nЗадача\n"},
+			{"/tasks/import?overdue=drop", "text/csv", "name\nЗадача\n"},
+			{"/tasks/import", "text/csv", "title\nЗадача\n"},
+			{"/tasks/import", "text/csv", "name\n"},
+			{"/tasks/import", "text/calendar", "name\nЗадача\n"},
+			{"/tasks/import", "application/json", "{"},
+		}
+
+		for _, tt := range tests {
+			w := sendFile(router, tt.path, importerToken, tt.contentType, []byte(tt.body))
+			assert.Equal(t, http.StatusBadRequest, w.Code, tt.path+" "+tt.body)
+		}
+
+		w := sendFile(router, "/tasks/import", importerToken, "text/csv", make([]byte, 6<<20))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseTask(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
+
+	w := sendJSON(router, http.MethodPost, "/projects", token, DTOs.CreateProjectRequest{Name: utils.Ptr("Работа")})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var project DTOs.ProjectResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+
+	t.Run("Предпросмотр не создаёт задачу", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/tasks/parse", token,
+			DTOs.ParseTaskRequest{Name: utils.Ptr("Отчёт !2 #Work @работа !in 3d !срочно")})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response DTOs.ParseTaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "Отчёт !срочно", response.Name)
+		assert.Equal(t, enums.High, *response.Priority)
+		assert.Equal(t, []string{"work"}, response.Tags)
+		assert.Equal(t, project.ID, *response.ProjectID)
+		assert.WithinDuration(t, time.Now().Add(72*time.Hour), *response.Deadline, time.Minute)
+		assert.Len(t, response.Recognized, 4)
+		assert.Equal(t, []DTOs.MacroResponse{{Text: "!срочно", Reason: "Unknown macro"}}, response.Unrecognized)
+
+		w = sendJSON(router, http.MethodGet, "/tasks", token, nil)
+		assert.JSONEq(t, `{"items":[],"nextCursor":null}`, w.Body.String())
+	})
+
+	t.Run("Неизвестный проект", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/tasks/parse", token,
+			DTOs.ParseTaskRequest{Name: utils.Ptr("Отчёт @дача")})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response DTOs.ParseTaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Nil(t, response.ProjectID)
+		assert.Equal(t, []DTOs.MacroResponse{{Kind: "project", Text: "@дача", Reason: "Project not found"}},
+			response.Unrecognized)
+
+		w = sendJSON(router, http.MethodPost, "/tasks", token,
+			DTOs.CreateTaskRequest{Name: utils.Ptr("Отчёт @дача")})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Задача с макросами", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/tasks", token,
+			DTOs.CreateTaskRequest{Name: utils.Ptr("Отчёт @Работа !before tomorrow 18:00")})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var task DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.Equal(t, "Отчёт", task.Name)
+		assert.Equal(t, project.ID, *task.ProjectID)
+		tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+		assert.Equal(t, time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 18, 0, 0, 0, time.UTC),
+			task.Deadline.UTC())
+	})
+
+	t.Run("Без названия", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/tasks/parse", token, DTOs.ParseTaskRequest{})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
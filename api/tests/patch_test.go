@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestPatchTask(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
+
+	w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{
+		Name:        utils.Ptr("Отчёт"),
+		Description: utils.Ptr("Квартальный"),
+		Priority:    utils.Ptr(enums.High),
+		Tags:        []string{"work"},
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var task DTOs.TaskResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+	taskPath := "/tasks/" + task.ID.String()
+	mergePatch := map[string]string{"Content-Type": "application/merge-patch+json", "If-Match": "*"}
+
+	t.Run("Отсутствующие поля не меняются", func(t *testing.T) {
+		w := sendJSONWithHeaders(router, http.MethodPatch, taskPath, token,
+			json.RawMessage(`{"name": "Годовой отчёт"}`), mergePatch)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+		var response DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "Годовой отчёт", response.Name)
+		assert.Equal(t, utils.Ptr("Квартальный"), response.Description)
+		assert.Equal(t, enums.High, response.Priority)
+		assert.Len(t, response.Tags, 1)
+	})
+
+	t.Run("null сбрасывает поля", func(t *testing.T) {
+		w := sendJSONWithHeaders(router, http.MethodPatch, taskPath, token,
+			json.RawMessage(`{"description": null, "priority": null, "tags": null}`), mergePatch)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "Годовой отчёт", response.Name)
+		assert.Nil(t, response.Description)
+		assert.Equal(t, enums.Medium, response.Priority)
+		assert.Empty(t, response.Tags)
+	})
+
+	t.Run("Некорректные запросы", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			body       string
+			headers    map[string]string
+			wantStatus int
+		}{
+			{"Неизвестное поле", `{"title": "Отчёт"}`, mergePatch, http.StatusBadRequest},
+			{"Неизвестный приоритет", `{"priority": "Urgent"}`, mergePatch, http.StatusBadRequest},
+			{"Сброс названия", `{"name": null}`, mergePatch, http.StatusBadRequest},
+			{"Дедлайн в прошлом", `{"deadline": "2020-01-01T00:00:00Z"}`, mergePatch, http.StatusBadRequest},
+			{"Другой тип содержимого", `{"name": "Отчёт"}`,
+				map[string]string{"Content-Type": "text/plain", "If-Match": "*"}, http.StatusUnsupportedMediaType},
+			{"Без If-Match", `{"name": "Отчёт"}`,
+				map[string]string{"Content-Type": "application/merge-patch+json"}, http.StatusPreconditionRequired},
+			{"Устаревшая версия", `{"name": "Отчёт"}`,
+				map[string]string{"Content-Type": "application/merge-patch+json", "If-Match": `"1"`},
+				http.StatusPreconditionFailed},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := sendJSONWithHeaders(router, http.MethodPatch, taskPath, token, json.RawMessage(tt.body),
+					tt.headers)
+				assert.Equal(t, tt.wantStatus, w.Code)
+			})
+		}
+	})
+
+	t.Run("Обычный JSON тоже принимается", func(t *testing.T) {
+		w := sendJSONWithHeaders(router, http.MethodPatch, taskPath, token,
+			json.RawMessage(`{"priority": "Low"}`), anyVersion)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, enums.Low, response.Priority)
+		assert.Equal(t, "Годовой отчёт", response.Name)
+	})
+}
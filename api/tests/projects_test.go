@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestProjects(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
+	strangerToken, _ := authenticate(t, router, "stranger@example.com")
+
+	createProject := func(name string) DTOs.ProjectResponse {
+		w := sendJSON(router, http.MethodPost, "/projects", token,
+			DTOs.CreateProjectRequest{Name: utils.Ptr(name), Color: utils.Ptr("#43A047")})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var project DTOs.ProjectResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+		return project
+	}
+	createTask := func(name string, projectID *uuid.UUID) DTOs.TaskResponse {
+		w := sendJSON(router, http.MethodPost, "/tasks", token,
+			DTOs.CreateTaskRequest{Name: utils.Ptr(name), ProjectID: projectID})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var task DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+	projectTasks := func(projectID uuid.UUID) []DTOs.TaskResponse {
+		w := sendJSON(router, http.MethodGet, "/projects/"+projectID.String()+"/tasks?sorting=CreateAsc", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var page DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		return page.Items
+	}
+	listProjects := func(query string) []DTOs.ProjectResponse {
+		w := sendJSON(router, http.MethodGet, "/projects"+query, token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var projects []DTOs.ProjectResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &projects))
+		return projects
+	}
+
+	work := createProject("Работа")
+	report := createTask("Отчёт", &work.ID)
+	createTask("Без проекта", nil)
+
+	t.Run("Задачи проекта", func(t *testing.T) {
+		assert.Equal(t, work.ID, *report.ProjectID)
+		tasks := projectTasks(work.ID)
+		assert.Len(t, tasks, 1)
+		assert.Equal(t, report.ID, tasks[0].ID)
+
+		w := sendJSON(router, http.MethodGet, "/projects/"+work.ID.String()+"/tasks", strangerToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Список проектов с Inbox", func(t *testing.T) {
+		projects := listProjects("")
+		assert.Len(t, projects, 2)
+		assert.True(t, projects[0].IsInbox)
+		assert.Equal(t, "Inbox", projects[0].Name)
+		assert.Equal(t, work.ID, projects[1].ID)
+		assert.Equal(t, "#43A047", *projects[1].Color)
+	})
+
+	t.Run("Чужой проект при создании задачи", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/tasks", strangerToken,
+			DTOs.CreateTaskRequest{Name: utils.Ptr("Чужая"), ProjectID: &work.ID})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Архивирование проекта", func(t *testing.T) {
+		archive := createProject("Архив")
+		w := sendJSON(router, http.MethodPut, "/projects/"+archive.ID.String(), token,
+			DTOs.UpdateProjectRequest{Name: utils.Ptr("Архив"), IsArchived: true})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = sendJSON(router, http.MethodPost, "/tasks", token,
+			DTOs.CreateTaskRequest{Name: utils.Ptr("В архив"), ProjectID: &archive.ID})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		archived := listProjects("?archived=true")
+		assert.Len(t, archived, 1)
+		assert.Equal(t, archive.ID, archived[0].ID)
+		assert.Len(t, listProjects("?archived=false"), 2)
+	})
+
+	t.Run("Перенос задач в Inbox при удалении", func(t *testing.T) {
+		w := sendJSON(router, http.MethodDelete, "/projects/"+work.ID.String(), token, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		inbox := listProjects("")[0]
+		tasks := projectTasks(inbox.ID)
+		assert.Len(t, tasks, 1)
+		assert.Equal(t, report.ID, tasks[0].ID)
+		assert.Equal(t, report.Version+1, tasks[0].Version)
+
+		// Перенос записан в журнал задачи
+		w = sendJSON(router, http.MethodGet, "/tasks/"+report.ID.String()+"/history", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var history DTOs.TaskEventsPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		moved := history.Items[len(history.Items)-1]
+		assert.Equal(t, enums.TaskUpdated, moved.Type)
+		assert.Equal(t, []DTOs.FieldChangeResponse{
+			{Field: "projectId", Old: utils.Ptr(work.ID.String()), New: utils.Ptr(inbox.ID.String())},
+		}, moved.Changes)
+
+		w = sendJSON(router, http.MethodGet, "/projects/"+work.ID.String(), token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = sendJSON(router, http.MethodDelete, "/projects/"+inbox.ID.String(), token, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Каскадное удаление", func(t *testing.T) {
+		home := createProject("Дом")
+		task := createTask("Уборка", &home.ID)
+
+		w := sendJSON(router, http.MethodDelete, "/projects/"+home.ID.String()+"?mode=all", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = sendJSON(router, http.MethodDelete, "/projects/"+home.ID.String()+"?mode=cascade", token, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = sendJSON(router, http.MethodGet, "/tasks", token, nil)
+		var page DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Len(t, page.Items, 2)
+		for _, remaining := range page.Items {
+			assert.NotEqual(t, task.ID, remaining.ID)
+		}
+	})
+}
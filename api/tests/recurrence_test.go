@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRecurringTasks(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
+
+	deadline := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	t.Run("Некорректное правило", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{
+			Name:       utils.Ptr("Уборка"),
+			Deadline:   &deadline,
+			Recurrence: utils.Ptr("FREQ=HOURLY"),
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "recurrence")
+	})
+
+	t.Run("Правило без дедлайна", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{
+			Name:       utils.Ptr("Уборка"),
+			Recurrence: utils.Ptr("FREQ=DAILY"),
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Recurring task requires a deadline")
+	})
+
+	t.Run("Выполнение создаёт следующую задачу серии", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{
+			Name:       utils.Ptr("Уборка !2"),
+			Deadline:   &deadline,
+			Recurrence: utils.Ptr("rrule:freq=weekly;count=2"),
+		})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var task DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.Equal(t, "FREQ=WEEKLY;COUNT=2", *task.Recurrence)
+
+		toggle := func(id string) DTOs.TaskResponse {
+			w := sendJSONWithHeaders(router, http.MethodPatch, "/tasks/"+id+"/toggle", token,
+				DTOs.ToggleTaskStatusRequest{IsDone: utils.Ptr(true)}, anyVersion)
+			assert.Equal(t, http.StatusOK, w.Code)
+			var response DTOs.TaskResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			return response
+		}
+
+		completed := toggle(task.ID.String())
+		assert.Equal(t, enums.Completed, completed.Status)
+		assert.NotNil(t, completed.NextOccurrenceID)
+
+		// Повторное выполнение после возврата в работу не создаёт ещё одну задачу
+		w = sendJSONWithHeaders(router, http.MethodPatch, "/tasks/"+task.ID.String()+"/toggle", token,
+			DTOs.ToggleTaskStatusRequest{IsDone: utils.Ptr(false)}, anyVersion)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, completed.NextOccurrenceID, toggle(task.ID.String()).NextOccurrenceID)
+
+		w = sendJSON(router, http.MethodGet, "/tasks?status=Active", token, nil)
+		var active DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &active))
+		assert.Len(t, active.Items, 1)
+		next := active.Items[0]
+		assert.Equal(t, *completed.NextOccurrenceID, next.ID)
+		assert.Equal(t, "Уборка", next.Name)
+		assert.Equal(t, enums.High, next.Priority)
+		assert.True(t, deadline.AddDate(0, 0, 7).Equal(*next.Deadline))
+		assert.Equal(t, "FREQ=WEEKLY;COUNT=1", *next.Recurrence)
+
+		// Последнее вхождение серии
+		assert.Nil(t, toggle(next.ID.String()).NextOccurrenceID)
+	})
+}
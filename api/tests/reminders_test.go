@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestReminders(t *testing.T) {
+	db := setupTestDB(t)
+	router, webhooksService, remindersService := setupTestRouterWithServices(db)
+	token, _ := authenticate(t, router, "user@example.com")
+	strangerToken, _ := authenticate(t, router, "stranger@example.com")
+	receiver := newWebhookReceiver()
+	defer receiver.Close()
+
+	w := sendJSON(router, http.MethodPost, "/webhooks", token, DTOs.WebhookRequest{
+		URL:        utils.Ptr(receiver.URL),
+		EventTypes: []enums.TaskChangeType{enums.TaskChangeReminder},
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	deadline := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+	w = sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{
+		Name:     utils.Ptr("Отчёт"),
+		Deadline: &deadline,
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var task DTOs.TaskResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+	remindersPath := "/tasks/" + task.ID.String() + "/reminders"
+
+	t.Run("Некорректные напоминания", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPut, remindersPath, token, DTOs.RemindersRequest{MinutesBefore: []int{60, 0}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "minutesBefore")
+
+		w = sendJSON(router, http.MethodPut, remindersPath, token, map[string]any{})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "InvalidRequest")
+	})
+
+	t.Run("Напоминания чужой задачи", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, remindersPath, strangerToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = sendJSON(router, http.MethodPut, remindersPath, strangerToken,
+			DTOs.RemindersRequest{MinutesBefore: []int{5}})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Установка напоминаний", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPut, remindersPath, token,
+			DTOs.RemindersRequest{MinutesBefore: []int{60, 24 * 60}})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = sendJSON(router, http.MethodGet, remindersPath, token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var reminders []DTOs.TaskReminderResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &reminders))
+		if assert.Len(t, reminders, 2) {
+			// Напоминание за сутки до дедлайна через два часа пропускается
+			assert.Equal(t, 24*60, reminders[0].MinutesBefore)
+			assert.NotNil(t, reminders[0].SentAt)
+			assert.Equal(t, 60, reminders[1].MinutesBefore)
+			assert.True(t, deadline.Add(-time.Hour).Equal(*reminders[1].RemindAt))
+			assert.Nil(t, reminders[1].SentAt)
+		}
+	})
+
+	t.Run("Напоминание подписке", func(t *testing.T) {
+		remindersService.SendDueReminders(time.Now())
+		webhooksService.DeliverPending(time.Now())
+		assert.Empty(t, receiver.received())
+
+		remindersService.SendDueReminders(deadline.Add(-30 * time.Minute))
+		// Повторная отправка в то же время ничего не отправляет
+		remindersService.SendDueReminders(deadline.Add(-30 * time.Minute))
+		webhooksService.DeliverPending(time.Now())
+
+		requests := receiver.received()
+		if assert.Len(t, requests, 1) {
+			assert.Equal(t, "reminder", requests[0].Event)
+			assert.Contains(t, string(requests[0].Body), task.ID.String())
+		}
+
+		w := sendJSON(router, http.MethodGet, remindersPath, token, nil)
+		var reminders []DTOs.TaskReminderResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &reminders))
+		assert.NotNil(t, reminders[1].SentAt)
+	})
+
+	t.Run("Перенос дедлайна снова включает напоминание", func(t *testing.T) {
+		newDeadline := deadline.Add(24 * time.Hour)
+		w := sendJSONWithHeaders(router, http.MethodPut, "/tasks/"+task.ID.String(), token, DTOs.UpdateTaskRequest{
+			Name:     utils.Ptr("Отчёт"),
+			Deadline: &newDeadline,
+		}, anyVersion)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = sendJSON(router, http.MethodGet, remindersPath, token, nil)
+		var reminders []DTOs.TaskReminderResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &reminders))
+		if assert.Len(t, reminders, 2) {
+			for _, reminder := range reminders {
+				assert.Nil(t, reminder.SentAt)
+			}
+			assert.True(t, newDeadline.Add(-24*time.Hour).Equal(*reminders[0].RemindAt))
+		}
+	})
+
+	t.Run("Отключение напоминаний", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPut, remindersPath, token, DTOs.RemindersRequest{MinutesBefore: []int{}})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, "[]", w.Body.String())
+	})
+}
@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
+	strangerToken, _ := authenticate(t, router, "stranger@example.com")
+
+	createTask := func(request DTOs.CreateTaskRequest) DTOs.TaskResponse {
+		w := sendJSON(router, http.MethodPost, "/tasks", token, request)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var task DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+	tagNames := func(tags []DTOs.TagResponse) []string {
+		names := []string{}
+		for _, tag := range tags {
+			names = append(names, tag.Name)
+		}
+		return names
+	}
+	listTasks := func(query string) []DTOs.TaskResponse {
+		w := sendJSON(router, http.MethodGet, "/tasks"+query, token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var page DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		return page.Items
+	}
+
+	w := sendJSON(router, http.MethodPost, "/tags", token, DTOs.TagRequest{Name: utils.Ptr("Work")})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var work DTOs.TagResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &work))
+	assert.Equal(t, "work", work.Name)
+
+	report := createTask(DTOs.CreateTaskRequest{Name: utils.Ptr("Отчёт #work #Urgent")})
+	shopping := createTask(DTOs.CreateTaskRequest{Name: utils.Ptr("Покупки"), Tags: []string{"home"}})
+	createTask(DTOs.CreateTaskRequest{Name: utils.Ptr("Без меток")})
+
+	t.Run("Метки из макросов и списка", func(t *testing.T) {
+		assert.Equal(t, "Отчёт", report.Name)
+		assert.Equal(t, []string{"urgent", "work"}, tagNames(report.Tags))
+		assert.Equal(t, work.ID, report.Tags[1].ID)
+		assert.Equal(t, []string{"home"}, tagNames(shopping.Tags))
+
+		w := sendJSON(router, http.MethodGet, "/tags", token, nil)
+		var tags []DTOs.TagResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tags))
+		assert.Equal(t, []string{"home", "urgent", "work"}, tagNames(tags))
+	})
+
+	t.Run("Фильтр по меткам", func(t *testing.T) {
+		tasks := listTasks("?tag=work")
+		assert.Len(t, tasks, 1)
+		assert.Equal(t, report.ID, tasks[0].ID)
+
+		assert.Len(t, listTasks("?tag=WORK&tag=home"), 2)
+		assert.Empty(t, listTasks("?tag=missing"))
+		assert.Len(t, listTasks(""), 3)
+
+		w := sendJSON(router, http.MethodGet, "/tasks?tag=home&limit=1", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var page DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Len(t, page.Items, 1)
+		assert.Equal(t, shopping.ID, page.Items[0].ID)
+		assert.Nil(t, page.NextCursor)
+	})
+
+	t.Run("Редактирование задачи", func(t *testing.T) {
+		path := "/tasks/" + shopping.ID.String()
+
+		// Без поля tags метки сохраняются
+		w := sendJSONWithHeaders(router, http.MethodPut, path, token, DTOs.UpdateTaskRequest{Name: utils.Ptr("Покупки")},
+			anyVersion)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var task DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.Equal(t, []string{"home"}, tagNames(task.Tags))
+
+		w = sendJSONWithHeaders(router, http.MethodPut, path, token, DTOs.UpdateTaskRequest{
+			Name: utils.Ptr("Покупки #weekend"),
+			Tags: []string{"work"},
+		}, anyVersion)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.Equal(t, []string{"weekend", "work"}, tagNames(task.Tags))
+
+		w = sendJSONWithHeaders(router, http.MethodPut, path, token, DTOs.UpdateTaskRequest{
+			Name: utils.Ptr("Покупки"),
+			Tags: []string{},
+		}, anyVersion)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.Empty(t, task.Tags)
+
+		w = sendJSONWithHeaders(router, http.MethodPut, path, token, DTOs.UpdateTaskRequest{
+			Name: utils.Ptr("Покупки"),
+			Tags: []string{"two words"},
+		}, anyVersion)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "tags")
+	})
+
+	t.Run("Переименование и конфликт имён", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/tags", token, DTOs.TagRequest{Name: utils.Ptr("#work")})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = sendJSON(router, http.MethodPut, "/tags/"+work.ID.String(), token, DTOs.TagRequest{Name: utils.Ptr("home")})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = sendJSON(router, http.MethodPut, "/tags/"+work.ID.String(), token, DTOs.TagRequest{Name: utils.Ptr("job")})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		tasks := listTasks("?tag=job")
+		assert.Len(t, tasks, 1)
+		assert.Equal(t, []string{"job", "urgent"}, tagNames(tasks[0].Tags))
+	})
+
+	t.Run("Метки другого пользователя", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, "/tags", strangerToken, nil)
+		assert.Equal(t, "[]", w.Body.String())
+
+		w = sendJSON(router, http.MethodDelete, "/tags/"+work.ID.String(), strangerToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		// Одноимённая метка другого пользователя — отдельная метка
+		w = sendJSON(router, http.MethodPost, "/tags", strangerToken, DTOs.TagRequest{Name: utils.Ptr("job")})
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Удаление метки снимает её с задач", func(t *testing.T) {
+		w := sendJSON(router, http.MethodDelete, "/tags/"+work.ID.String(), token, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		assert.Empty(t, listTasks("?tag=job"))
+		tasks := listTasks("?tag=urgent")
+		assert.Len(t, tasks, 1)
+		assert.Equal(t, []string{"urgent"}, tagNames(tasks[0].Tags))
+	})
+
+	t.Run("Некорректные запросы", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/tags", token, DTOs.TagRequest{})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = sendJSON(router, http.MethodPost, "/tags", token, DTOs.TagRequest{Name: utils.Ptr("a,b")})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = sendJSON(router, http.MethodPut, "/tags/not-a-uuid", token, DTOs.TagRequest{Name: utils.Ptr("x")})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = sendJSON(router, http.MethodGet, "/tags", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeZone(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
+
+	// Создание задачи «до конца сегодняшнего дня» с необязательным заголовком X-Time-Zone
+	createTask := func(timeZone string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(DTOs.CreateTaskRequest{Name: utils.Ptr("Отчёт !before today")})
+		req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		if timeZone != "" {
+			req.Header.Set("X-Time-Zone", timeZone)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	endOfToday := func(name string) time.Time {
+		location, err := time.LoadLocation(name)
+		assert.NoError(t, err)
+		year, month, day := time.Now().In(location).Date()
+		return time.Date(year, month, day, 23, 59, 59, 0, location)
+	}
+	assertDeadline := func(w *httptest.ResponseRecorder, want time.Time) {
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var task DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.True(t, want.Equal(*task.Deadline), "deadline %v, want %v", task.Deadline, want)
+	}
+
+	t.Run("По умолчанию UTC", func(t *testing.T) {
+		assertDeadline(createTask(""), endOfToday("UTC"))
+	})
+
+	t.Run("Часовой пояс из профиля", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPut, "/users/me", token,
+			DTOs.UpdateProfileRequest{TimeZone: utils.Ptr("Asia/Novosibirsk")})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = sendJSON(router, http.MethodGet, "/users/me", token, nil)
+		var user DTOs.UserResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+		assert.Equal(t, "Asia/Novosibirsk", *user.TimeZone)
+
+		w = createTask("")
+		assertDeadline(w, endOfToday("Asia/Novosibirsk"))
+		var task DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.Equal(t, "Asia/Novosibirsk", *task.TimeZone)
+	})
+
+	t.Run("Заголовок важнее профиля", func(t *testing.T) {
+		assertDeadline(createTask("America/New_York"), endOfToday("America/New_York"))
+	})
+
+	t.Run("Неизвестный часовой пояс", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, createTask("Mars/Olympus").Code)
+
+		w := sendJSON(router, http.MethodPut, "/users/me", token,
+			DTOs.UpdateProfileRequest{TimeZone: utils.Ptr("Mars/Olympus")})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestTrash(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupTestRouter(db)
+	token, _ := authenticate(t, router, "user@example.com")
+	strangerToken, _ := authenticate(t, router, "stranger@example.com")
+
+	w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{Name: utils.Ptr("Переезд #дом")})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var task DTOs.TaskResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+	taskPath := "/tasks/" + task.ID.String()
+
+	w = sendJSON(router, http.MethodPost, taskPath+"/items", token,
+		DTOs.CreateChecklistItemRequest{Name: utils.Ptr("Упаковать вещи")})
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	getTasks := func() []DTOs.TaskResponse {
+		w := sendJSON(router, http.MethodGet, "/tasks", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var page DTOs.TasksPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		return page.Items
+	}
+	getTrash := func() []DTOs.TaskResponse {
+		w := sendJSON(router, http.MethodGet, "/tasks/trash", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var tasks []DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tasks))
+		return tasks
+	}
+
+	t.Run("Удалённая задача попадает в корзину", func(t *testing.T) {
+		w := sendJSONWithHeaders(router, http.MethodDelete, taskPath, token, nil, anyVersion)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		assert.Empty(t, getTasks())
+		w = sendJSONWithHeaders(router, http.MethodPut, taskPath, token,
+			DTOs.UpdateTaskRequest{Name: utils.Ptr("Переезд")}, anyVersion)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		trash := getTrash()
+		assert.Len(t, trash, 1)
+		assert.Equal(t, task.ID, trash[0].ID)
+		assert.NotNil(t, trash[0].DeletedAt)
+	})
+
+	t.Run("История задачи в корзине", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, taskPath+"/history", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var page DTOs.TaskEventsPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Equal(t, enums.TaskDeleted, page.Items[len(page.Items)-1].Type)
+
+		w = sendJSON(router, http.MethodGet, taskPath+"/history", strangerToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Чужая задача не восстанавливается", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, taskPath+"/restore", strangerToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = sendJSON(router, http.MethodGet, "/tasks/trash", strangerToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, "[]", w.Body.String())
+	})
+
+	t.Run("Восстановление вместе с чек-листом и метками", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, taskPath+"/restore", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var restored DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &restored))
+		assert.Nil(t, restored.DeletedAt)
+		assert.Equal(t, DTOs.ChecklistProgressResponse{Done: 0, Total: 1}, restored.Progress)
+		assert.Len(t, restored.Tags, 1)
+
+		assert.Empty(t, getTrash())
+		tasks := getTasks()
+		assert.Len(t, tasks, 1)
+		assert.Equal(t, task.ID, tasks[0].ID)
+
+		w = sendJSON(router, http.MethodPost, taskPath+"/restore", token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Удаление и восстановление в истории", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, taskPath+"/history", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var page DTOs.TaskEventsPageResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+
+		var types []enums.TaskEventType
+		for _, event := range page.Items {
+			types = append(types, event.Type)
+		}
+		assert.Equal(t, []enums.TaskEventType{enums.TaskCreated, enums.TaskDeleted, enums.TaskRestored}, types)
+	})
+}
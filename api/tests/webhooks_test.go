@@ -0,0 +1,213 @@
+package tests
+
+import (
+	"HITS_ToDoList_Tests/internal/delivery/DTOs"
+	"HITS_ToDoList_Tests/internal/domain/enums"
+	"HITS_ToDoList_Tests/internal/infrastructure/webhooks"
+	"HITS_ToDoList_Tests/internal/pkg/utils"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type receivedWebhook struct {
+	Event     string
+	Signature string
+	Timestamp string
+	Body      []byte
+}
+
+// Получатель вебхуков: запоминает запросы и отвечает statusCode
+type webhookReceiver struct {
+	*httptest.Server
+	mu         sync.Mutex
+	statusCode int
+	requests   []receivedWebhook
+}
+
+func newWebhookReceiver() *webhookReceiver {
+	receiver := &webhookReceiver{statusCode: http.StatusOK}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+		receiver.requests = append(receiver.requests, receivedWebhook{
+			Event:     r.Header.Get("X-Webhook-Event"),
+			Signature: r.Header.Get("X-Webhook-Signature"),
+			Timestamp: r.Header.Get("X-Webhook-Timestamp"),
+			Body:      body,
+		})
+		w.WriteHeader(receiver.statusCode)
+	}))
+
+	return receiver
+}
+
+func (receiver *webhookReceiver) respondWith(statusCode int) {
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	receiver.statusCode = statusCode
+}
+
+func (receiver *webhookReceiver) received() []receivedWebhook {
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	return append([]receivedWebhook(nil), receiver.requests...)
+}
+
+func TestWebhooks(t *testing.T) {
+	db := setupTestDB(t)
+	router, webhooksService := setupTestRouterWithWebhooks(db)
+	token, _ := authenticate(t, router, "user@example.com")
+	strangerToken, _ := authenticate(t, router, "stranger@example.com")
+	receiver := newWebhookReceiver()
+	defer receiver.Close()
+
+	t.Run("Некорректная подписка", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/webhooks", token, DTOs.WebhookRequest{
+			URL:        utils.Ptr("not a url"),
+			Secret:     utils.Ptr("short"),
+			EventTypes: []enums.TaskChangeType{"renamed"},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "url")
+		assert.Contains(t, w.Body.String(), "secret")
+		assert.Contains(t, w.Body.String(), "eventTypes")
+	})
+
+	w := sendJSON(router, http.MethodPost, "/webhooks", token, DTOs.WebhookRequest{
+		URL:        utils.Ptr(receiver.URL),
+		EventTypes: []enums.TaskChangeType{enums.TaskChangeCreated, enums.TaskChangeOverdue},
+		Priorities: []enums.Priority{enums.Critical},
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var webhook DTOs.WebhookResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &webhook))
+	assert.NotNil(t, webhook.Secret)
+	secret := *webhook.Secret
+	webhookPath := "/webhooks/" + webhook.ID.String()
+
+	t.Run("Ключ подписи возвращается только при создании", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, webhookPath, token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "secret")
+
+		w = sendJSON(router, http.MethodGet, "/webhooks", token, nil)
+		var webhooks []DTOs.WebhookResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &webhooks))
+		assert.Len(t, webhooks, 1)
+		assert.Equal(t, []enums.Priority{enums.Critical}, webhooks[0].Priorities)
+	})
+
+	t.Run("Чужая подписка", func(t *testing.T) {
+		w := sendJSON(router, http.MethodGet, webhookPath, strangerToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = sendJSON(router, http.MethodDelete, webhookPath, strangerToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Подписанная отправка подходящего изменения", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{Name: utils.Ptr("Обычная")})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		w = sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{
+			Name:     utils.Ptr("Срочная"),
+			Priority: utils.Ptr(enums.Critical),
+		})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var task DTOs.TaskResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		w = sendJSON(router, http.MethodPost, "/tasks", strangerToken, DTOs.CreateTaskRequest{
+			Name:     utils.Ptr("Чужая срочная"),
+			Priority: utils.Ptr(enums.Critical),
+		})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		webhooksService.DeliverPending(time.Now())
+
+		requests := receiver.received()
+		if assert.Len(t, requests, 1) {
+			request := requests[0]
+			assert.Equal(t, "created", request.Event)
+			assert.Equal(t, webhooks.Sign(secret, request.Timestamp, request.Body), request.Signature)
+
+			var payload struct {
+				Event string
+				Task  DTOs.TaskResponse
+			}
+			assert.NoError(t, json.Unmarshal(request.Body, &payload))
+			assert.Equal(t, "created", payload.Event)
+			assert.Equal(t, task.ID, payload.Task.ID)
+			assert.Equal(t, "Срочная", payload.Task.Name)
+		}
+	})
+
+	t.Run("Неудавшаяся отправка и повтор", func(t *testing.T) {
+		receiver.respondWith(http.StatusInternalServerError)
+		w := sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{
+			Name:     utils.Ptr("Не дойдёт"),
+			Priority: utils.Ptr(enums.Critical),
+		})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		// Три попытки с паузами в минуту и две минуты
+		now := time.Now()
+		for _, at := range []time.Duration{0, time.Minute, 3 * time.Minute} {
+			webhooksService.DeliverPending(now.Add(at))
+		}
+		assert.Len(t, receiver.received(), 4)
+
+		w = sendJSON(router, http.MethodGet, "/webhooks/dead-letters", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var deadLetters []DTOs.WebhookDeliveryResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &deadLetters))
+		if !assert.Len(t, deadLetters, 1) {
+			return
+		}
+		assert.Equal(t, enums.DeliveryFailed, deadLetters[0].Status)
+		assert.Equal(t, 3, deadLetters[0].Attempts)
+		assert.Equal(t, "webhook responded with status 500", *deadLetters[0].LastError)
+		assert.Contains(t, string(deadLetters[0].Payload), "Не дойдёт")
+
+		retryPath := "/webhooks/dead-letters/" + deadLetters[0].ID.String() + "/retry"
+		w = sendJSON(router, http.MethodPost, retryPath, strangerToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		receiver.respondWith(http.StatusOK)
+		w = sendJSON(router, http.MethodPost, retryPath, token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		webhooksService.DeliverPending(time.Now())
+
+		assert.Len(t, receiver.received(), 5)
+		w = sendJSON(router, http.MethodGet, "/webhooks/dead-letters", token, nil)
+		assert.JSONEq(t, "[]", w.Body.String())
+	})
+
+	t.Run("Изменение и удаление подписки", func(t *testing.T) {
+		w := sendJSON(router, http.MethodPut, webhookPath, token, DTOs.WebhookRequest{URL: utils.Ptr(receiver.URL)})
+		assert.Equal(t, http.StatusOK, w.Code)
+		var updated DTOs.WebhookResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+		assert.Empty(t, updated.EventTypes)
+		assert.Empty(t, updated.Priorities)
+
+		w = sendJSON(router, http.MethodPost, "/tasks", token, DTOs.CreateTaskRequest{Name: utils.Ptr("Любая")})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		webhooksService.DeliverPending(time.Now())
+		requests := receiver.received()
+		assert.Len(t, requests, 6)
+		// Без нового ключа подпись прежняя
+		last := requests[len(requests)-1]
+		assert.Equal(t, webhooks.Sign(secret, last.Timestamp, last.Body), last.Signature)
+
+		w = sendJSON(router, http.MethodDelete, webhookPath, token, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w = sendJSON(router, http.MethodGet, webhookPath, token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
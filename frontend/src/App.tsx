@@ -1,12 +1,24 @@
+import { useState } from "react";
 import { ToDoList } from "./components/ToDoList.tsx";
 import { Header } from "./components/Header.tsx";
+import { LoginForm } from "./components/LoginForm.tsx";
+import { getToken, logout } from "./api/api.ts";
 
 function App() {
+    const [loggedIn, setLoggedIn] = useState(getToken() !== null);
+
+    const handleLogout = () => {
+        logout();
+        setLoggedIn(false);
+    };
+
     return (
         <div className="layout">
-            <Header/>
+            <Header onLogout={loggedIn ? handleLogout : undefined}/>
             <div className="page-content">
-                <ToDoList />
+                {loggedIn
+                    ? <ToDoList onUnauthorized={() => setLoggedIn(false)} />
+                    : <LoginForm onLogin={() => setLoggedIn(true)} />}
             </div>
         </div>
     )
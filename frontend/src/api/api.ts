@@ -3,12 +3,90 @@ import type {task} from "../entities/task.ts";
 import type {priority} from "../enums/priority.ts";
 
 const API_BASE = "http://localhost:8080";
+const TOKEN_KEY = "accessToken";
 
-export async function fetchTasks(sorting?: sorting) {
+// Сервер отвечает 401 без токена или с истёкшим токеном; сохранённый токен в этом случае сбрасывается
+export class UnauthorizedError extends Error {
+    constructor() {
+        super("Unauthorized");
+    }
+}
+
+export function getToken(): string | null {
+    return localStorage.getItem(TOKEN_KEY);
+}
+
+export function logout() {
+    localStorage.removeItem(TOKEN_KEY);
+}
+
+// Задачу успели изменить: сервер отклонил запрос с устаревшим If-Match
+export class PreconditionFailedError extends Error {
+    constructor() {
+        super("Задача была изменена в другом месте, список обновлён");
+    }
+}
+
+// ETag задачи — её версия в кавычках
+function ifMatch(version: number): string {
+    return `"${version}"`;
+}
+
+function authHeaders(headers: Record<string, string> = {}): Record<string, string> {
+    const token = getToken();
+    return token ? { ...headers, Authorization: `Bearer ${token}` } : headers;
+}
+
+function checkUnauthorized(response: Response) {
+    if (response.status === 401) {
+        logout();
+        throw new UnauthorizedError();
+    }
+}
+
+function checkPreconditionFailed(response: Response) {
+    if (response.status === 412) {
+        throw new PreconditionFailedError();
+    }
+}
+
+export async function register(email: string, password: string) {
+    const response = await fetch(`${API_BASE}/auth/register`, {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify({ email, password }),
+    });
+
+    if (!response.ok) {
+        const err = await response.json();
+        throw new Error(err?.Errors?.message ?? err?.Errors?.email ?? err?.Errors?.password ?? "Failed to register");
+    }
+}
+
+export async function login(email: string, password: string) {
+    const response = await fetch(`${API_BASE}/auth/login`, {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify({ email, password }),
+    });
+
+    if (!response.ok) {
+        const err = await response.json();
+        throw new Error(err?.Errors?.message ?? "Failed to log in");
+    }
+
+    const data = await response.json();
+    localStorage.setItem(TOKEN_KEY, data.accessToken);
+}
+
+// Сервер отдаёт задачи страницами; nextCursor из ответа запрашивает следующую, null — страниц больше нет
+export async function fetchTasks(sorting?: sorting, cursor?: string): Promise<{ items: any[]; nextCursor: string | null }> {
     const url = new URL(`${API_BASE}/tasks`);
     if (sorting) url.searchParams.set("sorting", sorting);
+    if (cursor) url.searchParams.set("cursor", cursor);
 
-    const response = await fetch(url.toString());
+    const response = await fetch(url.toString(), { headers: authHeaders() });
+    checkUnauthorized(response);
     if (!response.ok) throw new Error("Failed to fetch tasks");
 
     return response.json();
@@ -22,10 +100,11 @@ export async function createTask(data: {
 }) {
     const response = await fetch(`${API_BASE}/tasks`, {
         method: "POST",
-        headers: { "Content-Type": "application/json" },
+        headers: authHeaders({ "Content-Type": "application/json" }),
         body: JSON.stringify(data),
     });
 
+    checkUnauthorized(response);
     if (!response.ok) {
         const err = await response.json();
         throw new Error(err?.Errors?.message ?? "Failed to create task");
@@ -34,11 +113,14 @@ export async function createTask(data: {
     return response.json();
 }
 
-export async function deleteTask(id: string) {
+export async function deleteTask(id: string, version: number) {
     const response = await fetch(`${API_BASE}/tasks/${id}`, {
         method: "DELETE",
+        headers: authHeaders({ "If-Match": ifMatch(version) }),
     });
 
+    checkUnauthorized(response);
+    checkPreconditionFailed(response);
     if (!response.ok) {
         const err = await response.json();
         throw new Error(err?.Errors?.message ?? "Failed to delete task");
@@ -47,6 +129,7 @@ export async function deleteTask(id: string) {
 
 export async function updateTask(
     id: string,
+    version: number,
     data: {
         name: string;
         description?: string;
@@ -56,12 +139,15 @@ export async function updateTask(
 ): Promise<task> {
     const response = await fetch(`${API_BASE}/tasks/${id}`, {
         method: "PUT",
-        headers: {
+        headers: authHeaders({
             "Content-Type": "application/json",
-        },
+            "If-Match": ifMatch(version),
+        }),
         body: JSON.stringify(data),
     });
 
+    checkUnauthorized(response);
+    checkPreconditionFailed(response);
     if (!response.ok) {
         throw new Error("Failed to update task");
     }
@@ -69,15 +155,18 @@ export async function updateTask(
     return await response.json();
 }
 
-export async function toggleTaskStatus(id: string, isDone: boolean): Promise<task> {
+export async function toggleTaskStatus(id: string, version: number, isDone: boolean): Promise<task> {
     const response = await fetch(`${API_BASE}/tasks/${id}/toggle`, {
         method: "PATCH",
-        headers: {
+        headers: authHeaders({
             "Content-Type": "application/json",
-        },
+            "If-Match": ifMatch(version),
+        }),
         body: JSON.stringify({ isDone }),
     });
 
+    checkUnauthorized(response);
+    checkPreconditionFailed(response);
     if (!response.ok) {
         throw new Error("Failed to toggle task status");
     }
@@ -1,7 +1,12 @@
-export const Header = () => {
+type Props = {
+    onLogout?: () => void;
+};
+
+export const Header = ({ onLogout }: Props) => {
     return (
         <div className="app-header">
             <h1 className="app-title">My ToDo List</h1>
+            {onLogout && <button className="logout-button" onClick={onLogout}>Log Out</button>}
         </div>
     )
 }
\ No newline at end of file
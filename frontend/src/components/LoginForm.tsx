@@ -0,0 +1,61 @@
+import { useState } from "react";
+import { login, register } from "../api/api.ts";
+
+type Props = {
+    onLogin: () => void;
+};
+
+export const LoginForm = ({ onLogin }: Props) => {
+    const [form, setForm] = useState({ email: "", password: "" });
+
+    const handleLogin = async (e: React.FormEvent) => {
+        e.preventDefault();
+        try {
+            await login(form.email, form.password);
+            onLogin();
+        } catch (err: any) {
+            alert(err.message);
+        }
+    };
+
+    const handleRegister = async () => {
+        try {
+            await register(form.email, form.password);
+            await login(form.email, form.password);
+            onLogin();
+        } catch (err: any) {
+            alert(err.message);
+        }
+    };
+
+    return (
+        <div className="todolist">
+            <form className="create-task-form login-form" onSubmit={handleLogin}>
+                <h1>Log In</h1>
+                <div>
+                    <label htmlFor="login-email">Email:</label>
+                    <input
+                        id="login-email"
+                        type="email"
+                        value={form.email}
+                        onChange={e => setForm(f => ({ ...f, email: e.target.value }))}
+                        required
+                    />
+                </div>
+                <div>
+                    <label htmlFor="login-password">Password:</label>
+                    <input
+                        id="login-password"
+                        type="password"
+                        value={form.password}
+                        onChange={e => setForm(f => ({ ...f, password: e.target.value }))}
+                        required
+                        minLength={8}
+                    />
+                </div>
+                <button type="submit">Log In</button>
+                <button type="button" onClick={handleRegister}>Register</button>
+            </form>
+        </div>
+    );
+};
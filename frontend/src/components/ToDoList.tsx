@@ -1,10 +1,29 @@
 import { useEffect, useState } from "react";
 import { Task } from "./Task";
 import { task } from "../entities/task";
-import { fetchTasks, createTask, deleteTask, toggleTaskStatus, updateTask } from "../api/api.ts";
+import { fetchTasks, createTask, deleteTask, toggleTaskStatus, updateTask, PreconditionFailedError,
+    UnauthorizedError } from "../api/api.ts";
 
-export const ToDoList = () => {
+const parseTask = (t: any) =>
+    new task(
+        t.id,
+        t.name,
+        t.priority,
+        t.status,
+        t.createdAt,
+        t.version,
+        t.changedAt,
+        t.description,
+        t.deadline ? new Date(t.deadline) : undefined
+    );
+
+type Props = {
+    onUnauthorized: () => void;
+};
+
+export const ToDoList = ({ onUnauthorized }: Props) => {
     const [tasks, setTasks] = useState<task[]>([]);
+    const [nextCursor, setNextCursor] = useState<string | null>(null);
     const [sorting, setSorting] = useState<string>("");
     const [editingTask, setEditingTask] = useState<task | null>(null);
     const [form, setForm] = useState({
@@ -20,29 +39,48 @@ export const ToDoList = () => {
         priority: "",
     });
 
+    // Без действующего токена пользователь возвращается на форму входа; если задачу изменили
+    // в другом месте, список перезагружается, чтобы следующее изменение ушло с актуальной версией
+    const handleError = async (err: any) => {
+        if (err instanceof UnauthorizedError) {
+            onUnauthorized();
+            return;
+        }
+        if (err instanceof PreconditionFailedError) {
+            await loadTasks();
+        }
+        alert(err.message);
+    };
+
     const loadTasks = async () => {
         try {
             const data = await fetchTasks(sorting as any);
             console.log("data:", data);
-            const parsed = data.map((t: any) =>
-                new task(
-                    t.id,
-                    t.name,
-                    t.priority,
-                    t.status,
-                    t.createdAt,
-                    t.changedAt,
-                    t.description,
-                    t.deadline ? new Date(t.deadline) : undefined
-                )
-            );
+            const parsed = data.items.map(parseTask);
             setTasks(parsed);
+            setNextCursor(data.nextCursor);
             console.log("parsed:", parsed);
         } catch (err) {
+            if (err instanceof UnauthorizedError) {
+                onUnauthorized();
+                return;
+            }
             alert("Ошибка загрузки задач");
         }
     };
 
+    const loadMoreTasks = async () => {
+        if (!nextCursor) return;
+
+        try {
+            const data = await fetchTasks(sorting as any, nextCursor);
+            setTasks(prevTasks => [...prevTasks, ...data.items.map(parseTask)]);
+            setNextCursor(data.nextCursor);
+        } catch (err: any) {
+            handleError(err);
+        }
+    };
+
     useEffect(() => {
         loadTasks();
     }, [sorting]);
@@ -60,30 +98,40 @@ export const ToDoList = () => {
             setForm({ name: "", description: "", deadline: "", priority: "" });
             await loadTasks();
         } catch (err: any) {
-            alert(err.message);
+            handleError(err);
         }
     };
 
 
     const handleDelete = async (id: string) => {
+        const taskToDelete = tasks.find(t => t.id === id);
+        if (!taskToDelete) return;
+
         try {
-            await deleteTask(id);
+            await deleteTask(id, taskToDelete.version);
             await loadTasks();
         } catch (err: any) {
-            alert(err.message);
+            handleError(err);
         }
     };
 
     const handleToggleStatus = async (taskId: string, currentStatus: task["status"]) => {
+        const taskToToggle = tasks.find(t => t.id === taskId);
+        if (!taskToToggle) return;
+
         try {
             const newStatus = currentStatus !== "Completed" && currentStatus !== "Late";
-            const updatedTask = await toggleTaskStatus(taskId, newStatus);
+            const updatedTask = parseTask(await toggleTaskStatus(taskId, taskToToggle.version, newStatus));
             setTasks((prevTasks) =>
                 prevTasks.map((task) =>
                     task.id === taskId ? updatedTask : task
                 )
             );
         } catch (error) {
+            if (error instanceof UnauthorizedError || error instanceof PreconditionFailedError) {
+                await handleError(error);
+                return;
+            }
             console.error("Error toggling task status:", error);
         }
     };
@@ -107,7 +155,7 @@ export const ToDoList = () => {
 
         try {
             const deadline = editForm.deadline ? new Date(editForm.deadline).toISOString() : undefined;
-            await updateTask(editingTask.id, {
+            await updateTask(editingTask.id, editingTask.version, {
                 name: editForm.name,
                 description: editForm.description || undefined,
                 deadline: deadline,
@@ -116,7 +164,10 @@ export const ToDoList = () => {
             setEditingTask(null);
             await loadTasks();
         } catch (err: any) {
-            alert(err.message);
+            if (err instanceof PreconditionFailedError) {
+                setEditingTask(null);
+            }
+            handleError(err);
         }
     };
 
@@ -234,6 +285,8 @@ export const ToDoList = () => {
                     onEdit={handleEdit}
                 />
             ))}
+
+            {nextCursor && <button className="load-more" onClick={loadMoreTasks}>Load more</button>}
         </div>
     );
 };
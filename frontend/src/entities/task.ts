@@ -11,8 +11,10 @@ export class task {
     public priority: priority;
     public status: status;
     public isDone: boolean;
+    // Версия задачи на сервере, отправляется в If-Match при изменении и удалении
+    public version: number;
 
-    constructor(id: string, name: string, priority: priority, status: status, createdAt: Date, changedAt?: Date, description?: string, deadline?: Date) {
+    constructor(id: string, name: string, priority: priority, status: status, createdAt: Date, version: number, changedAt?: Date, description?: string, deadline?: Date) {
         this.id = id;
         this.name = name;
         this.description = description;
@@ -21,6 +23,7 @@ export class task {
         this.status = status;
         this.createdAt = createdAt;
         this.changedAt = changedAt;
+        this.version = version;
 
         this.isDone = status === "Completed" || status === "Late";
     }